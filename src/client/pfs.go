@@ -401,6 +401,20 @@ func (c APIClient) DeleteBranch(repoName string, branch string, force bool) erro
 	return grpcutil.ScrubGRPC(err)
 }
 
+// SetBranchSchema sets the schema which is enforced on records put into a
+// branch with the JSON, LINE or CSV delimiters. A nil schema removes the
+// branch's schema.
+func (c APIClient) SetBranchSchema(repoName string, branch string, schema *pfs.Schema) error {
+	_, err := c.PfsAPIClient.SetBranchSchema(
+		c.Ctx(),
+		&pfs.SetBranchSchemaRequest{
+			Branch: NewBranch(repoName, branch),
+			Schema: schema,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
 // DeleteCommit deletes a commit.
 func (c APIClient) DeleteCommit(repoName string, commitID string) error {
 	_, err := c.PfsAPIClient.DeleteCommit(
@@ -29,6 +29,72 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type ColumnType int32
+
+const (
+	ColumnType_STRING ColumnType = 0
+	ColumnType_INT    ColumnType = 1
+	ColumnType_FLOAT  ColumnType = 2
+	ColumnType_BOOL   ColumnType = 3
+	// TIMESTAMP columns are formatted as RFC 3339.
+	ColumnType_TIMESTAMP ColumnType = 4
+)
+
+var ColumnType_name = map[int32]string{
+	0: "STRING",
+	1: "INT",
+	2: "FLOAT",
+	3: "BOOL",
+	4: "TIMESTAMP",
+}
+
+var ColumnType_value = map[string]int32{
+	"STRING":    0,
+	"INT":       1,
+	"FLOAT":     2,
+	"BOOL":      3,
+	"TIMESTAMP": 4,
+}
+
+func (x ColumnType) String() string {
+	return proto.EnumName(ColumnType_name, int32(x))
+}
+
+func (ColumnType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b48f014707f6595c, []int{0}
+}
+
+// InvalidRecordAction is what happens to records which don't match a
+// branch's schema.
+type InvalidRecordAction int32
+
+const (
+	// REJECT leaves invalid records out of the commit and prevents it from being
+	// finished until the files containing them are overwritten or deleted.
+	InvalidRecordAction_REJECT InvalidRecordAction = 0
+	// QUARANTINE writes invalid records to the schema's quarantine path, the
+	// commit can still be finished.
+	InvalidRecordAction_QUARANTINE InvalidRecordAction = 1
+)
+
+var InvalidRecordAction_name = map[int32]string{
+	0: "REJECT",
+	1: "QUARANTINE",
+}
+
+var InvalidRecordAction_value = map[string]int32{
+	"REJECT":     0,
+	"QUARANTINE": 1,
+}
+
+func (x InvalidRecordAction) String() string {
+	return proto.EnumName(InvalidRecordAction_name, int32(x))
+}
+
+func (InvalidRecordAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b48f014707f6595c, []int{1}
+}
+
 // These are the different places where a commit may be originated from
 type OriginKind int32
 
@@ -55,7 +121,7 @@ func (x OriginKind) String() string {
 }
 
 func (OriginKind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{0}
+	return fileDescriptor_b48f014707f6595c, []int{2}
 }
 
 type FileType int32
@@ -83,7 +149,7 @@ func (x FileType) String() string {
 }
 
 func (FileType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{1}
+	return fileDescriptor_b48f014707f6595c, []int{3}
 }
 
 // CommitState describes the states a commit can be in.
@@ -113,7 +179,7 @@ func (x CommitState) String() string {
 }
 
 func (CommitState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{2}
+	return fileDescriptor_b48f014707f6595c, []int{4}
 }
 
 type Delimiter int32
@@ -124,6 +190,11 @@ const (
 	Delimiter_LINE Delimiter = 2
 	Delimiter_SQL  Delimiter = 3
 	Delimiter_CSV  Delimiter = 4
+	// PARQUET splits by row group, each split is a standalone parquet file.
+	Delimiter_PARQUET Delimiter = 5
+	// AVRO splits by block, each split is a standalone avro object container
+	// file.
+	Delimiter_AVRO Delimiter = 6
 )
 
 var Delimiter_name = map[int32]string{
@@ -132,14 +203,18 @@ var Delimiter_name = map[int32]string{
 	2: "LINE",
 	3: "SQL",
 	4: "CSV",
+	5: "PARQUET",
+	6: "AVRO",
 }
 
 var Delimiter_value = map[string]int32{
-	"NONE": 0,
-	"JSON": 1,
-	"LINE": 2,
-	"SQL":  3,
-	"CSV":  4,
+	"NONE":    0,
+	"JSON":    1,
+	"LINE":    2,
+	"SQL":     3,
+	"CSV":     4,
+	"PARQUET": 5,
+	"AVRO":    6,
 }
 
 func (x Delimiter) String() string {
@@ -147,7 +222,7 @@ func (x Delimiter) String() string {
 }
 
 func (Delimiter) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{3}
+	return fileDescriptor_b48f014707f6595c, []int{5}
 }
 
 type Repo struct {
@@ -258,6 +333,9 @@ type BranchInfo struct {
 	Provenance       []*Branch `protobuf:"bytes,3,rep,name=provenance,proto3" json:"provenance,omitempty"`
 	Subvenance       []*Branch `protobuf:"bytes,5,rep,name=subvenance,proto3" json:"subvenance,omitempty"`
 	DirectProvenance []*Branch `protobuf:"bytes,6,rep,name=direct_provenance,json=directProvenance,proto3" json:"direct_provenance,omitempty"`
+	// schema is enforced on the records of files which are split when they're
+	// put into the branch.
+	Schema *Schema `protobuf:"bytes,7,opt,name=schema,proto3" json:"schema,omitempty"`
 	// Deprecated field left for backward compatibility.
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -333,6 +411,13 @@ func (m *BranchInfo) GetDirectProvenance() []*Branch {
 	return nil
 }
 
+func (m *BranchInfo) GetSchema() *Schema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
 func (m *BranchInfo) GetName() string {
 	if m != nil {
 		return m.Name
@@ -340,6 +425,223 @@ func (m *BranchInfo) GetName() string {
 	return ""
 }
 
+type SchemaColumn struct {
+	Name string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type ColumnType `protobuf:"varint,2,opt,name=type,proto3,enum=pfs.ColumnType" json:"type,omitempty"`
+	// nullable allows the column to be empty.
+	Nullable             bool     `protobuf:"varint,3,opt,name=nullable,proto3" json:"nullable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchemaColumn) Reset()         { *m = SchemaColumn{} }
+func (m *SchemaColumn) String() string { return proto.CompactTextString(m) }
+func (*SchemaColumn) ProtoMessage()    {}
+func (*SchemaColumn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b48f014707f6595c, []int{3}
+}
+func (m *SchemaColumn) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SchemaColumn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SchemaColumn.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SchemaColumn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaColumn.Merge(m, src)
+}
+func (m *SchemaColumn) XXX_Size() int {
+	return m.Size()
+}
+func (m *SchemaColumn) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaColumn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaColumn proto.InternalMessageInfo
+
+func (m *SchemaColumn) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SchemaColumn) GetType() ColumnType {
+	if m != nil {
+		return m.Type
+	}
+	return ColumnType_STRING
+}
+
+func (m *SchemaColumn) GetNullable() bool {
+	if m != nil {
+		return m.Nullable
+	}
+	return false
+}
+
+// Schema describes the records of files which are split with the JSON, LINE
+// or CSV delimiters.
+type Schema struct {
+	// json_schema is a JSON Schema which every record of JSON and LINE splits
+	// must satisfy, LINE records must each be a JSON document.
+	JsonSchema string `protobuf:"bytes,1,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
+	// csv_columns are the columns of CSV records, in order. Header records
+	// aren't validated.
+	CsvColumns []*SchemaColumn     `protobuf:"bytes,2,rep,name=csv_columns,json=csvColumns,proto3" json:"csv_columns,omitempty"`
+	OnInvalid  InvalidRecordAction `protobuf:"varint,3,opt,name=on_invalid,json=onInvalid,proto3,enum=pfs.InvalidRecordAction" json:"on_invalid,omitempty"`
+	// quarantine_path is the directory invalid records are appended to when
+	// on_invalid is QUARANTINE, at the same path as the file they were put to.
+	QuarantinePath       string   `protobuf:"bytes,4,opt,name=quarantine_path,json=quarantinePath,proto3" json:"quarantine_path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Schema) Reset()         { *m = Schema{} }
+func (m *Schema) String() string { return proto.CompactTextString(m) }
+func (*Schema) ProtoMessage()    {}
+func (*Schema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b48f014707f6595c, []int{4}
+}
+func (m *Schema) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Schema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Schema.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Schema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schema.Merge(m, src)
+}
+func (m *Schema) XXX_Size() int {
+	return m.Size()
+}
+func (m *Schema) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Schema proto.InternalMessageInfo
+
+func (m *Schema) GetJsonSchema() string {
+	if m != nil {
+		return m.JsonSchema
+	}
+	return ""
+}
+
+func (m *Schema) GetCsvColumns() []*SchemaColumn {
+	if m != nil {
+		return m.CsvColumns
+	}
+	return nil
+}
+
+func (m *Schema) GetOnInvalid() InvalidRecordAction {
+	if m != nil {
+		return m.OnInvalid
+	}
+	return InvalidRecordAction_REJECT
+}
+
+func (m *Schema) GetQuarantinePath() string {
+	if m != nil {
+		return m.QuarantinePath
+	}
+	return ""
+}
+
+// ValidationResult summarizes the validation of records against a branch's
+// schema.
+type ValidationResult struct {
+	ValidRecords       int64 `protobuf:"varint,1,opt,name=valid_records,json=validRecords,proto3" json:"valid_records,omitempty"`
+	RejectedRecords    int64 `protobuf:"varint,2,opt,name=rejected_records,json=rejectedRecords,proto3" json:"rejected_records,omitempty"`
+	QuarantinedRecords int64 `protobuf:"varint,3,opt,name=quarantined_records,json=quarantinedRecords,proto3" json:"quarantined_records,omitempty"`
+	// errors describes the first invalid records.
+	Errors               []string `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidationResult) Reset()         { *m = ValidationResult{} }
+func (m *ValidationResult) String() string { return proto.CompactTextString(m) }
+func (*ValidationResult) ProtoMessage()    {}
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b48f014707f6595c, []int{5}
+}
+func (m *ValidationResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidationResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidationResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidationResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidationResult.Merge(m, src)
+}
+func (m *ValidationResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidationResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidationResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidationResult proto.InternalMessageInfo
+
+func (m *ValidationResult) GetValidRecords() int64 {
+	if m != nil {
+		return m.ValidRecords
+	}
+	return 0
+}
+
+func (m *ValidationResult) GetRejectedRecords() int64 {
+	if m != nil {
+		return m.RejectedRecords
+	}
+	return 0
+}
+
+func (m *ValidationResult) GetQuarantinedRecords() int64 {
+	if m != nil {
+		return m.QuarantinedRecords
+	}
+	return 0
+}
+
+func (m *ValidationResult) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
 type BranchInfos struct {
 	BranchInfo           []*BranchInfo `protobuf:"bytes,1,rep,name=branch_info,json=branchInfo,proto3" json:"branch_info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -351,7 +653,7 @@ func (m *BranchInfos) Reset()         { *m = BranchInfos{} }
 func (m *BranchInfos) String() string { return proto.CompactTextString(m) }
 func (*BranchInfos) ProtoMessage()    {}
 func (*BranchInfos) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{3}
+	return fileDescriptor_b48f014707f6595c, []int{6}
 }
 func (m *BranchInfos) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -399,7 +701,7 @@ func (m *File) Reset()         { *m = File{} }
 func (m *File) String() string { return proto.CompactTextString(m) }
 func (*File) ProtoMessage()    {}
 func (*File) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{4}
+	return fileDescriptor_b48f014707f6595c, []int{7}
 }
 func (m *File) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -453,7 +755,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{5}
+	return fileDescriptor_b48f014707f6595c, []int{8}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -500,7 +802,7 @@ func (m *Object) Reset()         { *m = Object{} }
 func (m *Object) String() string { return proto.CompactTextString(m) }
 func (*Object) ProtoMessage()    {}
 func (*Object) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{6}
+	return fileDescriptor_b48f014707f6595c, []int{9}
 }
 func (m *Object) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -547,7 +849,7 @@ func (m *Tag) Reset()         { *m = Tag{} }
 func (m *Tag) String() string { return proto.CompactTextString(m) }
 func (*Tag) ProtoMessage()    {}
 func (*Tag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{7}
+	return fileDescriptor_b48f014707f6595c, []int{10}
 }
 func (m *Tag) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -603,7 +905,7 @@ func (m *RepoInfo) Reset()         { *m = RepoInfo{} }
 func (m *RepoInfo) String() string { return proto.CompactTextString(m) }
 func (*RepoInfo) ProtoMessage()    {}
 func (*RepoInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{8}
+	return fileDescriptor_b48f014707f6595c, []int{11}
 }
 func (m *RepoInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -691,7 +993,7 @@ func (m *RepoAuthInfo) Reset()         { *m = RepoAuthInfo{} }
 func (m *RepoAuthInfo) String() string { return proto.CompactTextString(m) }
 func (*RepoAuthInfo) ProtoMessage()    {}
 func (*RepoAuthInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{9}
+	return fileDescriptor_b48f014707f6595c, []int{12}
 }
 func (m *RepoAuthInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -738,7 +1040,7 @@ func (m *CommitOrigin) Reset()         { *m = CommitOrigin{} }
 func (m *CommitOrigin) String() string { return proto.CompactTextString(m) }
 func (*CommitOrigin) ProtoMessage()    {}
 func (*CommitOrigin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{10}
+	return fileDescriptor_b48f014707f6595c, []int{13}
 }
 func (m *CommitOrigin) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -789,7 +1091,7 @@ func (m *Commit) Reset()         { *m = Commit{} }
 func (m *Commit) String() string { return proto.CompactTextString(m) }
 func (*Commit) ProtoMessage()    {}
 func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{11}
+	return fileDescriptor_b48f014707f6595c, []int{14}
 }
 func (m *Commit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -846,7 +1148,7 @@ func (m *CommitRange) Reset()         { *m = CommitRange{} }
 func (m *CommitRange) String() string { return proto.CompactTextString(m) }
 func (*CommitRange) ProtoMessage()    {}
 func (*CommitRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{12}
+	return fileDescriptor_b48f014707f6595c, []int{15}
 }
 func (m *CommitRange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -904,7 +1206,7 @@ func (m *CommitProvenance) Reset()         { *m = CommitProvenance{} }
 func (m *CommitProvenance) String() string { return proto.CompactTextString(m) }
 func (*CommitProvenance) ProtoMessage()    {}
 func (*CommitProvenance) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{13}
+	return fileDescriptor_b48f014707f6595c, []int{16}
 }
 func (m *CommitProvenance) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -977,16 +1279,19 @@ type CommitInfo struct {
 	SubvenantCommitsSuccess int64     `protobuf:"varint,18,opt,name=subvenant_commits_success,json=subvenantCommitsSuccess,proto3" json:"subvenant_commits_success,omitempty"`
 	SubvenantCommitsFailure int64     `protobuf:"varint,19,opt,name=subvenant_commits_failure,json=subvenantCommitsFailure,proto3" json:"subvenant_commits_failure,omitempty"`
 	SubvenantCommitsTotal   int64     `protobuf:"varint,20,opt,name=subvenant_commits_total,json=subvenantCommitsTotal,proto3" json:"subvenant_commits_total,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{}  `json:"-"`
-	XXX_unrecognized        []byte    `json:"-"`
-	XXX_sizecache           int32     `json:"-"`
+	// validation is the result of validating the records put into the commit
+	// against its branch's schema, it's only set if the branch has a schema.
+	Validation           *ValidationResult `protobuf:"bytes,21,opt,name=validation,proto3" json:"validation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *CommitInfo) Reset()         { *m = CommitInfo{} }
 func (m *CommitInfo) String() string { return proto.CompactTextString(m) }
 func (*CommitInfo) ProtoMessage()    {}
 func (*CommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{14}
+	return fileDescriptor_b48f014707f6595c, []int{17}
 }
 func (m *CommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1141,6 +1446,13 @@ func (m *CommitInfo) GetSubvenantCommitsTotal() int64 {
 	return 0
 }
 
+func (m *CommitInfo) GetValidation() *ValidationResult {
+	if m != nil {
+		return m.Validation
+	}
+	return nil
+}
+
 type FileInfo struct {
 	File      *File            `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
 	FileType  FileType         `protobuf:"varint,2,opt,name=file_type,json=fileType,proto3,enum=pfs.FileType" json:"file_type,omitempty"`
@@ -1161,7 +1473,7 @@ func (m *FileInfo) Reset()         { *m = FileInfo{} }
 func (m *FileInfo) String() string { return proto.CompactTextString(m) }
 func (*FileInfo) ProtoMessage()    {}
 func (*FileInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{15}
+	return fileDescriptor_b48f014707f6595c, []int{18}
 }
 func (m *FileInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1258,7 +1570,7 @@ func (m *ByteRange) Reset()         { *m = ByteRange{} }
 func (m *ByteRange) String() string { return proto.CompactTextString(m) }
 func (*ByteRange) ProtoMessage()    {}
 func (*ByteRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{16}
+	return fileDescriptor_b48f014707f6595c, []int{19}
 }
 func (m *ByteRange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1313,7 +1625,7 @@ func (m *BlockRef) Reset()         { *m = BlockRef{} }
 func (m *BlockRef) String() string { return proto.CompactTextString(m) }
 func (*BlockRef) ProtoMessage()    {}
 func (*BlockRef) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{17}
+	return fileDescriptor_b48f014707f6595c, []int{20}
 }
 func (m *BlockRef) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1368,7 +1680,7 @@ func (m *ObjectInfo) Reset()         { *m = ObjectInfo{} }
 func (m *ObjectInfo) String() string { return proto.CompactTextString(m) }
 func (*ObjectInfo) ProtoMessage()    {}
 func (*ObjectInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{18}
+	return fileDescriptor_b48f014707f6595c, []int{21}
 }
 func (m *ObjectInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1422,7 +1734,7 @@ func (m *Compaction) Reset()         { *m = Compaction{} }
 func (m *Compaction) String() string { return proto.CompactTextString(m) }
 func (*Compaction) ProtoMessage()    {}
 func (*Compaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{19}
+	return fileDescriptor_b48f014707f6595c, []int{22}
 }
 func (m *Compaction) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1471,7 +1783,7 @@ func (m *Shard) Reset()         { *m = Shard{} }
 func (m *Shard) String() string { return proto.CompactTextString(m) }
 func (*Shard) ProtoMessage()    {}
 func (*Shard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{20}
+	return fileDescriptor_b48f014707f6595c, []int{23}
 }
 func (m *Shard) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1533,7 +1845,7 @@ func (m *PathRange) Reset()         { *m = PathRange{} }
 func (m *PathRange) String() string { return proto.CompactTextString(m) }
 func (*PathRange) ProtoMessage()    {}
 func (*PathRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{21}
+	return fileDescriptor_b48f014707f6595c, []int{24}
 }
 func (m *PathRange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1589,7 +1901,7 @@ func (m *CreateRepoRequest) Reset()         { *m = CreateRepoRequest{} }
 func (m *CreateRepoRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRepoRequest) ProtoMessage()    {}
 func (*CreateRepoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{22}
+	return fileDescriptor_b48f014707f6595c, []int{25}
 }
 func (m *CreateRepoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1650,7 +1962,7 @@ func (m *InspectRepoRequest) Reset()         { *m = InspectRepoRequest{} }
 func (m *InspectRepoRequest) String() string { return proto.CompactTextString(m) }
 func (*InspectRepoRequest) ProtoMessage()    {}
 func (*InspectRepoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{23}
+	return fileDescriptor_b48f014707f6595c, []int{26}
 }
 func (m *InspectRepoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1696,7 +2008,7 @@ func (m *ListRepoRequest) Reset()         { *m = ListRepoRequest{} }
 func (m *ListRepoRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRepoRequest) ProtoMessage()    {}
 func (*ListRepoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{24}
+	return fileDescriptor_b48f014707f6595c, []int{27}
 }
 func (m *ListRepoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1736,7 +2048,7 @@ func (m *ListRepoResponse) Reset()         { *m = ListRepoResponse{} }
 func (m *ListRepoResponse) String() string { return proto.CompactTextString(m) }
 func (*ListRepoResponse) ProtoMessage()    {}
 func (*ListRepoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{25}
+	return fileDescriptor_b48f014707f6595c, []int{28}
 }
 func (m *ListRepoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1785,7 +2097,7 @@ func (m *DeleteRepoRequest) Reset()         { *m = DeleteRepoRequest{} }
 func (m *DeleteRepoRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRepoRequest) ProtoMessage()    {}
 func (*DeleteRepoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{26}
+	return fileDescriptor_b48f014707f6595c, []int{29}
 }
 func (m *DeleteRepoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1852,7 +2164,7 @@ func (m *StartCommitRequest) Reset()         { *m = StartCommitRequest{} }
 func (m *StartCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*StartCommitRequest) ProtoMessage()    {}
 func (*StartCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{27}
+	return fileDescriptor_b48f014707f6595c, []int{30}
 }
 func (m *StartCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1934,7 +2246,7 @@ func (m *BuildCommitRequest) Reset()         { *m = BuildCommitRequest{} }
 func (m *BuildCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*BuildCommitRequest) ProtoMessage()    {}
 func (*BuildCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{28}
+	return fileDescriptor_b48f014707f6595c, []int{31}
 }
 func (m *BuildCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2061,7 +2373,7 @@ func (m *FinishCommitRequest) Reset()         { *m = FinishCommitRequest{} }
 func (m *FinishCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*FinishCommitRequest) ProtoMessage()    {}
 func (*FinishCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{29}
+	return fileDescriptor_b48f014707f6595c, []int{32}
 }
 func (m *FinishCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2152,7 +2464,7 @@ func (m *InspectCommitRequest) Reset()         { *m = InspectCommitRequest{} }
 func (m *InspectCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*InspectCommitRequest) ProtoMessage()    {}
 func (*InspectCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{30}
+	return fileDescriptor_b48f014707f6595c, []int{33}
 }
 func (m *InspectCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2210,7 +2522,7 @@ func (m *ListCommitRequest) Reset()         { *m = ListCommitRequest{} }
 func (m *ListCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*ListCommitRequest) ProtoMessage()    {}
 func (*ListCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{31}
+	return fileDescriptor_b48f014707f6595c, []int{34}
 }
 func (m *ListCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2285,7 +2597,7 @@ func (m *CommitInfos) Reset()         { *m = CommitInfos{} }
 func (m *CommitInfos) String() string { return proto.CompactTextString(m) }
 func (*CommitInfos) ProtoMessage()    {}
 func (*CommitInfos) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{32}
+	return fileDescriptor_b48f014707f6595c, []int{35}
 }
 func (m *CommitInfos) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2338,7 +2650,7 @@ func (m *CreateBranchRequest) Reset()         { *m = CreateBranchRequest{} }
 func (m *CreateBranchRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateBranchRequest) ProtoMessage()    {}
 func (*CreateBranchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{33}
+	return fileDescriptor_b48f014707f6595c, []int{36}
 }
 func (m *CreateBranchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2406,7 +2718,7 @@ func (m *InspectBranchRequest) Reset()         { *m = InspectBranchRequest{} }
 func (m *InspectBranchRequest) String() string { return proto.CompactTextString(m) }
 func (*InspectBranchRequest) ProtoMessage()    {}
 func (*InspectBranchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{34}
+	return fileDescriptor_b48f014707f6595c, []int{37}
 }
 func (m *InspectBranchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2454,7 +2766,7 @@ func (m *ListBranchRequest) Reset()         { *m = ListBranchRequest{} }
 func (m *ListBranchRequest) String() string { return proto.CompactTextString(m) }
 func (*ListBranchRequest) ProtoMessage()    {}
 func (*ListBranchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{35}
+	return fileDescriptor_b48f014707f6595c, []int{38}
 }
 func (m *ListBranchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2497,26 +2809,27 @@ func (m *ListBranchRequest) GetReverse() bool {
 	return false
 }
 
-type DeleteBranchRequest struct {
-	Branch               *Branch  `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
-	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+type SetBranchSchemaRequest struct {
+	Branch *Branch `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
+	// schema replaces the branch's schema, if it's nil the schema is removed.
+	Schema               *Schema  `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteBranchRequest) Reset()         { *m = DeleteBranchRequest{} }
-func (m *DeleteBranchRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteBranchRequest) ProtoMessage()    {}
-func (*DeleteBranchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{36}
+func (m *SetBranchSchemaRequest) Reset()         { *m = SetBranchSchemaRequest{} }
+func (m *SetBranchSchemaRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBranchSchemaRequest) ProtoMessage()    {}
+func (*SetBranchSchemaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b48f014707f6595c, []int{39}
 }
-func (m *DeleteBranchRequest) XXX_Unmarshal(b []byte) error {
+func (m *SetBranchSchemaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetBranchSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteBranchRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetBranchSchemaRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2526,14 +2839,69 @@ func (m *DeleteBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *DeleteBranchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteBranchRequest.Merge(m, src)
+func (m *SetBranchSchemaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBranchSchemaRequest.Merge(m, src)
 }
-func (m *DeleteBranchRequest) XXX_Size() int {
+func (m *SetBranchSchemaRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteBranchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteBranchRequest.DiscardUnknown(m)
+func (m *SetBranchSchemaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBranchSchemaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBranchSchemaRequest proto.InternalMessageInfo
+
+func (m *SetBranchSchemaRequest) GetBranch() *Branch {
+	if m != nil {
+		return m.Branch
+	}
+	return nil
+}
+
+func (m *SetBranchSchemaRequest) GetSchema() *Schema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+type DeleteBranchRequest struct {
+	Branch               *Branch  `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteBranchRequest) Reset()         { *m = DeleteBranchRequest{} }
+func (m *DeleteBranchRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteBranchRequest) ProtoMessage()    {}
+func (*DeleteBranchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b48f014707f6595c, []int{40}
+}
+func (m *DeleteBranchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteBranchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeleteBranchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBranchRequest.Merge(m, src)
+}
+func (m *DeleteBranchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteBranchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBranchRequest.DiscardUnknown(m)
 }
 
 var xxx_messageInfo_DeleteBranchRequest proto.InternalMessageInfo
@@ -2563,7 +2931,7 @@ func (m *DeleteCommitRequest) Reset()         { *m = DeleteCommitRequest{} }
 func (m *DeleteCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteCommitRequest) ProtoMessage()    {}
 func (*DeleteCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{37}
+	return fileDescriptor_b48f014707f6595c, []int{41}
 }
 func (m *DeleteCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2611,7 +2979,7 @@ func (m *FlushCommitRequest) Reset()         { *m = FlushCommitRequest{} }
 func (m *FlushCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*FlushCommitRequest) ProtoMessage()    {}
 func (*FlushCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{38}
+	return fileDescriptor_b48f014707f6595c, []int{42}
 }
 func (m *FlushCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2671,7 +3039,7 @@ func (m *SubscribeCommitRequest) Reset()         { *m = SubscribeCommitRequest{}
 func (m *SubscribeCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*SubscribeCommitRequest) ProtoMessage()    {}
 func (*SubscribeCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{39}
+	return fileDescriptor_b48f014707f6595c, []int{43}
 }
 func (m *SubscribeCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2748,7 +3116,7 @@ func (m *GetFileRequest) Reset()         { *m = GetFileRequest{} }
 func (m *GetFileRequest) String() string { return proto.CompactTextString(m) }
 func (*GetFileRequest) ProtoMessage()    {}
 func (*GetFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{40}
+	return fileDescriptor_b48f014707f6595c, []int{44}
 }
 func (m *GetFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2813,7 +3181,7 @@ func (m *OverwriteIndex) Reset()         { *m = OverwriteIndex{} }
 func (m *OverwriteIndex) String() string { return proto.CompactTextString(m) }
 func (*OverwriteIndex) ProtoMessage()    {}
 func (*OverwriteIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{41}
+	return fileDescriptor_b48f014707f6595c, []int{45}
 }
 func (m *OverwriteIndex) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2898,7 +3266,7 @@ func (m *PutFileRequest) Reset()         { *m = PutFileRequest{} }
 func (m *PutFileRequest) String() string { return proto.CompactTextString(m) }
 func (*PutFileRequest) ProtoMessage()    {}
 func (*PutFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{42}
+	return fileDescriptor_b48f014707f6595c, []int{46}
 }
 func (m *PutFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3012,7 +3380,7 @@ func (m *PutFileRecord) Reset()         { *m = PutFileRecord{} }
 func (m *PutFileRecord) String() string { return proto.CompactTextString(m) }
 func (*PutFileRecord) ProtoMessage()    {}
 func (*PutFileRecord) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{43}
+	return fileDescriptor_b48f014707f6595c, []int{47}
 }
 func (m *PutFileRecord) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3070,21 +3438,22 @@ func (m *PutFileRecord) GetBlockRef() *BlockRef {
 }
 
 type PutFileRecords struct {
-	Split                bool             `protobuf:"varint,1,opt,name=split,proto3" json:"split,omitempty"`
-	Records              []*PutFileRecord `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
-	Tombstone            bool             `protobuf:"varint,3,opt,name=tombstone,proto3" json:"tombstone,omitempty"`
-	Header               *PutFileRecord   `protobuf:"bytes,4,opt,name=header,proto3" json:"header,omitempty"`
-	Footer               *PutFileRecord   `protobuf:"bytes,5,opt,name=footer,proto3" json:"footer,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Split                bool              `protobuf:"varint,1,opt,name=split,proto3" json:"split,omitempty"`
+	Records              []*PutFileRecord  `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	Tombstone            bool              `protobuf:"varint,3,opt,name=tombstone,proto3" json:"tombstone,omitempty"`
+	Header               *PutFileRecord    `protobuf:"bytes,4,opt,name=header,proto3" json:"header,omitempty"`
+	Footer               *PutFileRecord    `protobuf:"bytes,5,opt,name=footer,proto3" json:"footer,omitempty"`
+	Validation           *ValidationResult `protobuf:"bytes,6,opt,name=validation,proto3" json:"validation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *PutFileRecords) Reset()         { *m = PutFileRecords{} }
 func (m *PutFileRecords) String() string { return proto.CompactTextString(m) }
 func (*PutFileRecords) ProtoMessage()    {}
 func (*PutFileRecords) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{44}
+	return fileDescriptor_b48f014707f6595c, []int{48}
 }
 func (m *PutFileRecords) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3148,6 +3517,13 @@ func (m *PutFileRecords) GetFooter() *PutFileRecord {
 	return nil
 }
 
+func (m *PutFileRecords) GetValidation() *ValidationResult {
+	if m != nil {
+		return m.Validation
+	}
+	return nil
+}
+
 type CopyFileRequest struct {
 	Src                  *File    `protobuf:"bytes,1,opt,name=src,proto3" json:"src,omitempty"`
 	Dst                  *File    `protobuf:"bytes,2,opt,name=dst,proto3" json:"dst,omitempty"`
@@ -3161,7 +3537,7 @@ func (m *CopyFileRequest) Reset()         { *m = CopyFileRequest{} }
 func (m *CopyFileRequest) String() string { return proto.CompactTextString(m) }
 func (*CopyFileRequest) ProtoMessage()    {}
 func (*CopyFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{45}
+	return fileDescriptor_b48f014707f6595c, []int{49}
 }
 func (m *CopyFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3222,7 +3598,7 @@ func (m *InspectFileRequest) Reset()         { *m = InspectFileRequest{} }
 func (m *InspectFileRequest) String() string { return proto.CompactTextString(m) }
 func (*InspectFileRequest) ProtoMessage()    {}
 func (*InspectFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{46}
+	return fileDescriptor_b48f014707f6595c, []int{50}
 }
 func (m *InspectFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3289,7 +3665,7 @@ func (m *ListFileRequest) Reset()         { *m = ListFileRequest{} }
 func (m *ListFileRequest) String() string { return proto.CompactTextString(m) }
 func (*ListFileRequest) ProtoMessage()    {}
 func (*ListFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{47}
+	return fileDescriptor_b48f014707f6595c, []int{51}
 }
 func (m *ListFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3350,7 +3726,7 @@ func (m *WalkFileRequest) Reset()         { *m = WalkFileRequest{} }
 func (m *WalkFileRequest) String() string { return proto.CompactTextString(m) }
 func (*WalkFileRequest) ProtoMessage()    {}
 func (*WalkFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{48}
+	return fileDescriptor_b48f014707f6595c, []int{52}
 }
 func (m *WalkFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3398,7 +3774,7 @@ func (m *GlobFileRequest) Reset()         { *m = GlobFileRequest{} }
 func (m *GlobFileRequest) String() string { return proto.CompactTextString(m) }
 func (*GlobFileRequest) ProtoMessage()    {}
 func (*GlobFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{49}
+	return fileDescriptor_b48f014707f6595c, []int{53}
 }
 func (m *GlobFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3453,7 +3829,7 @@ func (m *FileInfos) Reset()         { *m = FileInfos{} }
 func (m *FileInfos) String() string { return proto.CompactTextString(m) }
 func (*FileInfos) ProtoMessage()    {}
 func (*FileInfos) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{50}
+	return fileDescriptor_b48f014707f6595c, []int{54}
 }
 func (m *FileInfos) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3504,7 +3880,7 @@ func (m *DiffFileRequest) Reset()         { *m = DiffFileRequest{} }
 func (m *DiffFileRequest) String() string { return proto.CompactTextString(m) }
 func (*DiffFileRequest) ProtoMessage()    {}
 func (*DiffFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{51}
+	return fileDescriptor_b48f014707f6595c, []int{55}
 }
 func (m *DiffFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3566,7 +3942,7 @@ func (m *DiffFileResponse) Reset()         { *m = DiffFileResponse{} }
 func (m *DiffFileResponse) String() string { return proto.CompactTextString(m) }
 func (*DiffFileResponse) ProtoMessage()    {}
 func (*DiffFileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{52}
+	return fileDescriptor_b48f014707f6595c, []int{56}
 }
 func (m *DiffFileResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3620,7 +3996,7 @@ func (m *DeleteFileRequest) Reset()         { *m = DeleteFileRequest{} }
 func (m *DeleteFileRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteFileRequest) ProtoMessage()    {}
 func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{53}
+	return fileDescriptor_b48f014707f6595c, []int{57}
 }
 func (m *DeleteFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3667,7 +4043,7 @@ func (m *FsckRequest) Reset()         { *m = FsckRequest{} }
 func (m *FsckRequest) String() string { return proto.CompactTextString(m) }
 func (*FsckRequest) ProtoMessage()    {}
 func (*FsckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{54}
+	return fileDescriptor_b48f014707f6595c, []int{58}
 }
 func (m *FsckRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3715,7 +4091,7 @@ func (m *FsckResponse) Reset()         { *m = FsckResponse{} }
 func (m *FsckResponse) String() string { return proto.CompactTextString(m) }
 func (*FsckResponse) ProtoMessage()    {}
 func (*FsckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{55}
+	return fileDescriptor_b48f014707f6595c, []int{59}
 }
 func (m *FsckResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3773,7 +4149,7 @@ func (m *FileOperationRequestV2) Reset()         { *m = FileOperationRequestV2{}
 func (m *FileOperationRequestV2) String() string { return proto.CompactTextString(m) }
 func (*FileOperationRequestV2) ProtoMessage()    {}
 func (*FileOperationRequestV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{56}
+	return fileDescriptor_b48f014707f6595c, []int{60}
 }
 func (m *FileOperationRequestV2) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3867,7 +4243,7 @@ func (m *PutTarRequestV2) Reset()         { *m = PutTarRequestV2{} }
 func (m *PutTarRequestV2) String() string { return proto.CompactTextString(m) }
 func (*PutTarRequestV2) ProtoMessage()    {}
 func (*PutTarRequestV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{57}
+	return fileDescriptor_b48f014707f6595c, []int{61}
 }
 func (m *PutTarRequestV2) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3929,7 +4305,7 @@ func (m *DeleteFilesRequestV2) Reset()         { *m = DeleteFilesRequestV2{} }
 func (m *DeleteFilesRequestV2) String() string { return proto.CompactTextString(m) }
 func (*DeleteFilesRequestV2) ProtoMessage()    {}
 func (*DeleteFilesRequestV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{58}
+	return fileDescriptor_b48f014707f6595c, []int{62}
 }
 func (m *DeleteFilesRequestV2) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3983,7 +4359,7 @@ func (m *GetTarRequestV2) Reset()         { *m = GetTarRequestV2{} }
 func (m *GetTarRequestV2) String() string { return proto.CompactTextString(m) }
 func (*GetTarRequestV2) ProtoMessage()    {}
 func (*GetTarRequestV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{59}
+	return fileDescriptor_b48f014707f6595c, []int{63}
 }
 func (m *GetTarRequestV2) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4031,7 +4407,7 @@ func (m *DiffFileResponseV2) Reset()         { *m = DiffFileResponseV2{} }
 func (m *DiffFileResponseV2) String() string { return proto.CompactTextString(m) }
 func (*DiffFileResponseV2) ProtoMessage()    {}
 func (*DiffFileResponseV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{60}
+	return fileDescriptor_b48f014707f6595c, []int{64}
 }
 func (m *DiffFileResponseV2) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4085,7 +4461,7 @@ func (m *ClearCommitRequestV2) Reset()         { *m = ClearCommitRequestV2{} }
 func (m *ClearCommitRequestV2) String() string { return proto.CompactTextString(m) }
 func (*ClearCommitRequestV2) ProtoMessage()    {}
 func (*ClearCommitRequestV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{61}
+	return fileDescriptor_b48f014707f6595c, []int{65}
 }
 func (m *ClearCommitRequestV2) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4134,7 +4510,7 @@ func (m *PutObjectRequest) Reset()         { *m = PutObjectRequest{} }
 func (m *PutObjectRequest) String() string { return proto.CompactTextString(m) }
 func (*PutObjectRequest) ProtoMessage()    {}
 func (*PutObjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{62}
+	return fileDescriptor_b48f014707f6595c, []int{66}
 }
 func (m *PutObjectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4196,7 +4572,7 @@ func (m *CreateObjectRequest) Reset()         { *m = CreateObjectRequest{} }
 func (m *CreateObjectRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateObjectRequest) ProtoMessage()    {}
 func (*CreateObjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{63}
+	return fileDescriptor_b48f014707f6595c, []int{67}
 }
 func (m *CreateObjectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4257,7 +4633,7 @@ func (m *GetObjectsRequest) Reset()         { *m = GetObjectsRequest{} }
 func (m *GetObjectsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetObjectsRequest) ProtoMessage()    {}
 func (*GetObjectsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{64}
+	return fileDescriptor_b48f014707f6595c, []int{68}
 }
 func (m *GetObjectsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4326,7 +4702,7 @@ func (m *PutBlockRequest) Reset()         { *m = PutBlockRequest{} }
 func (m *PutBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*PutBlockRequest) ProtoMessage()    {}
 func (*PutBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{65}
+	return fileDescriptor_b48f014707f6595c, []int{69}
 }
 func (m *PutBlockRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4380,7 +4756,7 @@ func (m *GetBlockRequest) Reset()         { *m = GetBlockRequest{} }
 func (m *GetBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockRequest) ProtoMessage()    {}
 func (*GetBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{66}
+	return fileDescriptor_b48f014707f6595c, []int{70}
 }
 func (m *GetBlockRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4434,7 +4810,7 @@ func (m *GetBlocksRequest) Reset()         { *m = GetBlocksRequest{} }
 func (m *GetBlocksRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlocksRequest) ProtoMessage()    {}
 func (*GetBlocksRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{67}
+	return fileDescriptor_b48f014707f6595c, []int{71}
 }
 func (m *GetBlocksRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4501,7 +4877,7 @@ func (m *ListBlockRequest) Reset()         { *m = ListBlockRequest{} }
 func (m *ListBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*ListBlockRequest) ProtoMessage()    {}
 func (*ListBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{68}
+	return fileDescriptor_b48f014707f6595c, []int{72}
 }
 func (m *ListBlockRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4542,7 +4918,7 @@ func (m *TagObjectRequest) Reset()         { *m = TagObjectRequest{} }
 func (m *TagObjectRequest) String() string { return proto.CompactTextString(m) }
 func (*TagObjectRequest) ProtoMessage()    {}
 func (*TagObjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{69}
+	return fileDescriptor_b48f014707f6595c, []int{73}
 }
 func (m *TagObjectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4595,7 +4971,7 @@ func (m *ListObjectsRequest) Reset()         { *m = ListObjectsRequest{} }
 func (m *ListObjectsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListObjectsRequest) ProtoMessage()    {}
 func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{70}
+	return fileDescriptor_b48f014707f6595c, []int{74}
 }
 func (m *ListObjectsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4636,7 +5012,7 @@ func (m *ListTagsRequest) Reset()         { *m = ListTagsRequest{} }
 func (m *ListTagsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListTagsRequest) ProtoMessage()    {}
 func (*ListTagsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{71}
+	return fileDescriptor_b48f014707f6595c, []int{75}
 }
 func (m *ListTagsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4691,7 +5067,7 @@ func (m *ListTagsResponse) Reset()         { *m = ListTagsResponse{} }
 func (m *ListTagsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListTagsResponse) ProtoMessage()    {}
 func (*ListTagsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{72}
+	return fileDescriptor_b48f014707f6595c, []int{76}
 }
 func (m *ListTagsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4745,7 +5121,7 @@ func (m *DeleteObjectsRequest) Reset()         { *m = DeleteObjectsRequest{} }
 func (m *DeleteObjectsRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteObjectsRequest) ProtoMessage()    {}
 func (*DeleteObjectsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{73}
+	return fileDescriptor_b48f014707f6595c, []int{77}
 }
 func (m *DeleteObjectsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4791,7 +5167,7 @@ func (m *DeleteObjectsResponse) Reset()         { *m = DeleteObjectsResponse{} }
 func (m *DeleteObjectsResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteObjectsResponse) ProtoMessage()    {}
 func (*DeleteObjectsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{74}
+	return fileDescriptor_b48f014707f6595c, []int{78}
 }
 func (m *DeleteObjectsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4831,7 +5207,7 @@ func (m *DeleteTagsRequest) Reset()         { *m = DeleteTagsRequest{} }
 func (m *DeleteTagsRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteTagsRequest) ProtoMessage()    {}
 func (*DeleteTagsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{75}
+	return fileDescriptor_b48f014707f6595c, []int{79}
 }
 func (m *DeleteTagsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4877,7 +5253,7 @@ func (m *DeleteTagsResponse) Reset()         { *m = DeleteTagsResponse{} }
 func (m *DeleteTagsResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteTagsResponse) ProtoMessage()    {}
 func (*DeleteTagsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{76}
+	return fileDescriptor_b48f014707f6595c, []int{80}
 }
 func (m *DeleteTagsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4917,7 +5293,7 @@ func (m *CheckObjectRequest) Reset()         { *m = CheckObjectRequest{} }
 func (m *CheckObjectRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckObjectRequest) ProtoMessage()    {}
 func (*CheckObjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{77}
+	return fileDescriptor_b48f014707f6595c, []int{81}
 }
 func (m *CheckObjectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4964,7 +5340,7 @@ func (m *CheckObjectResponse) Reset()         { *m = CheckObjectResponse{} }
 func (m *CheckObjectResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckObjectResponse) ProtoMessage()    {}
 func (*CheckObjectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{78}
+	return fileDescriptor_b48f014707f6595c, []int{82}
 }
 func (m *CheckObjectResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5011,7 +5387,7 @@ func (m *Objects) Reset()         { *m = Objects{} }
 func (m *Objects) String() string { return proto.CompactTextString(m) }
 func (*Objects) ProtoMessage()    {}
 func (*Objects) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{79}
+	return fileDescriptor_b48f014707f6595c, []int{83}
 }
 func (m *Objects) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5059,7 +5435,7 @@ func (m *PutObjDirectRequest) Reset()         { *m = PutObjDirectRequest{} }
 func (m *PutObjDirectRequest) String() string { return proto.CompactTextString(m) }
 func (*PutObjDirectRequest) ProtoMessage()    {}
 func (*PutObjDirectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{80}
+	return fileDescriptor_b48f014707f6595c, []int{84}
 }
 func (m *PutObjDirectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5113,7 +5489,7 @@ func (m *GetObjDirectRequest) Reset()         { *m = GetObjDirectRequest{} }
 func (m *GetObjDirectRequest) String() string { return proto.CompactTextString(m) }
 func (*GetObjDirectRequest) ProtoMessage()    {}
 func (*GetObjDirectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{81}
+	return fileDescriptor_b48f014707f6595c, []int{85}
 }
 func (m *GetObjDirectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5161,7 +5537,7 @@ func (m *ObjectIndex) Reset()         { *m = ObjectIndex{} }
 func (m *ObjectIndex) String() string { return proto.CompactTextString(m) }
 func (*ObjectIndex) ProtoMessage()    {}
 func (*ObjectIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{82}
+	return fileDescriptor_b48f014707f6595c, []int{86}
 }
 func (m *ObjectIndex) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5205,6 +5581,8 @@ func (m *ObjectIndex) GetTags() map[string]*Object {
 }
 
 func init() {
+	proto.RegisterEnum("pfs.ColumnType", ColumnType_name, ColumnType_value)
+	proto.RegisterEnum("pfs.InvalidRecordAction", InvalidRecordAction_name, InvalidRecordAction_value)
 	proto.RegisterEnum("pfs.OriginKind", OriginKind_name, OriginKind_value)
 	proto.RegisterEnum("pfs.FileType", FileType_name, FileType_value)
 	proto.RegisterEnum("pfs.CommitState", CommitState_name, CommitState_value)
@@ -5212,6 +5590,9 @@ func init() {
 	proto.RegisterType((*Repo)(nil), "pfs.Repo")
 	proto.RegisterType((*Branch)(nil), "pfs.Branch")
 	proto.RegisterType((*BranchInfo)(nil), "pfs.BranchInfo")
+	proto.RegisterType((*SchemaColumn)(nil), "pfs.SchemaColumn")
+	proto.RegisterType((*Schema)(nil), "pfs.Schema")
+	proto.RegisterType((*ValidationResult)(nil), "pfs.ValidationResult")
 	proto.RegisterType((*BranchInfos)(nil), "pfs.BranchInfos")
 	proto.RegisterType((*File)(nil), "pfs.File")
 	proto.RegisterType((*Block)(nil), "pfs.Block")
@@ -5245,6 +5626,7 @@ func init() {
 	proto.RegisterType((*CreateBranchRequest)(nil), "pfs.CreateBranchRequest")
 	proto.RegisterType((*InspectBranchRequest)(nil), "pfs.InspectBranchRequest")
 	proto.RegisterType((*ListBranchRequest)(nil), "pfs.ListBranchRequest")
+	proto.RegisterType((*SetBranchSchemaRequest)(nil), "pfs.SetBranchSchemaRequest")
 	proto.RegisterType((*DeleteBranchRequest)(nil), "pfs.DeleteBranchRequest")
 	proto.RegisterType((*DeleteCommitRequest)(nil), "pfs.DeleteCommitRequest")
 	proto.RegisterType((*FlushCommitRequest)(nil), "pfs.FlushCommitRequest")
@@ -5299,244 +5681,267 @@ func init() {
 func init() { proto.RegisterFile("client/pfs/pfs.proto", fileDescriptor_b48f014707f6595c) }
 
 var fileDescriptor_b48f014707f6595c = []byte{
-	// 3783 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3b, 0xcb, 0x72, 0x1b, 0xc7,
-	0xb5, 0x1c, 0x3c, 0x67, 0x0e, 0x40, 0x72, 0xd8, 0xa4, 0x28, 0x08, 0xb2, 0x1e, 0x1e, 0x49, 0xb6,
-	0x4c, 0xdb, 0x24, 0x4d, 0x5e, 0xdb, 0x7a, 0xd8, 0x52, 0x89, 0x2f, 0x89, 0xb2, 0x4a, 0xe4, 0x1d,
-	0xd0, 0xbc, 0x75, 0x5d, 0xf7, 0x06, 0x35, 0x00, 0x1a, 0xc4, 0x48, 0x43, 0x0c, 0x3c, 0x33, 0x90,
-	0x44, 0x6f, 0xb2, 0x4b, 0x3e, 0x22, 0x9b, 0x54, 0xf2, 0x03, 0x29, 0xef, 0x52, 0x59, 0x64, 0x91,
-	0x4d, 0x2a, 0x55, 0xa9, 0xca, 0x17, 0xa4, 0x52, 0xfa, 0x0c, 0x6f, 0x92, 0xea, 0xd7, 0x4c, 0xcf,
-	0x03, 0x0f, 0xaa, 0x92, 0x85, 0xcd, 0x9e, 0xee, 0x73, 0x4e, 0x9f, 0x3e, 0xe7, 0xf4, 0x79, 0x35,
-	0x04, 0x4b, 0x6d, 0xc7, 0xc6, 0xfd, 0x60, 0x6d, 0xd0, 0xf5, 0xc9, 0x7f, 0xab, 0x03, 0xcf, 0x0d,
-	0x5c, 0x94, 0x1f, 0x74, 0xfd, 0xfa, 0xe5, 0x13, 0xd7, 0x3d, 0x71, 0xf0, 0x1a, 0x9d, 0x6a, 0x0d,
-	0xbb, 0x6b, 0xf8, 0x74, 0x10, 0x9c, 0x31, 0x88, 0xfa, 0xb5, 0xe4, 0x62, 0x60, 0x9f, 0x62, 0x3f,
-	0xb0, 0x4e, 0x07, 0x1c, 0xe0, 0x6a, 0x12, 0xe0, 0xb5, 0x67, 0x0d, 0x06, 0xd8, 0xe3, 0x5b, 0xd4,
-	0x97, 0x4e, 0xdc, 0x13, 0x97, 0x0e, 0xd7, 0xc8, 0x88, 0xcf, 0x2e, 0x73, 0x76, 0xac, 0x61, 0xd0,
-	0xa3, 0xff, 0x63, 0xf3, 0x46, 0x1d, 0x0a, 0x26, 0x1e, 0xb8, 0x08, 0x41, 0xa1, 0x6f, 0x9d, 0xe2,
-	0x9a, 0x72, 0x5d, 0xb9, 0xad, 0x99, 0x74, 0x6c, 0xdc, 0x87, 0xd2, 0x96, 0x67, 0xf5, 0xdb, 0x3d,
-	0x74, 0x05, 0x0a, 0x1e, 0x1e, 0xb8, 0x74, 0xb5, 0xb2, 0xa1, 0xad, 0x92, 0x03, 0x11, 0x34, 0x93,
-	0x4e, 0x87, 0xc8, 0x39, 0x09, 0xf9, 0x27, 0x05, 0x80, 0x61, 0xef, 0xf7, 0xbb, 0x2e, 0xba, 0x01,
-	0xa5, 0x16, 0xfd, 0xaa, 0x15, 0x28, 0x8d, 0x0a, 0xa5, 0xc1, 0x00, 0x4c, 0xbe, 0x84, 0xae, 0x41,
-	0xa1, 0x87, 0xad, 0x0e, 0xa5, 0x23, 0x40, 0xb6, 0xdd, 0xd3, 0x53, 0x3b, 0x30, 0xe9, 0x02, 0xfa,
-	0x18, 0x60, 0xe0, 0xb9, 0xaf, 0x70, 0xdf, 0xea, 0xb7, 0x71, 0x2d, 0x7f, 0x3d, 0x9f, 0xa4, 0x24,
-	0x2d, 0x13, 0x60, 0x7f, 0xd8, 0x12, 0xc0, 0xc5, 0x0c, 0xe0, 0x68, 0x19, 0xdd, 0x81, 0x85, 0x8e,
-	0xed, 0xe1, 0x76, 0xd0, 0x94, 0x36, 0x28, 0xa5, 0x71, 0x74, 0x06, 0x75, 0x18, 0x6d, 0x93, 0x25,
-	0xb9, 0x87, 0x50, 0x89, 0xce, 0xee, 0xa3, 0x75, 0xa8, 0xb0, 0x13, 0x36, 0xed, 0x7e, 0x97, 0x48,
-	0x91, 0x90, 0x9d, 0x97, 0xc8, 0x12, 0x30, 0x13, 0x5a, 0xe1, 0xd8, 0x78, 0x08, 0x85, 0x3d, 0xdb,
-	0xc1, 0x44, 0x6c, 0x6d, 0x2a, 0x00, 0x2e, 0xfa, 0x98, 0x4c, 0xf8, 0x12, 0xe1, 0x60, 0x60, 0x05,
-	0x3d, 0x21, 0x7e, 0x32, 0x36, 0x2e, 0x43, 0x71, 0xcb, 0x71, 0xdb, 0x2f, 0xc9, 0x62, 0xcf, 0xf2,
-	0x7b, 0x82, 0x3d, 0x32, 0x36, 0xde, 0x83, 0xd2, 0x41, 0xeb, 0x05, 0x6e, 0x07, 0x99, 0xab, 0x97,
-	0x20, 0x7f, 0x64, 0x9d, 0x64, 0x9e, 0xeb, 0x9f, 0x0a, 0xa8, 0x44, 0xef, 0x54, 0xa5, 0x13, 0x8c,
-	0xe2, 0xbf, 0xa0, 0xdc, 0xf6, 0xb0, 0x15, 0x60, 0xa1, 0xcf, 0xfa, 0x2a, 0xb3, 0xdc, 0x55, 0x61,
-	0xb9, 0xab, 0x47, 0xc2, 0xb4, 0x4d, 0x01, 0x8a, 0xae, 0x00, 0xf8, 0xf6, 0x0f, 0xb8, 0xd9, 0x3a,
-	0x0b, 0xb0, 0x5f, 0xcb, 0x5f, 0x57, 0x6e, 0x17, 0x4c, 0x8d, 0xcc, 0x6c, 0x91, 0x09, 0x74, 0x1d,
-	0x2a, 0x1d, 0xec, 0xb7, 0x3d, 0x7b, 0x10, 0xd8, 0x6e, 0xbf, 0x56, 0xa4, 0xbc, 0xc9, 0x53, 0xe8,
-	0x43, 0x50, 0x99, 0x1c, 0xb1, 0x5f, 0x2b, 0xa7, 0xf5, 0x17, 0x2e, 0xa2, 0x55, 0xd0, 0xc8, 0x3d,
-	0x60, 0x2a, 0x29, 0x51, 0x0e, 0x17, 0xc2, 0x33, 0x3c, 0x1a, 0x06, 0x4c, 0x29, 0xaa, 0xc5, 0x47,
-	0x4f, 0x0b, 0x6a, 0x41, 0x2f, 0x1a, 0x0f, 0xa0, 0x2a, 0xaf, 0xa3, 0x55, 0xa8, 0x5a, 0xed, 0x36,
-	0xf6, 0xfd, 0xa6, 0x83, 0x5f, 0x61, 0x87, 0x0a, 0x63, 0x6e, 0xa3, 0xb2, 0x4a, 0xaf, 0x58, 0xa3,
-	0xed, 0x0e, 0xb0, 0x59, 0x61, 0x00, 0xcf, 0xc8, 0xba, 0xb1, 0x09, 0x55, 0xa6, 0xbd, 0x03, 0xcf,
-	0x3e, 0xb1, 0xfb, 0xe8, 0x06, 0x14, 0x5e, 0xda, 0xfd, 0x0e, 0xc7, 0x63, 0x36, 0xc1, 0x96, 0xbe,
-	0xb1, 0xfb, 0x1d, 0x93, 0x2e, 0x1a, 0x0f, 0xa1, 0xc4, 0x90, 0x26, 0xc9, 0x7c, 0x19, 0x72, 0x36,
-	0x13, 0xb7, 0xb6, 0x55, 0x7a, 0xfb, 0xf7, 0x6b, 0xb9, 0xfd, 0x1d, 0x33, 0x67, 0x77, 0x8c, 0x06,
-	0x54, 0xb8, 0xcd, 0x58, 0xfd, 0x13, 0x8c, 0xde, 0x87, 0xa2, 0xe3, 0xbe, 0xc6, 0x5e, 0x96, 0x51,
-	0xb1, 0x15, 0x02, 0x32, 0x24, 0x5e, 0x25, 0xeb, 0x2e, 0xb2, 0x15, 0xe3, 0xff, 0x40, 0x67, 0x13,
-	0xd2, 0x65, 0x98, 0xca, 0x5e, 0x23, 0x5f, 0x90, 0x1b, 0xe9, 0x0b, 0x8c, 0xbf, 0x96, 0x00, 0x18,
-	0x9e, 0xf0, 0x1f, 0xe7, 0x21, 0x3c, 0x3f, 0xda, 0xc9, 0x7c, 0x04, 0x25, 0x97, 0x0a, 0xb8, 0xb6,
-	0x20, 0x29, 0x5d, 0x56, 0x8a, 0xc9, 0x01, 0x92, 0xd6, 0xa6, 0xa6, 0xad, 0x6d, 0x1d, 0x66, 0x07,
-	0x96, 0x87, 0xfb, 0x41, 0x93, 0x73, 0x97, 0x21, 0xae, 0x2a, 0x83, 0xe0, 0x1a, 0x5c, 0x87, 0xd9,
-	0x76, 0xcf, 0x76, 0x3a, 0x1c, 0xc1, 0xaf, 0x55, 0x24, 0x23, 0x15, 0x18, 0x14, 0x82, 0x7d, 0xf8,
-	0xe4, 0x22, 0xf9, 0x81, 0xe5, 0x91, 0x8b, 0x94, 0x9f, 0x7c, 0x91, 0x38, 0x28, 0xfa, 0x02, 0xd4,
-	0xae, 0xdd, 0xb7, 0xfd, 0x1e, 0xee, 0x70, 0x97, 0x3b, 0x0e, 0x2d, 0x84, 0x4d, 0x5c, 0xc0, 0x62,
-	0xf2, 0x02, 0x7e, 0x1e, 0xf3, 0xc0, 0x3a, 0xe5, 0xfd, 0x82, 0xc4, 0x7b, 0x64, 0x0b, 0x31, 0x5f,
-	0xfc, 0x11, 0xe8, 0x1e, 0xb6, 0x3a, 0x67, 0xb2, 0x77, 0xad, 0x5e, 0x57, 0x6e, 0xe7, 0xcd, 0x79,
-	0x3a, 0x2f, 0x99, 0xd0, 0x7a, 0xcc, 0x6d, 0x6b, 0x74, 0x07, 0x5d, 0x96, 0x0e, 0x31, 0xe1, 0x98,
-	0xef, 0xbe, 0x06, 0x85, 0xc0, 0xc3, 0xb8, 0x56, 0x96, 0x64, 0xcf, 0xfc, 0x9b, 0x49, 0x17, 0x88,
-	0x31, 0x93, 0xbf, 0x7e, 0x6d, 0x56, 0x92, 0x35, 0x87, 0x60, 0x2b, 0xc4, 0x74, 0x3a, 0x56, 0x30,
-	0x3c, 0xf5, 0x6b, 0x73, 0x69, 0x2a, 0x7c, 0x09, 0xdd, 0x83, 0x4b, 0x62, 0x5b, 0xa1, 0x70, 0xbf,
-	0xe9, 0x0f, 0xe9, 0xf5, 0xae, 0x21, 0x7a, 0x9c, 0x8b, 0x21, 0x00, 0x57, 0x5f, 0x83, 0x2d, 0x67,
-	0xe3, 0x76, 0x2d, 0xdb, 0x19, 0x7a, 0xb8, 0xb6, 0x98, 0x8d, 0xbb, 0xc7, 0x96, 0xd1, 0x17, 0x70,
-	0x31, 0x8d, 0x1b, 0xb8, 0x81, 0xe5, 0xd4, 0x96, 0x28, 0xe6, 0x85, 0x24, 0xe6, 0x11, 0x59, 0x7c,
-	0x5a, 0x50, 0x4b, 0x7a, 0xf9, 0x69, 0x41, 0x05, 0xbd, 0x62, 0xfc, 0x98, 0x03, 0x95, 0x84, 0x14,
-	0xe1, 0xba, 0xbb, 0xb6, 0x83, 0x63, 0x6e, 0x84, 0x2c, 0x9a, 0x74, 0x1a, 0xad, 0x80, 0x46, 0xfe,
-	0x36, 0x83, 0xb3, 0x01, 0x0b, 0xea, 0x73, 0x1b, 0xb3, 0x21, 0xcc, 0xd1, 0xd9, 0x00, 0x13, 0x7b,
-	0x61, 0xa3, 0x49, 0x0e, 0xfb, 0x0e, 0x68, 0x8c, 0x61, 0x62, 0xbe, 0x30, 0xd1, 0x0e, 0x23, 0x60,
-	0x54, 0x07, 0x95, 0x5e, 0x03, 0x0f, 0xf7, 0x69, 0x20, 0xd6, 0xcc, 0xf0, 0x1b, 0xdd, 0x82, 0xb2,
-	0x4b, 0x55, 0xe3, 0xd7, 0xd4, 0xb4, 0x4a, 0xc5, 0x1a, 0xfa, 0x18, 0xb4, 0x16, 0x09, 0x82, 0x26,
-	0xee, 0xfa, 0xdc, 0x92, 0xd8, 0x39, 0xb6, 0xf8, 0xac, 0x19, 0xad, 0x87, 0xa1, 0x90, 0x58, 0x51,
-	0x95, 0x87, 0xc2, 0x2f, 0x41, 0x23, 0xc7, 0x60, 0x5e, 0x73, 0x49, 0xf6, 0x9a, 0x05, 0xe1, 0x28,
-	0x97, 0x64, 0x47, 0x59, 0x10, 0xbe, 0xd1, 0x04, 0x55, 0xec, 0x81, 0xae, 0x43, 0x91, 0xee, 0xc2,
-	0xa5, 0x0d, 0x12, 0x07, 0x6c, 0x01, 0xdd, 0x84, 0xa2, 0x47, 0xb6, 0xe0, 0xde, 0x63, 0x8e, 0x41,
-	0x88, 0x8d, 0x4d, 0xb6, 0x68, 0xfc, 0x3f, 0x00, 0x3b, 0xa0, 0x70, 0x88, 0xec, 0x98, 0x31, 0x87,
-	0x28, 0x0c, 0x96, 0x2d, 0x11, 0x45, 0xd2, 0x1d, 0x9a, 0x1e, 0xee, 0x72, 0xe2, 0x09, 0x01, 0xa8,
-	0x42, 0x00, 0xc6, 0x26, 0xf5, 0xb7, 0x03, 0xab, 0x4d, 0x1d, 0xdb, 0x2d, 0x98, 0xb3, 0xfb, 0x83,
-	0x21, 0x49, 0x87, 0x70, 0xd7, 0x7e, 0x83, 0xfd, 0x5a, 0x8e, 0xea, 0x60, 0x96, 0xce, 0x1e, 0xf2,
-	0x49, 0xe3, 0xe7, 0x50, 0x6c, 0xf4, 0x2c, 0xaf, 0x83, 0xd6, 0x00, 0xda, 0x21, 0x36, 0x67, 0x69,
-	0x5e, 0xdc, 0x5a, 0x3e, 0x6d, 0x4a, 0x20, 0xd9, 0x67, 0x3e, 0xb4, 0x82, 0x9e, 0x7c, 0x66, 0x74,
-	0x0d, 0x2a, 0xee, 0x30, 0xa0, 0x7c, 0x90, 0x0c, 0x27, 0x4f, 0x3d, 0x30, 0xb0, 0x29, 0x02, 0x4c,
-	0x34, 0x14, 0x22, 0xc5, 0x35, 0xa4, 0x65, 0x6a, 0x48, 0x13, 0x1a, 0xf2, 0x60, 0x61, 0x9b, 0xe6,
-	0x1c, 0x34, 0x7c, 0xe2, 0xef, 0x87, 0xd8, 0x9f, 0x18, 0x5e, 0x13, 0xf1, 0x20, 0x9f, 0x8e, 0x07,
-	0xcb, 0x50, 0x1a, 0x0e, 0x3a, 0x56, 0x80, 0xa9, 0xcf, 0x55, 0x4d, 0xfe, 0xf5, 0xb4, 0xa0, 0xe6,
-	0xf4, 0xbc, 0xb1, 0x09, 0x68, 0xbf, 0xef, 0x0f, 0x88, 0x86, 0xa6, 0xde, 0xd4, 0xb8, 0x08, 0xf3,
-	0xcf, 0x6c, 0x5f, 0xc6, 0x78, 0x5a, 0x50, 0x15, 0x3d, 0x67, 0x3c, 0x00, 0x3d, 0x5a, 0xf0, 0x07,
-	0x6e, 0xdf, 0xa7, 0x37, 0x97, 0x20, 0xc9, 0x79, 0xe6, 0x6c, 0x48, 0x90, 0x25, 0x34, 0x1e, 0x1f,
-	0x19, 0xdf, 0xc1, 0xc2, 0x0e, 0x76, 0xf0, 0xb9, 0x24, 0xb0, 0x04, 0xc5, 0xae, 0xeb, 0xb5, 0x99,
-	0xd6, 0x54, 0x93, 0x7d, 0x20, 0x1d, 0xf2, 0x96, 0xe3, 0x50, 0x79, 0xa8, 0x26, 0x19, 0x1a, 0xbf,
-	0x53, 0x00, 0x35, 0x48, 0x24, 0xe2, 0x3e, 0x9b, 0x53, 0xbf, 0x01, 0x25, 0x16, 0x0c, 0x33, 0xa3,
-	0x38, 0x5b, 0x4a, 0x4a, 0xb9, 0x90, 0x29, 0x65, 0x1e, 0xe7, 0x99, 0x0a, 0x44, 0x68, 0x8f, 0x07,
-	0xa7, 0xe2, 0x94, 0xc1, 0x89, 0x2b, 0xe7, 0x8f, 0x79, 0x40, 0x5b, 0xc3, 0x30, 0xee, 0x9e, 0x8b,
-	0xe5, 0xe5, 0x58, 0x75, 0xa3, 0x65, 0xe4, 0x1a, 0xd5, 0x49, 0xb9, 0x46, 0x9c, 0xf7, 0xd2, 0xb4,
-	0x81, 0x55, 0xc4, 0xbe, 0xfc, 0xc4, 0xd8, 0x57, 0x9e, 0x22, 0xf6, 0xa9, 0xa3, 0x63, 0xdf, 0x1c,
-	0xe4, 0xf6, 0x77, 0x78, 0xc2, 0x9d, 0xdb, 0xdf, 0x49, 0xf8, 0x7d, 0x2d, 0xe9, 0xf7, 0xa5, 0xa4,
-	0x05, 0xde, 0x2d, 0x69, 0xa9, 0x4c, 0x9f, 0xb4, 0x70, 0x0d, 0xfe, 0xa4, 0xc0, 0xe2, 0x1e, 0x9d,
-	0x4a, 0xa9, 0x70, 0x72, 0xee, 0x98, 0xb0, 0xba, 0x5c, 0xda, 0xea, 0xa6, 0x17, 0x75, 0x71, 0x0a,
-	0x51, 0x97, 0x47, 0x8b, 0x3a, 0x2e, 0xda, 0x52, 0x52, 0xb4, 0x4b, 0x50, 0xa4, 0x0d, 0x03, 0xee,
-	0x62, 0xd8, 0x87, 0xd1, 0x87, 0x25, 0xee, 0x5b, 0xde, 0xe1, 0xf0, 0x9f, 0x41, 0x85, 0xc5, 0x09,
-	0x3f, 0x20, 0xbe, 0x8b, 0x85, 0x7c, 0x39, 0xe9, 0x6a, 0x90, 0x79, 0x13, 0x28, 0x10, 0x1d, 0x1b,
-	0xbf, 0x51, 0x60, 0x81, 0xb8, 0x9f, 0xf8, 0x6e, 0x13, 0xdc, 0xc7, 0x35, 0x28, 0x74, 0x3d, 0xf7,
-	0x34, 0xb3, 0xc0, 0x27, 0x0b, 0xe8, 0x32, 0xe4, 0x02, 0x37, 0x26, 0x61, 0xbe, 0x9c, 0x0b, 0x48,
-	0x75, 0x53, 0xea, 0x0f, 0x4f, 0x5b, 0xd8, 0xa3, 0x27, 0x2f, 0x98, 0xfc, 0x0b, 0xd5, 0xa0, 0xec,
-	0xe1, 0x57, 0xd8, 0xf3, 0x31, 0xb5, 0x4f, 0xd5, 0x14, 0x9f, 0xa4, 0x0e, 0x8f, 0x6a, 0x08, 0x5a,
-	0x87, 0xb3, 0x03, 0xa7, 0xeb, 0xf0, 0x08, 0x8c, 0x46, 0x29, 0x3e, 0x36, 0x7e, 0xab, 0xc0, 0x22,
-	0x0b, 0x13, 0xbc, 0x8a, 0xe0, 0xe7, 0x14, 0x9d, 0x0a, 0x65, 0x54, 0xa7, 0xe2, 0x12, 0xa8, 0x7e,
-	0x53, 0xaa, 0x72, 0x34, 0xb3, 0xec, 0xf3, 0x66, 0xca, 0x8d, 0x98, 0xf7, 0x1a, 0x51, 0xa5, 0xc4,
-	0x3b, 0x1d, 0x85, 0xb1, 0x9d, 0x0e, 0xe3, 0x7e, 0xa8, 0xfb, 0x38, 0x97, 0xd1, 0x4e, 0xca, 0xe8,
-	0x42, 0xeb, 0x19, 0xd3, 0x63, 0x1c, 0x73, 0x82, 0x1e, 0x25, 0x89, 0xe7, 0xe2, 0x12, 0x3f, 0x84,
-	0x45, 0x16, 0x54, 0xce, 0xcf, 0x49, 0x76, 0x70, 0x31, 0xee, 0x09, 0x8a, 0xe7, 0xb7, 0x6b, 0xc3,
-	0x02, 0xb4, 0xe7, 0x0c, 0x93, 0xfe, 0xe0, 0x16, 0x94, 0x45, 0xf1, 0xa5, 0xa4, 0x8b, 0x2f, 0xb1,
-	0x86, 0x6e, 0x82, 0x1a, 0xb8, 0x4d, 0x72, 0x5e, 0x96, 0xfc, 0xc4, 0xe4, 0x50, 0x0e, 0x5c, 0xf2,
-	0xd7, 0x37, 0xfe, 0xa4, 0xc0, 0x72, 0x63, 0xd8, 0x22, 0x6e, 0xa2, 0x85, 0xcf, 0x75, 0x19, 0x96,
-	0x63, 0x65, 0xb0, 0x1c, 0x34, 0x0a, 0x44, 0xb7, 0xd4, 0x96, 0x47, 0xc6, 0x00, 0x0a, 0x12, 0xde,
-	0xa7, 0xfc, 0xa8, 0xfb, 0xf4, 0x01, 0x14, 0xd9, 0x95, 0x2e, 0x8c, 0xb8, 0xd2, 0x6c, 0xd9, 0xf8,
-	0x1e, 0xe6, 0x1e, 0xe3, 0x80, 0x96, 0x00, 0x11, 0xf3, 0xe3, 0x4a, 0x84, 0xf7, 0xa1, 0xea, 0x76,
-	0xbb, 0x3e, 0x0e, 0xb8, 0x97, 0xca, 0xd1, 0x3a, 0xa4, 0xc2, 0xe6, 0x98, 0x9f, 0x4a, 0x57, 0x06,
-	0x79, 0xc9, 0x8d, 0x19, 0x1f, 0xc0, 0xdc, 0xc1, 0x2b, 0xec, 0xbd, 0xf6, 0xec, 0x00, 0xef, 0xf7,
-	0x3b, 0xf8, 0x0d, 0xd1, 0xbf, 0x4d, 0x06, 0x74, 0xcf, 0xbc, 0xc9, 0x3e, 0x8c, 0x5f, 0xe4, 0x61,
-	0xee, 0x70, 0x78, 0x1e, 0xde, 0x96, 0xa0, 0xf8, 0xca, 0x72, 0x86, 0xcc, 0x53, 0x57, 0x4d, 0xf6,
-	0x41, 0x92, 0x94, 0xa1, 0xe7, 0xf0, 0x08, 0x46, 0x86, 0xe8, 0x3d, 0x92, 0x2c, 0xb5, 0x87, 0x9e,
-	0x6f, 0xbf, 0xc2, 0xd4, 0xcd, 0xaa, 0x66, 0x34, 0x81, 0x3e, 0x01, 0xad, 0x83, 0x1d, 0xfb, 0xd4,
-	0x0e, 0xb0, 0x47, 0xbd, 0xf5, 0x1c, 0x4f, 0x52, 0x77, 0xc4, 0xac, 0x19, 0x01, 0xa0, 0x4f, 0x00,
-	0x05, 0x96, 0x77, 0x82, 0x83, 0x26, 0xad, 0x9c, 0xa4, 0x78, 0x9a, 0x37, 0x75, 0xb6, 0x42, 0x38,
-	0xdc, 0x61, 0x1e, 0x7e, 0x05, 0x16, 0x64, 0xe8, 0x28, 0x86, 0xe6, 0xcd, 0xf9, 0x08, 0x98, 0x89,
-	0xf1, 0x16, 0xcc, 0x11, 0x8f, 0x82, 0xbd, 0xa6, 0x87, 0xdb, 0xae, 0xd7, 0xf1, 0x69, 0x64, 0xcc,
-	0x9b, 0xb3, 0x6c, 0xd6, 0x64, 0x93, 0xe8, 0x2b, 0x98, 0x77, 0x85, 0x38, 0x9b, 0x4c, 0x8c, 0x2c,
-	0xf0, 0x2e, 0xb2, 0x10, 0x13, 0x13, 0xb5, 0x39, 0xe7, 0xc6, 0x45, 0xbf, 0x0c, 0xa5, 0x0e, 0xbd,
-	0x64, 0x34, 0x51, 0x51, 0x4d, 0xfe, 0xc5, 0x02, 0x2b, 0x6f, 0x7d, 0xfd, 0x5e, 0x81, 0xd9, 0x50,
-	0x11, 0x64, 0xd3, 0x84, 0x86, 0x95, 0x84, 0x86, 0x69, 0xf2, 0x4e, 0x23, 0x5b, 0x93, 0x16, 0x56,
-	0x39, 0x9e, 0xbc, 0xd3, 0xa9, 0x27, 0x96, 0xdf, 0xcb, 0xe2, 0x39, 0x3f, 0x3d, 0xcf, 0xb1, 0xe2,
-	0xa6, 0x30, 0xbe, 0xb8, 0xf9, 0x8b, 0x22, 0x19, 0x11, 0x13, 0xd8, 0x12, 0x14, 0xfd, 0x81, 0xc3,
-	0xfd, 0x87, 0x6a, 0xb2, 0x0f, 0xf4, 0x09, 0xf1, 0x6c, 0x4c, 0xcc, 0xec, 0xce, 0x23, 0x56, 0x98,
-	0xc8, 0xb8, 0xa6, 0x00, 0x21, 0x16, 0x14, 0xb8, 0xa7, 0x2d, 0x3f, 0x70, 0xfb, 0x98, 0xa7, 0xbf,
-	0xd1, 0x04, 0x5a, 0x81, 0x12, 0xd3, 0x11, 0xe7, 0x2e, 0x8b, 0x14, 0x87, 0x20, 0xb0, 0x5d, 0xd7,
-	0x25, 0xa6, 0x56, 0x1c, 0x0d, 0xcb, 0x20, 0x0c, 0x1b, 0xe6, 0xb7, 0xdd, 0xc1, 0x99, 0x7c, 0x23,
-	0x2e, 0x43, 0xde, 0xf7, 0xda, 0xe9, 0x0b, 0x41, 0x66, 0xc9, 0x62, 0xc7, 0x17, 0xad, 0x29, 0x79,
-	0xb1, 0xe3, 0x07, 0xe4, 0x08, 0xa1, 0x5c, 0xc5, 0x11, 0xc2, 0x09, 0xa9, 0x62, 0x99, 0xfe, 0xfe,
-	0x19, 0x3f, 0x63, 0x15, 0xcb, 0x39, 0x6e, 0x2c, 0x82, 0x42, 0x77, 0xe8, 0x38, 0xdc, 0xf1, 0xd3,
-	0x31, 0x89, 0x31, 0x3d, 0xdb, 0x0f, 0x5c, 0xef, 0x8c, 0xfb, 0x0e, 0xf1, 0x69, 0xac, 0xc3, 0xfc,
-	0xff, 0x58, 0xce, 0xcb, 0x73, 0x70, 0x74, 0x08, 0xf3, 0x8f, 0x1d, 0xb7, 0x25, 0x63, 0x4c, 0x95,
-	0x17, 0xd5, 0xa0, 0x3c, 0xb0, 0x82, 0x00, 0x7b, 0x22, 0x21, 0x14, 0x9f, 0xa4, 0xee, 0x14, 0xdd,
-	0x14, 0x3f, 0xec, 0x97, 0xa4, 0xaa, 0x2e, 0x01, 0xc2, 0xfa, 0x25, 0x34, 0xa3, 0x78, 0x0d, 0xf3,
-	0x3b, 0x76, 0xb7, 0x2b, 0xb3, 0x72, 0x13, 0xd4, 0x3e, 0x7e, 0xdd, 0xcc, 0x3e, 0x40, 0xb9, 0x8f,
-	0x5f, 0xd3, 0xa7, 0x80, 0x9b, 0xa0, 0xba, 0x4e, 0x87, 0x41, 0xa5, 0x54, 0x59, 0x76, 0x9d, 0x0e,
-	0x85, 0xaa, 0x41, 0xd9, 0xef, 0x59, 0x8e, 0xe3, 0xbe, 0xe6, 0xca, 0x14, 0x9f, 0xc6, 0x0b, 0xd0,
-	0xa3, 0x8d, 0xa3, 0x72, 0x51, 0xec, 0xec, 0x8f, 0x60, 0x9c, 0x6f, 0x4f, 0x0f, 0x29, 0xf6, 0x17,
-	0x77, 0x23, 0x09, 0xcb, 0x99, 0xf0, 0x8d, 0x0d, 0x51, 0x5a, 0x9e, 0x43, 0x47, 0xd7, 0xa0, 0xb2,
-	0xe7, 0x93, 0xdb, 0xca, 0xa0, 0x75, 0xc8, 0x77, 0xed, 0x37, 0xfc, 0x72, 0x92, 0xa1, 0xf1, 0x05,
-	0x54, 0x19, 0x00, 0x67, 0x5e, 0x82, 0xd0, 0x28, 0x04, 0xcd, 0x8c, 0x3d, 0xcf, 0x0d, 0x2b, 0x7d,
-	0xfa, 0x61, 0xfc, 0x41, 0x81, 0x65, 0xb2, 0xcf, 0xc1, 0x00, 0x7b, 0x16, 0xed, 0x43, 0xb0, 0x2d,
-	0x8e, 0x37, 0xa6, 0x33, 0x82, 0x35, 0x28, 0x0f, 0x86, 0x41, 0x33, 0xb0, 0x44, 0x33, 0x7c, 0x49,
-	0xdc, 0xcd, 0x23, 0xcb, 0x0b, 0x69, 0x3d, 0x99, 0x31, 0x4b, 0x03, 0x3a, 0x85, 0x1e, 0x40, 0x95,
-	0xb9, 0x4f, 0x2e, 0x2c, 0xe6, 0xd3, 0x2e, 0x89, 0xe0, 0xc1, 0xc5, 0xe2, 0xcb, 0xa8, 0x95, 0x4e,
-	0x34, 0xbf, 0x55, 0x01, 0xcd, 0x15, 0xbc, 0x1a, 0xfb, 0x30, 0x9f, 0xd8, 0x89, 0x1c, 0x3c, 0xb0,
-	0x4e, 0xc4, 0xc1, 0x03, 0xf6, 0x56, 0xd3, 0xb1, 0x02, 0x8b, 0xf2, 0x57, 0x35, 0xe9, 0x98, 0x40,
-	0xed, 0x1e, 0xec, 0x89, 0xa2, 0x7c, 0xf7, 0x60, 0xcf, 0x78, 0x00, 0x4b, 0x59, 0xdb, 0xd3, 0xbc,
-	0x2b, 0xb4, 0x00, 0xcd, 0x64, 0x1f, 0x62, 0x97, 0x5c, 0xb8, 0x0b, 0xb9, 0x77, 0x8f, 0x71, 0x9c,
-	0x95, 0x09, 0x3a, 0xed, 0x01, 0x4a, 0xda, 0xdc, 0xf1, 0x06, 0xba, 0x2d, 0x59, 0xb2, 0x22, 0xf9,
-	0xed, 0xd0, 0x90, 0x42, 0x6b, 0xbe, 0x2d, 0xdd, 0x8c, 0x5c, 0x26, 0x24, 0x37, 0x4f, 0x92, 0x02,
-	0x6f, 0x3b, 0xd8, 0xf2, 0x62, 0x19, 0xd8, 0x94, 0x1a, 0x36, 0x7a, 0xa0, 0x1f, 0x0e, 0x03, 0x5e,
-	0x85, 0x71, 0xfb, 0x0b, 0x93, 0x08, 0x45, 0x4e, 0x22, 0xde, 0x83, 0x42, 0x60, 0x9d, 0x08, 0xfb,
-	0x57, 0x29, 0xb1, 0x23, 0xeb, 0xc4, 0xa4, 0xb3, 0x51, 0xa7, 0x2f, 0x3f, 0xa2, 0xd3, 0x67, 0x74,
-	0x45, 0x39, 0x11, 0xdf, 0xec, 0xdf, 0xde, 0xcc, 0xfb, 0x95, 0x02, 0x0b, 0x8f, 0x31, 0x3f, 0x92,
-	0x2f, 0x25, 0xbe, 0xa2, 0x6d, 0xaa, 0x8c, 0x69, 0x9b, 0x66, 0xe5, 0x76, 0x85, 0x49, 0xb9, 0x5d,
-	0xac, 0x44, 0xbd, 0x02, 0x40, 0xdb, 0xd3, 0x4d, 0x32, 0xc5, 0xab, 0x35, 0x8d, 0xce, 0x34, 0xec,
-	0x1f, 0x30, 0xb7, 0x69, 0xce, 0x36, 0x63, 0x6d, 0x72, 0x93, 0x34, 0x54, 0x48, 0x4e, 0x52, 0x88,
-	0xb1, 0x49, 0x6d, 0xf2, 0x7c, 0xa4, 0x8c, 0x5f, 0x2b, 0xa0, 0x0b, 0xac, 0x50, 0x38, 0xb1, 0x66,
-	0xb1, 0x32, 0xa1, 0x59, 0xfc, 0x1f, 0x17, 0x11, 0x62, 0xcd, 0x3d, 0xf9, 0x60, 0xc6, 0xb7, 0xa0,
-	0x1f, 0x59, 0x27, 0xef, 0x60, 0x39, 0x63, 0xad, 0xd6, 0x58, 0x02, 0x44, 0xb6, 0x8a, 0xdb, 0x0a,
-	0x09, 0x99, 0x64, 0xf6, 0xc8, 0x3a, 0x09, 0x25, 0xb4, 0x0c, 0x25, 0xd6, 0x0d, 0xe6, 0xae, 0x87,
-	0x7f, 0xb1, 0x5e, 0x71, 0xdb, 0x19, 0x76, 0x70, 0x93, 0xf3, 0xc2, 0xe2, 0xf8, 0x2c, 0x9f, 0x65,
-	0x94, 0x8d, 0x06, 0x3b, 0x12, 0xa3, 0xc8, 0x7d, 0x78, 0x3d, 0x72, 0x65, 0x32, 0x63, 0xd4, 0xa9,
-	0x45, 0x47, 0xcb, 0x8d, 0x3c, 0x9a, 0xf1, 0xb5, 0xf0, 0x69, 0xef, 0x64, 0xea, 0xc6, 0x45, 0xb8,
-	0x90, 0x40, 0x67, 0x8c, 0x19, 0x9f, 0x89, 0x08, 0x26, 0x0b, 0x40, 0xc8, 0x51, 0x19, 0x25, 0x47,
-	0x19, 0x85, 0x13, 0xba, 0x0b, 0x68, 0xbb, 0x87, 0xdb, 0x2f, 0xcf, 0xaf, 0x36, 0xe3, 0x53, 0x58,
-	0x8c, 0xa1, 0x72, 0x99, 0x2d, 0x43, 0x09, 0xbf, 0xb1, 0xfd, 0xc0, 0xe7, 0xc1, 0x91, 0x7f, 0x19,
-	0xeb, 0x50, 0xe6, 0xa7, 0x98, 0xf6, 0xf4, 0x5f, 0xc3, 0x22, 0xf3, 0x7b, 0x3b, 0xf4, 0x47, 0x0d,
-	0x52, 0xe8, 0x75, 0x5b, 0x2f, 0x44, 0x7c, 0x71, 0x5b, 0x2f, 0x46, 0xdc, 0xbd, 0x0f, 0x61, 0x91,
-	0xf9, 0x98, 0x09, 0xe8, 0xc6, 0x2f, 0x73, 0x50, 0x11, 0x4f, 0x17, 0x24, 0x73, 0xff, 0x32, 0xc9,
-	0xde, 0x15, 0x89, 0x3d, 0x0a, 0xc2, 0xc7, 0xfe, 0x6e, 0x3f, 0xf0, 0xce, 0x22, 0xcf, 0xb4, 0x1a,
-	0x33, 0xe4, 0x7a, 0x0a, 0x8b, 0x48, 0x9e, 0xa1, 0x50, 0xb8, 0xfa, 0x3e, 0x54, 0x65, 0x42, 0x84,
-	0xb5, 0x97, 0xf8, 0x4c, 0xb0, 0xf6, 0x12, 0x9f, 0xa1, 0x1b, 0xf2, 0xc9, 0x52, 0x37, 0x9e, 0xad,
-	0xdd, 0xcb, 0xdd, 0x51, 0xea, 0x3b, 0xa0, 0x85, 0xd4, 0x33, 0xe8, 0xbc, 0x1f, 0xa7, 0x13, 0xef,
-	0xfd, 0x85, 0x54, 0x56, 0x56, 0x00, 0xa2, 0xd7, 0x7d, 0xa4, 0x42, 0xe1, 0xdb, 0xc6, 0xae, 0xa9,
-	0xcf, 0x90, 0xd1, 0xa3, 0x6f, 0x8f, 0x0e, 0x74, 0x85, 0x8c, 0xf6, 0x1a, 0xdb, 0xdf, 0xe8, 0xb9,
-	0x95, 0x8f, 0xd9, 0x83, 0x1d, 0x7d, 0x65, 0xab, 0x82, 0x6a, 0xee, 0x36, 0x76, 0xcd, 0xe3, 0xdd,
-	0x1d, 0x06, 0xbd, 0xb7, 0xff, 0x6c, 0x57, 0x57, 0x50, 0x19, 0xf2, 0x3b, 0xfb, 0xa6, 0x9e, 0x5b,
-	0xd9, 0x14, 0x9d, 0x2e, 0x5a, 0xd6, 0xa3, 0x0a, 0x94, 0x1b, 0x47, 0x8f, 0xcc, 0x23, 0x0a, 0xae,
-	0x41, 0xd1, 0xdc, 0x7d, 0xb4, 0xf3, 0xbf, 0xba, 0x42, 0xe8, 0xec, 0xed, 0x3f, 0xdf, 0x6f, 0x3c,
-	0xd9, 0xdd, 0xd1, 0x73, 0x2b, 0xf7, 0x41, 0x0b, 0x8b, 0x59, 0x42, 0xf4, 0xf9, 0xc1, 0xf3, 0x5d,
-	0x46, 0xfe, 0x69, 0xe3, 0xe0, 0x39, 0x63, 0xe6, 0xd9, 0xfe, 0xf3, 0x5d, 0x3d, 0x47, 0x36, 0x6a,
-	0xfc, 0xf7, 0x33, 0x3d, 0x4f, 0x06, 0xdb, 0x8d, 0x63, 0xbd, 0xb0, 0xf1, 0xa3, 0x0e, 0xf9, 0x47,
-	0x87, 0xfb, 0xe8, 0x01, 0x40, 0xf4, 0x90, 0x82, 0x96, 0x59, 0x7c, 0x4d, 0xbe, 0xac, 0xd4, 0x97,
-	0x53, 0x7d, 0xdc, 0x5d, 0xda, 0xb6, 0x9c, 0x41, 0x5f, 0x42, 0x45, 0x7a, 0x14, 0x41, 0x17, 0x29,
-	0x81, 0xf4, 0x33, 0x49, 0x3d, 0xfe, 0x8e, 0x61, 0xcc, 0xa0, 0xbb, 0xa0, 0x8a, 0xf7, 0x0f, 0xc4,
-	0x52, 0xb2, 0xc4, 0x3b, 0x49, 0xfd, 0x42, 0x62, 0x96, 0x5f, 0xc9, 0x19, 0xc2, 0x73, 0xf4, 0xf4,
-	0xc1, 0x79, 0x4e, 0xbd, 0x85, 0x8c, 0xe1, 0xf9, 0x73, 0xa8, 0x48, 0xaf, 0x1b, 0x9c, 0xe7, 0xf4,
-	0x7b, 0x47, 0x5d, 0xce, 0x36, 0x8c, 0x19, 0xb4, 0x05, 0x55, 0xb9, 0x3f, 0x8d, 0x6a, 0x3c, 0x99,
-	0x49, 0xb5, 0xac, 0xc7, 0x6c, 0xfd, 0x35, 0xcc, 0xc6, 0xfa, 0xbc, 0xe8, 0x92, 0x2c, 0xb0, 0x38,
-	0x95, 0x64, 0x6b, 0xd3, 0x98, 0x41, 0x77, 0x00, 0xa2, 0xae, 0x2d, 0x3f, 0x79, 0xaa, 0x8d, 0x5b,
-	0xd7, 0x13, 0x88, 0xbe, 0x31, 0x83, 0x1e, 0x32, 0xf7, 0x2d, 0xac, 0xcc, 0xc3, 0xd6, 0xe9, 0x48,
-	0xfc, 0xf4, 0xc6, 0xeb, 0x0a, 0x39, 0xbd, 0xdc, 0xc8, 0xe3, 0xa7, 0xcf, 0xe8, 0xed, 0x8d, 0x39,
-	0xfd, 0x7d, 0xa8, 0x48, 0x0d, 0x3d, 0x2e, 0xf8, 0x74, 0x8b, 0x2f, 0x9b, 0x81, 0x6d, 0x98, 0x4f,
-	0x74, 0xea, 0xd0, 0x65, 0xa6, 0xb9, 0xcc, 0xfe, 0x5d, 0x36, 0x91, 0xcf, 0xa1, 0x22, 0xbd, 0x12,
-	0x71, 0x0e, 0xd2, 0xef, 0x46, 0x19, 0xaa, 0x97, 0xfb, 0xc8, 0xfc, 0xf0, 0x19, 0xad, 0xe5, 0xa9,
-	0x54, 0xcf, 0x89, 0xc4, 0x54, 0x1f, 0xa7, 0x92, 0xfc, 0x75, 0x59, 0xa4, 0x7a, 0x8e, 0x1b, 0xa9,
-	0x2e, 0x8e, 0xa8, 0x27, 0x10, 0x7d, 0xc6, 0xbc, 0xdc, 0xd4, 0x8d, 0x69, 0x6e, 0x5a, 0xe6, 0xef,
-	0x41, 0x99, 0x77, 0x33, 0xd0, 0x62, 0xbc, 0xb7, 0x31, 0x01, 0xf3, 0xb6, 0x82, 0xee, 0x81, 0x2a,
-	0x1a, 0x1e, 0xfc, 0xa6, 0x27, 0xfa, 0x1f, 0x63, 0xf6, 0x7d, 0x08, 0x65, 0xde, 0xd9, 0xe4, 0xfb,
-	0xc6, 0xfb, 0x9c, 0xf5, 0xcb, 0x29, 0x4c, 0x9a, 0x9f, 0x1d, 0xd3, 0x08, 0x47, 0x14, 0x1e, 0xf9,
-	0x27, 0x4a, 0x24, 0xe6, 0x9f, 0x64, 0x42, 0xf1, 0xca, 0xc4, 0x98, 0x41, 0x1b, 0xcc, 0x3f, 0x49,
-	0x5c, 0x27, 0xba, 0x22, 0xf5, 0xb9, 0x18, 0x8a, 0x4f, 0x7d, 0xda, 0x9c, 0x00, 0xe2, 0x57, 0x2c,
-	0x1b, 0x33, 0xb9, 0xd9, 0xba, 0x82, 0x36, 0x41, 0x15, 0x5d, 0x11, 0x8e, 0x94, 0x68, 0x92, 0x64,
-	0x21, 0x6d, 0x80, 0x2a, 0x1a, 0x23, 0x1c, 0x29, 0xd1, 0x27, 0xc9, 0xe6, 0x51, 0x00, 0xc5, 0x78,
-	0x4c, 0x62, 0x66, 0x6c, 0x77, 0x17, 0x54, 0x51, 0x0f, 0x72, 0xa4, 0x44, 0x2f, 0x84, 0xbb, 0xec,
-	0x64, 0xd1, 0x28, 0xbb, 0x6c, 0x8a, 0xbc, 0x9c, 0x28, 0xa6, 0xa7, 0xb9, 0x3c, 0x1a, 0x03, 0x7f,
-	0xe4, 0x38, 0x68, 0x04, 0xd8, 0x18, 0xf4, 0x35, 0x28, 0xec, 0xf9, 0xed, 0x97, 0x88, 0x5d, 0x0f,
-	0xa9, 0x51, 0x51, 0x5f, 0x90, 0x66, 0x04, 0xb7, 0xeb, 0x0a, 0x7a, 0x0a, 0xf3, 0xb1, 0xa6, 0xc3,
-	0xf1, 0x06, 0x77, 0x36, 0xd9, 0xad, 0x88, 0xb1, 0xf6, 0xff, 0x08, 0x54, 0x56, 0x78, 0x93, 0x62,
-	0x5d, 0x18, 0xb1, 0x5c, 0x87, 0x4f, 0xb6, 0xe2, 0x87, 0x00, 0x42, 0xa8, 0x21, 0x91, 0xa4, 0xec,
-	0x2f, 0x66, 0xca, 0xfe, 0x78, 0x83, 0x12, 0xd8, 0x81, 0x59, 0xa9, 0xc0, 0x3e, 0xde, 0xe0, 0xce,
-	0x27, 0xab, 0xe8, 0x1e, 0x7d, 0x96, 0x8d, 0xb7, 0x00, 0x1a, 0xcb, 0x8a, 0x48, 0xea, 0xb0, 0x09,
-	0x5a, 0x58, 0x77, 0xa3, 0x0b, 0xc2, 0x2b, 0xc4, 0x32, 0xe5, 0xba, 0x9c, 0x49, 0x51, 0x61, 0xdc,
-	0xa5, 0x9d, 0x5c, 0x36, 0xd1, 0xa0, 0x3d, 0xdb, 0x11, 0x98, 0x55, 0x09, 0xd3, 0xa7, 0xa8, 0x0f,
-	0x01, 0x42, 0x28, 0x7f, 0x14, 0xda, 0x38, 0x45, 0x84, 0x5e, 0x9c, 0xf3, 0x2c, 0x7b, 0xf1, 0x29,
-	0xa9, 0xa0, 0xbb, 0xa0, 0x85, 0x95, 0x39, 0x92, 0x4f, 0x37, 0x59, 0x89, 0xbb, 0x00, 0x51, 0x51,
-	0xcf, 0xef, 0x40, 0xaa, 0xca, 0x9f, 0x4c, 0xe6, 0x2b, 0x50, 0x45, 0xf9, 0x8d, 0xc2, 0x5e, 0x96,
-	0x5c, 0x69, 0x4e, 0x61, 0x8c, 0x32, 0x76, 0xa2, 0x00, 0x9f, 0xcc, 0xc0, 0x36, 0x15, 0x01, 0x2b,
-	0xbf, 0xb9, 0x1a, 0x92, 0xe5, 0xf8, 0x64, 0x22, 0x1b, 0xa0, 0x85, 0x15, 0x32, 0x8a, 0x32, 0xbd,
-	0x18, 0x27, 0x52, 0xed, 0xcf, 0x4f, 0xae, 0x85, 0x15, 0x34, 0xc7, 0x49, 0x56, 0xd4, 0x63, 0x7d,
-	0x80, 0x88, 0xbf, 0x59, 0xda, 0x9b, 0x8f, 0x55, 0x23, 0x34, 0x02, 0x6c, 0x41, 0x45, 0x2a, 0xe0,
-	0x78, 0xe8, 0x48, 0x57, 0x83, 0xf5, 0x5a, 0x7a, 0x21, 0xf4, 0x7b, 0xf7, 0xa1, 0x22, 0x55, 0xe7,
-	0x9c, 0x46, 0xba, 0x5e, 0xcf, 0xd8, 0x7e, 0x5d, 0x41, 0x4f, 0x60, 0x36, 0x56, 0xde, 0x22, 0xb9,
-	0x09, 0x99, 0x20, 0x50, 0xcf, 0x5a, 0x0a, 0xd9, 0xd8, 0x84, 0x12, 0xf5, 0x39, 0x27, 0x28, 0x2c,
-	0x7b, 0x27, 0xab, 0xe8, 0x23, 0x00, 0x2e, 0xb0, 0x38, 0x62, 0x86, 0xa8, 0xee, 0xb3, 0x60, 0x49,
-	0x4a, 0x2c, 0x29, 0xe4, 0x49, 0xc5, 0xb7, 0x94, 0xcc, 0xc7, 0xea, 0x6b, 0xe1, 0xdc, 0xc2, 0xca,
-	0x3b, 0x16, 0x1b, 0x64, 0x02, 0x17, 0x53, 0xf3, 0x92, 0x90, 0xcb, 0xfc, 0x67, 0x6a, 0xef, 0x10,
-	0x1a, 0x76, 0xa0, 0x2a, 0x57, 0xd1, 0xdc, 0x29, 0x64, 0x14, 0xd6, 0x63, 0xaf, 0xd5, 0x3e, 0x54,
-	0xe5, 0x62, 0x9a, 0x53, 0xc9, 0xa8, 0xaf, 0x27, 0x8a, 0x7d, 0xeb, 0xfe, 0x9f, 0xdf, 0x5e, 0x55,
-	0xfe, 0xf6, 0xf6, 0xaa, 0xf2, 0x8f, 0xb7, 0x57, 0x95, 0xef, 0x3e, 0x3d, 0xb1, 0x83, 0xde, 0xb0,
-	0xb5, 0xda, 0x76, 0x4f, 0xd7, 0x06, 0x56, 0xbb, 0x77, 0xd6, 0xc1, 0x9e, 0x3c, 0xf2, 0xbd, 0xf6,
-	0x5a, 0xf4, 0x6f, 0x55, 0x5a, 0x25, 0x4a, 0x75, 0xf3, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x03,
-	0x06, 0x9a, 0xde, 0xc0, 0x32, 0x00, 0x00,
+	// 4148 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5b, 0x5f, 0x73, 0xdb, 0xd6,
+	0x72, 0x17, 0xf8, 0x17, 0x58, 0x52, 0x24, 0x74, 0x24, 0xcb, 0x34, 0x9d, 0xc4, 0x0e, 0x9c, 0xdc,
+	0x38, 0x4a, 0xae, 0xa4, 0x48, 0x4d, 0x1c, 0xdb, 0x37, 0xf6, 0xe8, 0x0f, 0x65, 0xd3, 0xd7, 0xd7,
+	0x52, 0x40, 0x5a, 0x9d, 0xde, 0x69, 0xcb, 0x01, 0xc9, 0x43, 0x11, 0x36, 0x44, 0x30, 0x00, 0x28,
+	0x47, 0xf7, 0xa5, 0xd3, 0x99, 0x4e, 0xfb, 0x21, 0xfa, 0xd0, 0x4e, 0xfb, 0xd8, 0x97, 0x4e, 0xdf,
+	0x3a, 0x7d, 0xb8, 0x0f, 0x7d, 0xe9, 0x63, 0x3f, 0x41, 0xa7, 0xe3, 0x8f, 0xd1, 0xe9, 0x4c, 0x3b,
+	0xe7, 0x1f, 0x70, 0x00, 0x82, 0x22, 0xe5, 0x69, 0x1f, 0x12, 0x01, 0xe7, 0xec, 0xee, 0xd9, 0xb3,
+	0xbb, 0x67, 0x77, 0xcf, 0x0f, 0x34, 0xac, 0xf5, 0x1c, 0x1b, 0x8f, 0x82, 0xad, 0xf1, 0xc0, 0x27,
+	0xff, 0x6d, 0x8e, 0x3d, 0x37, 0x70, 0x51, 0x76, 0x3c, 0xf0, 0xeb, 0xb7, 0xcf, 0x5c, 0xf7, 0xcc,
+	0xc1, 0x5b, 0x74, 0xa8, 0x3b, 0x19, 0x6c, 0xe1, 0xf3, 0x71, 0x70, 0xc9, 0x28, 0xea, 0x77, 0x92,
+	0x93, 0x81, 0x7d, 0x8e, 0xfd, 0xc0, 0x3a, 0x1f, 0x73, 0x82, 0x4f, 0x92, 0x04, 0xef, 0x3c, 0x6b,
+	0x3c, 0xc6, 0x1e, 0x5f, 0xa2, 0xbe, 0x76, 0xe6, 0x9e, 0xb9, 0xf4, 0x71, 0x8b, 0x3c, 0xf1, 0xd1,
+	0x75, 0xae, 0x8e, 0x35, 0x09, 0x86, 0xf4, 0x7f, 0x6c, 0xdc, 0xa8, 0x43, 0xce, 0xc4, 0x63, 0x17,
+	0x21, 0xc8, 0x8d, 0xac, 0x73, 0x5c, 0x53, 0xee, 0x2a, 0xf7, 0x35, 0x93, 0x3e, 0x1b, 0x8f, 0xa1,
+	0xb0, 0xef, 0x59, 0xa3, 0xde, 0x10, 0x7d, 0x0c, 0x39, 0x0f, 0x8f, 0x5d, 0x3a, 0x5b, 0xda, 0xd1,
+	0x36, 0xc9, 0x86, 0x08, 0x9b, 0x99, 0xf3, 0x64, 0xe6, 0x8c, 0xc4, 0xfc, 0x37, 0x19, 0x00, 0xc6,
+	0xdd, 0x1c, 0x0d, 0x5c, 0x74, 0x0f, 0x0a, 0x5d, 0xfa, 0x56, 0xcb, 0x51, 0x19, 0x25, 0x2a, 0x83,
+	0x11, 0x98, 0x7c, 0x0a, 0xdd, 0x81, 0xdc, 0x10, 0x5b, 0xfd, 0x5a, 0x46, 0x22, 0x39, 0x70, 0xcf,
+	0xcf, 0xed, 0xc0, 0xa4, 0x13, 0xe8, 0x2b, 0x80, 0xb1, 0xe7, 0x5e, 0xe0, 0x91, 0x35, 0xea, 0xe1,
+	0x5a, 0xf6, 0x6e, 0x36, 0x29, 0x49, 0x9a, 0x26, 0xc4, 0xfe, 0xa4, 0x2b, 0x88, 0xf3, 0x29, 0xc4,
+	0xd1, 0x34, 0xfa, 0x1e, 0x56, 0xfa, 0xb6, 0x87, 0x7b, 0x41, 0x47, 0x5a, 0xa0, 0x30, 0xcd, 0xa3,
+	0x33, 0xaa, 0x93, 0x68, 0x99, 0x7b, 0x50, 0xf0, 0x7b, 0x43, 0x7c, 0x6e, 0xd5, 0x8a, 0x92, 0xda,
+	0x2d, 0x3a, 0x64, 0xf2, 0xa9, 0x54, 0xf3, 0xf6, 0xa0, 0xcc, 0xa8, 0x0e, 0x5c, 0x67, 0x72, 0x3e,
+	0x4a, 0xa3, 0x41, 0xf7, 0x20, 0x17, 0x5c, 0x8e, 0x99, 0x65, 0x2b, 0x3b, 0x55, 0x6e, 0x11, 0x42,
+	0xde, 0xbe, 0x1c, 0x63, 0x93, 0x4e, 0xa2, 0x3a, 0xa8, 0xa3, 0x89, 0xe3, 0x58, 0x5d, 0x87, 0xd8,
+	0x44, 0xb9, 0xaf, 0x9a, 0xe1, 0xbb, 0xf1, 0x7b, 0x05, 0x0a, 0x6c, 0x15, 0x74, 0x07, 0x4a, 0x6f,
+	0x7c, 0x77, 0xd4, 0xe1, 0xda, 0xb2, 0x65, 0x80, 0x0c, 0x71, 0x82, 0x1d, 0x28, 0xf5, 0xfc, 0x8b,
+	0x4e, 0x8f, 0xca, 0xf7, 0x6b, 0x19, 0xba, 0xfb, 0x15, 0x69, 0x3b, 0x6c, 0x65, 0x13, 0x7a, 0xfe,
+	0x05, 0x7b, 0xf4, 0xd1, 0x03, 0x00, 0x77, 0xd4, 0xb1, 0x47, 0x17, 0x96, 0x63, 0xf7, 0xe9, 0xea,
+	0x95, 0x9d, 0x1a, 0x65, 0x69, 0xb2, 0x31, 0x13, 0xf7, 0x5c, 0xaf, 0xbf, 0xd7, 0x0b, 0x6c, 0x77,
+	0x64, 0x6a, 0xee, 0x88, 0x0f, 0xa3, 0x2f, 0xa0, 0xfa, 0xd3, 0xc4, 0xf2, 0xac, 0x51, 0x60, 0x8f,
+	0x70, 0x67, 0x6c, 0x05, 0x2c, 0x32, 0x34, 0xb3, 0x12, 0x0d, 0x9f, 0x58, 0xc1, 0xd0, 0xf8, 0x07,
+	0x05, 0xf4, 0x53, 0xc2, 0x62, 0x51, 0x11, 0xd8, 0x9f, 0x38, 0x01, 0xba, 0x07, 0xcb, 0x54, 0x4c,
+	0xc7, 0xa3, 0xe2, 0x7d, 0xba, 0x9b, 0xac, 0x59, 0x96, 0x96, 0xf4, 0xd1, 0x97, 0xa0, 0x7b, 0xf8,
+	0x0d, 0xee, 0x05, 0x38, 0xa2, 0xcb, 0x50, 0xba, 0xaa, 0x18, 0x17, 0xa4, 0x5b, 0xb0, 0x1a, 0x2d,
+	0x1b, 0x51, 0x67, 0x29, 0x35, 0x92, 0xa6, 0x04, 0xc3, 0x3a, 0x14, 0xb0, 0xe7, 0xb9, 0x9e, 0x5f,
+	0xcb, 0xdd, 0xcd, 0xde, 0xd7, 0x4c, 0xfe, 0x66, 0x3c, 0x85, 0x52, 0x14, 0xf5, 0x3e, 0xda, 0x86,
+	0x12, 0x8b, 0xed, 0x8e, 0x3d, 0x1a, 0x90, 0xf3, 0x43, 0x4c, 0x5a, 0x95, 0x02, 0x8a, 0x90, 0x99,
+	0xd0, 0x0d, 0x9f, 0x8d, 0xa7, 0x90, 0x3b, 0xb2, 0x1d, 0x1a, 0x56, 0x3d, 0x1a, 0xfa, 0x35, 0x45,
+	0x0a, 0x2b, 0x7e, 0x1a, 0xf8, 0x14, 0x09, 0x19, 0x6a, 0x39, 0x7e, 0xf0, 0xc8, 0xb3, 0x71, 0x1b,
+	0xf2, 0xfb, 0x8e, 0xdb, 0x7b, 0x4b, 0x26, 0x87, 0x96, 0x3f, 0x14, 0xf1, 0x44, 0x9e, 0x8d, 0x8f,
+	0xa0, 0x70, 0xdc, 0x25, 0x5b, 0x4f, 0x9d, 0xbd, 0x05, 0xd9, 0xb6, 0x75, 0x96, 0x1a, 0xac, 0xff,
+	0xa3, 0x80, 0x4a, 0x4e, 0x3c, 0x3d, 0xcc, 0x73, 0xd2, 0xc1, 0x1f, 0x40, 0xb1, 0xe7, 0x61, 0x2b,
+	0xc0, 0xe2, 0x24, 0xd7, 0x37, 0x59, 0xce, 0xda, 0x14, 0x39, 0x6b, 0xb3, 0x2d, 0x92, 0x9a, 0x29,
+	0x48, 0xd1, 0xc7, 0x00, 0xbe, 0xfd, 0x3b, 0xdc, 0xe9, 0x5e, 0x06, 0x98, 0x59, 0x3e, 0x67, 0x6a,
+	0x64, 0x64, 0x9f, 0x0c, 0xa0, 0xbb, 0x50, 0xea, 0x63, 0xbf, 0xe7, 0xd9, 0x63, 0x12, 0x06, 0xb5,
+	0x3c, 0xd5, 0x4d, 0x1e, 0x42, 0x5f, 0x80, 0xca, 0xec, 0x88, 0xfd, 0x5a, 0x71, 0xfa, 0xe4, 0x86,
+	0x93, 0x68, 0x13, 0x34, 0x92, 0x01, 0x99, 0x4b, 0x0a, 0x77, 0x95, 0x30, 0xca, 0xc9, 0x1e, 0xf6,
+	0x26, 0x01, 0x73, 0x8a, 0x6a, 0xf1, 0xa7, 0x17, 0x39, 0x35, 0xa7, 0xe7, 0x8d, 0x27, 0x50, 0x96,
+	0xe7, 0xd1, 0x26, 0x94, 0xad, 0x5e, 0x0f, 0xfb, 0x7e, 0xc7, 0xc1, 0x17, 0xd8, 0xa1, 0xc6, 0xa8,
+	0xec, 0x94, 0x36, 0x09, 0xdb, 0x66, 0xab, 0xe7, 0x8e, 0xb1, 0x59, 0x62, 0x04, 0x2f, 0xc9, 0xbc,
+	0xb1, 0x0b, 0x65, 0xe6, 0xbd, 0x63, 0xcf, 0x3e, 0xb3, 0x47, 0xe4, 0x68, 0xbf, 0xb5, 0x47, 0x7d,
+	0xce, 0xc7, 0x62, 0x82, 0x4d, 0xfd, 0xda, 0x1e, 0xf5, 0x4d, 0x3a, 0x69, 0x3c, 0x85, 0x02, 0x63,
+	0x9a, 0x67, 0xf3, 0x75, 0xc8, 0xd8, 0xcc, 0xdc, 0xda, 0x7e, 0xe1, 0xfd, 0x7f, 0xdc, 0xc9, 0x34,
+	0x0f, 0xcd, 0x8c, 0xdd, 0x37, 0x5a, 0x50, 0xe2, 0x31, 0x63, 0x8d, 0xce, 0x30, 0xfa, 0x14, 0xf2,
+	0x8e, 0xfb, 0x0e, 0x7b, 0x69, 0x41, 0xc5, 0x66, 0x08, 0xc9, 0x84, 0xd4, 0x93, 0xb4, 0x2c, 0xcc,
+	0x66, 0x8c, 0x3f, 0x06, 0x9d, 0x0d, 0xc4, 0xd3, 0xe0, 0xfc, 0x78, 0x8d, 0xaa, 0x40, 0x66, 0x66,
+	0x15, 0x30, 0xfe, 0xa2, 0x08, 0xc0, 0xf8, 0x44, 0xe5, 0xb8, 0x8e, 0xe0, 0xea, 0xec, 0xf2, 0xf2,
+	0x25, 0x14, 0x5c, 0x6a, 0xe0, 0xda, 0x8a, 0xe4, 0x74, 0xd9, 0x29, 0x26, 0x27, 0x48, 0x46, 0x9b,
+	0x3a, 0x1d, 0x6d, 0xdb, 0xb0, 0x3c, 0xb6, 0x3c, 0x3c, 0x0a, 0x3a, 0x5c, 0xbb, 0x14, 0x73, 0x95,
+	0x19, 0x05, 0x7b, 0x23, 0x1c, 0xbd, 0xa1, 0xed, 0xf4, 0x39, 0x83, 0x5f, 0x2b, 0x49, 0x41, 0x2a,
+	0x38, 0x28, 0x05, 0x7b, 0xf1, 0xc9, 0x41, 0xf2, 0x03, 0xcb, 0x23, 0x07, 0x29, 0x3b, 0xff, 0x20,
+	0x71, 0x52, 0xf4, 0x1d, 0xa8, 0x03, 0x7b, 0x64, 0xfb, 0x43, 0xdc, 0xaf, 0xe5, 0xe6, 0xb2, 0x85,
+	0xb4, 0x89, 0x03, 0x98, 0x4f, 0x1e, 0xc0, 0x6f, 0x63, 0xb5, 0x57, 0xa7, 0xba, 0xdf, 0x90, 0x74,
+	0x8f, 0x62, 0x21, 0x56, 0x85, 0x69, 0x12, 0xb6, 0xfa, 0x97, 0x72, 0x5d, 0x2d, 0x8b, 0x24, 0x6c,
+	0xf5, 0x2f, 0x23, 0x36, 0xb4, 0x1d, 0x2b, 0xd8, 0x1a, 0x5d, 0x41, 0x97, 0xad, 0x43, 0x42, 0x38,
+	0x56, 0xb5, 0xef, 0x40, 0x2e, 0xf0, 0x30, 0x8e, 0x55, 0x5e, 0x96, 0xdf, 0x4c, 0x3a, 0x41, 0x82,
+	0x99, 0xfc, 0xf5, 0x6b, 0xcb, 0x77, 0xb3, 0x49, 0x0a, 0x36, 0x43, 0x42, 0xa7, 0x6f, 0x05, 0x93,
+	0x73, 0xbf, 0x56, 0x99, 0x96, 0xc2, 0xa7, 0xd0, 0x23, 0xb8, 0x25, 0x96, 0x15, 0x0e, 0xf7, 0x3b,
+	0xfe, 0x84, 0x1e, 0xef, 0x1a, 0xa2, 0xdb, 0xb9, 0x19, 0x12, 0x70, 0xf7, 0xb5, 0xd8, 0x74, 0x3a,
+	0xef, 0xc0, 0xb2, 0x9d, 0x89, 0x87, 0x6b, 0xab, 0xe9, 0xbc, 0x47, 0x6c, 0x1a, 0x7d, 0x07, 0x37,
+	0xa7, 0x79, 0x03, 0x37, 0xb0, 0x9c, 0xda, 0x1a, 0xe5, 0xbc, 0x91, 0xe4, 0x6c, 0x93, 0x49, 0xe2,
+	0xac, 0x8b, 0xb0, 0x66, 0xd6, 0x6e, 0xdc, 0x55, 0x42, 0x67, 0x25, 0x4b, 0xa9, 0x29, 0x11, 0xbe,
+	0xc8, 0xa9, 0x05, 0xbd, 0xf8, 0x22, 0xa7, 0x82, 0x5e, 0x32, 0xfe, 0x29, 0x03, 0x2a, 0xa9, 0x44,
+	0x22, 0xe3, 0x0f, 0x6c, 0x07, 0xc7, 0xb2, 0x0f, 0x99, 0x34, 0xe9, 0x30, 0xda, 0x00, 0x8d, 0xfc,
+	0xed, 0x48, 0xbd, 0xca, 0x72, 0x48, 0x43, 0x3b, 0x15, 0x75, 0xc0, 0x9f, 0xe6, 0xe5, 0xf9, 0xef,
+	0x41, 0x63, 0xfb, 0x24, 0x51, 0x0f, 0x73, 0xc3, 0x37, 0x22, 0x26, 0x6d, 0x10, 0x3d, 0x3d, 0x1e,
+	0x1e, 0xd1, 0xce, 0x4d, 0x33, 0xc3, 0x77, 0xf4, 0x39, 0x14, 0x5d, 0xea, 0x51, 0xbf, 0xa6, 0x4e,
+	0x47, 0x82, 0x98, 0x43, 0x5f, 0x81, 0xd6, 0x25, 0xb5, 0xd3, 0xc4, 0x03, 0x9f, 0x07, 0x20, 0xdb,
+	0xc7, 0x3e, 0x1f, 0x35, 0xa3, 0xf9, 0xb0, 0x82, 0x92, 0xe0, 0x2b, 0xf3, 0x0a, 0xfa, 0x00, 0x34,
+	0xb2, 0x0d, 0x96, 0x6c, 0xd7, 0xe4, 0x64, 0x9b, 0x13, 0xf9, 0x75, 0x4d, 0xce, 0xaf, 0x39, 0x91,
+	0x52, 0x4d, 0x50, 0xc5, 0x1a, 0xe8, 0x2e, 0xe4, 0xe9, 0x2a, 0xdc, 0xda, 0x20, 0x69, 0xc0, 0x26,
+	0xd0, 0x67, 0x90, 0xf7, 0xc8, 0x12, 0x3c, 0xe9, 0x54, 0x18, 0x85, 0x58, 0xd8, 0x64, 0x93, 0xc6,
+	0x9f, 0x00, 0xb0, 0x0d, 0x8a, 0x3c, 0xca, 0xb6, 0x19, 0xcb, 0xa3, 0x22, 0xce, 0xd9, 0x14, 0x71,
+	0x24, 0x5d, 0xa1, 0xe3, 0xe1, 0x01, 0x17, 0x9e, 0x30, 0x80, 0x2a, 0x0c, 0x60, 0xec, 0xd2, 0x34,
+	0x3d, 0xb6, 0x68, 0x6b, 0x87, 0x3e, 0x87, 0x8a, 0x3d, 0x1a, 0x4f, 0x48, 0xff, 0x8c, 0x07, 0xf6,
+	0xcf, 0x98, 0xf5, 0x8f, 0x9a, 0xb9, 0x4c, 0x47, 0x4f, 0xf8, 0xa0, 0xf1, 0x67, 0x90, 0x6f, 0x0d,
+	0x2d, 0xaf, 0x8f, 0xb6, 0x00, 0x7a, 0x21, 0x37, 0x57, 0x49, 0xf4, 0xb7, 0x62, 0xd8, 0x94, 0x48,
+	0xd2, 0xf7, 0x4c, 0x3a, 0x44, 0x79, 0xcf, 0xa4, 0xc9, 0x75, 0x27, 0x01, 0xd5, 0x83, 0x34, 0x46,
+	0x59, 0xd6, 0xe4, 0xb2, 0x21, 0xda, 0x4e, 0x3e, 0x00, 0x2d, 0x64, 0x8a, 0x7b, 0x48, 0x4b, 0xf5,
+	0x90, 0x26, 0x3c, 0xe4, 0xc1, 0xca, 0x01, 0x6d, 0x55, 0x68, 0xd5, 0xc5, 0x3f, 0x4d, 0xb0, 0x3f,
+	0xb7, 0x2a, 0x27, 0xca, 0x48, 0x76, 0xba, 0x8c, 0xac, 0x43, 0x61, 0x32, 0xee, 0x5b, 0x01, 0xa6,
+	0xa9, 0x5a, 0x35, 0xf9, 0xdb, 0x8b, 0x9c, 0x9a, 0xd1, 0xb3, 0xc6, 0x2e, 0xa0, 0xe6, 0xc8, 0x1f,
+	0x13, 0x0f, 0x2d, 0xbc, 0xa8, 0x71, 0x13, 0xaa, 0x2f, 0x6d, 0x5f, 0xe6, 0x78, 0x91, 0x53, 0x15,
+	0x3d, 0x63, 0x3c, 0x01, 0x3d, 0x9a, 0xf0, 0xc7, 0xee, 0xc8, 0xa7, 0x27, 0x97, 0x30, 0xc9, 0xed,
+	0xe9, 0x72, 0x28, 0x90, 0xf5, 0x41, 0x1e, 0x7f, 0x32, 0x7e, 0x0b, 0x2b, 0x87, 0xd8, 0xc1, 0xd7,
+	0xb2, 0xc0, 0x1a, 0xe4, 0x07, 0xae, 0xd7, 0x63, 0x5e, 0x53, 0x4d, 0xf6, 0x82, 0x74, 0xc8, 0x5a,
+	0x8e, 0xc3, 0x2f, 0x2b, 0xe4, 0xd1, 0xf8, 0x47, 0x05, 0x50, 0x8b, 0x14, 0x30, 0x9e, 0xea, 0xb9,
+	0xf4, 0x7b, 0x50, 0x60, 0x35, 0x34, 0xb5, 0xf8, 0xb3, 0xa9, 0xa4, 0x95, 0x73, 0xa9, 0x56, 0xe6,
+	0xed, 0x01, 0x73, 0x01, 0x7f, 0x4b, 0xd4, 0xb4, 0xfc, 0x82, 0x35, 0x8d, 0x3b, 0xe7, 0xf7, 0x59,
+	0x40, 0xfb, 0x93, 0xb0, 0x5c, 0x5f, 0x4b, 0xe5, 0xf5, 0xd8, 0x75, 0x58, 0x4b, 0x69, 0x51, 0xca,
+	0xf3, 0x5a, 0x94, 0xb8, 0xee, 0x85, 0x45, 0xeb, 0xb1, 0x28, 0x99, 0xd9, 0xb9, 0x25, 0xb3, 0xb8,
+	0x40, 0xc9, 0x54, 0x67, 0x97, 0xcc, 0x0a, 0x64, 0x9a, 0x87, 0xbc, 0x4f, 0xcf, 0x34, 0x0f, 0x13,
+	0x79, 0x5f, 0x4b, 0xe6, 0x7d, 0xa9, 0xd7, 0x81, 0x0f, 0xeb, 0x75, 0x4a, 0x8b, 0xf7, 0x3a, 0xdc,
+	0x83, 0xff, 0xa5, 0xc0, 0xea, 0x11, 0x1d, 0x9a, 0x72, 0xe1, 0xfc, 0x96, 0x33, 0x11, 0x75, 0x99,
+	0xe9, 0xa8, 0x5b, 0xdc, 0xd4, 0xf9, 0x05, 0x4c, 0x5d, 0x9c, 0x6d, 0xea, 0xb8, 0x69, 0x0b, 0x49,
+	0xd3, 0xae, 0x41, 0x9e, 0x22, 0x4c, 0x3c, 0xc5, 0xb0, 0x17, 0x63, 0x04, 0x6b, 0x3c, 0xb7, 0x7c,
+	0xc0, 0xe6, 0xbf, 0x81, 0x12, 0xab, 0x13, 0x7e, 0x40, 0x72, 0x17, 0x2b, 0xf9, 0x72, 0xaf, 0xd6,
+	0x22, 0xe3, 0x26, 0x50, 0x22, 0xfa, 0x6c, 0xfc, 0x9d, 0x02, 0x2b, 0x24, 0xfd, 0xc4, 0x57, 0x9b,
+	0x93, 0x3e, 0xee, 0x40, 0x6e, 0xe0, 0xb9, 0xe7, 0xa9, 0x88, 0x10, 0x99, 0x40, 0xb7, 0x21, 0x13,
+	0xb8, 0xb5, 0xec, 0xf4, 0x74, 0x26, 0x20, 0x97, 0xa2, 0xc2, 0x68, 0x72, 0xde, 0xc5, 0x1e, 0xdd,
+	0x79, 0xce, 0xe4, 0x6f, 0xa8, 0x06, 0x45, 0x0f, 0x5f, 0x60, 0xcf, 0xc7, 0x34, 0x3e, 0x55, 0x53,
+	0xbc, 0x92, 0xeb, 0x7b, 0x74, 0xf5, 0xa0, 0xd7, 0x77, 0xb6, 0xe1, 0xe9, 0xeb, 0x7b, 0x44, 0x46,
+	0xab, 0x14, 0x7f, 0x36, 0xfe, 0x5e, 0x81, 0x55, 0x56, 0x26, 0xf8, 0xe5, 0x83, 0xef, 0x53, 0x40,
+	0x5b, 0xca, 0x2c, 0x68, 0xeb, 0x16, 0xa8, 0x7e, 0x47, 0xba, 0x1c, 0x69, 0x66, 0xd1, 0x67, 0x22,
+	0xa4, 0xcb, 0x4d, 0x76, 0xf6, 0xe5, 0x26, 0x0e, 0x8d, 0xe5, 0xae, 0x84, 0xc6, 0x8c, 0xc7, 0xa1,
+	0xef, 0xe3, 0x5a, 0x46, 0x2b, 0x29, 0xb3, 0xef, 0x67, 0x2f, 0x99, 0x1f, 0xe3, 0x9c, 0x73, 0xfc,
+	0x28, 0x59, 0x3c, 0x13, 0xb7, 0x78, 0x17, 0xd6, 0x5b, 0x98, 0x0b, 0xe3, 0xa0, 0xd9, 0x35, 0x94,
+	0x91, 0xd0, 0xb7, 0xcc, 0x4c, 0xf4, 0xcd, 0x38, 0x81, 0x55, 0x56, 0xb8, 0xae, 0xbf, 0xdb, 0xf4,
+	0x02, 0x66, 0x3c, 0x12, 0x12, 0xaf, 0x7f, 0x76, 0x0c, 0x0b, 0xd0, 0x91, 0x33, 0x49, 0xe6, 0x9c,
+	0xcf, 0xa1, 0x28, 0xee, 0x85, 0xca, 0xf4, 0xbd, 0x50, 0xcc, 0xa1, 0xcf, 0x40, 0x0d, 0xdc, 0x0e,
+	0xb1, 0xa9, 0x00, 0xe8, 0x24, 0x5b, 0x17, 0x03, 0x97, 0xfc, 0xf5, 0x8d, 0x7f, 0x55, 0x60, 0xbd,
+	0x35, 0xe9, 0x92, 0x54, 0xd4, 0xc5, 0xd7, 0x3a, 0x70, 0xeb, 0xb1, 0x1b, 0xba, 0x5c, 0x98, 0x72,
+	0x24, 0x7e, 0xe8, 0x79, 0x99, 0x59, 0x67, 0x28, 0x49, 0x78, 0x66, 0xb3, 0xb3, 0xce, 0xec, 0x2f,
+	0x20, 0xcf, 0xd2, 0x46, 0x6e, 0x46, 0xda, 0x60, 0xd3, 0xc6, 0x4f, 0x50, 0x79, 0x86, 0x03, 0x7a,
+	0xcd, 0x88, 0x94, 0xbf, 0xea, 0x1a, 0xf2, 0x29, 0x94, 0xdd, 0xc1, 0xc0, 0xc7, 0x01, 0xcf, 0x84,
+	0x0c, 0xec, 0x2b, 0xb1, 0x31, 0x96, 0x0b, 0xa7, 0x6f, 0x1f, 0x59, 0x29, 0x55, 0x1a, 0xbf, 0x80,
+	0xca, 0xf1, 0x05, 0xf6, 0xde, 0x79, 0x76, 0x80, 0x9b, 0xa3, 0x3e, 0xfe, 0x99, 0xf8, 0xdf, 0x26,
+	0x0f, 0x1c, 0x61, 0x64, 0x2f, 0xc6, 0x5f, 0x66, 0xa1, 0x72, 0x32, 0xb9, 0x8e, 0x6e, 0x6b, 0x90,
+	0xbf, 0xb0, 0x9c, 0x09, 0xab, 0x06, 0x65, 0x93, 0xbd, 0x90, 0x46, 0x68, 0xe2, 0x39, 0xbc, 0x4a,
+	0x92, 0x47, 0xf4, 0x11, 0x69, 0xc8, 0x7a, 0x13, 0xcf, 0xb7, 0x2f, 0x30, 0x4d, 0xe5, 0xaa, 0x19,
+	0x0d, 0xa0, 0xaf, 0x41, 0xeb, 0x63, 0xc7, 0x3e, 0xb7, 0x03, 0xec, 0xd1, 0x8a, 0x50, 0xe1, 0x8d,
+	0xf0, 0xa1, 0x18, 0x35, 0x23, 0x02, 0xf4, 0x35, 0xa0, 0xc0, 0xf2, 0xce, 0x70, 0xd0, 0xa1, 0xb7,
+	0x33, 0xa9, 0x66, 0x67, 0x4d, 0x9d, 0xcd, 0x10, 0x0d, 0x0f, 0xe9, 0x38, 0xda, 0x80, 0x15, 0x99,
+	0x3a, 0xaa, 0xd3, 0x59, 0xb3, 0x1a, 0x11, 0x33, 0x33, 0x7e, 0x0e, 0x15, 0x92, 0xb5, 0xb0, 0x17,
+	0x42, 0xa5, 0x25, 0x4a, 0xb8, 0xcc, 0x46, 0x05, 0x4a, 0xfa, 0x2b, 0xa8, 0xba, 0xc2, 0x9c, 0x1d,
+	0x66, 0x46, 0x56, 0xdc, 0x57, 0x59, 0x19, 0x8b, 0x99, 0xda, 0xac, 0xb8, 0x71, 0xd3, 0xaf, 0x43,
+	0xa1, 0x4f, 0x0f, 0x19, 0x6d, 0x86, 0x54, 0x93, 0xbf, 0xb1, 0xe2, 0xcd, 0x51, 0xb9, 0x7f, 0x56,
+	0x60, 0x39, 0x74, 0x04, 0x59, 0x34, 0xe1, 0x61, 0x25, 0xe1, 0x61, 0x7a, 0x41, 0xa0, 0xd5, 0xb3,
+	0x43, 0x2f, 0x6f, 0x19, 0x7e, 0x41, 0xa0, 0x43, 0xcf, 0x2d, 0x7f, 0x98, 0xa6, 0x73, 0x76, 0x71,
+	0x9d, 0x63, 0x17, 0xa8, 0xdc, 0xd5, 0x17, 0xa8, 0x3f, 0xcf, 0x40, 0x25, 0xa6, 0x3b, 0x2d, 0xd5,
+	0xfe, 0xd8, 0xe1, 0xf9, 0x43, 0x35, 0xd9, 0x0b, 0xfa, 0x9a, 0x64, 0x4f, 0x81, 0x5f, 0x93, 0x33,
+	0x8f, 0xd8, 0xe5, 0x47, 0xe6, 0x35, 0x05, 0x09, 0x89, 0xa0, 0xc0, 0x3d, 0xef, 0xfa, 0x81, 0x3b,
+	0x12, 0xdf, 0x03, 0xa2, 0x01, 0xb4, 0x01, 0x05, 0xe6, 0x23, 0xae, 0x5d, 0x9a, 0x28, 0x4e, 0x41,
+	0x68, 0x07, 0xae, 0x4b, 0x42, 0x2d, 0x3f, 0x9b, 0x96, 0x51, 0x24, 0x10, 0x87, 0xc2, 0x82, 0x88,
+	0x83, 0x61, 0x43, 0xf5, 0xc0, 0x1d, 0x5f, 0xca, 0x07, 0xe9, 0x36, 0x64, 0x7d, 0xaf, 0x37, 0x7d,
+	0x8e, 0xc8, 0x28, 0x99, 0xec, 0xfb, 0x02, 0x6c, 0x93, 0x27, 0xfb, 0x7e, 0x40, 0x76, 0x1e, 0xba,
+	0x43, 0xec, 0x3c, 0x1c, 0x90, 0x2e, 0x53, 0x8b, 0x1f, 0x5b, 0xe3, 0x4f, 0xd9, 0x65, 0xea, 0x1a,
+	0x07, 0x1d, 0x41, 0x6e, 0x30, 0x71, 0x1c, 0x5e, 0x2f, 0xe8, 0x33, 0x29, 0x7f, 0x43, 0xdb, 0x0f,
+	0x5c, 0xef, 0x92, 0xa7, 0x1c, 0xf1, 0x6a, 0x6c, 0x43, 0xf5, 0x0f, 0x2d, 0xe7, 0xed, 0x35, 0x34,
+	0x3a, 0x81, 0xea, 0x33, 0xc7, 0xed, 0xca, 0x1c, 0x0b, 0xb5, 0x6c, 0x35, 0x28, 0x8e, 0xad, 0x20,
+	0xc0, 0x9e, 0xe8, 0x55, 0xc5, 0x2b, 0xb9, 0x12, 0x0b, 0xa0, 0xc7, 0x0f, 0xa1, 0x9c, 0xa9, 0x0b,
+	0xa1, 0x20, 0x61, 0x50, 0x0e, 0x79, 0x32, 0xde, 0x41, 0xf5, 0xd0, 0x1e, 0x0c, 0x64, 0x55, 0x3e,
+	0x03, 0x75, 0x84, 0xdf, 0x75, 0xd2, 0x37, 0x50, 0x1c, 0xe1, 0x77, 0xe4, 0x81, 0x50, 0xb9, 0x4e,
+	0x9f, 0x51, 0x4d, 0xb9, 0xb2, 0xe8, 0x3a, 0x7d, 0x4a, 0x55, 0x83, 0xa2, 0x3f, 0xb4, 0x1c, 0xc7,
+	0x7d, 0xc7, 0x9d, 0x29, 0x5e, 0x8d, 0x37, 0xa0, 0x47, 0x0b, 0x47, 0x37, 0x59, 0xb1, 0xb2, 0x3f,
+	0x43, 0x71, 0xbe, 0x3c, 0xdd, 0xa4, 0x58, 0x5f, 0x1c, 0xa9, 0x24, 0x2d, 0x57, 0xc2, 0x37, 0x76,
+	0xc4, 0xad, 0xf7, 0x1a, 0x3e, 0xba, 0x03, 0xa5, 0x23, 0xbf, 0xf7, 0x56, 0x50, 0xeb, 0x90, 0x1d,
+	0xd8, 0x3f, 0xf3, 0x33, 0x4d, 0x1e, 0x8d, 0xef, 0xa0, 0xcc, 0x08, 0xb8, 0xf2, 0x12, 0x85, 0x46,
+	0x29, 0x68, 0xd3, 0x4e, 0x3e, 0x29, 0x09, 0x10, 0x82, 0xbe, 0x18, 0xff, 0xa2, 0xc0, 0x3a, 0x59,
+	0xe7, 0x78, 0x8c, 0x3d, 0x7e, 0xa4, 0xe8, 0x12, 0xa7, 0x3b, 0x8b, 0x05, 0xc1, 0x16, 0x14, 0x09,
+	0x36, 0x12, 0x58, 0x02, 0xde, 0x5f, 0x13, 0x47, 0xba, 0x6d, 0x79, 0xa1, 0xac, 0xe7, 0x4b, 0x66,
+	0x61, 0x4c, 0x87, 0xd0, 0x13, 0x28, 0xb3, 0xac, 0xcb, 0x8d, 0xc5, 0x52, 0xe1, 0x2d, 0x51, 0x73,
+	0xb8, 0x59, 0x7c, 0x99, 0xb5, 0xd4, 0x8f, 0xc6, 0xf7, 0x4b, 0xa0, 0xb9, 0x42, 0x57, 0xa3, 0x09,
+	0xd5, 0xc4, 0x4a, 0x64, 0xe3, 0x81, 0x75, 0x26, 0x36, 0x1e, 0xb0, 0xaf, 0x4f, 0x7d, 0x2b, 0x60,
+	0xfd, 0x5c, 0xd9, 0xa4, 0xcf, 0x84, 0xaa, 0x71, 0x7c, 0x24, 0xf0, 0x82, 0xc6, 0xf1, 0x91, 0xf1,
+	0x04, 0xd6, 0xd2, 0x96, 0xa7, 0xed, 0x5a, 0x18, 0x01, 0x9a, 0xc9, 0x5e, 0xc4, 0x2a, 0x99, 0x70,
+	0x15, 0x72, 0xee, 0x9e, 0xe1, 0xb8, 0x2a, 0x73, 0x7c, 0x3a, 0x04, 0x94, 0x8c, 0xb9, 0xd3, 0x1d,
+	0x74, 0x5f, 0x8a, 0x64, 0x45, 0x4a, 0xf7, 0x61, 0x20, 0x85, 0xd1, 0x7c, 0x5f, 0x3a, 0x19, 0x99,
+	0x54, 0x4a, 0x1e, 0x9e, 0xa4, 0x3b, 0x3f, 0x70, 0xb0, 0xe5, 0xc5, 0x1a, 0xb7, 0x05, 0x3d, 0x6c,
+	0x0c, 0x41, 0x3f, 0x99, 0x04, 0xfc, 0x82, 0xc8, 0xe3, 0x2f, 0xec, 0x3d, 0x14, 0xb9, 0xf7, 0xf8,
+	0x08, 0x72, 0x81, 0x75, 0x26, 0xe2, 0x5f, 0xa5, 0xc2, 0xda, 0xd6, 0x99, 0x49, 0x47, 0x23, 0x10,
+	0x32, 0x3b, 0x03, 0x84, 0x34, 0x06, 0xe2, 0xa6, 0x13, 0x5f, 0xec, 0xff, 0x1c, 0x67, 0xfc, 0x6b,
+	0x05, 0x56, 0x9e, 0x61, 0xbe, 0x25, 0x5f, 0xea, 0x97, 0x05, 0xa2, 0xab, 0x5c, 0x81, 0xe8, 0xa6,
+	0xb5, 0x84, 0xb9, 0x79, 0x2d, 0x61, 0xec, 0xf6, 0xfc, 0x31, 0x00, 0x05, 0xdc, 0x3b, 0x64, 0x88,
+	0x5f, 0x24, 0x35, 0x3a, 0xd2, 0xb2, 0x7f, 0x87, 0x79, 0x4c, 0x73, 0xb5, 0x99, 0x6a, 0xf3, 0xf1,
+	0xdb, 0xd0, 0x21, 0x19, 0xc9, 0x21, 0xc6, 0x2e, 0x8d, 0xc9, 0xeb, 0x89, 0x32, 0xfe, 0x56, 0x01,
+	0x5d, 0x70, 0x85, 0xc6, 0x89, 0xe1, 0xd8, 0xca, 0x1c, 0x1c, 0xfb, 0xff, 0xdd, 0x44, 0x88, 0xe1,
+	0x8e, 0xf2, 0xc6, 0x8c, 0xd7, 0xa0, 0xb7, 0xad, 0xb3, 0x0f, 0x88, 0x9c, 0x2b, 0xa3, 0xd6, 0x58,
+	0x03, 0x44, 0x96, 0x8a, 0xc7, 0x0a, 0x29, 0x99, 0x64, 0xb4, 0x6d, 0x9d, 0x85, 0x16, 0x5a, 0x87,
+	0x02, 0x03, 0xaa, 0x79, 0xea, 0xe1, 0x6f, 0x0c, 0xc6, 0xee, 0x39, 0x93, 0x3e, 0xee, 0x70, 0x5d,
+	0x58, 0x1d, 0x5f, 0xe6, 0xa3, 0x4c, 0xb2, 0xd1, 0x02, 0x3d, 0x92, 0xc8, 0x73, 0x78, 0x3d, 0x4a,
+	0x65, 0xb2, 0x62, 0x64, 0x50, 0xda, 0x5a, 0x66, 0xe6, 0xd6, 0x8c, 0x1f, 0x44, 0x4e, 0xfb, 0xa0,
+	0x50, 0x37, 0x6e, 0xc2, 0x8d, 0x04, 0x3b, 0x53, 0xcc, 0xf8, 0x46, 0x54, 0x30, 0xd9, 0x00, 0xc2,
+	0x8e, 0xca, 0x2c, 0x3b, 0xca, 0x2c, 0x5c, 0xd0, 0x43, 0x40, 0x07, 0x43, 0xdc, 0x7b, 0x7b, 0x7d,
+	0xb7, 0x19, 0xbf, 0x84, 0xd5, 0x18, 0x2b, 0xb7, 0x19, 0xf9, 0x19, 0xc5, 0xcf, 0xb6, 0x1f, 0xf8,
+	0xbc, 0x38, 0xf2, 0x37, 0x63, 0x1b, 0x8a, 0x7c, 0x17, 0x8b, 0xee, 0xfe, 0x07, 0x58, 0x65, 0x79,
+	0xef, 0xd0, 0xf6, 0x24, 0xe5, 0x74, 0xc8, 0xba, 0xdd, 0x37, 0xa2, 0xbe, 0xb8, 0xdd, 0x37, 0x33,
+	0xce, 0xde, 0x17, 0xb0, 0xfa, 0x0c, 0x2f, 0xc0, 0x6e, 0xfc, 0x55, 0x06, 0x4a, 0xe2, 0xab, 0x0a,
+	0x69, 0xf8, 0x1f, 0x24, 0xd5, 0xfb, 0x58, 0x52, 0x8f, 0x92, 0xf0, 0x67, 0xbf, 0x31, 0x0a, 0xbc,
+	0xcb, 0x28, 0x33, 0x6d, 0xc6, 0x02, 0xb9, 0x3e, 0xc5, 0x45, 0x2c, 0xcf, 0x58, 0x28, 0x5d, 0xbd,
+	0x09, 0x65, 0x59, 0x10, 0x51, 0xed, 0x2d, 0xbe, 0x14, 0xaa, 0xbd, 0xc5, 0x97, 0xe8, 0x9e, 0xbc,
+	0xb3, 0xa9, 0x13, 0xcf, 0xe6, 0x1e, 0x65, 0xbe, 0x57, 0xea, 0x87, 0xa0, 0x85, 0xd2, 0x53, 0xe4,
+	0x7c, 0x1a, 0x97, 0x13, 0x87, 0x25, 0x43, 0x29, 0x1b, 0x0d, 0x00, 0xf6, 0x2b, 0x20, 0xfa, 0x59,
+	0x0f, 0xa0, 0xd0, 0x6a, 0x9b, 0xcd, 0x57, 0xcf, 0xf4, 0x25, 0x54, 0x84, 0x6c, 0xf3, 0x55, 0x5b,
+	0x57, 0x90, 0x06, 0xf9, 0xa3, 0x97, 0xc7, 0x7b, 0x6d, 0x3d, 0x83, 0x54, 0xc8, 0xed, 0x1f, 0x1f,
+	0xbf, 0xd4, 0xb3, 0x68, 0x19, 0xb4, 0x76, 0xf3, 0x37, 0x8d, 0x56, 0x7b, 0xef, 0x37, 0x27, 0x7a,
+	0x6e, 0xe3, 0x1b, 0x58, 0x4d, 0xf9, 0xa9, 0x10, 0x91, 0x67, 0x36, 0x5e, 0x34, 0x0e, 0xda, 0xfa,
+	0x12, 0xaa, 0x00, 0xfc, 0xf8, 0x7a, 0xcf, 0xdc, 0x7b, 0xd5, 0x6e, 0xbe, 0x6a, 0xe8, 0xca, 0xc6,
+	0x06, 0x40, 0xf4, 0x4b, 0x09, 0x22, 0xf9, 0x75, 0xab, 0x61, 0xea, 0x4b, 0xe4, 0x69, 0xef, 0x75,
+	0xfb, 0x58, 0x57, 0xc8, 0xd3, 0x51, 0xeb, 0xe0, 0xd7, 0x7a, 0x66, 0xe3, 0x2b, 0xf6, 0x15, 0x93,
+	0xea, 0x58, 0x06, 0xd5, 0x6c, 0xb4, 0x1a, 0xe6, 0x69, 0xe3, 0x90, 0x51, 0x1f, 0x35, 0x5f, 0x36,
+	0x74, 0x85, 0xe8, 0x7b, 0xd8, 0x34, 0xf5, 0xcc, 0xc6, 0x2e, 0x94, 0x24, 0x1c, 0x02, 0x95, 0xa0,
+	0xd8, 0x6a, 0xef, 0x99, 0x6d, 0x4a, 0xae, 0x41, 0xde, 0x6c, 0xec, 0x1d, 0xfe, 0x91, 0xae, 0x10,
+	0x39, 0x47, 0xcd, 0x57, 0xcd, 0xd6, 0xf3, 0xc6, 0xa1, 0x9e, 0xd9, 0x30, 0x41, 0x0b, 0x6f, 0xdf,
+	0x44, 0xe8, 0xab, 0xe3, 0x57, 0x0d, 0x26, 0xfe, 0x45, 0xeb, 0xf8, 0x15, 0x53, 0xe6, 0x25, 0x51,
+	0x3c, 0x43, 0x16, 0x6a, 0xfd, 0x48, 0x6c, 0x50, 0x84, 0xec, 0x41, 0xeb, 0x54, 0xcf, 0x91, 0x25,
+	0x4e, 0xf6, 0xcc, 0x1f, 0x5f, 0x37, 0xda, 0x7a, 0x9e, 0xea, 0x7f, 0x6a, 0x1e, 0xeb, 0x85, 0x9d,
+	0xff, 0xd6, 0x21, 0xbb, 0x77, 0xd2, 0x44, 0x4f, 0x00, 0xa2, 0x8f, 0x4e, 0x68, 0x9d, 0x15, 0xfc,
+	0xe4, 0x57, 0xa8, 0xfa, 0xfa, 0x14, 0xe6, 0xdd, 0xa0, 0x10, 0xef, 0x12, 0x7a, 0x00, 0x25, 0xe9,
+	0x03, 0x12, 0xba, 0xc9, 0x7f, 0x99, 0x95, 0xfc, 0xa4, 0x54, 0x8f, 0x7f, 0xf3, 0x31, 0x96, 0xd0,
+	0x43, 0x50, 0xc5, 0xb7, 0x22, 0xc4, 0x7a, 0xc4, 0xc4, 0x37, 0xa5, 0xfa, 0x8d, 0xc4, 0x28, 0xcf,
+	0x11, 0x4b, 0x44, 0xe7, 0xe8, 0x33, 0x11, 0xd7, 0x79, 0xea, 0xbb, 0xd1, 0x15, 0x3a, 0x7f, 0x0b,
+	0x25, 0xe9, 0x4b, 0x10, 0xd7, 0x79, 0xfa, 0xdb, 0x50, 0x5d, 0x6e, 0x7f, 0x8c, 0x25, 0xb4, 0x0f,
+	0x65, 0x19, 0xcb, 0x47, 0x35, 0xde, 0x5d, 0x4d, 0xc1, 0xfb, 0x57, 0x2c, 0xfd, 0x03, 0x2c, 0xc7,
+	0x30, 0x71, 0x74, 0x4b, 0x36, 0x58, 0x5c, 0x4a, 0x12, 0x06, 0x36, 0x96, 0xd0, 0xf7, 0x00, 0x11,
+	0xc2, 0xcd, 0x77, 0x3e, 0x05, 0x79, 0xd7, 0xf5, 0x04, 0xa3, 0x6f, 0x2c, 0xa1, 0xa7, 0xac, 0x9e,
+	0x88, 0xe0, 0xf3, 0xb0, 0x75, 0x3e, 0x93, 0x7f, 0x7a, 0xe1, 0x6d, 0x85, 0xec, 0x5e, 0x06, 0x24,
+	0xf9, 0xee, 0x53, 0x30, 0xca, 0x2b, 0x76, 0xff, 0x18, 0x4a, 0x12, 0x30, 0xc9, 0x0d, 0x3f, 0x0d,
+	0x55, 0xa6, 0x2b, 0x70, 0x00, 0xd5, 0x04, 0xe2, 0x88, 0x6e, 0x33, 0xcf, 0xa5, 0xe2, 0x90, 0xe9,
+	0x42, 0xbe, 0x85, 0x92, 0xf4, 0x45, 0x8d, 0x6b, 0x30, 0xfd, 0x8d, 0x2d, 0xc5, 0xf5, 0x32, 0xe6,
+	0xce, 0x37, 0x9f, 0x02, 0xc3, 0x2f, 0xe4, 0x7a, 0x2e, 0x24, 0xe6, 0xfa, 0xb8, 0x94, 0xe4, 0x0f,
+	0xf8, 0x22, 0xd7, 0x73, 0xde, 0xc8, 0x75, 0x71, 0x46, 0x3d, 0xc1, 0xe8, 0x33, 0xe5, 0x65, 0x70,
+	0x3a, 0xe6, 0xb9, 0x45, 0x95, 0x7f, 0x0e, 0xd5, 0x04, 0x88, 0x2e, 0x8c, 0x9f, 0x0a, 0xad, 0x5f,
+	0x21, 0xe9, 0x11, 0x14, 0x39, 0xbe, 0x83, 0x56, 0xe3, 0x68, 0xcf, 0x1c, 0xce, 0xfb, 0x0a, 0x7a,
+	0x04, 0xaa, 0xc0, 0x72, 0x78, 0xce, 0x48, 0x40, 0x3b, 0x57, 0xac, 0xfb, 0x14, 0x8a, 0xcf, 0xb0,
+	0xbc, 0x6e, 0x1c, 0xf9, 0xad, 0xdf, 0x9e, 0xe2, 0xa4, 0xad, 0xe7, 0x29, 0x2d, 0xde, 0x24, 0x74,
+	0xa2, 0x4c, 0x47, 0x85, 0xc4, 0x32, 0x9d, 0x2c, 0x28, 0x7e, 0xe9, 0x32, 0x96, 0xd0, 0x0e, 0xcb,
+	0x74, 0x92, 0xd6, 0x09, 0xc0, 0xa7, 0x5e, 0x89, 0xb1, 0xf8, 0x34, 0x3b, 0x56, 0x04, 0x11, 0x3f,
+	0xac, 0xe9, 0x9c, 0xc9, 0xc5, 0xb6, 0x15, 0xb4, 0x0b, 0xaa, 0x00, 0x7c, 0x38, 0x53, 0x02, 0xff,
+	0x49, 0x63, 0xda, 0x01, 0x55, 0x60, 0x3e, 0x9c, 0x29, 0x01, 0x01, 0xa5, 0xeb, 0x28, 0x88, 0x62,
+	0x3a, 0x26, 0x39, 0x53, 0x96, 0x7b, 0x08, 0xaa, 0xb8, 0xea, 0x72, 0xa6, 0x04, 0xcc, 0x53, 0xbf,
+	0x91, 0x18, 0x9d, 0x4e, 0xfe, 0x94, 0x79, 0x3d, 0x81, 0x13, 0x2c, 0x72, 0x0c, 0x35, 0x46, 0xbe,
+	0xe7, 0x38, 0x68, 0x06, 0xd9, 0x15, 0xec, 0x5b, 0x90, 0x23, 0xb8, 0x0a, 0x62, 0x07, 0x4d, 0xc2,
+	0x60, 0xea, 0x2b, 0xd2, 0x88, 0xd0, 0x76, 0x5b, 0x41, 0x2f, 0xa0, 0x1a, 0xc3, 0x53, 0x4e, 0x77,
+	0xf8, 0xc9, 0x49, 0x47, 0x59, 0xae, 0x8c, 0xff, 0x3d, 0x50, 0x19, 0xa6, 0x40, 0x70, 0x08, 0x11,
+	0xc4, 0x32, 0xc4, 0x30, 0x3f, 0x8a, 0x9f, 0x02, 0x08, 0xa3, 0x86, 0x42, 0x92, 0xb6, 0xbf, 0x99,
+	0x6a, 0xfb, 0xd3, 0x1d, 0x2a, 0xe0, 0x10, 0x96, 0x25, 0xec, 0xe0, 0x74, 0x87, 0xa7, 0xb1, 0x34,
+	0x3c, 0x61, 0xf6, 0x5e, 0x76, 0xde, 0x03, 0x68, 0xac, 0xe1, 0x23, 0x4d, 0xc8, 0x2e, 0x68, 0x21,
+	0xa4, 0x80, 0x6e, 0x88, 0xac, 0x10, 0xbb, 0x04, 0xd4, 0xe5, 0x26, 0x91, 0x1a, 0xe3, 0x21, 0xc5,
+	0xb6, 0xd9, 0x40, 0x8b, 0xa2, 0xd8, 0x33, 0x38, 0xcb, 0x12, 0xa7, 0x4f, 0x59, 0x9f, 0x02, 0x84,
+	0x54, 0xfe, 0x2c, 0xb6, 0xab, 0x1c, 0x11, 0xd6, 0x03, 0xae, 0xb3, 0x5c, 0x0f, 0x16, 0x94, 0x82,
+	0x1e, 0x82, 0x16, 0x82, 0x0e, 0x48, 0xde, 0xdd, 0x7c, 0x27, 0x36, 0x00, 0x42, 0x56, 0x9f, 0x9f,
+	0x81, 0x29, 0x00, 0x63, 0xbe, 0x98, 0x5f, 0x81, 0x2a, 0x90, 0x05, 0x14, 0xc2, 0x74, 0xf2, 0x25,
+	0x7a, 0x81, 0x60, 0x94, 0xb9, 0x13, 0xd8, 0xc2, 0x7c, 0x05, 0x0e, 0x40, 0x13, 0x3c, 0xc2, 0x0d,
+	0x49, 0xa4, 0x61, 0xbe, 0x90, 0x1d, 0xd0, 0xc2, 0xcb, 0x3f, 0x8a, 0x7a, 0xc6, 0x98, 0x26, 0x12,
+	0xac, 0xc1, 0x77, 0xae, 0x85, 0xe0, 0x00, 0xe7, 0x49, 0x82, 0x05, 0x57, 0xe6, 0x00, 0x51, 0xc9,
+	0xd3, 0xbc, 0x57, 0x8d, 0x5d, 0xb4, 0x68, 0x05, 0xd8, 0x87, 0x92, 0x74, 0x37, 0xe5, 0xa5, 0x63,
+	0xfa, 0xa2, 0x5b, 0xaf, 0x4d, 0x4f, 0x84, 0x79, 0xef, 0x31, 0x94, 0x24, 0xe0, 0x81, 0xcb, 0x98,
+	0x86, 0x22, 0x52, 0x96, 0xdf, 0x56, 0xd0, 0x73, 0x58, 0x8e, 0xdd, 0xdc, 0x91, 0x8c, 0xaf, 0x26,
+	0x04, 0xd4, 0xd3, 0xa6, 0x42, 0x35, 0x76, 0xa1, 0x40, 0x73, 0xce, 0x19, 0x0a, 0x6f, 0xf4, 0xf3,
+	0x5d, 0xf4, 0x25, 0x00, 0x37, 0x58, 0x9c, 0x31, 0xc5, 0x54, 0x8f, 0x59, 0xb1, 0x24, 0xb7, 0x47,
+	0xa9, 0xe4, 0x49, 0xb8, 0x42, 0xfd, 0x46, 0x62, 0x54, 0xca, 0xb5, 0x4f, 0x45, 0x6d, 0xa0, 0xec,
+	0x72, 0x6d, 0x90, 0x05, 0xdc, 0x9c, 0x1a, 0x97, 0x8c, 0x5c, 0xe4, 0x3f, 0x0e, 0xfc, 0x80, 0xd2,
+	0x70, 0x08, 0x65, 0x19, 0x20, 0xe0, 0x49, 0x21, 0x05, 0x33, 0xb8, 0xf2, 0x58, 0x35, 0xa1, 0xfc,
+	0x0c, 0x4f, 0x49, 0x49, 0x81, 0x0e, 0xe6, 0x9a, 0x7d, 0xff, 0xf1, 0xbf, 0xbd, 0xff, 0x44, 0xf9,
+	0xf7, 0xf7, 0x9f, 0x28, 0xff, 0xf9, 0xfe, 0x13, 0xe5, 0xb7, 0xbf, 0x3c, 0xb3, 0x83, 0xe1, 0xa4,
+	0xbb, 0xd9, 0x73, 0xcf, 0xb7, 0xc6, 0x56, 0x6f, 0x78, 0xd9, 0xc7, 0x9e, 0xfc, 0xe4, 0x7b, 0xbd,
+	0xad, 0xe8, 0x9f, 0x94, 0x75, 0x0b, 0x54, 0xea, 0xee, 0xff, 0x0e, 0x00, 0x82, 0xe1, 0x12, 0xee,
+	0x67, 0x36, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -5589,6 +5994,9 @@ type APIClient interface {
 	ListBranch(ctx context.Context, in *ListBranchRequest, opts ...grpc.CallOption) (*BranchInfos, error)
 	// DeleteBranch deletes a branch; note that the commits still exist.
 	DeleteBranch(ctx context.Context, in *DeleteBranchRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// SetBranchSchema sets the schema that's enforced on records put into a
+	// branch.
+	SetBranchSchema(ctx context.Context, in *SetBranchSchemaRequest, opts ...grpc.CallOption) (*types.Empty, error)
 	// File rpcs
 	// PutFile writes the specified file to pfs.
 	PutFile(ctx context.Context, opts ...grpc.CallOption) (API_PutFileClient, error)
@@ -5862,6 +6270,15 @@ func (c *aPIClient) DeleteBranch(ctx context.Context, in *DeleteBranchRequest, o
 	return out, nil
 }
 
+func (c *aPIClient) SetBranchSchema(ctx context.Context, in *SetBranchSchemaRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/SetBranchSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) PutFile(ctx context.Context, opts ...grpc.CallOption) (API_PutFileClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[3], "/pfs.API/PutFile", opts...)
 	if err != nil {
@@ -6266,6 +6683,9 @@ type APIServer interface {
 	ListBranch(context.Context, *ListBranchRequest) (*BranchInfos, error)
 	// DeleteBranch deletes a branch; note that the commits still exist.
 	DeleteBranch(context.Context, *DeleteBranchRequest) (*types.Empty, error)
+	// SetBranchSchema sets the schema that's enforced on records put into a
+	// branch.
+	SetBranchSchema(context.Context, *SetBranchSchemaRequest) (*types.Empty, error)
 	// File rpcs
 	// PutFile writes the specified file to pfs.
 	PutFile(API_PutFileServer) error
@@ -6364,6 +6784,9 @@ func (*UnimplementedAPIServer) ListBranch(ctx context.Context, req *ListBranchRe
 func (*UnimplementedAPIServer) DeleteBranch(ctx context.Context, req *DeleteBranchRequest) (*types.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBranch not implemented")
 }
+func (*UnimplementedAPIServer) SetBranchSchema(ctx context.Context, req *SetBranchSchemaRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBranchSchema not implemented")
+}
 func (*UnimplementedAPIServer) PutFile(srv API_PutFileServer) error {
 	return status.Errorf(codes.Unimplemented, "method PutFile not implemented")
 }
@@ -6735,6 +7158,24 @@ func _API_DeleteBranch_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_SetBranchSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBranchSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SetBranchSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/SetBranchSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetBranchSchema(ctx, req.(*SetBranchSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_PutFile_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(APIServer).PutFile(&aPIPutFileServer{stream})
 }
@@ -7138,6 +7579,10 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBranch",
 			Handler:    _API_DeleteBranch_Handler,
 		},
+		{
+			MethodName: "SetBranchSchema",
+			Handler:    _API_SetBranchSchema_Handler,
+		},
 		{
 			MethodName: "CopyFile",
 			Handler:    _API_CopyFile_Handler,
@@ -8594,6 +9039,18 @@ func (m *BranchInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Schema != nil {
+		{
+			size, err := m.Schema.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPfs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
 	if len(m.DirectProvenance) > 0 {
 		for iNdEx := len(m.DirectProvenance) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -8670,7 +9127,7 @@ func (m *BranchInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *BranchInfos) Marshal() (dAtA []byte, err error) {
+func (m *SchemaColumn) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -8680,12 +9137,12 @@ func (m *BranchInfos) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BranchInfos) MarshalTo(dAtA []byte) (int, error) {
+func (m *SchemaColumn) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BranchInfos) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SchemaColumn) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -8694,24 +9151,32 @@ func (m *BranchInfos) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.BranchInfo) > 0 {
-		for iNdEx := len(m.BranchInfo) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.BranchInfo[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPfs(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	if m.Nullable {
+		i--
+		if m.Nullable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Type != 0 {
+		i = encodeVarintPfs(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *File) Marshal() (dAtA []byte, err error) {
+func (m *Schema) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -8721,12 +9186,12 @@ func (m *File) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *File) MarshalTo(dAtA []byte) (int, error) {
+func (m *Schema) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *File) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Schema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -8735,29 +9200,43 @@ func (m *File) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Path) > 0 {
-		i -= len(m.Path)
-		copy(dAtA[i:], m.Path)
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Path)))
+	if len(m.QuarantinePath) > 0 {
+		i -= len(m.QuarantinePath)
+		copy(dAtA[i:], m.QuarantinePath)
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.QuarantinePath)))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x22
 	}
-	if m.Commit != nil {
-		{
-			size, err := m.Commit.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if m.OnInvalid != 0 {
+		i = encodeVarintPfs(dAtA, i, uint64(m.OnInvalid))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.CsvColumns) > 0 {
+		for iNdEx := len(m.CsvColumns) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CsvColumns[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPfs(dAtA, i, uint64(size))
 			}
-			i -= size
-			i = encodeVarintPfs(dAtA, i, uint64(size))
+			i--
+			dAtA[i] = 0x12
 		}
+	}
+	if len(m.JsonSchema) > 0 {
+		i -= len(m.JsonSchema)
+		copy(dAtA[i:], m.JsonSchema)
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.JsonSchema)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Block) Marshal() (dAtA []byte, err error) {
+func (m *ValidationResult) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -8767,12 +9246,12 @@ func (m *Block) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Block) MarshalTo(dAtA []byte) (int, error) {
+func (m *ValidationResult) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Block) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ValidationResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -8781,17 +9260,155 @@ func (m *Block) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Hash) > 0 {
-		i -= len(m.Hash)
-		copy(dAtA[i:], m.Hash)
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Hash)))
+	if len(m.Errors) > 0 {
+		for iNdEx := len(m.Errors) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Errors[iNdEx])
+			copy(dAtA[i:], m.Errors[iNdEx])
+			i = encodeVarintPfs(dAtA, i, uint64(len(m.Errors[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.QuarantinedRecords != 0 {
+		i = encodeVarintPfs(dAtA, i, uint64(m.QuarantinedRecords))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x18
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Object) Marshal() (dAtA []byte, err error) {
+	if m.RejectedRecords != 0 {
+		i = encodeVarintPfs(dAtA, i, uint64(m.RejectedRecords))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ValidRecords != 0 {
+		i = encodeVarintPfs(dAtA, i, uint64(m.ValidRecords))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BranchInfos) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BranchInfos) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchInfos) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.BranchInfo) > 0 {
+		for iNdEx := len(m.BranchInfo) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BranchInfo[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPfs(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *File) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *File) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *File) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Commit != nil {
+		{
+			size, err := m.Commit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPfs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Block) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Block) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Block) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Object) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -9184,6 +9801,20 @@ func (m *CommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Validation != nil {
+		{
+			size, err := m.Validation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPfs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xaa
+	}
 	if m.SubvenantCommitsTotal != 0 {
 		i = encodeVarintPfs(dAtA, i, uint64(m.SubvenantCommitsTotal))
 		i--
@@ -10617,6 +11248,57 @@ func (m *ListBranchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SetBranchSchemaRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetBranchSchemaRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetBranchSchemaRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Schema != nil {
+		{
+			size, err := m.Schema.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPfs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Branch != nil {
+		{
+			size, err := m.Branch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPfs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *DeleteBranchRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -11108,6 +11790,18 @@ func (m *PutFileRecords) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Validation != nil {
+		{
+			size, err := m.Validation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPfs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.Footer != nil {
 		{
 			size, err := m.Footer.MarshalToSizedBuffer(dAtA[:i])
@@ -12995,23 +13689,31 @@ func (m *BranchInfo) Size() (n int) {
 			n += 1 + l + sovPfs(uint64(l))
 		}
 	}
+	if m.Schema != nil {
+		l = m.Schema.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *BranchInfos) Size() (n int) {
+func (m *SchemaColumn) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.BranchInfo) > 0 {
-		for _, e := range m.BranchInfo {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovPfs(uint64(m.Type))
+	}
+	if m.Nullable {
+		n += 2
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -13019,17 +13721,26 @@ func (m *BranchInfos) Size() (n int) {
 	return n
 }
 
-func (m *File) Size() (n int) {
+func (m *Schema) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
+	l = len(m.JsonSchema)
+	if l > 0 {
 		n += 1 + l + sovPfs(uint64(l))
 	}
-	l = len(m.Path)
+	if len(m.CsvColumns) > 0 {
+		for _, e := range m.CsvColumns {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.OnInvalid != 0 {
+		n += 1 + sovPfs(uint64(m.OnInvalid))
+	}
+	l = len(m.QuarantinePath)
 	if l > 0 {
 		n += 1 + l + sovPfs(uint64(l))
 	}
@@ -13039,15 +13750,26 @@ func (m *File) Size() (n int) {
 	return n
 }
 
-func (m *Block) Size() (n int) {
+func (m *ValidationResult) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if m.ValidRecords != 0 {
+		n += 1 + sovPfs(uint64(m.ValidRecords))
+	}
+	if m.RejectedRecords != 0 {
+		n += 1 + sovPfs(uint64(m.RejectedRecords))
+	}
+	if m.QuarantinedRecords != 0 {
+		n += 1 + sovPfs(uint64(m.QuarantinedRecords))
+	}
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			l = len(s)
+			n += 1 + l + sovPfs(uint64(l))
+		}
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -13055,15 +13777,17 @@ func (m *Block) Size() (n int) {
 	return n
 }
 
-func (m *Object) Size() (n int) {
+func (m *BranchInfos) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.BranchInfo) > 0 {
+		for _, e := range m.BranchInfo {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -13071,13 +13795,17 @@ func (m *Object) Size() (n int) {
 	return n
 }
 
-func (m *Tag) Size() (n int) {
+func (m *File) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Name)
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Path)
 	if l > 0 {
 		n += 1 + l + sovPfs(uint64(l))
 	}
@@ -13087,14 +13815,62 @@ func (m *Tag) Size() (n int) {
 	return n
 }
 
-func (m *RepoInfo) Size() (n int) {
+func (m *Block) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Repo != nil {
-		l = m.Repo.Size()
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Object) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Tag) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RepoInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
 		n += 1 + l + sovPfs(uint64(l))
 	}
 	if m.Created != nil {
@@ -13295,6 +14071,10 @@ func (m *CommitInfo) Size() (n int) {
 	if m.SubvenantCommitsTotal != 0 {
 		n += 2 + sovPfs(uint64(m.SubvenantCommitsTotal))
 	}
+	if m.Validation != nil {
+		l = m.Validation.Size()
+		n += 2 + l + sovPfs(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -13821,6 +14601,26 @@ func (m *ListBranchRequest) Size() (n int) {
 	return n
 }
 
+func (m *SetBranchSchemaRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Branch != nil {
+		l = m.Branch.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Schema != nil {
+		l = m.Schema.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *DeleteBranchRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -14047,6 +14847,10 @@ func (m *PutFileRecords) Size() (n int) {
 		l = m.Footer.Size()
 		n += 1 + l + sovPfs(uint64(l))
 	}
+	if m.Validation != nil {
+		l = m.Validation.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -15159,7 +15963,497 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
 			}
-			var msglen int
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Branch == nil {
+				m.Branch = &Branch{}
+			}
+			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subvenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subvenance = append(m.Subvenance, &Branch{})
+			if err := m.Subvenance[len(m.Subvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DirectProvenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DirectProvenance = append(m.DirectProvenance, &Branch{})
+			if err := m.DirectProvenance[len(m.DirectProvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Schema == nil {
+				m.Schema = &Schema{}
+			}
+			if err := m.Schema.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchemaColumn) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchemaColumn: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchemaColumn: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ColumnType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nullable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Nullable = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Schema) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Schema: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Schema: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JsonSchema", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JsonSchema = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CsvColumns", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CsvColumns = append(m.CsvColumns, &SchemaColumn{})
+			if err := m.CsvColumns[len(m.CsvColumns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OnInvalid", wireType)
+			}
+			m.OnInvalid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OnInvalid |= InvalidRecordAction(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuarantinePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QuarantinePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidationResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidationResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidationResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidRecords", wireType)
+			}
+			m.ValidRecords = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15169,33 +16463,16 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ValidRecords |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPfs
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Branch == nil {
-				m.Branch = &Branch{}
-			}
-			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Subvenance", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectedRecords", wireType)
 			}
-			var msglen int
+			m.RejectedRecords = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15205,31 +16482,35 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.RejectedRecords |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPfs
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuarantinedRecords", wireType)
 			}
-			m.Subvenance = append(m.Subvenance, &Branch{})
-			if err := m.Subvenance[len(m.Subvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.QuarantinedRecords = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QuarantinedRecords |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 6:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DirectProvenance", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15239,25 +16520,23 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPfs
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DirectProvenance = append(m.DirectProvenance, &Branch{})
-			if err := m.DirectProvenance[len(m.DirectProvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -17099,6 +18378,42 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Validation == nil {
+				m.Validation = &ValidationResult{}
+			}
+			if err := m.Validation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -20298,6 +21613,132 @@ func (m *ListBranchRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SetBranchSchemaRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetBranchSchemaRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetBranchSchemaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Branch == nil {
+				m.Branch = &Branch{}
+			}
+			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Schema == nil {
+				m.Schema = &Schema{}
+			}
+			if err := m.Schema.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *DeleteBranchRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -21694,6 +23135,42 @@ func (m *PutFileRecords) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Validation == nil {
+				m.Validation = &ValidationResult{}
+			}
+			if err := m.Validation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
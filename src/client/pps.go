@@ -30,6 +30,9 @@ const (
 	// PPSPipelineNameEnv is the env var that sets the name of the pipeline
 	// that the workers are running.
 	PPSPipelineNameEnv = "PPS_PIPELINE_NAME"
+	// PPSWorkerPoolEnv is the env var that sets the name of the worker pool
+	// that a worker belongs to. It's unset for a pipeline's regular workers.
+	PPSWorkerPoolEnv = "PPS_WORKER_POOL"
 	// PPSJobIDEnv is the env var that sets the ID of the job that the
 	// workers are running (if the workers belong to an orphan job, rather than a
 	// pipeline).
@@ -63,6 +66,23 @@ const (
 	// OutputCommitIDEnv is an env var that is added to the environment of user
 	// pipelined code and indicates the id of the output commit.
 	OutputCommitIDEnv = "PACH_OUTPUT_COMMIT_ID"
+	// OutputDirEnv is an env var that is added to the environment of user
+	// pipeline code and indicates the directory that output should be written
+	// to, this is /pfs/out unless the pipeline has a datum_concurrency.
+	OutputDirEnv = "PACH_OUTPUT_DIR"
+	// CheckpointDirEnv is an env var that is added to the environment of user
+	// pipeline code and indicates the directory that the user code can save
+	// its progress on the datum to, if the pipeline checkpoints preempted
+	// datums.
+	CheckpointDirEnv = "PACH_CHECKPOINT_DIR"
+	// SpoutSocketEnv is an env var that is added to the environment of the user
+	// code of transactional spouts and indicates the unix socket that the
+	// spout's transactions are served on.
+	SpoutSocketEnv = "PACH_SPOUT_SOCKET"
+	// ServicePortEnv is an env var that is added to the environment of the user
+	// code of services and indicates the port that the user code should serve
+	// on, this is the service's internal_port unless it has rolling updates.
+	ServicePortEnv = "PACH_SERVICE_PORT"
 	// PeerPortEnv is the env var that sets a custom peer port
 	PeerPortEnv = "PEER_PORT"
 )
@@ -347,6 +367,36 @@ func (c APIClient) FlushJobAll(commits []*pfs.Commit, toPipelines []string) ([]*
 	return result, nil
 }
 
+// DiffJob compares two jobs: their pipeline specs, the commits their inputs
+// read, their datums and, once both have finished, their outputs. limit is the
+// most datums and files of each kind to return (100 if 0).
+func (c APIClient) DiffJob(a, b string, limit int64) (*pps.JobDiff, error) {
+	jobDiff, err := c.PpsAPIClient.DiffJob(
+		c.Ctx(),
+		&pps.DiffJobRequest{
+			A:     NewJob(a),
+			B:     NewJob(b),
+			Limit: limit,
+		},
+	)
+	return jobDiff, grpcutil.ScrubGRPC(err)
+}
+
+// RerunJob reruns a job's inputs with its pipeline's spec, patched with
+// specPatch (a JSON merge patch), in a new scratch pipeline. If pipeline is
+// "", the scratch pipeline is named "<pipeline>-rerun-<job ID prefix>".
+func (c APIClient) RerunJob(jobID string, specPatch string, pipeline string) (*pps.RerunJobResponse, error) {
+	request := &pps.RerunJobRequest{
+		Job:       NewJob(jobID),
+		SpecPatch: specPatch,
+	}
+	if pipeline != "" {
+		request.Pipeline = NewPipeline(pipeline)
+	}
+	response, err := c.PpsAPIClient.RerunJob(c.Ctx(), request)
+	return response, grpcutil.ScrubGRPC(err)
+}
+
 // DeleteJob deletes a job.
 func (c APIClient) DeleteJob(jobID string) error {
 	_, err := c.PpsAPIClient.DeleteJob(
@@ -727,6 +777,21 @@ func (c APIClient) RunCron(name string) error {
 	return grpcutil.ScrubGRPC(err)
 }
 
+// InspectDAG returns the DAG of repos and pipelines, sorted so that each repo
+// comes after its inputs. If from is set, only from and the repos downstream
+// of it (i.e. the pipelines that a commit to from would trigger) are returned.
+func (c APIClient) InspectDAG(from string) ([]*pps.DAGNode, error) {
+	request := &pps.InspectDAGRequest{}
+	if from != "" {
+		request.From = NewRepo(from)
+	}
+	dagInfo, err := c.PpsAPIClient.InspectDAG(c.Ctx(), request)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return dagInfo.Nodes, nil
+}
+
 // CreateSecret creates a secret on the cluster.
 func (c APIClient) CreateSecret(file []byte) error {
 	_, err := c.PpsAPIClient.CreateSecret(
@@ -769,6 +834,200 @@ func (c APIClient) ListSecret() ([]*pps.SecretInfo, error) {
 	return secretInfos.SecretInfo, grpcutil.ScrubGRPC(err)
 }
 
+// NewPipelineTemplate creates a pps.PipelineTemplate.
+func NewPipelineTemplate(templateName string) *pps.PipelineTemplate {
+	return &pps.PipelineTemplate{Name: templateName}
+}
+
+// CreatePipelineTemplate creates a new pipeline template. spec is a pipeline
+// spec, in JSON or YAML, with Go template actions that refer to params, e.g.
+// {{.image}}.
+func (c APIClient) CreatePipelineTemplate(name string, spec string, params []*pps.TemplateParameter, description string, update bool) error {
+	_, err := c.PpsAPIClient.CreatePipelineTemplate(
+		c.Ctx(),
+		&pps.CreatePipelineTemplateRequest{
+			Template:    NewPipelineTemplate(name),
+			Spec:        spec,
+			Parameters:  params,
+			Description: description,
+			Update:      update,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// InspectPipelineTemplate returns info about a specific pipeline template.
+func (c APIClient) InspectPipelineTemplate(name string) (*pps.PipelineTemplateInfo, error) {
+	templateInfo, err := c.PpsAPIClient.InspectPipelineTemplate(
+		c.Ctx(),
+		&pps.InspectPipelineTemplateRequest{
+			Template: NewPipelineTemplate(name),
+		},
+	)
+	return templateInfo, grpcutil.ScrubGRPC(err)
+}
+
+// ListPipelineTemplate returns info about all pipeline templates.
+func (c APIClient) ListPipelineTemplate() ([]*pps.PipelineTemplateInfo, error) {
+	templateInfos, err := c.PpsAPIClient.ListPipelineTemplate(
+		c.Ctx(),
+		&pps.ListPipelineTemplateRequest{},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return templateInfos.TemplateInfo, nil
+}
+
+// DeletePipelineTemplate deletes a pipeline template, pipelines created from
+// it aren't affected.
+func (c APIClient) DeletePipelineTemplate(name string) error {
+	_, err := c.PpsAPIClient.DeletePipelineTemplate(
+		c.Ctx(),
+		&pps.DeletePipelineTemplateRequest{
+			Template: NewPipelineTemplate(name),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// CreatePipelineFromTemplate creates, or updates, a pipeline from the latest
+// version of a template, filled in with params.
+func (c APIClient) CreatePipelineFromTemplate(name string, params map[string]string, update bool, reprocess bool) error {
+	_, err := c.PpsAPIClient.CreatePipelineFromTemplate(
+		c.Ctx(),
+		&pps.CreatePipelineFromTemplateRequest{
+			Template:  NewPipelineTemplate(name),
+			Params:    params,
+			Update:    update,
+			Reprocess: reprocess,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// NewQueue creates a pps.Queue.
+func NewQueue(queueName string) *pps.Queue {
+	return &pps.Queue{Name: queueName}
+}
+
+// CreateQueue creates, or updates, a scheduling queue. request.Queue must be
+// set.
+func (c APIClient) CreateQueue(request *pps.CreateQueueRequest) error {
+	_, err := c.PpsAPIClient.CreateQueue(c.Ctx(), request)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// InspectQueue returns info about a specific queue, including the pipelines
+// running in it and waiting for it.
+func (c APIClient) InspectQueue(name string) (*pps.QueueInfo, error) {
+	queueInfo, err := c.PpsAPIClient.InspectQueue(
+		c.Ctx(),
+		&pps.InspectQueueRequest{
+			Queue: NewQueue(name),
+		},
+	)
+	return queueInfo, grpcutil.ScrubGRPC(err)
+}
+
+// ListQueue returns info about all queues.
+func (c APIClient) ListQueue() ([]*pps.QueueInfo, error) {
+	queueInfos, err := c.PpsAPIClient.ListQueue(
+		c.Ctx(),
+		&pps.ListQueueRequest{},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return queueInfos.QueueInfo, nil
+}
+
+// DeleteQueue deletes a queue. Pipelines in the queue are no longer limited
+// by its quota.
+func (c APIClient) DeleteQueue(name string) error {
+	_, err := c.PpsAPIClient.DeleteQueue(
+		c.Ctx(),
+		&pps.DeleteQueueRequest{
+			Queue: NewQueue(name),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// NewAlert creates a pps.Alert.
+func NewAlert(alertName string) *pps.Alert {
+	return &pps.Alert{Name: alertName}
+}
+
+// CreateAlert creates, or updates, an alert. request.Alert must be set.
+func (c APIClient) CreateAlert(request *pps.CreateAlertRequest) error {
+	_, err := c.PpsAPIClient.CreateAlert(c.Ctx(), request)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// InspectAlert returns info about a specific alert, including the most recent
+// events it has fired.
+func (c APIClient) InspectAlert(name string) (*pps.AlertInfo, error) {
+	alertInfo, err := c.PpsAPIClient.InspectAlert(
+		c.Ctx(),
+		&pps.InspectAlertRequest{
+			Alert: NewAlert(name),
+		},
+	)
+	return alertInfo, grpcutil.ScrubGRPC(err)
+}
+
+// ListAlert returns info about all alerts.
+func (c APIClient) ListAlert() ([]*pps.AlertInfo, error) {
+	alertInfos, err := c.PpsAPIClient.ListAlert(
+		c.Ctx(),
+		&pps.ListAlertRequest{},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return alertInfos.AlertInfo, nil
+}
+
+// DeleteAlert deletes an alert, along with any of its events that haven't
+// been delivered yet.
+func (c APIClient) DeleteAlert(name string) error {
+	_, err := c.PpsAPIClient.DeleteAlert(
+		c.Ctx(),
+		&pps.DeleteAlertRequest{
+			Alert: NewAlert(name),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// ListUsage returns the cpu time, memory and data used by the jobs that
+// finished in the time range in request, grouped by pipeline or by the value
+// of request.Label.
+func (c APIClient) ListUsage(request *pps.ListUsageRequest) ([]*pps.UsageInfo, error) {
+	usageInfos, err := c.PpsAPIClient.ListUsage(c.Ctx(), request)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return usageInfos.UsageInfo, nil
+}
+
+// DryRunPipeline validates the pipeline spec in request and previews the
+// datums it would produce, without creating the pipeline. Input repos with a
+// commit in inputCommits use that commit, other inputs use the head of their
+// branch. sample is the number of datums to return (10 if 0).
+func (c APIClient) DryRunPipeline(request *pps.CreatePipelineRequest, inputCommits []*pfs.Commit, sample int64) (*pps.DryRunPipelineResponse, error) {
+	response, err := c.PpsAPIClient.DryRunPipeline(
+		c.Ctx(),
+		&pps.DryRunPipelineRequest{
+			Pipeline:     request,
+			InputCommits: inputCommits,
+			Sample:       sample,
+		},
+	)
+	return response, grpcutil.ScrubGRPC(err)
+}
+
 // CreatePipelineService creates a new pipeline service.
 func (c APIClient) CreatePipelineService(
 	name string,
@@ -87,6 +87,12 @@ func InputBranches(input *Input) []*pfs.Branch {
 				Name: "master",
 			})
 		}
+		if input.ObjectStorage != nil {
+			result = append(result, &pfs.Branch{
+				Repo: &pfs.Repo{Name: input.ObjectStorage.Repo},
+				Name: "master",
+			})
+		}
 		if input.Git != nil {
 			result = append(result, &pfs.Branch{
 				Repo: &pfs.Repo{Name: input.Git.Name},
@@ -129,3 +135,23 @@ func ValidateGitCloneURL(url string) error {
 
 	return nil
 }
+
+// EgressTargets returns the destinations that egress copies output commits to,
+// which are its targets if it has any, and otherwise egress itself.
+func EgressTargets(egress *Egress) []*Egress {
+	if egress == nil {
+		return nil
+	}
+	if len(egress.Targets) > 0 {
+		return egress.Targets
+	}
+	return []*Egress{egress}
+}
+
+// EgressTarget returns the URL of a single egress destination.
+func EgressTarget(egress *Egress) string {
+	if egress.SQL != nil {
+		return egress.SQL.URL
+	}
+	return egress.URL
+}
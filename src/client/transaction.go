@@ -347,6 +347,9 @@ func (c *pfsBuilderClient) InspectBranch(ctx context.Context, req *pfs.InspectBr
 func (c *pfsBuilderClient) ListBranch(ctx context.Context, req *pfs.ListBranchRequest, opts ...grpc.CallOption) (*pfs.BranchInfos, error) {
 	return nil, unsupportedError("ListBranch")
 }
+func (c *pfsBuilderClient) SetBranchSchema(ctx context.Context, req *pfs.SetBranchSchemaRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("SetBranchSchema")
+}
 func (c *pfsBuilderClient) PutFile(ctx context.Context, opts ...grpc.CallOption) (pfs.API_PutFileClient, error) {
 	return nil, unsupportedError("PutFile")
 }
@@ -481,6 +484,12 @@ func (c *ppsBuilderClient) ListJobStream(ctx context.Context, req *pps.ListJobRe
 func (c *ppsBuilderClient) FlushJob(ctx context.Context, req *pps.FlushJobRequest, opts ...grpc.CallOption) (pps.API_FlushJobClient, error) {
 	return nil, unsupportedError("FlushJob")
 }
+func (c *ppsBuilderClient) DiffJob(ctx context.Context, req *pps.DiffJobRequest, opts ...grpc.CallOption) (*pps.JobDiff, error) {
+	return nil, unsupportedError("DiffJob")
+}
+func (c *ppsBuilderClient) RerunJob(ctx context.Context, req *pps.RerunJobRequest, opts ...grpc.CallOption) (*pps.RerunJobResponse, error) {
+	return nil, unsupportedError("RerunJob")
+}
 func (c *ppsBuilderClient) DeleteJob(ctx context.Context, req *pps.DeleteJobRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("DeleteJob")
 }
@@ -502,6 +511,9 @@ func (c *ppsBuilderClient) RestartDatum(ctx context.Context, req *pps.RestartDat
 func (c *ppsBuilderClient) CreatePipeline(ctx context.Context, req *pps.CreatePipelineRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("CreatePipeline")
 }
+func (c *ppsBuilderClient) DryRunPipeline(ctx context.Context, req *pps.DryRunPipelineRequest, opts ...grpc.CallOption) (*pps.DryRunPipelineResponse, error) {
+	return nil, unsupportedError("DryRunPipeline")
+}
 func (c *ppsBuilderClient) InspectPipeline(ctx context.Context, req *pps.InspectPipelineRequest, opts ...grpc.CallOption) (*pps.PipelineInfo, error) {
 	return nil, unsupportedError("InspectPipeline")
 }
@@ -535,6 +547,9 @@ func (c *ppsBuilderClient) ActivateAuth(ctx context.Context, req *pps.ActivateAu
 func (c *ppsBuilderClient) RunCron(ctx context.Context, req *pps.RunCronRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("RunCron")
 }
+func (c *ppsBuilderClient) InspectDAG(ctx context.Context, req *pps.InspectDAGRequest, opts ...grpc.CallOption) (*pps.DAGInfo, error) {
+	return nil, unsupportedError("InspectDAG")
+}
 func (c *ppsBuilderClient) CreateSecret(ctx context.Context, req *pps.CreateSecretRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("CreateSecret")
 }
@@ -547,6 +562,48 @@ func (c *ppsBuilderClient) InspectSecret(ctx context.Context, req *pps.InspectSe
 func (c *ppsBuilderClient) ListSecret(ctx context.Context, in *types.Empty, opt ...grpc.CallOption) (*pps.SecretInfos, error) {
 	return nil, unsupportedError("ListSecret")
 }
+func (c *ppsBuilderClient) CreatePipelineTemplate(ctx context.Context, req *pps.CreatePipelineTemplateRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CreatePipelineTemplate")
+}
+func (c *ppsBuilderClient) InspectPipelineTemplate(ctx context.Context, req *pps.InspectPipelineTemplateRequest, opts ...grpc.CallOption) (*pps.PipelineTemplateInfo, error) {
+	return nil, unsupportedError("InspectPipelineTemplate")
+}
+func (c *ppsBuilderClient) ListPipelineTemplate(ctx context.Context, req *pps.ListPipelineTemplateRequest, opts ...grpc.CallOption) (*pps.PipelineTemplateInfos, error) {
+	return nil, unsupportedError("ListPipelineTemplate")
+}
+func (c *ppsBuilderClient) DeletePipelineTemplate(ctx context.Context, req *pps.DeletePipelineTemplateRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeletePipelineTemplate")
+}
+func (c *ppsBuilderClient) CreatePipelineFromTemplate(ctx context.Context, req *pps.CreatePipelineFromTemplateRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CreatePipelineFromTemplate")
+}
+func (c *ppsBuilderClient) CreateQueue(ctx context.Context, req *pps.CreateQueueRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CreateQueue")
+}
+func (c *ppsBuilderClient) InspectQueue(ctx context.Context, req *pps.InspectQueueRequest, opts ...grpc.CallOption) (*pps.QueueInfo, error) {
+	return nil, unsupportedError("InspectQueue")
+}
+func (c *ppsBuilderClient) ListQueue(ctx context.Context, req *pps.ListQueueRequest, opts ...grpc.CallOption) (*pps.QueueInfos, error) {
+	return nil, unsupportedError("ListQueue")
+}
+func (c *ppsBuilderClient) DeleteQueue(ctx context.Context, req *pps.DeleteQueueRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteQueue")
+}
+func (c *ppsBuilderClient) CreateAlert(ctx context.Context, req *pps.CreateAlertRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CreateAlert")
+}
+func (c *ppsBuilderClient) InspectAlert(ctx context.Context, req *pps.InspectAlertRequest, opts ...grpc.CallOption) (*pps.AlertInfo, error) {
+	return nil, unsupportedError("InspectAlert")
+}
+func (c *ppsBuilderClient) ListAlert(ctx context.Context, req *pps.ListAlertRequest, opts ...grpc.CallOption) (*pps.AlertInfos, error) {
+	return nil, unsupportedError("ListAlert")
+}
+func (c *ppsBuilderClient) DeleteAlert(ctx context.Context, req *pps.DeleteAlertRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteAlert")
+}
+func (c *ppsBuilderClient) ListUsage(ctx context.Context, req *pps.ListUsageRequest, opts ...grpc.CallOption) (*pps.UsageInfos, error) {
+	return nil, unsupportedError("ListUsage")
+}
 
 func (c *authBuilderClient) Activate(ctx context.Context, req *auth.ActivateRequest, opts ...grpc.CallOption) (*auth.ActivateResponse, error) {
 	return nil, unsupportedError("Activate")
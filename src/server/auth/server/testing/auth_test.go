@@ -3174,6 +3174,67 @@ func TestDeletePipelineMissingRepos(t *testing.T) {
 	})
 }
 
+// TestDiffJobWebhookURL checks that DiffJob hides the webhook URL in the
+// pipeline's alerts from non-admins, as InspectPipeline does.
+func TestDiffJobWebhookURL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	deleteAll(t)
+	defer deleteAll(t)
+	alice := tu.UniqueString("alice")
+	aliceClient, adminClient := getPachClient(t, alice), getPachClient(t, admin)
+
+	// alice creates a pipeline without alerts
+	repo := tu.UniqueString(t.Name())
+	require.NoError(t, aliceClient.CreateRepo(repo))
+	_, err := aliceClient.PutFile(repo, "master", "/file1", strings.NewReader("1"))
+	require.NoError(t, err)
+	pipeline := tu.UniqueString("pipeline")
+	request := &pps.CreatePipelineRequest{
+		Pipeline:        client.NewPipeline(pipeline),
+		Transform:       &pps.Transform{Cmd: []string{"bash"}, Stdin: []string{"cp /pfs/*/* /pfs/out/"}},
+		ParallelismSpec: &pps.ParallelismSpec{Constant: 1},
+		Input:           client.NewPFSInput(repo, "/*"),
+	}
+	_, err = aliceClient.PpsAPIClient.CreatePipeline(aliceClient.Ctx(), request)
+	require.NoError(t, err)
+
+	// the admin adds alerts with a webhook to it
+	webhookURL := "https://hooks.example.com/pachyderm"
+	request.Alerts = &pps.AlertSpec{
+		Rules:      []*pps.AlertRule{{Condition: pps.AlertCondition_ALERT_JOB_FAILURE}},
+		WebhookURL: webhookURL,
+	}
+	request.Update = true
+	_, err = adminClient.PpsAPIClient.CreatePipeline(adminClient.Ctx(), request)
+	require.NoError(t, err)
+	_, err = aliceClient.PutFile(repo, "master", "/file2", strings.NewReader("2"))
+	require.NoError(t, err)
+
+	var jobs []*pps.JobInfo
+	require.NoErrorWithinTRetry(t, 60*time.Second, func() error {
+		jobs, err = aliceClient.ListJob(pipeline, nil /*inputs*/, nil /*output*/, -1 /*history*/, false)
+		if err != nil {
+			return err
+		}
+		if len(jobs) < 2 {
+			return errors.Errorf("expected at least two jobs but got %d", len(jobs))
+		}
+		return nil
+	})
+	oldJob, newJob := jobs[len(jobs)-1].Job.ID, jobs[0].Job.ID
+
+	// The admin sees the webhook URL in the spec changes, alice doesn't
+	diff, err := adminClient.DiffJob(oldJob, newJob, 0)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(diff.String(), webhookURL))
+	diff, err = aliceClient.DiffJob(oldJob, newJob, 0)
+	require.NoError(t, err)
+	require.True(t, len(diff.SpecChanges) > 0)
+	require.False(t, strings.Contains(diff.String(), webhookURL))
+}
+
 func TestDisableGitHubAuth(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
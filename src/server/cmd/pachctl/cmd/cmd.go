@@ -816,12 +816,30 @@ This resets the cluster to its initial state.`,
 	}
 	subcommands = append(subcommands, cmdutil.CreateAlias(runDocs, "run"))
 
+	rerunDocs := &cobra.Command{
+		Short: "Rerun an existing Pachyderm resource.",
+		Long:  "Rerun an existing Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(rerunDocs, "rerun"))
+
+	drawDocs := &cobra.Command{
+		Short: "Draw a diagram of Pachyderm resources.",
+		Long:  "Draw a diagram of Pachyderm resources.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(drawDocs, "draw"))
+
 	editDocs := &cobra.Command{
 		Short: "Edit the value of an existing Pachyderm resource.",
 		Long:  "Edit the value of an existing Pachyderm resource.",
 	}
 	subcommands = append(subcommands, cmdutil.CreateAlias(editDocs, "edit"))
 
+	setDocs := &cobra.Command{
+		Short: "Set a property of an existing Pachyderm resource.",
+		Long:  "Set a property of an existing Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(setDocs, "set"))
+
 	subcommands = append(subcommands, pfscmds.Cmds()...)
 	subcommands = append(subcommands, ppscmds.Cmds()...)
 	subcommands = append(subcommands, deploycmds.Cmds()...)
@@ -872,6 +890,7 @@ func applyRootUsageFunc(rootCmd *cobra.Command) {
 			"create",
 			"delete",
 			"diff",
+			"draw",
 			"edit",
 			"finish",
 			"flush",
@@ -880,7 +899,9 @@ func applyRootUsageFunc(rootCmd *cobra.Command) {
 			"inspect",
 			"list",
 			"put",
+			"rerun",
 			"restart",
+			"set",
 			"start",
 			"stop",
 			"subscribe",
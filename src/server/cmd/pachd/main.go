@@ -6,10 +6,13 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"path"
 	"runtime/debug"
 	"runtime/pprof"
 	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	adminclient "github.com/pachyderm/pachyderm/src/client/admin"
@@ -94,6 +97,26 @@ func doReadinessCheck(config interface{}) error {
 	return env.GetPachClient(context.Background()).Health()
 }
 
+// exitAfterWorker handles SIGTERM in the sidecars of preemptible workers.
+// Preempted workers still need their sidecar to save their checkpoints, so
+// rather than exiting right away, the sidecar exits once the worker in the
+// pod's user container has stopped serving on workerPort.
+func exitAfterWorker(workerPort uint16) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM)
+	<-sigs
+	log.Infof("waiting for the worker to stop before exiting")
+	addr := net.JoinHostPort("localhost", strconv.FormatUint(uint64(workerPort), 10))
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err != nil {
+			os.Exit(0)
+		}
+		conn.Close()
+		time.Sleep(time.Second)
+	}
+}
+
 func doSidecarMode(config interface{}) (retErr error) {
 	defer func() {
 		if retErr != nil {
@@ -101,6 +124,9 @@ func doSidecarMode(config interface{}) (retErr error) {
 		}
 	}()
 	env := serviceenv.InitWithKube(serviceenv.NewConfiguration(config))
+	if env.PPSPreemption {
+		go exitAfterWorker(env.PPSWorkerPort)
+	}
 	debug.SetGCPercent(env.GCPercent)
 	switch env.LogLevel {
 	case "debug":
@@ -401,6 +427,7 @@ func doFullMode(config interface{}) (retErr error) {
 				env.HTTPPort,
 				env.PeerPort,
 				env.GCPercent,
+				env.PPSMaxWorkers,
 			)
 			if err != nil {
 				return err
@@ -590,6 +617,7 @@ func doFullMode(config interface{}) (retErr error) {
 				env.HTTPPort,
 				env.PeerPort,
 				env.GCPercent,
+				env.PPSMaxWorkers,
 			)
 			if err != nil {
 				return err
@@ -28,6 +28,7 @@ func versionPath(p string) string {
 
 var (
 	getFilePath = versionPath("pfs/repos/:repoName/commits/:commitID/files/*filePath")
+	ingestPath  = versionPath("ingest/:repoName/:branchName/*filePath")
 	servicePath = versionPath("pps/services/:serviceName/*path")
 	loginPath   = versionPath("auth/login")
 	logoutPath  = versionPath("auth/logout")
@@ -41,6 +42,7 @@ type server struct {
 	pachClient     *client.APIClient
 	pachClientOnce sync.Once
 	httpClient     *http.Client
+	ingest         *ingester
 }
 
 // NewHTTPServer returns a Pachyderm HTTP server.
@@ -51,6 +53,7 @@ func NewHTTPServer(address string) (http.Handler, error) {
 		address:    address,
 		httpClient: &http.Client{},
 	}
+	s.ingest = newIngester(s.getPachClient)
 
 	router.GET(getFilePath, s.getFileHandler)
 	router.GET(servicePath, s.serviceHandler)
@@ -58,6 +61,8 @@ func NewHTTPServer(address string) (http.Handler, error) {
 	router.POST(loginPath, s.authLoginHandler)
 	router.POST(logoutPath, s.authLogoutHandler)
 	router.POST(servicePath, s.serviceHandler)
+	router.POST(ingestPath, s.ingest.handle)
+	router.PUT(ingestPath, s.ingest.handle)
 
 	router.NotFound = http.HandlerFunc(notFound)
 	return s, nil
@@ -66,15 +71,7 @@ func NewHTTPServer(address string) (http.Handler, error) {
 func (s *server) getFileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	filePaths := strings.Split(ps.ByName("filePath"), "/")
 	fileName := filePaths[len(filePaths)-1]
-	ctx := context.Background()
-	for _, cookie := range r.Cookies() {
-		if cookie.Name == auth.ContextTokenKey {
-			ctx = metadata.NewIncomingContext(
-				ctx,
-				metadata.Pairs(auth.ContextTokenKey, cookie.Value),
-			)
-		}
-	}
+	ctx := requestContext(r)
 	downloadValues := r.URL.Query()["download"]
 	if len(downloadValues) == 1 && downloadValues[0] == "true" {
 		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v\"", fileName))
@@ -138,16 +135,47 @@ func (s *server) authLogoutHandler(w http.ResponseWriter, r *http.Request, ps ht
 	w.WriteHeader(http.StatusOK)
 }
 
+// bearerPrefix is the prefix of Authorization headers with the Bearer scheme,
+// which is case insensitive.
+const bearerPrefix = "bearer "
+
+// requestContext returns a context carrying the request's auth token, which is
+// read from the auth cookie or from an "Authorization: Bearer <token>" header.
+// Authorization headers with other schemes are ignored.
+func requestContext(r *http.Request) context.Context {
+	ctx := context.Background()
+	var token string
+	if header := r.Header.Get("Authorization"); len(header) > len(bearerPrefix) &&
+		strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		token = strings.TrimSpace(header[len(bearerPrefix):])
+	}
+	for _, cookie := range r.Cookies() {
+		if cookie.Name == auth.ContextTokenKey {
+			token = cookie.Value
+		}
+	}
+	if token != "" {
+		ctx = metadata.NewIncomingContext(
+			ctx,
+			metadata.Pairs(auth.ContextTokenKey, token),
+		)
+	}
+	return ctx
+}
+
 func notFound(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "route not found", http.StatusNotFound)
 }
 
 func httpError(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), httpErrorStatus(err))
+}
+
+func httpErrorStatus(err error) int {
 	if errutil.IsNotFoundError(err) {
-		http.Error(w, err.Error(), http.StatusNotFound)
-	} else {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return http.StatusNotFound
 	}
+	return http.StatusInternalServerError
 }
 
 func (s *server) getPachClient() *client.APIClient {
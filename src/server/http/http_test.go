@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestRequestContext(t *testing.T) {
+	token := func(authorization string, cookie string) string {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		if cookie != "" {
+			r.AddCookie(&http.Cookie{Name: auth.ContextTokenKey, Value: cookie})
+		}
+		md, ok := metadata.FromIncomingContext(requestContext(r))
+		if !ok {
+			return ""
+		}
+		tokens := md.Get(auth.ContextTokenKey)
+		require.Equal(t, 1, len(tokens))
+		return tokens[0]
+	}
+	require.Equal(t, "", token("", ""))
+	require.Equal(t, "abc", token("Bearer abc", ""))
+	require.Equal(t, "abc", token("bearer abc", ""))
+	// Only the Bearer scheme carries a Pachyderm token
+	require.Equal(t, "", token("Basic dXNlcjpwYXNz", ""))
+	require.Equal(t, "", token("abc", ""))
+	require.Equal(t, "", token("Bearer ", ""))
+	// The auth cookie takes precedence
+	require.Equal(t, "def", token("Bearer abc", "def"))
+	require.Equal(t, "def", token("Basic dXNlcjpwYXNz", "def"))
+}
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// ingester implements the ingest API, which lets clients that can't use the
+// gRPC client or the s3 gateway push files into PFS with plain HTTP requests:
+//
+//	POST /v1/ingest/<repo>/<branch>/<path>
+//
+// The request body is written to <path>, streaming it to PutFile, so bodies
+// sent with chunked transfer encoding are never buffered in pachd. If the
+// request is multipart/form-data, each file part is written to
+// <path>/<part filename> instead. Files are appended to unless ?overwrite=true
+// is set.
+//
+// By default each request is written to its own commit, which is finished
+// before the response is sent. With ?window=<duration> the request is added to
+// the open batch commit for the branch, which is finished <duration> after the
+// batch was started, so that many small requests end up in a single commit.
+// A batched request that fails can't take back what it already wrote, as the
+// commit is shared, so its error is returned along with the files it wrote to,
+// the last of which may only have been partly written.
+type ingester struct {
+	getPachClient func() *client.APIClient
+
+	mu      sync.Mutex
+	batches map[string]*ingestBatch
+}
+
+// ingestBatch is an open commit that ingest requests are written to until its
+// window expires.
+type ingestBatch struct {
+	commit *pfs.Commit
+	// pachClient carries the auth token of the request that started the batch,
+	// it's used to finish the commit.
+	pachClient *client.APIClient
+	// writers counts the requests writing to the commit, it's finished once
+	// they're all done.
+	writers sync.WaitGroup
+}
+
+type ingestResponse struct {
+	Commit   string   `json:"commit"`
+	Files    []string `json:"files"`
+	Finished bool     `json:"finished"`
+	// Error is set when a batched request fails after it started writing, in
+	// which case Files are the files it wrote to in the batch's commit.
+	Error string `json:"error,omitempty"`
+}
+
+func newIngester(getPachClient func() *client.APIClient) *ingester {
+	return &ingester{
+		getPachClient: getPachClient,
+		batches:       make(map[string]*ingestBatch),
+	}
+}
+
+func (i *ingester) handle(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	repo, branch := ps.ByName("repoName"), ps.ByName("branchName")
+	filePath := strings.Trim(ps.ByName("filePath"), "/")
+	query := r.URL.Query()
+	overwrite := false
+	if v := query.Get("overwrite"); v != "" {
+		var err error
+		if overwrite, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, "invalid overwrite: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	var window time.Duration
+	if v := query.Get("window"); v != "" {
+		var err error
+		if window, err = time.ParseDuration(v); err != nil || window < 0 {
+			http.Error(w, "invalid window: "+v, http.StatusBadRequest)
+			return
+		}
+	}
+	multipart := false
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil {
+		multipart = mediaType == "multipart/form-data"
+	}
+	if filePath == "" && !multipart {
+		http.Error(w, "a file path is required", http.StatusBadRequest)
+		return
+	}
+
+	c := i.getPachClient().WithCtx(requestContext(r))
+	var commit *pfs.Commit
+	var batch *ingestBatch
+	var err error
+	if window > 0 {
+		batch, err = i.joinBatch(c, repo, branch, window)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		defer batch.writers.Done()
+		commit = batch.commit
+	} else {
+		commit, err = c.StartCommit(repo, branch)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+	}
+
+	put := func(p string, data io.Reader) error {
+		if overwrite {
+			_, err := c.PutFileOverwrite(repo, commit.ID, p, data, 0)
+			return err
+		}
+		_, err := c.PutFile(repo, commit.ID, p, data)
+		return err
+	}
+	var files []string
+	if err := func() error {
+		if !multipart {
+			files = append(files, filePath)
+			return put(filePath, r.Body)
+		}
+		reader, err := r.MultipartReader()
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			// Only file parts are written, other form fields are ignored.
+			if part.FileName() == "" {
+				continue
+			}
+			p := path.Join(filePath, path.Base(part.FileName()))
+			files = append(files, p)
+			if err := put(p, part); err != nil {
+				return err
+			}
+		}
+	}(); err != nil {
+		if batch == nil {
+			c.DeleteCommit(repo, commit.ID)
+			httpError(w, err)
+			return
+		}
+		// The batch's commit is still finished when its window expires, so
+		// the client is told which files it has to fix before it retries.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(httpErrorStatus(err))
+		json.NewEncoder(w).Encode(ingestResponse{
+			Commit: commit.ID,
+			Files:  files,
+			Error:  err.Error(),
+		})
+		return
+	}
+	if batch == nil {
+		if err := c.FinishCommit(repo, commit.ID); err != nil {
+			httpError(w, err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ingestResponse{
+		Commit:   commit.ID,
+		Files:    files,
+		Finished: batch == nil,
+	})
+}
+
+// joinBatch returns the open batch for the branch, starting a new one if there
+// isn't one. The caller must call writers.Done() on the batch when it's done
+// writing.
+func (i *ingester) joinBatch(c *client.APIClient, repo, branch string, window time.Duration) (*ingestBatch, error) {
+	key := repo + "@" + branch
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if batch, ok := i.batches[key]; ok {
+		batch.writers.Add(1)
+		return batch, nil
+	}
+	commit, err := c.StartCommit(repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	// c's context comes from requestContext, which isn't canceled when the
+	// request ends, so it can be used after the request that started the
+	// batch has returned.
+	batch := &ingestBatch{
+		commit:     commit,
+		pachClient: c,
+	}
+	batch.writers.Add(1)
+	i.batches[key] = batch
+	time.AfterFunc(window, func() {
+		i.mu.Lock()
+		delete(i.batches, key)
+		i.mu.Unlock()
+		batch.writers.Wait()
+		if err := batch.pachClient.FinishCommit(repo, commit.ID); err != nil {
+			log.Errorf("error finishing ingest commit %s@%s: %v", repo, commit.ID, err)
+		}
+	})
+	return batch, nil
+}
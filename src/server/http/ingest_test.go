@@ -0,0 +1,134 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+)
+
+func newTestServer(t *testing.T, env *testpachd.RealEnv) *httptest.Server {
+	handler, err := NewHTTPServer("")
+	require.NoError(t, err)
+	s := handler.(*server)
+	s.pachClientOnce.Do(func() { s.pachClient = env.PachClient })
+	return httptest.NewServer(s)
+}
+
+func ingest(t *testing.T, url string, contentType string, body io.Reader) (int, ingestResponse) {
+	resp, err := http.Post(url, contentType, body)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var result ingestResponse
+	if resp.Header.Get("Content-Type") == "application/json" {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	}
+	return resp.StatusCode, result
+}
+
+func TestIngest(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		require.NoError(t, c.CreateRepo("iot"))
+		ts := newTestServer(t, env)
+		defer ts.Close()
+
+		// A plain request writes its body to its own commit.
+		status, resp := ingest(t, ts.URL+"/v1/ingest/iot/master/sensors/a", "text/plain", strings.NewReader("foo"))
+		require.Equal(t, http.StatusOK, status)
+		require.True(t, resp.Finished)
+		require.Equal(t, []string{"sensors/a"}, resp.Files)
+		commitInfo, err := c.InspectCommit("iot", "master")
+		require.NoError(t, err)
+		require.Equal(t, resp.Commit, commitInfo.Commit.ID)
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile("iot", "master", "sensors/a", 0, 0, &buf))
+		require.Equal(t, "foo", buf.String())
+
+		// Files are appended to unless overwrite is set.
+		status, _ = ingest(t, ts.URL+"/v1/ingest/iot/master/sensors/a", "text/plain", strings.NewReader("bar"))
+		require.Equal(t, http.StatusOK, status)
+		buf.Reset()
+		require.NoError(t, c.GetFile("iot", "master", "sensors/a", 0, 0, &buf))
+		require.Equal(t, "foobar", buf.String())
+		status, _ = ingest(t, ts.URL+"/v1/ingest/iot/master/sensors/a?overwrite=true", "text/plain", strings.NewReader("baz"))
+		require.Equal(t, http.StatusOK, status)
+		buf.Reset()
+		require.NoError(t, c.GetFile("iot", "master", "sensors/a", 0, 0, &buf))
+		require.Equal(t, "baz", buf.String())
+
+		// Each file part of a multipart request is written under the path.
+		var form bytes.Buffer
+		mw := multipart.NewWriter(&form)
+		require.NoError(t, mw.WriteField("note", "ignored"))
+		for _, name := range []string{"b", "c"} {
+			part, err := mw.CreateFormFile("file", name)
+			require.NoError(t, err)
+			_, err = part.Write([]byte(name))
+			require.NoError(t, err)
+		}
+		require.NoError(t, mw.Close())
+		status, resp = ingest(t, ts.URL+"/v1/ingest/iot/master/uploads", mw.FormDataContentType(), &form)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"uploads/b", "uploads/c"}, resp.Files)
+		buf.Reset()
+		require.NoError(t, c.GetFile("iot", "master", "uploads/c", 0, 0, &buf))
+		require.Equal(t, "c", buf.String())
+
+		// Requests with a window share a commit that's finished when the window
+		// expires.
+		status, first := ingest(t, ts.URL+"/v1/ingest/iot/batched/x?window=1s", "text/plain", strings.NewReader("x"))
+		require.Equal(t, http.StatusOK, status)
+		require.False(t, first.Finished)
+		status, second := ingest(t, ts.URL+"/v1/ingest/iot/batched/y?window=1s", "text/plain", strings.NewReader("y"))
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, first.Commit, second.Commit)
+		require.NoErrorWithinT(t, 30*time.Second, func() error {
+			_, err := c.BlockCommit("iot", first.Commit)
+			return err
+		})
+		files, err := c.ListFile("iot", first.Commit, "")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(files))
+
+		// A batched request that fails reports the files it already wrote, as
+		// they stay in the batch's commit. The form below is cut off in its
+		// second file.
+		form.Reset()
+		mw = multipart.NewWriter(&form)
+		for _, name := range []string{"d", "e"} {
+			part, err := mw.CreateFormFile("file", name)
+			require.NoError(t, err)
+			_, err = part.Write([]byte(name))
+			require.NoError(t, err)
+		}
+		status, resp = ingest(t, ts.URL+"/v1/ingest/iot/failed/uploads?window=1s", mw.FormDataContentType(), &form)
+		require.Equal(t, http.StatusInternalServerError, status)
+		require.NotEqual(t, "", resp.Error)
+		require.Equal(t, []string{"uploads/d", "uploads/e"}, resp.Files)
+		require.NoErrorWithinT(t, 30*time.Second, func() error {
+			_, err := c.BlockCommit("iot", resp.Commit)
+			return err
+		})
+		buf.Reset()
+		require.NoError(t, c.GetFile("iot", resp.Commit, "uploads/d", 0, 0, &buf))
+		require.Equal(t, "d", buf.String())
+
+		// Errors are returned as HTTP statuses.
+		status, _ = ingest(t, ts.URL+"/v1/ingest/iot/master/", "text/plain", strings.NewReader("x"))
+		require.Equal(t, http.StatusBadRequest, status)
+		status, _ = ingest(t, ts.URL+"/v1/ingest/iot/master/x?window=soon", "text/plain", strings.NewReader("x"))
+		require.Equal(t, http.StatusBadRequest, status)
+		status, _ = ingest(t, ts.URL+"/v1/ingest/missing/master/x", "text/plain", strings.NewReader("x"))
+		require.Equal(t, http.StatusNotFound, status)
+		return nil
+	}))
+}
@@ -714,6 +714,91 @@ Any pachctl command that can take a Commit ID, can take a branch name instead.`,
 	shell.RegisterCompletionFunc(deleteBranch, shell.BranchCompletion)
 	commands = append(commands, cmdutil.CreateAlias(deleteBranch, "delete branch"))
 
+	var jsonSchemaPath string
+	var csvColumns cmdutil.RepeatedStringArg
+	var onInvalid string
+	var quarantinePath string
+	setSchema := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch>",
+		Short: "Set the schema that records put on a branch are validated against.",
+		Long: `Set the schema that records put on a branch are validated against.
+
+The schema applies to files split with 'put file --split'. JSON and line
+records are validated against a JSON schema, CSV records against a list of
+typed columns. Records which don't match the schema are either rejected, which
+prevents the commit from being finished, or moved to a quarantine path in the
+same commit.`,
+		Example: `
+# Validate JSON records put on master against a JSON schema
+$ {{alias}} repo@master --json-schema schema.json
+
+# Validate CSV records, moving invalid ones to /quarantine
+$ {{alias}} repo@master --csv-column id:int --csv-column name:string --csv-column score:float? --on-invalid quarantine --quarantine-path /quarantine`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			branch, err := cmdutil.ParseBranch(args[0])
+			if err != nil {
+				return err
+			}
+			schema := &pfsclient.Schema{QuarantinePath: quarantinePath}
+			if jsonSchemaPath != "" {
+				jsonSchema, err := ioutil.ReadFile(jsonSchemaPath)
+				if err != nil {
+					return errors.Wrapf(err, "could not read JSON schema")
+				}
+				schema.JsonSchema = string(jsonSchema)
+			}
+			for _, arg := range csvColumns {
+				column, err := parseSchemaColumn(arg)
+				if err != nil {
+					return err
+				}
+				schema.CsvColumns = append(schema.CsvColumns, column)
+			}
+			if schema.JsonSchema == "" && len(schema.CsvColumns) == 0 {
+				return errors.Errorf("one of --json-schema or --csv-column must be set")
+			}
+			action, ok := pfsclient.InvalidRecordAction_value[strings.ToUpper(onInvalid)]
+			if !ok {
+				return errors.Errorf("invalid value for --on-invalid: %q, must be \"reject\" or \"quarantine\"", onInvalid)
+			}
+			schema.OnInvalid = pfsclient.InvalidRecordAction(action)
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			return c.SetBranchSchema(branch.Repo.Name, branch.Name, schema)
+		}),
+	}
+	setSchema.Flags().StringVar(&jsonSchemaPath, "json-schema", "", "A file containing the JSON schema that JSON and line records are validated against.")
+	setSchema.Flags().Var(&csvColumns, "csv-column", "A column of CSV records, format: <name>:<type>, where type is one of string, int, float, bool or timestamp, followed by '?' if the column can be empty. Can be specified multiple times, in column order.")
+	setSchema.Flags().StringVar(&onInvalid, "on-invalid", "reject", "What to do with records which don't match the schema, one of reject or quarantine.")
+	setSchema.Flags().StringVar(&quarantinePath, "quarantine-path", "", "The directory that quarantined records are written to.")
+	shell.RegisterCompletionFunc(setSchema, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(setSchema, "set schema"))
+
+	deleteSchema := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch>",
+		Short: "Remove the schema from a branch.",
+		Long:  "Remove the schema from a branch, records put on it are no longer validated.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			branch, err := cmdutil.ParseBranch(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			return c.SetBranchSchema(branch.Repo.Name, branch.Name, nil)
+		}),
+	}
+	shell.RegisterCompletionFunc(deleteSchema, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(deleteSchema, "delete schema"))
+
 	fileDocs := &cobra.Command{
 		Short: "Docs for files.",
 		Long: `Files are the lowest level data objects in Pachyderm.
@@ -884,7 +969,7 @@ $ {{alias}} repo@branch -i http://host/path`,
 	putFile.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively put the files in a directory.")
 	putFile.Flags().BoolVarP(&compress, "compress", "", false, "Compress data during upload. This parameter might help you upload your uncompressed data, such as CSV files, to Pachyderm faster. Use 'compress' with caution, because if your data is already compressed, this parameter might slow down the upload speed instead of increasing.")
 	putFile.Flags().IntVarP(&parallelism, "parallelism", "p", DefaultParallelism, "The maximum number of files that can be uploaded in parallel.")
-	putFile.Flags().StringVar(&split, "split", "", "Split the input file into smaller files, subject to the constraints of --target-file-datums and --target-file-bytes. Permissible values are `line`, `json`, `sql` and `csv`.")
+	putFile.Flags().StringVar(&split, "split", "", "Split the input file into smaller files, subject to the constraints of --target-file-datums and --target-file-bytes. Permissible values are `line`, `json`, `sql`, `csv`, `parquet` (split by row group) and `avro` (split by block).")
 	putFile.Flags().UintVar(&targetFileDatums, "target-file-datums", 0, "The upper bound of the number of datums that each file contains, the last file will contain fewer if the datums don't divide evenly; needs to be used with --split.")
 	putFile.Flags().UintVar(&targetFileBytes, "target-file-bytes", 0, "The target upper bound of the number of bytes that each file contains; needs to be used with --split.")
 	putFile.Flags().UintVar(&headerRecords, "header-records", 0, "the number of records that will be converted to a PFS 'header', and prepended to future retrievals of any subset of data from PFS; needs to be used with --split=(json|line|csv)")
@@ -1382,9 +1467,13 @@ func putFileHelper(c *client.APIClient, pfc client.PutFileClient,
 			delimiter = pfsclient.Delimiter_SQL
 		case "csv":
 			delimiter = pfsclient.Delimiter_CSV
+		case "parquet":
+			delimiter = pfsclient.Delimiter_PARQUET
+		case "avro":
+			delimiter = pfsclient.Delimiter_AVRO
 		default:
 			return errors.Errorf("unrecognized delimiter '%s'; only accepts one of "+
-				"{json,line,sql,csv}", split)
+				"{json,line,sql,csv,parquet,avro}", split)
 		}
 		_, err := pfc.PutFileSplit(repo, commit, path, delimiter, int64(targetFileDatums), int64(targetFileBytes), int64(headerRecords), overwrite, reader)
 		return err
@@ -1461,6 +1550,27 @@ func joinPaths(prefix, filePath string) string {
 	return filepath.Join(prefix, filePath)
 }
 
+// parseSchemaColumn parses a CSV column of the form <name>:<type>, type may
+// be followed by '?' if the column is nullable.
+func parseSchemaColumn(arg string) (*pfsclient.SchemaColumn, error) {
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, errors.Errorf("invalid CSV column %q, format: <name>:<type>", arg)
+	}
+	column := &pfsclient.SchemaColumn{Name: parts[0]}
+	typ := parts[1]
+	if strings.HasSuffix(typ, "?") {
+		column.Nullable = true
+		typ = strings.TrimSuffix(typ, "?")
+	}
+	t, ok := pfsclient.ColumnType_value[strings.ToUpper(typ)]
+	if !ok {
+		return nil, errors.Errorf("invalid type %q for CSV column %q, must be one of string, int, float, bool or timestamp", typ, parts[0])
+	}
+	column.Type = pfsclient.ColumnType(t)
+	return column, nil
+}
+
 func isPipe(r io.ReadSeeker) (bool, error) {
 	file, ok := r.(*os.File)
 	if !ok {
@@ -6,9 +6,12 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
@@ -65,11 +68,35 @@ func parseRepoOpts(args []string) (map[string]*fuse.RepoOptions, error) {
 	return result, nil
 }
 
+func parseFlushMode(flush string) (fuse.FlushMode, error) {
+	switch flush {
+	case "unmount":
+		return fuse.FlushOnUnmount, nil
+	case "close":
+		return fuse.FlushOnClose, nil
+	case "periodic":
+		return fuse.FlushPeriodic, nil
+	default:
+		return 0, errors.Errorf("invalid flush mode %q: must be one of \"unmount\", \"close\" or \"periodic\"", flush)
+	}
+}
+
+// writeControl sends a command to the mount at mountPoint.
+func writeControl(mountPoint string, command string) error {
+	if err := ioutil.WriteFile(filepath.Join(mountPoint, fuse.ControlFile), []byte(command+"\n"), 0666); err != nil {
+		return errors.Wrapf(err, "could not send %q to the mount at %s", command, mountPoint)
+	}
+	return nil
+}
+
 func mountCmds() []*cobra.Command {
 	var commands []*cobra.Command
 
 	var write bool
+	var history bool
 	var debug bool
+	var flush string
+	var flushInterval time.Duration
 	var repoOpts cmdutil.RepeatedStringArg
 	mount := &cobra.Command{
 		Use:   "{{alias}} <path/to/mount/point>",
@@ -86,8 +113,15 @@ func mountCmds() []*cobra.Command {
 			if err != nil {
 				return err
 			}
+			flushMode, err := parseFlushMode(flush)
+			if err != nil {
+				return err
+			}
 			opts := &fuse.Options{
-				Write: write,
+				Write:         write,
+				History:       history,
+				Flush:         flushMode,
+				FlushInterval: flushInterval,
 				Fuse: &fs.Options{
 					MountOptions: gofuse.MountOptions{
 						Debug:  debug,
@@ -103,11 +137,42 @@ func mountCmds() []*cobra.Command {
 		}),
 	}
 	mount.Flags().BoolVarP(&write, "write", "w", false, "Allow writing to pfs through the mount.")
+	mount.Flags().BoolVar(&history, "history", false, "Mount every branch and commit of each repo read only, as \"repo/@branch\" and \"repo/@commit-id\".")
 	mount.Flags().BoolVarP(&debug, "debug", "d", false, "Turn on debug messages.")
 	mount.Flags().VarP(&repoOpts, "repos", "r", "Repos and branches / commits to mount, arguments should be of the form \"repo@branch+w\", where the trailing flag \"+w\" indicates write.")
+	mount.Flags().StringVar(&flush, "flush", "unmount", "When to commit writes to pfs, one of \"unmount\", \"close\" (when a written file is closed) or \"periodic\" (every --flush-interval).")
+	mount.Flags().DurationVar(&flushInterval, "flush-interval", fuse.DefaultFlushInterval, "How often to commit writes when --flush is \"periodic\".")
 	mount.MarkFlagCustom("repos", "__pachctl_get_repo_branch")
 	commands = append(commands, cmdutil.CreateAlias(mount, "mount"))
 
+	commit := &cobra.Command{
+		Use:   "{{alias}} <path/to/mount/point> [<repo>]",
+		Short: "Commit the writes made to a mount.",
+		Long:  "Commit the writes made to a mount. Each repo's writes are committed in a single commit on its mounted branch. If no repo is specified the writes to every repo are committed.",
+		Run: cmdutil.RunBoundedArgs(1, 2, func(args []string) error {
+			command := "commit"
+			if len(args) == 2 {
+				command += " " + args[1]
+			}
+			return writeControl(args[0], command)
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(commit, "mount commit"))
+
+	checkout := &cobra.Command{
+		Use:   "{{alias}} <path/to/mount/point> <repo>@<branch-or-commit>",
+		Short: "Switch a mounted repo to a different branch or commit.",
+		Long:  "Switch a mounted repo to a different branch or commit without remounting. Writes to the repo are committed before switching.",
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			repoAndBranch := strings.SplitN(args[1], "@", 2)
+			if len(repoAndBranch) != 2 || repoAndBranch[0] == "" || repoAndBranch[1] == "" {
+				return errors.Errorf("invalid format %q: must be of the form \"repo@branch\"", args[1])
+			}
+			return writeControl(args[0], fmt.Sprintf("checkout %s %s", repoAndBranch[0], repoAndBranch[1]))
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(checkout, "mount checkout"))
+
 	var all bool
 	unmount := &cobra.Command{
 		Use:   "{{alias}} <path/to/mount/point>",
@@ -0,0 +1,176 @@
+package fuse
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// blockCache records which blocks of each file have been fetched into the
+// loopback directory. Files in the meta state are sparse, so the blocks which
+// haven't been fetched yet are just zeros on disk.
+type blockCache struct {
+	mu        sync.Mutex
+	blockSize int64
+	blocks    map[string]map[int64]bool
+}
+
+func newBlockCache(blockSize int64) *blockCache {
+	return &blockCache{
+		blockSize: blockSize,
+		blocks:    make(map[string]map[int64]bool),
+	}
+}
+
+// missing returns the blocks overlapping [off, off+size) that haven't been
+// fetched for path.
+func (b *blockCache) missing(path string, off, size int64) []int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result []int64
+	for block := off / b.blockSize; block*b.blockSize < off+size; block++ {
+		if !b.blocks[path][block] {
+			result = append(result, block)
+		}
+	}
+	return result
+}
+
+func (b *blockCache) add(path string, block int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.blocks[path] == nil {
+		b.blocks[path] = make(map[int64]bool)
+	}
+	b.blocks[path][block] = true
+}
+
+// clear forgets all the blocks fetched for paths under prefix.
+func (b *blockCache) clear(prefix string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for path := range b.blocks {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			delete(b.blocks, path)
+		}
+	}
+}
+
+// pfsFile is the FileHandle for files in pfs, reads fetch the requested
+// ranges lazily and writes mark the file dirty so it gets committed.
+type pfsFile struct {
+	*loopbackFile
+	node  *loopbackNode
+	path  string
+	write bool
+}
+
+var _ = (fs.FileReader)((*pfsFile)(nil))
+var _ = (fs.FileWriter)((*pfsFile)(nil))
+var _ = (fs.FileFlusher)((*pfsFile)(nil))
+
+func newPFSFile(n *loopbackNode, path string, fd int, write bool) *pfsFile {
+	return &pfsFile{
+		loopbackFile: &loopbackFile{fd: fd},
+		node:         n,
+		path:         path,
+		write:        write,
+	}
+}
+
+func (f *pfsFile) Read(ctx context.Context, buf []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	if err := f.fetch(off, int64(len(buf))); err != nil {
+		return nil, fs.ToErrno(err)
+	}
+	return f.loopbackFile.Read(ctx, buf, off)
+}
+
+func (f *pfsFile) Write(ctx context.Context, data []byte, off int64) (uint32, syscall.Errno) {
+	n, errno := f.loopbackFile.Write(ctx, data, off)
+	if errno == 0 {
+		// A flush may have cleaned the file since it was opened.
+		f.node.setFileState(f.path, dirty)
+	}
+	return n, errno
+}
+
+func (f *pfsFile) Flush(ctx context.Context) syscall.Errno {
+	if errno := f.loopbackFile.Flush(ctx); errno != 0 {
+		return errno
+	}
+	if f.write && f.node.root().flushMode == FlushOnClose {
+		repo, _ := splitPath(f.node.trimPath(f.path))
+		if err := f.node.root().flush(repo, f.node.trimPath(f.path)); err != nil {
+			return fs.ToErrno(err)
+		}
+	}
+	return 0
+}
+
+// fetch downloads the blocks overlapping [off, off+size) which aren't
+// present locally yet.
+func (f *pfsFile) fetch(off, size int64) error {
+	if f.node.getFileState(f.path) >= full {
+		return nil
+	}
+	st := syscall.Stat_t{}
+	if err := syscall.Fstat(f.fd, &st); err != nil {
+		return errors.WithStack(err)
+	}
+	if off+size > st.Size {
+		size = st.Size - off
+	}
+	if size <= 0 {
+		return nil
+	}
+	cache := f.node.root().cache
+	path := f.node.trimPath(f.path)
+	repo, file := splitPath(path)
+	commit, err := f.node.commit(repo)
+	if err != nil {
+		return err
+	}
+	if commit == "" {
+		return nil
+	}
+	missing := cache.missing(path, off, size)
+	if len(missing) == 0 {
+		return nil
+	}
+	// The file handle has the caller's access mode, which is usually
+	// read-only, so the blocks are written to the loopback file through a
+	// separate fd.
+	fd, err := syscall.Open(f.path, syscall.O_WRONLY, 0)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer syscall.Close(fd)
+	for _, block := range missing {
+		var buf bytes.Buffer
+		if err := f.node.c().GetFile(repo, commit, file, block*cache.blockSize, cache.blockSize, &buf); err != nil {
+			return err
+		}
+		if _, err := syscall.Pwrite(fd, buf.Bytes(), block*cache.blockSize); err != nil {
+			return errors.WithStack(err)
+		}
+		cache.add(path, block)
+	}
+	return nil
+}
+
+// splitPath splits a path relative to the root of the mount into the repo
+// and the path of the file within the repo.
+func splitPath(path string) (string, string) {
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,275 @@
+package fuse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/progress"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+// ControlFile is the name of the file at the root of a mount which is used
+// to control it at runtime. Each line written to it is a command, either
+// "commit" to commit the writes to every repo, "commit <repo>" to commit the
+// writes to a single repo, or "checkout <repo> <branch-or-commit>" to commit
+// the writes to a repo and switch it to another branch or commit. Reading it
+// returns the branch or commit each repo is mounted at.
+const ControlFile = ".control"
+
+type controlNode struct {
+	fs.Inode
+	root *loopbackRoot
+}
+
+var _ = (fs.NodeOpener)((*controlNode)(nil))
+var _ = (fs.NodeGetattrer)((*controlNode)(nil))
+var _ = (fs.NodeSetattrer)((*controlNode)(nil))
+
+func (n *controlNode) Open(ctx context.Context, flags uint32) (fs.FileHandle, uint32, syscall.Errno) {
+	// The content of the control file is generated on every read, so the
+	// kernel mustn't cache it or trust its size.
+	return &controlFile{root: n.root}, fuse.FOPEN_DIRECT_IO, 0
+}
+
+func (n *controlNode) Getattr(ctx context.Context, f fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	out.Mode = fuse.S_IFREG | 0666
+	return 0
+}
+
+func (n *controlNode) Setattr(ctx context.Context, f fs.FileHandle, in *fuse.SetAttrIn, out *fuse.AttrOut) syscall.Errno {
+	// Shells truncate the file before writing to it, which is a no-op.
+	return n.Getattr(ctx, f, out)
+}
+
+type controlFile struct {
+	root *loopbackRoot
+}
+
+var _ = (fs.FileReader)((*controlFile)(nil))
+var _ = (fs.FileWriter)((*controlFile)(nil))
+
+func (f *controlFile) Read(ctx context.Context, buf []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	var state bytes.Buffer
+	for _, repo := range f.root.mountedRepos() {
+		fmt.Fprintf(&state, "%s@%s\n", repo, f.root.branch(repo))
+	}
+	data := state.Bytes()
+	if off >= int64(len(data)) {
+		return fuse.ReadResultData(nil), 0
+	}
+	end := off + int64(len(buf))
+	if end > int64(len(data)) {
+		end = int64(len(data))
+	}
+	return fuse.ReadResultData(data[off:end]), 0
+}
+
+func (f *controlFile) Write(ctx context.Context, data []byte, off int64) (uint32, syscall.Errno) {
+	for _, line := range strings.Split(string(data), "\n") {
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+		var err error
+		switch {
+		case args[0] == "commit" && len(args) == 1:
+			err = f.root.flushAll()
+		case args[0] == "commit" && len(args) == 2:
+			err = f.root.flush(args[1])
+		case args[0] == "checkout" && len(args) == 3:
+			err = f.root.checkout(args[1], args[2])
+		default:
+			return 0, syscall.EINVAL
+		}
+		if err != nil {
+			return 0, fs.ToErrno(err)
+		}
+	}
+	return uint32(len(data)), 0
+}
+
+// mountedRepos returns the repos which have been accessed through the mount
+// or were explicitly configured for it.
+func (r *loopbackRoot) mountedRepos() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	repos := make(map[string]bool)
+	for repo := range r.repoOpts {
+		repos[repo] = true
+	}
+	for repo := range r.commits {
+		repos[repo] = true
+	}
+	var result []string
+	for repo := range repos {
+		result = append(result, repo)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// flushAll commits the writes to every repo.
+func (r *loopbackRoot) flushAll() error {
+	repos := make(map[string]bool)
+	func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for path, state := range r.files {
+			if state == dirty {
+				repo, _ := splitPath(path)
+				repos[repo] = true
+			}
+		}
+	}()
+	for repo := range repos {
+		if err := r.flush(repo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flush commits the writes to repo in a single commit on the mounted branch.
+// If paths are specified only those files are committed.
+func (r *loopbackRoot) flush(repo string, paths ...string) (retErr error) {
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+	// Mark the files clean before they're uploaded, a concurrent write will
+	// then dirty them again so they're picked up by the next flush.
+	var files []string
+	func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if len(paths) == 0 {
+			for path, state := range r.files {
+				if p, _ := splitPath(path); p == repo && state == dirty {
+					paths = append(paths, path)
+				}
+			}
+		}
+		for _, path := range paths {
+			if r.files[path] == dirty {
+				r.files[path] = full
+				files = append(files, path)
+			}
+		}
+	}()
+	if len(files) == 0 {
+		return nil
+	}
+	defer func() {
+		if retErr != nil {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			for _, path := range files {
+				r.files[path] = dirty
+			}
+		}
+	}()
+	sort.Strings(files)
+	commit, err := r.c.StartCommit(repo, r.branch(repo))
+	if err != nil {
+		return err
+	}
+	pfc, err := r.c.NewPutFileClient()
+	if err != nil {
+		return err
+	}
+	if err := func() (retErr error) {
+		defer func() {
+			if err := pfc.Close(); err != nil && retErr == nil {
+				retErr = err
+			}
+		}()
+		for _, path := range files {
+			if err := r.upload(pfc, commit.ID, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return err
+	}
+	if err := r.c.FinishCommit(repo, commit.ID); err != nil {
+		return err
+	}
+	// Files which haven't been written are read from the new commit, it
+	// only differs from the old one by what we just uploaded.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commits[repo] = commit.ID
+	return nil
+}
+
+func (r *loopbackRoot) upload(pfc client.PutFileClient, commitID, path string) (retErr error) {
+	repo, file := splitPath(path)
+	f, err := progress.Open(filepath.Join(r.rootPath, path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return pfc.DeleteFile(repo, commitID, file)
+		}
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = errors.WithStack(err)
+		}
+	}()
+	_, err = pfc.PutFileOverwrite(repo, commitID, file, f, 0)
+	return err
+}
+
+// checkout commits the writes to repo and switches it to branchOrCommit.
+func (r *loopbackRoot) checkout(repo, branchOrCommit string) error {
+	if r.checkWrite(filepath.Join(r.rootPath, repo)) == 0 {
+		if err := validateWrite(r.c, repo, branchOrCommit); err != nil {
+			return err
+		}
+	} else if !uuid.IsUUIDWithoutDashes(branchOrCommit) {
+		if _, err := r.c.InspectBranch(repo, branchOrCommit); err != nil {
+			return err
+		}
+	}
+	if err := r.flush(repo); err != nil {
+		return err
+	}
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+	if err := func() error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.branches[repo] = branchOrCommit
+		delete(r.commits, repo)
+		for path := range r.files {
+			if path == repo || strings.HasPrefix(path, repo+"/") {
+				delete(r.files, path)
+			}
+		}
+		r.cache.clear(repo)
+		p := filepath.Join(r.rootPath, repo)
+		if err := os.RemoveAll(p); err != nil {
+			return errors.WithStack(err)
+		}
+		return errors.WithStack(os.MkdirAll(p, 0777))
+	}(); err != nil {
+		return err
+	}
+	// Forget the old contents of the repo so that the kernel looks them up
+	// again.
+	if ch := r.GetChild(repo); ch != nil {
+		ch.RmAllChildren()
+	}
+	r.NotifyEntry(repo)
+	return nil
+}
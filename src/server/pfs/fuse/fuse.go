@@ -4,16 +4,13 @@ import (
 	"io/ioutil"
 	"os"
 	"os/signal"
-	pathpkg "path"
-	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/hanwen/go-fuse/v2/fs"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
-	"github.com/pachyderm/pachyderm/src/server/pkg/progress"
-	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 )
 
 // Mount pfs to target, opts may be left nil.
@@ -21,11 +18,8 @@ func Mount(c *client.APIClient, target string, opts *Options) (retErr error) {
 	if err := opts.validate(c); err != nil {
 		return err
 	}
-	commits := make(map[string]string)
-	for repo, branch := range opts.getBranches() {
-		if uuid.IsUUIDWithoutDashes(branch) {
-			commits[repo] = branch
-		}
+	if opts.getHistory() {
+		return mountHistory(c, target, opts)
 	}
 	rootDir, err := ioutil.TempDir("", "pfs")
 	if err != nil {
@@ -53,56 +47,42 @@ func Mount(c *client.APIClient, target string, opts *Options) (retErr error) {
 		}
 		server.Unmount()
 	}()
+	if opts.getFlush() == FlushPeriodic {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			ticker := time.NewTicker(opts.getFlushInterval())
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if err := root.flushAll(); err != nil {
+						log.Errorf("error committing writes: %v", err)
+					}
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
 	server.Serve()
-	pfcs := make(map[string]client.PutFileClient)
-	pfc := func(repo string) (client.PutFileClient, error) {
-		if pfc, ok := pfcs[repo]; ok {
-			return pfc, nil
-		}
-		pfc, err := c.NewPutFileClient()
-		if err != nil {
-			return nil, err
-		}
-		pfcs[repo] = pfc
-		return pfc, nil
+	return root.flushAll()
+}
+
+func mountHistory(c *client.APIClient, target string, opts *Options) error {
+	server, err := fs.Mount(target, newHistoryRoot(c, opts), opts.getFuse())
+	if err != nil {
+		return errors.WithStack(err)
 	}
-	defer func() {
-		for _, pfc := range pfcs {
-			if err := pfc.Close(); err != nil && retErr == nil {
-				retErr = err
-			}
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt)
+	go func() {
+		select {
+		case <-sigChan:
+		case <-opts.getUnmount():
 		}
+		server.Unmount()
 	}()
-	for path, state := range root.files {
-		if state != dirty {
-			continue
-		}
-		parts := strings.Split(path, "/")
-		pfc, err := pfc(parts[0])
-		if err != nil {
-			return err
-		}
-		if err := func() (retErr error) {
-			f, err := progress.Open(filepath.Join(root.rootPath, path))
-			if err != nil {
-				if os.IsNotExist(err) {
-					return pfc.DeleteFile(parts[0], root.branch(parts[0]), pathpkg.Join(parts[1:]...))
-				}
-				return errors.WithStack(err)
-			}
-			defer func() {
-				if err := f.Close(); err != nil && retErr == nil {
-					retErr = errors.WithStack(err)
-				}
-			}()
-			if _, err := pfc.PutFileOverwrite(parts[0], root.branch(parts[0]),
-				pathpkg.Join(parts[1:]...), f, 0); err != nil {
-				return err
-			}
-			return nil
-		}(); err != nil {
-			return err
-		}
-	}
+	server.Serve()
 	return nil
 }
@@ -3,6 +3,7 @@ package fuse
 import (
 	"bytes"
 	"crypto/sha256"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -360,6 +361,150 @@ func TestOpenCommit(t *testing.T) {
 	})
 }
 
+func TestRangedRead(t *testing.T) {
+	c := server.GetPachClient(t, server.GetBasicConfig())
+	require.NoError(t, c.CreateRepo("repo"))
+	data := workload.RandString(rand.New(rand.NewSource(123)), 10*MB)
+	_, err := c.PutFile("repo", "master", "file", strings.NewReader(data))
+	require.NoError(t, err)
+	withMount(t, c, &Options{BlockSize: MB}, func(mountPoint string) {
+		f, err := os.Open(filepath.Join(mountPoint, "repo", "file"))
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, f.Close())
+		}()
+		// Read ranges that straddle block boundaries, out of order.
+		for _, offset := range []int64{5*MB - 10, 10, 9*MB + 7, MB - 1} {
+			buf := make([]byte, 100)
+			n, err := f.ReadAt(buf, offset)
+			require.NoError(t, err)
+			require.Equal(t, data[offset:offset+int64(n)], string(buf[:n]))
+		}
+	})
+}
+
+func TestReadOnlyOpen(t *testing.T) {
+	c := server.GetPachClient(t, server.GetBasicConfig())
+	require.NoError(t, c.CreateRepo("repo"))
+	data := workload.RandString(rand.New(rand.NewSource(123)), 3*MB)
+	_, err := c.PutFile("repo", "master", "file", strings.NewReader(data))
+	require.NoError(t, err)
+	withMount(t, c, &Options{BlockSize: MB}, func(mountPoint string) {
+		// The file is opened read-only, but the blocks it reads are still
+		// fetched into the loopback file.
+		f, err := os.OpenFile(filepath.Join(mountPoint, "repo", "file"), os.O_RDONLY, 0)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, f.Close())
+		}()
+		buf := make([]byte, 2*MB)
+		_, err = io.ReadFull(f, buf)
+		require.NoError(t, err)
+		require.Equal(t, data[:2*MB], string(buf))
+	})
+}
+
+func TestFlushOnClose(t *testing.T) {
+	c := server.GetPachClient(t, server.GetBasicConfig())
+	require.NoError(t, c.CreateRepo("repo"))
+	withMount(t, c, &Options{
+		Write: true,
+		Flush: FlushOnClose,
+	}, func(mountPoint string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(mountPoint, "repo", "foo"), []byte("foo\n"), 0644))
+		// The file is committed before the mount is unmounted.
+		var b bytes.Buffer
+		require.NoError(t, c.GetFile("repo", "master", "foo", 0, 0, &b))
+		require.Equal(t, "foo\n", b.String())
+		require.NoError(t, ioutil.WriteFile(filepath.Join(mountPoint, "repo", "bar"), []byte("bar\n"), 0644))
+	})
+	commits, err := c.ListCommit("repo", "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(commits))
+}
+
+func TestControlCommit(t *testing.T) {
+	c := server.GetPachClient(t, server.GetBasicConfig())
+	require.NoError(t, c.CreateRepo("repo"))
+	withMount(t, c, &Options{
+		Write: true,
+	}, func(mountPoint string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(mountPoint, "repo", "foo"), []byte("foo\n"), 0644))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(mountPoint, "repo", "bar"), []byte("bar\n"), 0644))
+		_, err := c.InspectFile("repo", "master", "foo")
+		require.YesError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(mountPoint, ControlFile), []byte("commit repo\n"), 0644))
+		// Both files are committed in a single commit.
+		commits, err := c.ListCommit("repo", "master", "", 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(commits))
+		fis, err := c.ListFile("repo", "master", "")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(fis))
+		require.YesError(t, ioutil.WriteFile(filepath.Join(mountPoint, ControlFile), []byte("push repo\n"), 0644))
+	})
+	// Nothing left to commit on unmount.
+	commits, err := c.ListCommit("repo", "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(commits))
+}
+
+func TestControlCheckout(t *testing.T) {
+	c := server.GetPachClient(t, server.GetBasicConfig())
+	require.NoError(t, c.CreateRepo("repo"))
+	_, err := c.PutFile("repo", "master", "foo", strings.NewReader("foo\n"))
+	require.NoError(t, err)
+	_, err = c.PutFile("repo", "staging", "bar", strings.NewReader("bar\n"))
+	require.NoError(t, err)
+	withMount(t, c, &Options{
+		RepoOptions: map[string]*RepoOptions{
+			"repo": {Branch: "master"},
+		},
+	}, func(mountPoint string) {
+		data, err := ioutil.ReadFile(filepath.Join(mountPoint, "repo", "foo"))
+		require.NoError(t, err)
+		require.Equal(t, "foo\n", string(data))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(mountPoint, ControlFile), []byte("checkout repo staging\n"), 0644))
+		state, err := ioutil.ReadFile(filepath.Join(mountPoint, ControlFile))
+		require.NoError(t, err)
+		require.Equal(t, "repo@staging\n", string(state))
+		_, err = ioutil.ReadFile(filepath.Join(mountPoint, "repo", "foo"))
+		require.YesError(t, err)
+		data, err = ioutil.ReadFile(filepath.Join(mountPoint, "repo", "bar"))
+		require.NoError(t, err)
+		require.Equal(t, "bar\n", string(data))
+	})
+}
+
+func TestHistory(t *testing.T) {
+	c := server.GetPachClient(t, server.GetBasicConfig())
+	require.NoError(t, c.CreateRepo("repo"))
+	commit1, err := c.StartCommit("repo", "master")
+	require.NoError(t, err)
+	_, err = c.PutFile("repo", commit1.ID, "dir/file", strings.NewReader("foo\n"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit("repo", commit1.ID))
+	_, err = c.PutFile("repo", "master", "dir/file", strings.NewReader("bar\n"))
+	require.NoError(t, err)
+	withMount(t, c, &Options{History: true}, func(mountPoint string) {
+		refs, err := ioutil.ReadDir(filepath.Join(mountPoint, "repo"))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(refs))
+		require.Equal(t, "@master", refs[0].Name())
+		data, err := ioutil.ReadFile(filepath.Join(mountPoint, "repo", "@master", "dir", "file"))
+		require.NoError(t, err)
+		require.Equal(t, "foo\nbar\n", string(data))
+		data, err = ioutil.ReadFile(filepath.Join(mountPoint, "repo", "@"+commit1.ID, "dir", "file"))
+		require.NoError(t, err)
+		require.Equal(t, "foo\n", string(data))
+		data, err = ioutil.ReadFile(filepath.Join(mountPoint, "repo", "@"+commit1.ID, ".pfs", "commit.json"))
+		require.NoError(t, err)
+		require.True(t, strings.Contains(string(data), commit1.ID))
+		require.YesError(t, ioutil.WriteFile(filepath.Join(mountPoint, "repo", "@master", "dir", "file"), []byte("baz\n"), 0644))
+	})
+	require.YesError(t, Mount(c, "/tmp/unused", &Options{History: true, Write: true}))
+}
+
 func withMount(tb testing.TB, c *client.APIClient, opts *Options, f func(mountPoint string)) {
 	dir, err := ioutil.TempDir("", "pfs-mount")
 	require.NoError(tb, err)
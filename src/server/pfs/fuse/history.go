@@ -0,0 +1,288 @@
+package fuse
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+	"syscall"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+)
+
+const (
+	// refPrefix prefixes the names of the branch and commit directories in a
+	// history mount.
+	refPrefix = "@"
+	// metaDir is the name of the virtual directory at the root of each
+	// commit in a history mount which contains information about the commit.
+	metaDir = ".pfs"
+)
+
+// metaFiles are the files in metaDir.
+var metaFiles = []string{"commit.json", "provenance.json"}
+
+// historyRoot is the root of a history mount. History mounts are read only
+// and expose each repo as a directory containing a directory for each of its
+// branches (named "@branch") and commits (named "@commit-id"). Commit
+// directories aren't listed, since there can be arbitrarily many of them, but
+// can be looked up by ID. Since commits are immutable the contents of a
+// history mount are served directly from pfs without a loopback directory.
+type historyRoot struct {
+	fs.Inode
+
+	c        *client.APIClient
+	repoOpts map[string]*RepoOptions
+}
+
+func newHistoryRoot(c *client.APIClient, opts *Options) *historyRoot {
+	return &historyRoot{
+		c:        c,
+		repoOpts: opts.getRepoOpts(),
+	}
+}
+
+var _ = (fs.NodeLookuper)((*historyRoot)(nil))
+var _ = (fs.NodeReaddirer)((*historyRoot)(nil))
+
+func (r *historyRoot) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	if len(r.repoOpts) > 0 && r.repoOpts[name] == nil {
+		return nil, syscall.ENOENT
+	}
+	if _, err := r.c.InspectRepo(name); err != nil {
+		return nil, toErrno(err)
+	}
+	out.Mode = fuse.S_IFDIR | 0555
+	return r.NewInode(ctx, &historyRepo{c: r.c, repo: name}, fs.StableAttr{Mode: fuse.S_IFDIR}), 0
+}
+
+func (r *historyRoot) Readdir(ctx context.Context) (fs.DirStream, syscall.Errno) {
+	ris, err := r.c.ListRepo()
+	if err != nil {
+		return nil, toErrno(err)
+	}
+	var entries []fuse.DirEntry
+	for _, ri := range ris {
+		if len(r.repoOpts) > 0 && r.repoOpts[ri.Repo.Name] == nil {
+			continue
+		}
+		entries = append(entries, fuse.DirEntry{Name: ri.Repo.Name, Mode: fuse.S_IFDIR})
+	}
+	return fs.NewListDirStream(entries), 0
+}
+
+// historyRepo is the directory for a repo in a history mount.
+type historyRepo struct {
+	fs.Inode
+
+	c    *client.APIClient
+	repo string
+}
+
+var _ = (fs.NodeLookuper)((*historyRepo)(nil))
+var _ = (fs.NodeReaddirer)((*historyRepo)(nil))
+
+func (n *historyRepo) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	if !strings.HasPrefix(name, refPrefix) {
+		return nil, syscall.ENOENT
+	}
+	// InspectCommit resolves both branches and commit IDs.
+	ci, err := n.c.InspectCommit(n.repo, strings.TrimPrefix(name, refPrefix))
+	if err != nil {
+		return nil, toErrno(err)
+	}
+	out.Mode = fuse.S_IFDIR | 0555
+	setTime(&out.Attr, ci.Finished)
+	return n.NewInode(ctx, &historyDir{c: n.c, commit: ci.Commit, info: ci}, fs.StableAttr{Mode: fuse.S_IFDIR}), 0
+}
+
+func (n *historyRepo) Readdir(ctx context.Context) (fs.DirStream, syscall.Errno) {
+	bis, err := n.c.ListBranch(n.repo)
+	if err != nil {
+		return nil, toErrno(err)
+	}
+	var entries []fuse.DirEntry
+	for _, bi := range bis {
+		entries = append(entries, fuse.DirEntry{Name: refPrefix + bi.Branch.Name, Mode: fuse.S_IFDIR})
+	}
+	return fs.NewListDirStream(entries), 0
+}
+
+// historyDir is a directory within a commit in a history mount, info is set
+// for the root directory of the commit.
+type historyDir struct {
+	fs.Inode
+
+	c      *client.APIClient
+	commit *pfs.Commit
+	path   string
+	info   *pfs.CommitInfo
+}
+
+var _ = (fs.NodeLookuper)((*historyDir)(nil))
+var _ = (fs.NodeReaddirer)((*historyDir)(nil))
+
+func (n *historyDir) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	if n.info != nil && name == metaDir {
+		out.Mode = fuse.S_IFDIR | 0555
+		return n.NewInode(ctx, &historyMeta{info: n.info}, fs.StableAttr{Mode: fuse.S_IFDIR}), 0
+	}
+	fi, err := n.c.InspectFile(n.commit.Repo.Name, n.commit.ID, path.Join(n.path, name))
+	if err != nil {
+		return nil, toErrno(err)
+	}
+	setTime(&out.Attr, fi.Committed)
+	if fi.FileType == pfs.FileType_DIR {
+		out.Mode = fuse.S_IFDIR | 0555
+		return n.NewInode(ctx, &historyDir{c: n.c, commit: n.commit, path: fi.File.Path}, fs.StableAttr{Mode: fuse.S_IFDIR}), 0
+	}
+	out.Mode = fuse.S_IFREG | 0444
+	out.Size = fi.SizeBytes
+	return n.NewInode(ctx, &historyFile{c: n.c, info: fi}, fs.StableAttr{Mode: fuse.S_IFREG}), 0
+}
+
+func (n *historyDir) Readdir(ctx context.Context) (fs.DirStream, syscall.Errno) {
+	var entries []fuse.DirEntry
+	if n.info != nil {
+		entries = append(entries, fuse.DirEntry{Name: metaDir, Mode: fuse.S_IFDIR})
+	}
+	if err := n.c.ListFileF(n.commit.Repo.Name, n.commit.ID, n.path, 0, func(fi *pfs.FileInfo) error {
+		mode := uint32(fuse.S_IFREG)
+		if fi.FileType == pfs.FileType_DIR {
+			mode = fuse.S_IFDIR
+		}
+		entries = append(entries, fuse.DirEntry{Name: path.Base(fi.File.Path), Mode: mode})
+		return nil
+	}); err != nil && !pfsserver.IsOutputCommitNotFinishedErr(err) {
+		return nil, toErrno(err)
+	}
+	return fs.NewListDirStream(entries), 0
+}
+
+// historyFile is a file within a commit in a history mount.
+type historyFile struct {
+	fs.Inode
+
+	c    *client.APIClient
+	info *pfs.FileInfo
+}
+
+var _ = (fs.NodeOpener)((*historyFile)(nil))
+var _ = (fs.NodeReader)((*historyFile)(nil))
+var _ = (fs.NodeGetattrer)((*historyFile)(nil))
+
+func (n *historyFile) Open(ctx context.Context, flags uint32) (fs.FileHandle, uint32, syscall.Errno) {
+	if isWrite(flags) {
+		return nil, 0, syscall.EROFS
+	}
+	// Files in a commit never change, so the kernel can keep caching them.
+	return nil, fuse.FOPEN_KEEP_CACHE, 0
+}
+
+func (n *historyFile) Read(ctx context.Context, f fs.FileHandle, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	if off >= int64(n.info.SizeBytes) {
+		return fuse.ReadResultData(nil), 0
+	}
+	var buf bytes.Buffer
+	file := n.info.File
+	if err := n.c.GetFile(file.Commit.Repo.Name, file.Commit.ID, file.Path, off, int64(len(dest)), &buf); err != nil {
+		return nil, toErrno(err)
+	}
+	return fuse.ReadResultData(buf.Bytes()), 0
+}
+
+func (n *historyFile) Getattr(ctx context.Context, f fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	out.Mode = fuse.S_IFREG | 0444
+	out.Size = n.info.SizeBytes
+	setTime(&out.Attr, n.info.Committed)
+	return 0
+}
+
+// historyMeta is the virtual directory at the root of each commit in a
+// history mount, it contains the commit's CommitInfo and provenance as JSON.
+type historyMeta struct {
+	fs.Inode
+
+	info *pfs.CommitInfo
+}
+
+var _ = (fs.NodeLookuper)((*historyMeta)(nil))
+var _ = (fs.NodeReaddirer)((*historyMeta)(nil))
+
+func (n *historyMeta) files() (map[string][]byte, error) {
+	marshaller := &jsonpb.Marshaler{Indent: "  "}
+	commit, err := marshaller.MarshalToString(n.info)
+	if err != nil {
+		return nil, err
+	}
+	// jsonpb only marshals messages, so the provenance array is assembled by
+	// hand.
+	provenance := []string{}
+	for _, p := range n.info.Provenance {
+		s, err := marshaller.MarshalToString(p)
+		if err != nil {
+			return nil, err
+		}
+		provenance = append(provenance, s)
+	}
+	return map[string][]byte{
+		"commit.json":     []byte(commit + "\n"),
+		"provenance.json": []byte("[" + strings.Join(provenance, ",\n") + "]\n"),
+	}, nil
+}
+
+func (n *historyMeta) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	files, err := n.files()
+	if err != nil {
+		return nil, toErrno(err)
+	}
+	data, ok := files[name]
+	if !ok {
+		return nil, syscall.ENOENT
+	}
+	out.Mode = fuse.S_IFREG | 0444
+	out.Size = uint64(len(data))
+	return n.NewInode(ctx, &fs.MemRegularFile{
+		Data: data,
+		Attr: fuse.Attr{Mode: 0444},
+	}, fs.StableAttr{Mode: fuse.S_IFREG}), 0
+}
+
+func (n *historyMeta) Readdir(ctx context.Context) (fs.DirStream, syscall.Errno) {
+	files, err := n.files()
+	if err != nil {
+		return nil, toErrno(err)
+	}
+	var entries []fuse.DirEntry
+	for _, name := range metaFiles {
+		if _, ok := files[name]; ok {
+			entries = append(entries, fuse.DirEntry{Name: name, Mode: fuse.S_IFREG})
+		}
+	}
+	return fs.NewListDirStream(entries), 0
+}
+
+func setTime(attr *fuse.Attr, t *types.Timestamp) {
+	if t == nil {
+		return
+	}
+	attr.Mtime = uint64(t.Seconds)
+	attr.Mtimensec = uint32(t.Nanos)
+	attr.Ctime = attr.Mtime
+	attr.Ctimensec = attr.Mtimensec
+}
+
+func toErrno(err error) syscall.Errno {
+	if errutil.IsNotFoundError(err) {
+		return syscall.ENOENT
+	}
+	return fs.ToErrno(err)
+}
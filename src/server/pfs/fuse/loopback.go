@@ -21,6 +21,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
 	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 )
 
 type fileState int32
@@ -42,13 +43,19 @@ type loopbackRoot struct {
 
 	write bool
 
+	flushMode FlushMode
+
 	c *client.APIClient
 
 	repoOpts map[string]*RepoOptions
 	branches map[string]string
 	commits  map[string]string
 	files    map[string]fileState
+	cache    *blockCache
 	mu       sync.Mutex
+	// flushMu serializes commits so that each file is uploaded by at most
+	// one of them.
+	flushMu sync.Mutex
 }
 
 type loopbackNode struct {
@@ -111,6 +118,10 @@ func (n *loopbackNode) path() string {
 }
 
 func (n *loopbackNode) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	if n.IsRoot() && name == ControlFile {
+		out.Attr.Mode = fuse.S_IFREG | 0666
+		return n.NewInode(ctx, &controlNode{root: n.root()}, fs.StableAttr{Mode: fuse.S_IFREG}), 0
+	}
 	p := filepath.Join(n.path(), name)
 	if err := n.download(p, meta); err != nil {
 		return nil, fs.ToErrno(err)
@@ -280,7 +291,7 @@ func (n *loopbackNode) Create(ctx context.Context, name string, flags uint32, mo
 
 	node := &loopbackNode{}
 	ch := n.NewInode(ctx, node, n.root().idFromStat(&st))
-	lf := NewLoopbackFile(fd)
+	lf := newPFSFile(n, p, fd, true)
 
 	out.FromStat(&st)
 	return ch, lf, 0, 0
@@ -370,7 +381,9 @@ func (n *loopbackNode) Readlink(ctx context.Context) ([]byte, syscall.Errno) {
 
 func (n *loopbackNode) Open(ctx context.Context, flags uint32) (fh fs.FileHandle, fuseFlags uint32, errno syscall.Errno) {
 	p := n.path()
-	state := full
+	// Reads are served lazily by the pfsFile, so we only need the full
+	// content up front if the file is going to be written.
+	state := meta
 	if isWrite(flags) {
 		if errno := n.checkWrite(p); errno != 0 {
 			return nil, 0, errno
@@ -391,7 +404,7 @@ func (n *loopbackNode) Open(ctx context.Context, flags uint32) (fh fs.FileHandle
 	if err != nil {
 		return nil, 0, fs.ToErrno(err)
 	}
-	lf := NewLoopbackFile(f)
+	lf := newPFSFile(n, p, f, isWrite(flags))
 	return lf, 0, 0
 }
 
@@ -521,11 +534,13 @@ func newLoopbackRoot(root, target string, c *client.APIClient, opts *Options) (*
 		rootDev:    uint64(st.Dev),
 		targetPath: target,
 		write:      opts.getWrite(),
+		flushMode:  opts.getFlush(),
 		c:          c,
 		repoOpts:   opts.getRepoOpts(),
 		branches:   opts.getBranches(),
 		commits:    make(map[string]string),
 		files:      make(map[string]fileState),
+		cache:      newBlockCache(opts.getBlockSize()),
 	}
 	return n, nil
 }
@@ -592,6 +607,11 @@ func (n *loopbackNode) download(path string, state fileState) (retErr error) {
 				return os.MkdirAll(n.filePath(fi), 0777)
 			}
 			p := n.filePath(fi)
+			if n.getFileState(p) >= state {
+				// Don't clobber content that has already been fetched or
+				// written, listing a directory downloads all of its files.
+				return nil
+			}
 			// Make sure the directory exists
 			// I think this may be unnecessary based on the constraints the
 			// OS imposes, but don't want to rely on that, especially
@@ -609,6 +629,7 @@ func (n *loopbackNode) download(path string, state fileState) (retErr error) {
 				}
 			}()
 			if state < full {
+				n.root().cache.clear(n.trimPath(p))
 				return f.Truncate(int64(fi.SizeBytes))
 			}
 			if err := n.c().GetFile(fi.File.Commit.Repo.Name, fi.File.Commit.ID, fi.File.Path, 0, 0, f); err != nil {
@@ -633,7 +654,8 @@ func (n *loopbackNode) trimTargetPath(path string) string {
 }
 
 func (n *loopbackNode) branch(repo string) string {
-	// no need to lock mu for branches since we only ever read from it.
+	n.root().mu.Lock()
+	defer n.root().mu.Unlock()
 	if branch, ok := n.root().branches[repo]; ok {
 		return branch
 	}
@@ -650,6 +672,13 @@ func (n *loopbackNode) commit(repo string) (string, error) {
 		return commit, nil
 	}
 	branch := n.root().branch(repo)
+	if uuid.IsUUIDWithoutDashes(branch) {
+		// The repo is mounted at a commit rather than a branch.
+		n.root().mu.Lock()
+		defer n.root().mu.Unlock()
+		n.root().commits[repo] = branch
+		return branch, nil
+	}
 	bi, err := n.root().c.InspectBranch(repo, branch)
 	if err != nil && !errutil.IsNotFoundError(err) {
 		return "", err
@@ -1,6 +1,8 @@
 package fuse
 
 import (
+	"time"
+
 	"github.com/hanwen/go-fuse/v2/fs"
 
 	"github.com/pachyderm/pachyderm/src/client"
@@ -19,14 +21,54 @@ type Options struct {
 	// Writes will be written back to the filesystem.
 	Write bool
 
+	// History mounts every repo read only with a directory for each of its
+	// branches ("repo/@branch") and commits ("repo/@commit-id"), rather than
+	// a single branch per repo. The root of each of those directories
+	// contains a virtual ".pfs" directory with the commit's CommitInfo and
+	// provenance as JSON.
+	History bool
+
 	// RepoOptions is a map from repo names to options associated with them.
 	RepoOptions map[string]*RepoOptions
 
+	// Flush controls when writes are committed back to pfs, it defaults to
+	// FlushOnUnmount. Writes can always be committed explicitly through the
+	// control file.
+	Flush FlushMode
+
+	// FlushInterval is how often writes are committed when Flush is
+	// FlushPeriodic.
+	FlushInterval time.Duration
+
+	// BlockSize is the size of the ranges that are fetched from pfs when a
+	// file is read, it defaults to DefaultBlockSize.
+	BlockSize int64
+
 	// Unmount is a channel that will be closed when the filesystem has been
 	// unmounted. It can be nil in which case it's ignored.
 	Unmount chan struct{}
 }
 
+// FlushMode determines when writes to a mount are committed to pfs.
+type FlushMode int
+
+const (
+	// FlushOnUnmount commits writes when the filesystem is unmounted.
+	FlushOnUnmount FlushMode = iota
+	// FlushOnClose commits a file as soon as a handle that was opened for
+	// writing is closed.
+	FlushOnClose
+	// FlushPeriodic commits writes every FlushInterval.
+	FlushPeriodic
+)
+
+const (
+	// DefaultBlockSize is the default size of the ranges fetched from pfs.
+	DefaultBlockSize = 4 * 1024 * 1024
+	// DefaultFlushInterval is the default interval used by FlushPeriodic.
+	DefaultFlushInterval = time.Minute
+)
+
 // RepoOptions are the options associated with a mounted repo.
 type RepoOptions struct {
 	// Branch is the branch of the repo to mount
@@ -71,6 +113,34 @@ func (o *Options) getWrite() bool {
 	return o.Write
 }
 
+func (o *Options) getFlush() FlushMode {
+	if o == nil {
+		return FlushOnUnmount
+	}
+	return o.Flush
+}
+
+func (o *Options) getFlushInterval() time.Duration {
+	if o == nil || o.FlushInterval == 0 {
+		return DefaultFlushInterval
+	}
+	return o.FlushInterval
+}
+
+func (o *Options) getBlockSize() int64 {
+	if o == nil || o.BlockSize == 0 {
+		return DefaultBlockSize
+	}
+	return o.BlockSize
+}
+
+func (o *Options) getHistory() bool {
+	if o == nil {
+		return false
+	}
+	return o.History
+}
+
 func (o *Options) getUnmount() chan struct{} {
 	if o == nil {
 		return nil
@@ -82,19 +152,40 @@ func (o *Options) validate(c *client.APIClient) error {
 	if o == nil {
 		return nil
 	}
+	if o.History {
+		if o.Write {
+			return errors.Errorf("history mounts are read only")
+		}
+		for repo, opts := range o.RepoOptions {
+			if opts.Write {
+				return errors.Errorf("can't mount %s in Write mode because history mounts are read only", repo)
+			}
+		}
+	}
+	if o.Flush < FlushOnUnmount || o.Flush > FlushPeriodic {
+		return errors.Errorf("invalid flush mode: %d", o.Flush)
+	}
 	for repo, opts := range o.RepoOptions {
 		if opts.Write {
-			if uuid.IsUUIDWithoutDashes(opts.Branch) {
-				return errors.Errorf("can't mount commit %s@%s in Write mode (mount a branch instead)", repo, opts.Branch)
-			}
-			bi, err := c.InspectBranch(repo, opts.Branch)
-			if err != nil && !errutil.IsNotFoundError(err) {
+			if err := validateWrite(c, repo, opts.Branch); err != nil {
 				return err
 			}
-			if bi != nil && len(bi.Provenance) > 0 {
-				return errors.Errorf("can't mount branch %s@%s in Write mode because it's an output branch", repo, opts.Branch)
-			}
 		}
 	}
 	return nil
 }
+
+// validateWrite checks that branch of repo can be mounted for writing.
+func validateWrite(c *client.APIClient, repo, branch string) error {
+	if uuid.IsUUIDWithoutDashes(branch) {
+		return errors.Errorf("can't mount commit %s@%s in Write mode (mount a branch instead)", repo, branch)
+	}
+	bi, err := c.InspectBranch(repo, branch)
+	if err != nil && !errutil.IsNotFoundError(err) {
+		return err
+	}
+	if bi != nil && len(bi.Provenance) > 0 {
+		return errors.Errorf("can't mount branch %s@%s in Write mode because it's an output branch", repo, branch)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"io"
 	"os"
+	"strings"
 
 	units "github.com/docker/go-units"
 	"github.com/fatih/color"
@@ -95,7 +96,11 @@ func PrintDetailedBranchInfo(branchInfo *pfs.BranchInfo) error {
 	template, err := template.New("BranchInfo").Funcs(funcMap).Parse(
 		`Name: {{.Branch.Repo.Name}}@{{.Branch.Name}}{{if .Head}}
 Head Commit: {{ .Head.Repo.Name}}@{{.Head.ID}} {{end}}{{if .Provenance}}
-Provenance: {{range .Provenance}} {{.Repo.Name}}@{{.Name}} {{end}} {{end}}
+Provenance: {{range .Provenance}} {{.Repo.Name}}@{{.Name}} {{end}} {{end}}{{with .Schema}}
+Schema:{{if .JsonSchema}}
+  JSON Schema: {{.JsonSchema}}{{end}}{{if .CsvColumns}}
+  CSV Columns: {{range .CsvColumns}} {{.Name}}:{{columnType .}} {{end}}{{end}}
+  Invalid Records: {{.OnInvalid}}{{if .QuarantinePath}} ({{.QuarantinePath}}){{end}}{{end}}
 `)
 	if err != nil {
 		return err
@@ -169,7 +174,9 @@ Started: {{prettyAgo .Started}}{{end}}{{if .Finished}}{{if .FullTimestamps}}
 Finished: {{.Finished}}{{else}}
 Finished: {{prettyAgo .Finished}}{{end}}{{end}}
 Size: {{prettySize .SizeBytes}}{{if .Provenance}}
-Provenance: {{range .Provenance}} {{.Commit.Repo.Name}}@{{.Commit.ID}} ({{.Branch.Name}}) {{end}} {{end}}
+Provenance: {{range .Provenance}} {{.Commit.Repo.Name}}@{{.Commit.ID}} ({{.Branch.Name}}) {{end}} {{end}}{{with .Validation}}
+Validation: {{.ValidRecords}} valid, {{.RejectedRecords}} rejected, {{.QuarantinedRecords}} quarantined records{{range .Errors}}
+  {{.}}{{end}}{{end}}
 `)
 	if err != nil {
 		return err
@@ -231,6 +238,16 @@ Children: {{range .Children}} {{.}} {{end}}
 	return template.Execute(os.Stdout, fileInfo)
 }
 
+// columnType renders a schema column's type, with a trailing "?" if it's
+// nullable, which is the syntax used by pachctl set schema.
+func columnType(column *pfs.SchemaColumn) string {
+	result := strings.ToLower(column.Type.String())
+	if column.Nullable {
+		result += "?"
+	}
+	return result
+}
+
 func fileType(fileType pfs.FileType) string {
 	if fileType == pfs.FileType_FILE {
 		return "file"
@@ -241,6 +258,7 @@ func fileType(fileType pfs.FileType) string {
 var funcMap = template.FuncMap{
 	"prettyAgo":  pretty.Ago,
 	"prettySize": pretty.Size,
+	"columnType": columnType,
 	"fileType":   fileType,
 }
 
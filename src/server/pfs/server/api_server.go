@@ -329,6 +329,19 @@ func (a *apiServer) ListBranch(ctx context.Context, request *pfs.ListBranchReque
 	return &pfs.BranchInfos{BranchInfo: branches}, nil
 }
 
+// SetBranchSchema implements the protobuf pfs.SetBranchSchema RPC
+func (a *apiServer) SetBranchSchema(ctx context.Context, request *pfs.SetBranchSchemaRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	if err := a.txnEnv.WithWriteContext(ctx, func(txnCtx *txnenv.TransactionContext) error {
+		return a.driver.setBranchSchema(txnCtx, request.Branch, request.Schema)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
 // DeleteBranchInTransaction is identical to DeleteBranch except that it can run
 // inside an existing etcd STM transaction.  This is not an RPC.
 func (a *apiServer) DeleteBranchInTransaction(
@@ -29,10 +29,12 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
+	"github.com/pachyderm/pachyderm/src/server/pkg/avro"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/parquet"
 	"github.com/pachyderm/pachyderm/src/server/pkg/pfsdb"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
 	"github.com/pachyderm/pachyderm/src/server/pkg/serviceenv"
@@ -1296,6 +1298,12 @@ func (d *driver) makeCommit(
 				return nil, err
 			}
 			defer destroyHashtree(tree)
+			for _, record := range records {
+				mergeValidation(&newCommitInfo.Validation, record.Validation)
+			}
+			if err := rejectedRecordsErr(newCommit, newCommitInfo.Validation); err != nil {
+				return nil, err
+			}
 			for i, record := range records {
 				if err := d.applyWrite(recordFiles[i], record, tree); err != nil {
 					return nil, err
@@ -1514,7 +1522,14 @@ func (d *driver) finishCommit(txnCtx *txnenv.TransactionContext, commit *pfs.Com
 		}()
 
 		if tree == nil {
-			var err error
+			validation, err := d.openCommitValidation(txnCtx.Client, commit)
+			if err != nil {
+				return err
+			}
+			if err := rejectedRecordsErr(commit, validation); err != nil {
+				return err
+			}
+			commitInfo.Validation = validation
 			finishedTree, err = d.getTreeForOpenCommit(txnCtx.Client, &pfs.File{Commit: commit}, parentTree)
 			if err != nil {
 				return err
@@ -2928,7 +2943,7 @@ func (d *driver) putFiles(pachClient *client.APIClient, s *putFileServer) error
 	var putFileRecords []*pfs.PutFileRecords
 	var mu sync.Mutex
 	oneOff, repo, branch, err := d.forEachPutFile(pachClient, s, func(req *pfs.PutFileRequest, r io.Reader) error {
-		records, err := d.putFile(pachClient, req.File, req.Delimiter, req.TargetFileDatums,
+		records, quarantine, err := d.putFile(pachClient, req.File, req.Delimiter, req.TargetFileDatums,
 			req.TargetFileBytes, req.HeaderRecords, req.OverwriteIndex, req.Delete, r)
 		if err != nil {
 			return err
@@ -2938,6 +2953,11 @@ func (d *driver) putFiles(pachClient *client.APIClient, s *putFileServer) error
 		files = append(files, req.File)
 		putFilePaths = append(putFilePaths, req.File.Path)
 		putFileRecords = append(putFileRecords, records)
+		if quarantine != nil {
+			files = append(files, quarantine.file)
+			putFilePaths = append(putFilePaths, quarantine.file.Path)
+			putFileRecords = append(putFileRecords, quarantine.records)
+		}
 		return nil
 	})
 	if err != nil {
@@ -2962,30 +2982,40 @@ func (d *driver) putFiles(pachClient *client.APIClient, s *putFileServer) error
 	return nil
 }
 
+// quarantinedRecords are the records which putFile appends to a schema's
+// quarantine path.
+type quarantinedRecords struct {
+	file    *pfs.File
+	records *pfs.PutFileRecords
+}
+
 func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter pfs.Delimiter,
 	targetFileDatums, targetFileBytes, headerRecords int64, overwriteIndex *pfs.OverwriteIndex,
-	del bool, reader io.Reader) (*pfs.PutFileRecords, error) {
+	del bool, reader io.Reader) (_ *pfs.PutFileRecords, _ *quarantinedRecords, retErr error) {
 	if err := d.checkIsAuthorized(pachClient, file.Commit.Repo, auth.Scope_WRITER); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	//  validation -- make sure the various putFileSplit options are coherent
 	hasPutFileOptions := targetFileBytes != 0 || targetFileDatums != 0 || headerRecords != 0
 	if hasPutFileOptions && delimiter == pfs.Delimiter_NONE {
-		return nil, errors.Errorf("cannot set split options--targetFileBytes, targetFileDatums, or headerRecords--with delimiter == NONE, split disabled")
+		return nil, nil, errors.Errorf("cannot set split options--targetFileBytes, targetFileDatums, or headerRecords--with delimiter == NONE, split disabled")
+	}
+	if headerRecords != 0 && (delimiter == pfs.Delimiter_PARQUET || delimiter == pfs.Delimiter_AVRO) {
+		return nil, nil, errors.Errorf("cannot set headerRecords with delimiter == %s, the schema is copied into every split file", delimiter)
 	}
 	records := &pfs.PutFileRecords{}
 	if del {
 		records.Tombstone = true
-		return records, nil
+		return records, nil, nil
 	}
 	if overwriteIndex != nil && overwriteIndex.Index == 0 {
 		records.Tombstone = true
 	}
 	if err := checkFilePath(file.Path); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if err := hashtree.ValidatePath(file.Path); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if delimiter == pfs.Delimiter_NONE {
@@ -2993,7 +3023,7 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 		defer d.putObjectLimiter.Release()
 		objects, size, err := pachClient.PutObjectSplit(reader)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Here we use the invariant that every one but the last object
@@ -3018,6 +3048,16 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 			records.Records = append(records.Records, record)
 		}
 	} else {
+		var validator *recordValidator
+		if delimiter == pfs.Delimiter_JSON || delimiter == pfs.Delimiter_LINE || delimiter == pfs.Delimiter_CSV {
+			schema, err := d.branchSchema(pachClient, file.Commit)
+			if err != nil {
+				return nil, nil, err
+			}
+			if validator, err = newRecordValidator(file, schema, delimiter); err != nil {
+				return nil, nil, err
+			}
+		}
 		var (
 			buffer        = &bytes.Buffer{}
 			datumsWritten int64
@@ -3033,9 +3073,13 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 			bufioR    = bufio.NewReader(reader)
 			decoder   = json.NewDecoder(bufioR)
 			sqlReader = sql.NewPGDumpReader(bufioR)
-			csvReader = csv.NewReader(bufioR)
-			csvBuffer bytes.Buffer
-			csvWriter = csv.NewWriter(&csvBuffer)
+			// parquet and avro files are split by row group and block
+			// respectively, and the schema is copied into every split file.
+			parquetReader = parquet.NewReader(bufioR)
+			avroReader    = avro.NewReader(bufioR)
+			csvReader     = csv.NewReader(bufioR)
+			csvBuffer     bytes.Buffer
+			csvWriter     = csv.NewWriter(&csvBuffer)
 			// indexToRecord serves as a de-facto slice of PutFileRecords. We can't
 			// use a real slice of PutFileRecords b/c indexToRecord has data appended
 			// to it by concurrent processes, and you can't append() to a slice
@@ -3044,6 +3088,11 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 			indexToRecord = make(map[int]*pfs.PutFileRecord)
 			mu            sync.Mutex
 		)
+		defer func() {
+			if err := parquetReader.Close(); err != nil && retErr == nil {
+				retErr = err
+			}
+		}()
 		csvReader.FieldsPerRecord = -1 // ignore unexpected # of fields, for now
 		csvReader.ReuseRecord = true   // returned rows are written to buffer immediately
 		for !EOF {
@@ -3073,26 +3122,48 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 				csvBuffer.Reset()
 				if csvRow, err = csvReader.Read(); err == nil {
 					if err := csvWriter.Write(csvRow); err != nil {
-						return nil, errors.Wrapf(err, "error parsing csv record")
+						return nil, nil, errors.Wrapf(err, "error parsing csv record")
 					}
 					if csvWriter.Flush(); csvWriter.Error() != nil {
-						return nil, errors.Wrapf(csvWriter.Error(), "error copying csv record")
+						return nil, nil, errors.Wrapf(csvWriter.Error(), "error copying csv record")
 					}
 					value = csvBuffer.Bytes()
 				}
+			case pfs.Delimiter_PARQUET:
+				value, err = parquetReader.ReadRowGroup()
+				if header == nil {
+					header = parquet.Magic
+				}
+			case pfs.Delimiter_AVRO:
+				value, err = avroReader.ReadBlock()
+				if header == nil {
+					header = avroReader.Header
+				}
 			default:
-				return nil, errors.Errorf("unrecognized delimiter %s", delimiter.String())
+				return nil, nil, errors.Errorf("unrecognized delimiter %s", delimiter.String())
 			}
 			if err != nil {
 				if errors.Is(err, io.EOF) {
 					EOF = true
 				} else {
-					return nil, err
+					return nil, nil, err
 				}
 			}
-			buffer.Write(value)
-			bytesWritten += int64(len(value))
-			datumsWritten++
+			// Header records aren't validated, invalid records are left out
+			// of the file.
+			valid := true
+			if validator != nil && len(value) > 0 && (headerRecords == 0 || header != nil) {
+				if delimiter == pfs.Delimiter_CSV {
+					valid = validator.validateCSV(csvRow, value) == nil
+				} else {
+					valid = validator.validateJSON(value) == nil
+				}
+			}
+			if valid {
+				buffer.Write(value)
+				bytesWritten += int64(len(value))
+				datumsWritten++
+			}
 			var (
 				headerDone         = headerRecords == 0 || header != nil
 				headerReady        = !headerDone && datumsWritten >= headerRecords
@@ -3106,6 +3177,11 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 				if !headerDone /* implies headerReady || EOF */ {
 					header = _buffer.Bytes() // record header
 				} else {
+					if delimiter == pfs.Delimiter_PARQUET {
+						// Every parquet file needs its own footer, since it
+						// contains the offsets of the row groups.
+						_buffer.Write(parquetReader.Footer())
+					}
 					// put contents
 					_bufferLen := int64(_buffer.Len())
 					index := filesPut
@@ -3134,7 +3210,7 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 			}
 		}
 		if err := eg.Wait(); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		records.Split = true
@@ -3168,10 +3244,30 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 			setHeaderFooter(footer, &records.Footer)
 		}
 		if err := eg.Wait(); err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if validator != nil {
+			records.Validation = validator.result
+			if validator.quarantine.Len() > 0 {
+				d.putObjectLimiter.Acquire()
+				defer d.putObjectLimiter.Release()
+				object, size, err := pachClient.PutObject(&validator.quarantine)
+				if err != nil {
+					return nil, nil, err
+				}
+				return records, &quarantinedRecords{
+					file: validator.quarantineFile(),
+					records: &pfs.PutFileRecords{
+						Records: []*pfs.PutFileRecord{{
+							SizeBytes:  size,
+							ObjectHash: object.Hash,
+						}},
+					},
+				}, nil
+			}
 		}
 	}
-	return records, nil
+	return records, nil, nil
 }
 
 func appendRecords(pfr *pfs.PutFileRecords, node *hashtree.NodeProto) {
@@ -3553,6 +3649,21 @@ func (d *driver) getTreeForOpenCommit(pachClient *client.APIClient, file *pfs.Fi
 	return tree, nil
 }
 
+// openCommitValidation merges the results of validating the records put into
+// an open commit.
+func (d *driver) openCommitValidation(pachClient *client.APIClient, commit *pfs.Commit) (*pfs.ValidationResult, error) {
+	var result *pfs.ValidationResult
+	recordsCol := d.putFileRecords.ReadOnly(pachClient.Ctx())
+	putFileRecords := &pfs.PutFileRecords{}
+	if err := recordsCol.ListPrefix(d.scratchCommitPrefix(commit), putFileRecords, col.DefaultOptions, func(string) error {
+		mergeValidation(&result, putFileRecords.Validation)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // this is a helper function to check if the given provenance has provenance on an input branch
 func provenantOnInput(provenance []*pfs.CommitProvenance) bool {
 	provenanceCount := len(provenance)
@@ -4288,7 +4399,9 @@ func (d *driver) upsertPutFileRecords(
 			if newRecords.Tombstone {
 				existingRecords.Tombstone = true
 				existingRecords.Records = nil
+				existingRecords.Validation = nil
 			}
+			mergeValidation(&existingRecords.Validation, newRecords.Validation)
 			existingRecords.Split = newRecords.Split
 			existingRecords.Records = append(existingRecords.Records, newRecords.Records...)
 			existingRecords.Header = newRecords.Header
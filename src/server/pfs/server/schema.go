@@ -0,0 +1,250 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
+	txnenv "github.com/pachyderm/pachyderm/src/server/pkg/transactionenv"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+// maxValidationErrors is the number of invalid records which are described in
+// a ValidationResult.
+const maxValidationErrors = 10
+
+func (d *driver) setBranchSchema(txnCtx *txnenv.TransactionContext, branch *pfs.Branch, schema *pfs.Schema) error {
+	if branch == nil {
+		return errors.New("branch cannot be nil")
+	}
+	if branch.Repo == nil {
+		return errors.New("branch repo cannot be nil")
+	}
+	if err := d.checkIsAuthorizedInTransaction(txnCtx, branch.Repo, auth.Scope_WRITER); err != nil {
+		return err
+	}
+	if err := ancestry.ValidateName(branch.Name); err != nil {
+		return err
+	}
+	if schema != nil {
+		if schema.JsonSchema != "" {
+			if _, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema.JsonSchema)); err != nil {
+				return errors.Wrapf(err, "invalid JSON schema")
+			}
+		}
+		if schema.OnInvalid == pfs.InvalidRecordAction_QUARANTINE {
+			if schema.QuarantinePath == "" {
+				return errors.Errorf("quarantine path must be set to quarantine invalid records")
+			}
+			if err := checkFilePath(schema.QuarantinePath); err != nil {
+				return err
+			}
+		}
+	}
+	branchInfo := &pfs.BranchInfo{}
+	return d.branches(branch.Repo.Name).ReadWrite(txnCtx.Stm).Update(branch.Name, branchInfo, func() error {
+		branchInfo.Schema = schema
+		return nil
+	})
+}
+
+// branchSchema returns the schema of the branch that commit is on, or nil if
+// it doesn't have one.
+func (d *driver) branchSchema(pachClient *client.APIClient, commit *pfs.Commit) (*pfs.Schema, error) {
+	branch := client.NewBranch(commit.Repo.Name, commit.ID)
+	if uuid.IsUUIDWithoutDashes(commit.ID) {
+		commitInfo, err := d.inspectCommit(pachClient, commit, pfs.CommitState_STARTED)
+		if err != nil {
+			return nil, err
+		}
+		if commitInfo.Branch == nil {
+			return nil, nil
+		}
+		branch = commitInfo.Branch
+	}
+	var branchInfo *pfs.BranchInfo
+	if err := d.txnEnv.WithReadContext(pachClient.Ctx(), func(txnCtx *txnenv.TransactionContext) error {
+		var err error
+		branchInfo, err = d.inspectBranch(txnCtx, branch)
+		return err
+	}); err != nil {
+		if isNotFoundErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return branchInfo.Schema, nil
+}
+
+// recordValidator validates the records of a file being split against a
+// schema.
+type recordValidator struct {
+	file       *pfs.File
+	schema     *pfs.Schema
+	jsonSchema *gojsonschema.Schema
+	result     *pfs.ValidationResult
+	// quarantine holds the invalid records when the schema's on_invalid is
+	// QUARANTINE.
+	quarantine bytes.Buffer
+}
+
+// newRecordValidator returns a recordValidator for the records of file split
+// with delimiter, or nil if the schema doesn't apply to them.
+func newRecordValidator(file *pfs.File, schema *pfs.Schema, delimiter pfs.Delimiter) (*recordValidator, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	v := &recordValidator{
+		file:   file,
+		schema: schema,
+		result: &pfs.ValidationResult{},
+	}
+	switch delimiter {
+	case pfs.Delimiter_JSON, pfs.Delimiter_LINE:
+		if schema.JsonSchema == "" {
+			return nil, nil
+		}
+		jsonSchema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema.JsonSchema))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid JSON schema")
+		}
+		v.jsonSchema = jsonSchema
+	case pfs.Delimiter_CSV:
+		if len(schema.CsvColumns) == 0 {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	return v, nil
+}
+
+// validateJSON validates a JSON record, or a LINE record which should contain
+// a JSON document.
+func (v *recordValidator) validateJSON(record []byte) error {
+	if len(bytes.TrimSpace(record)) == 0 {
+		return nil
+	}
+	var err error
+	if !json.Valid(record) {
+		err = errors.Errorf("invalid JSON")
+	} else {
+		var result *gojsonschema.Result
+		result, err = v.jsonSchema.Validate(gojsonschema.NewBytesLoader(record))
+		if err == nil && !result.Valid() {
+			var descriptions []string
+			for _, e := range result.Errors() {
+				descriptions = append(descriptions, e.String())
+			}
+			err = errors.New(strings.Join(descriptions, ", "))
+		}
+	}
+	return v.record(record, err)
+}
+
+// validateCSV validates the fields of a CSV record, record is the encoded
+// row.
+func (v *recordValidator) validateCSV(row []string, record []byte) error {
+	return v.record(record, v.checkCSV(row))
+}
+
+func (v *recordValidator) checkCSV(row []string) error {
+	if len(row) != len(v.schema.CsvColumns) {
+		return errors.Errorf("expected %d columns, got %d", len(v.schema.CsvColumns), len(row))
+	}
+	for i, column := range v.schema.CsvColumns {
+		field := row[i]
+		if field == "" {
+			if column.Nullable || column.Type == pfs.ColumnType_STRING {
+				continue
+			}
+			return errors.Errorf("column %q can't be empty", column.Name)
+		}
+		var err error
+		switch column.Type {
+		case pfs.ColumnType_INT:
+			_, err = strconv.ParseInt(field, 10, 64)
+		case pfs.ColumnType_FLOAT:
+			_, err = strconv.ParseFloat(field, 64)
+		case pfs.ColumnType_BOOL:
+			_, err = strconv.ParseBool(field)
+		case pfs.ColumnType_TIMESTAMP:
+			_, err = time.Parse(time.RFC3339, field)
+		}
+		if err != nil {
+			return errors.Errorf("column %q: %q isn't a valid %s", column.Name, field, strings.ToLower(column.Type.String()))
+		}
+	}
+	return nil
+}
+
+// record records the result of validating a record. It returns a non-nil
+// error if the record is invalid and shouldn't be written to the file.
+func (v *recordValidator) record(record []byte, err error) error {
+	if err == nil {
+		v.result.ValidRecords++
+		return nil
+	}
+	n := v.result.ValidRecords + v.result.RejectedRecords + v.result.QuarantinedRecords + 1
+	if len(v.result.Errors) < maxValidationErrors {
+		v.result.Errors = append(v.result.Errors, fmt.Sprintf("%s: record %d: %v", v.file.Path, n, err))
+	}
+	if v.schema.OnInvalid != pfs.InvalidRecordAction_QUARANTINE {
+		v.result.RejectedRecords++
+	} else {
+		v.result.QuarantinedRecords++
+		v.quarantine.Write(record)
+		if len(record) > 0 && record[len(record)-1] != '\n' {
+			v.quarantine.WriteByte('\n')
+		}
+	}
+	return err
+}
+
+// quarantineFile returns the file that quarantined records are appended to.
+func (v *recordValidator) quarantineFile() *pfs.File {
+	return &pfs.File{
+		Commit: v.file.Commit,
+		Path:   path.Join(v.schema.QuarantinePath, v.file.Path),
+	}
+}
+
+// mergeValidation adds the counts and errors of src to dst.
+func mergeValidation(dst **pfs.ValidationResult, src *pfs.ValidationResult) {
+	if src == nil {
+		return
+	}
+	if *dst == nil {
+		*dst = &pfs.ValidationResult{}
+	}
+	(*dst).ValidRecords += src.ValidRecords
+	(*dst).RejectedRecords += src.RejectedRecords
+	(*dst).QuarantinedRecords += src.QuarantinedRecords
+	for _, e := range src.Errors {
+		if len((*dst).Errors) >= maxValidationErrors {
+			break
+		}
+		(*dst).Errors = append((*dst).Errors, e)
+	}
+}
+
+// rejectedRecordsErr returns an error if records were rejected from commit,
+// which prevents it from being finished.
+func rejectedRecordsErr(commit *pfs.Commit, result *pfs.ValidationResult) error {
+	if result == nil || result.RejectedRecords == 0 {
+		return nil
+	}
+	return errors.Errorf("commit %s has %d records which don't match the schema of its branch, overwrite or delete the files containing them: %s",
+		commit.ID, result.RejectedRecords, strings.Join(result.Errors, "; "))
+}
@@ -3364,6 +3364,143 @@ func TestPutFileSplitSQL(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPutFileSplitAvro(t *testing.T) {
+	t.Parallel()
+	err := testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		repo := tu.UniqueString("TestPutFileSplitAvro")
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+
+		// An object container file with a string schema and a block for each of
+		// "foo", "bar" and "baz". Every length is small enough to be encoded as a
+		// single byte, twice the length.
+		sync := "0123456789abcdef"
+		header := "Obj\x01" + "\x02" + "\x16avro.schema" + "\x10\"string\"" + "\x00" + sync
+		block := func(record string) string {
+			return "\x02" + "\x08" + "\x06" + record + sync
+		}
+		_, err := env.PachClient.PutFileSplit(repo, "master", "data", pfs.Delimiter_AVRO, 2, 0, 0, false,
+			strings.NewReader(header+block("foo")+block("bar")+block("baz")))
+		require.NoError(t, err)
+		fileInfos, err := env.PachClient.ListFile(repo, "master", "/data")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(fileInfos))
+
+		// Each file is a standalone object container file.
+		var contents bytes.Buffer
+		require.NoError(t, env.PachClient.GetFile(repo, "master", "/data/0000000000000000", 0, 0, &contents))
+		require.Equal(t, header+block("foo")+block("bar"), contents.String())
+		contents.Reset()
+		require.NoError(t, env.PachClient.GetFile(repo, "master", "/data/0000000000000001", 0, 0, &contents))
+		require.Equal(t, header+block("baz"), contents.String())
+
+		// Header records don't make sense for avro.
+		_, err = env.PachClient.PutFileSplit(repo, "master", "data", pfs.Delimiter_AVRO, 0, 0, 1, false,
+			strings.NewReader(header+block("foo")))
+		require.YesError(t, err)
+		_, err = env.PachClient.PutFileSplit(repo, "master", "bad", pfs.Delimiter_AVRO, 0, 0, 0, false,
+			strings.NewReader("not avro"))
+		require.YesError(t, err)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestPutFileSchemaQuarantine(t *testing.T) {
+	t.Parallel()
+	err := testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		repo := tu.UniqueString("TestPutFileSchemaQuarantine")
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		require.NoError(t, env.PachClient.CreateBranch(repo, "master", "", nil))
+		require.NoError(t, env.PachClient.SetBranchSchema(repo, "master", &pfs.Schema{
+			CsvColumns: []*pfs.SchemaColumn{
+				{Name: "id", Type: pfs.ColumnType_INT},
+				{Name: "score", Type: pfs.ColumnType_FLOAT, Nullable: true},
+			},
+			OnInvalid:      pfs.InvalidRecordAction_QUARANTINE,
+			QuarantinePath: "quarantine",
+		}))
+		branchInfo, err := env.PachClient.InspectBranch(repo, "master")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(branchInfo.Schema.CsvColumns))
+
+		_, err = env.PachClient.PutFileSplit(repo, "master", "data", pfs.Delimiter_CSV, 0, 0, 0, false,
+			strings.NewReader("1,0.5\nfoo,1\n2,\n3,4,5\n"))
+		require.NoError(t, err)
+		fileInfos, err := env.PachClient.ListFile(repo, "master", "/data")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(fileInfos))
+		var contents bytes.Buffer
+		require.NoError(t, env.PachClient.GetFile(repo, "master", "/quarantine/data", 0, 0, &contents))
+		require.Equal(t, "foo,1\n3,4,5\n", contents.String())
+
+		commitInfo, err := env.PachClient.InspectCommit(repo, "master")
+		require.NoError(t, err)
+		require.Equal(t, int64(2), commitInfo.Validation.ValidRecords)
+		require.Equal(t, int64(2), commitInfo.Validation.QuarantinedRecords)
+		require.Equal(t, 2, len(commitInfo.Validation.Errors))
+
+		// Once the schema is removed nothing is validated.
+		require.NoError(t, env.PachClient.SetBranchSchema(repo, "master", nil))
+		_, err = env.PachClient.PutFileSplit(repo, "master", "data", pfs.Delimiter_CSV, 0, 0, 0, false,
+			strings.NewReader("foo,1\n"))
+		require.NoError(t, err)
+		commitInfo, err = env.PachClient.InspectCommit(repo, "master")
+		require.NoError(t, err)
+		require.Nil(t, commitInfo.Validation)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestPutFileSchemaReject(t *testing.T) {
+	t.Parallel()
+	err := testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		repo := tu.UniqueString("TestPutFileSchemaReject")
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		require.NoError(t, env.PachClient.CreateBranch(repo, "master", "", nil))
+		require.NoError(t, env.PachClient.SetBranchSchema(repo, "master", &pfs.Schema{
+			JsonSchema: `{"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}}`,
+		}))
+		// Quarantining requires a quarantine path
+		require.YesError(t, env.PachClient.SetBranchSchema(repo, "master", &pfs.Schema{
+			JsonSchema: `{"type": "object"}`,
+			OnInvalid:  pfs.InvalidRecordAction_QUARANTINE,
+		}))
+		require.YesError(t, env.PachClient.SetBranchSchema(repo, "master", &pfs.Schema{
+			JsonSchema: `not a schema`,
+		}))
+
+		// An invalid record prevents the commit from being finished until the
+		// file containing it is overwritten.
+		commit, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		_, err = env.PachClient.PutFileSplit(repo, commit.ID, "data", pfs.Delimiter_JSON, 0, 0, 0, false,
+			strings.NewReader(`{"id": 1}{"id": "foo"}`))
+		require.NoError(t, err)
+		require.YesError(t, env.PachClient.FinishCommit(repo, commit.ID))
+		_, err = env.PachClient.PutFileSplit(repo, commit.ID, "data", pfs.Delimiter_JSON, 0, 0, 0, true,
+			strings.NewReader(`{"id": 1}{"id": 2}`))
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.FinishCommit(repo, commit.ID))
+		fileInfos, err := env.PachClient.ListFile(repo, "master", "/data")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(fileInfos))
+		commitInfo, err := env.PachClient.InspectCommit(repo, commit.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), commitInfo.Validation.ValidRecords)
+		require.Equal(t, int64(0), commitInfo.Validation.RejectedRecords)
+
+		// A put without an open commit fails outright.
+		_, err = env.PachClient.PutFileSplit(repo, "master", "other", pfs.Delimiter_LINE, 0, 0, 0, false,
+			strings.NewReader("{\"id\": 1}\nfoo\n"))
+		require.YesError(t, err)
+		_, err = env.PachClient.InspectFile(repo, "master", "/other")
+		require.YesError(t, err)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 func TestPutFileHeaderRecordsBasic(t *testing.T) {
 	t.Parallel()
 	err := testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
@@ -0,0 +1,145 @@
+package avro
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+const syncSize = 16
+
+var magic = []byte{'O', 'b', 'j', 1}
+
+// Reader splits an avro object container file into its header and blocks.
+// The records in a block aren't decoded, a block is returned as it appears in
+// the file, so the header followed by any subset of the blocks is a valid
+// object container file with the same schema and codec.
+type Reader struct {
+	// Header is populated by the first call to ReadBlock.
+	Header []byte
+	rd     *bufio.Reader
+	buf    bytes.Buffer
+	sync   []byte
+}
+
+// NewReader creates a new Reader
+func NewReader(r *bufio.Reader) *Reader {
+	return &Reader{
+		rd: r,
+	}
+}
+
+// ReadBlock returns the next block in the file, including its trailing sync
+// marker. It returns EOF when there are no more blocks.
+func (r *Reader) ReadBlock() ([]byte, error) {
+	if r.Header == nil {
+		if err := r.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+	r.buf.Reset()
+	if _, err := r.rd.Peek(1); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, errors.Wrapf(err, "error reading avro block")
+	}
+	if _, err := r.readLong(); err != nil {
+		return nil, errors.Wrapf(err, "error reading avro block count")
+	}
+	size, err := r.readLong()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading avro block size")
+	}
+	if size < 0 {
+		return nil, errors.Errorf("invalid avro block size %d", size)
+	}
+	if err := r.copy(size); err != nil {
+		return nil, errors.Wrapf(err, "error reading avro block")
+	}
+	if err := r.copy(syncSize); err != nil {
+		return nil, errors.Wrapf(err, "error reading avro sync marker")
+	}
+	block := r.buf.Bytes()
+	if !bytes.Equal(block[len(block)-syncSize:], r.sync) {
+		return nil, errors.Errorf("invalid avro block - sync marker doesn't match header")
+	}
+	return append([]byte(nil), block...), nil
+}
+
+// readHeader reads the magic bytes, the file metadata map and the sync
+// marker.
+func (r *Reader) readHeader() error {
+	r.buf.Reset()
+	if err := r.copy(int64(len(magic))); err != nil {
+		return errors.Wrapf(err, "error reading avro header")
+	}
+	if !bytes.Equal(r.buf.Bytes(), magic) {
+		return errors.Errorf("invalid avro header - not an object container file")
+	}
+	// The metadata is a map of strings to bytes, encoded as a series of
+	// blocks of key value pairs terminated by an empty block.
+	for {
+		count, err := r.readLong()
+		if err != nil {
+			return errors.Wrapf(err, "error reading avro metadata")
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			// A negative count is followed by the size of the block.
+			count = -count
+			if _, err := r.readLong(); err != nil {
+				return errors.Wrapf(err, "error reading avro metadata")
+			}
+		}
+		for i := int64(0); i < 2*count; i++ {
+			n, err := r.readLong()
+			if err != nil {
+				return errors.Wrapf(err, "error reading avro metadata")
+			}
+			if err := r.copy(n); err != nil {
+				return errors.Wrapf(err, "error reading avro metadata")
+			}
+		}
+	}
+	if err := r.copy(syncSize); err != nil {
+		return errors.Wrapf(err, "error reading avro sync marker")
+	}
+	r.Header = append([]byte(nil), r.buf.Bytes()...)
+	r.sync = r.Header[len(r.Header)-syncSize:]
+	return nil
+}
+
+// readLong reads a zig-zag encoded long, avro uses the same encoding as
+// encoding/binary.
+func (r *Reader) readLong() (int64, error) {
+	n, err := binary.ReadVarint(r.rd)
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	var buf [binary.MaxVarintLen64]byte
+	r.buf.Write(buf[:binary.PutVarint(buf[:], n)])
+	return n, nil
+}
+
+func (r *Reader) copy(n int64) error {
+	if n < 0 {
+		return errors.Errorf("invalid length %d", n)
+	}
+	if _, err := io.CopyN(&r.buf, r.rd, n); err != nil {
+		return unexpectedEOF(err)
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package avro
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+var sync = []byte("0123456789abcdef")
+
+func writeLong(buf *bytes.Buffer, n int64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutVarint(b[:], n)])
+}
+
+func writeBytes(buf *bytes.Buffer, data string) {
+	writeLong(buf, int64(len(data)))
+	buf.WriteString(data)
+}
+
+func header() []byte {
+	var buf bytes.Buffer
+	buf.Write(magic)
+	writeLong(&buf, 2)
+	writeBytes(&buf, "avro.schema")
+	writeBytes(&buf, `"string"`)
+	writeBytes(&buf, "avro.codec")
+	writeBytes(&buf, "null")
+	writeLong(&buf, 0)
+	buf.Write(sync)
+	return buf.Bytes()
+}
+
+func block(records ...string) []byte {
+	var data bytes.Buffer
+	for _, r := range records {
+		writeBytes(&data, r)
+	}
+	var buf bytes.Buffer
+	writeLong(&buf, int64(len(records)))
+	writeLong(&buf, int64(data.Len()))
+	buf.Write(data.Bytes())
+	buf.Write(sync)
+	return buf.Bytes()
+}
+
+func TestReadBlock(t *testing.T) {
+	blocks := [][]byte{block("foo", "bar"), block("baz")}
+	file := append(header(), bytes.Join(blocks, nil)...)
+	r := NewReader(bufio.NewReader(bytes.NewReader(file)))
+	for _, expected := range blocks {
+		b, err := r.ReadBlock()
+		require.NoError(t, err)
+		require.Equal(t, expected, b)
+	}
+	_, err := r.ReadBlock()
+	require.True(t, errors.Is(err, io.EOF))
+	require.Equal(t, header(), r.Header)
+}
+
+func TestReadBlockInvalid(t *testing.T) {
+	_, err := NewReader(bufio.NewReader(bytes.NewReader([]byte("not avro")))).ReadBlock()
+	require.YesError(t, err)
+	// Truncated block
+	file := append(header(), block("foo")...)
+	_, err = NewReader(bufio.NewReader(bytes.NewReader(file[:len(file)-1]))).ReadBlock()
+	require.YesError(t, err)
+	// Sync marker doesn't match the header
+	file = append(header(), block("foo")...)
+	file[len(file)-1] = 'x'
+	_, err = NewReader(bufio.NewReader(bytes.NewReader(file))).ReadBlock()
+	require.YesError(t, err)
+}
@@ -241,7 +241,14 @@ func (c *amazonClient) Writer(ctx context.Context, name string) (io.WriteCloser,
 	return newBackoffWriteCloser(ctx, c, newWriter(ctx, c, name)), nil
 }
 
-func (c *amazonClient) Walk(_ context.Context, name string, fn func(name string) error) error {
+func (c *amazonClient) Walk(ctx context.Context, name string, fn func(name string) error) error {
+	return c.WalkInfo(ctx, name, func(info *ObjectInfo) error {
+		return fn(info.Name)
+	})
+}
+
+// WalkInfo implements the InfoWalker interface
+func (c *amazonClient) WalkInfo(_ context.Context, name string, fn func(info *ObjectInfo) error) error {
 	var fnErr error
 	var prefix *string
 
@@ -263,7 +270,12 @@ func (c *amazonClient) Walk(_ context.Context, name string, fn func(name string)
 					key = reverse(key)
 				}
 				if strings.HasPrefix(key, name) {
-					if err := fn(key); err != nil {
+					if err := fn(&ObjectInfo{
+						Name:    key,
+						ETag:    strings.Trim(aws.StringValue(object.ETag), `"`),
+						ModTime: aws.TimeValue(object.LastModified),
+						Size:    aws.Int64Value(object.Size),
+					}); err != nil {
 						fnErr = err
 						return false
 					}
@@ -75,7 +75,14 @@ func (c *localClient) Delete(_ context.Context, path string) error {
 	return errors.EnsureStack(os.Remove(c.normPath(path)))
 }
 
-func (c *localClient) Walk(_ context.Context, dir string, walkFn func(name string) error) error {
+func (c *localClient) Walk(ctx context.Context, dir string, walkFn func(name string) error) error {
+	return c.WalkInfo(ctx, dir, func(info *ObjectInfo) error {
+		return walkFn(info.Name)
+	})
+}
+
+// WalkInfo implements the InfoWalker interface, local files don't have ETags.
+func (c *localClient) WalkInfo(_ context.Context, dir string, walkFn func(info *ObjectInfo) error) error {
 	dir = c.normPath(dir)
 	fi, _ := os.Stat(dir)
 	prefix := ""
@@ -96,7 +103,11 @@ func (c *localClient) Walk(_ context.Context, dir string, walkFn func(name strin
 		if !strings.HasPrefix(filepath.Base(relPath), prefix) {
 			return nil
 		}
-		return walkFn(relPath)
+		return walkFn(&ObjectInfo{
+			Name:    relPath,
+			ModTime: fileInfo.ModTime(),
+			Size:    fileInfo.Size(),
+		})
 	})
 	return errors.EnsureStack(err)
 }
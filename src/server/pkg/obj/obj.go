@@ -222,6 +222,34 @@ type Client interface {
 	IsIgnorable(err error) bool
 }
 
+// ObjectInfo describes an object found by WalkInfo.
+type ObjectInfo struct {
+	Name string
+	// ETag identifies the contents of the object, it's empty if the object
+	// store doesn't provide one.
+	ETag    string
+	ModTime time.Time
+	Size    int64
+}
+
+// InfoWalker is implemented by clients that can list the metadata of objects
+// along with their names.
+type InfoWalker interface {
+	// WalkInfo calls `fn` with the objects which can be found under `prefix`.
+	WalkInfo(ctx context.Context, prefix string, fn func(info *ObjectInfo) error) error
+}
+
+// WalkInfo calls fn with the objects under prefix. If c doesn't implement
+// InfoWalker, only the names of the objects are set.
+func WalkInfo(ctx context.Context, c Client, prefix string, fn func(info *ObjectInfo) error) error {
+	if w, ok := c.(InfoWalker); ok {
+		return w.WalkInfo(ctx, prefix, fn)
+	}
+	return c.Walk(ctx, prefix, func(name string) error {
+		return fn(&ObjectInfo{Name: name})
+	})
+}
+
 type checkedReadCloser struct {
 	io.ReadCloser
 	size  uint64
@@ -262,6 +290,11 @@ func newCheckedClient(c Client) Client {
 	return &checkedClient{Client: c}
 }
 
+// WalkInfo implements the InfoWalker interface
+func (wc *checkedClient) WalkInfo(ctx context.Context, prefix string, fn func(info *ObjectInfo) error) error {
+	return WalkInfo(ctx, wc.Client, prefix, fn)
+}
+
 func (wc *checkedClient) Reader(ctx context.Context, name string, offset uint64, size uint64) (io.ReadCloser, error) {
 	rc, err := wc.Client.Reader(ctx, name, offset, size)
 	if err != nil {
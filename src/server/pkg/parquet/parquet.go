@@ -0,0 +1,237 @@
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// Magic is the header of a parquet file, it's also the last 4 bytes of the
+// file.
+var Magic = []byte("PAR1")
+
+// field ids from parquet's thrift definitions
+const (
+	fileMetaDataNumRows                  = 3
+	fileMetaDataRowGroups                = 4
+	fileMetaDataEncryptionAlgorithm      = 8
+	fileMetaDataFooterSigningKeyMetadata = 9
+
+	rowGroupColumns             = 1
+	rowGroupNumRows             = 3
+	rowGroupFileOffset          = 5
+	rowGroupTotalCompressedSize = 6
+	rowGroupOrdinal             = 7
+
+	columnChunkFilePath                = 1
+	columnChunkFileOffset              = 2
+	columnChunkMetaData                = 3
+	columnChunkOffsetIndexOffset       = 4
+	columnChunkOffsetIndexLength       = 5
+	columnChunkColumnIndexOffset       = 6
+	columnChunkColumnIndexLength       = 7
+	columnChunkCryptoMetadata          = 8
+	columnChunkEncryptedColumnMetadata = 9
+
+	columnMetaDataTotalCompressedSize  = 7
+	columnMetaDataDataPageOffset       = 9
+	columnMetaDataIndexPageOffset      = 10
+	columnMetaDataDictionaryPageOffset = 11
+	columnMetaDataBloomFilterOffset    = 14
+	columnMetaDataBloomFilterLength    = 15
+)
+
+// Reader splits a parquet file into row groups. Parquet files can't be read
+// as a stream, since the metadata is at the end of the file, so the input is
+// spooled to a temporary file first. Reader returns each row group's column
+// chunks as they appear in the file, Magic followed by any sequence of row
+// groups and then the Footer for that sequence is a valid parquet file with
+// the same schema.
+type Reader struct {
+	rd       io.Reader
+	f        *os.File
+	meta     *tstruct
+	groups   []value
+	next     int
+	pos      int64
+	selected []value
+}
+
+// NewReader creates a new Reader
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		rd:  r,
+		pos: int64(len(Magic)),
+	}
+}
+
+// ReadRowGroup returns the column chunks of the next row group in the file.
+// It returns EOF when there are no more row groups.
+func (r *Reader) ReadRowGroup() ([]byte, error) {
+	if r.meta == nil {
+		if err := r.readMetadata(); err != nil {
+			return nil, err
+		}
+	}
+	if r.next >= len(r.groups) {
+		return nil, io.EOF
+	}
+	group := r.groups[r.next].(*tstruct).copy()
+	r.next++
+	var columns *list
+	if f := group.get(rowGroupColumns); f != nil {
+		columns, _ = f.val.(*list)
+	}
+	if columns == nil {
+		return nil, errors.Errorf("invalid parquet metadata - row group has no columns")
+	}
+	var buf bytes.Buffer
+	newColumns := &list{elemType: columns.elemType}
+	for _, c := range columns.elems {
+		chunk := c.(*tstruct).copy()
+		if chunk.get(columnChunkFilePath) != nil {
+			return nil, errors.Errorf("parquet files with columns in external files can't be split")
+		}
+		if chunk.get(columnChunkCryptoMetadata) != nil || chunk.get(columnChunkEncryptedColumnMetadata) != nil {
+			return nil, errors.Errorf("encrypted parquet files can't be split")
+		}
+		f := chunk.get(columnChunkMetaData)
+		if f == nil {
+			return nil, errors.Errorf("invalid parquet metadata - column chunk has no metadata")
+		}
+		meta := f.val.(*tstruct).copy()
+		f.val = meta
+		start, ok := meta.getInt(columnMetaDataDataPageOffset)
+		if !ok {
+			return nil, errors.Errorf("invalid parquet metadata - column chunk has no data page offset")
+		}
+		if offset, ok := meta.getInt(columnMetaDataDictionaryPageOffset); ok && offset > 0 && offset < start {
+			start = offset
+		}
+		size, ok := meta.getInt(columnMetaDataTotalCompressedSize)
+		if !ok {
+			return nil, errors.Errorf("invalid parquet metadata - column chunk has no size")
+		}
+		if n, err := io.Copy(&buf, io.NewSectionReader(r.f, start, size)); err != nil {
+			return nil, errors.WithStack(err)
+		} else if n != size {
+			return nil, errors.Errorf("invalid parquet metadata - column chunk extends past the end of the file")
+		}
+		// Move the offsets from the position of the chunk in the input to its
+		// position in the split.
+		delta := r.pos + int64(buf.Len()) - size - start
+		for _, id := range []int16{columnMetaDataDataPageOffset, columnMetaDataIndexPageOffset, columnMetaDataDictionaryPageOffset} {
+			if offset, ok := meta.getInt(id); ok && offset > 0 {
+				meta.setInt(id, typeI64, offset+delta)
+			}
+		}
+		if offset, ok := chunk.getInt(columnChunkFileOffset); ok && offset > 0 {
+			chunk.setInt(columnChunkFileOffset, typeI64, offset+delta)
+		}
+		// Page indexes and bloom filters are stored outside of the column
+		// chunks, so they aren't copied.
+		for _, id := range []int16{columnChunkOffsetIndexOffset, columnChunkOffsetIndexLength, columnChunkColumnIndexOffset, columnChunkColumnIndexLength} {
+			chunk.remove(id)
+		}
+		meta.remove(columnMetaDataBloomFilterOffset)
+		meta.remove(columnMetaDataBloomFilterLength)
+		newColumns.elems = append(newColumns.elems, chunk)
+	}
+	group.set(&field{id: rowGroupColumns, typ: typeList, val: newColumns})
+	if group.get(rowGroupFileOffset) != nil {
+		group.setInt(rowGroupFileOffset, typeI64, r.pos)
+	}
+	if group.get(rowGroupTotalCompressedSize) != nil {
+		group.setInt(rowGroupTotalCompressedSize, typeI64, int64(buf.Len()))
+	}
+	if group.get(rowGroupOrdinal) != nil {
+		group.setInt(rowGroupOrdinal, typeI16, int64(len(r.selected)))
+	}
+	r.selected = append(r.selected, group)
+	r.pos += int64(buf.Len())
+	return buf.Bytes(), nil
+}
+
+// Footer returns the footer for the row groups returned by ReadRowGroup since
+// the last call to Footer, which are assumed to follow Magic.
+func (r *Reader) Footer() []byte {
+	meta := r.meta.copy()
+	var numRows int64
+	for _, group := range r.selected {
+		n, _ := group.(*tstruct).getInt(rowGroupNumRows)
+		numRows += n
+	}
+	meta.setInt(fileMetaDataNumRows, typeI64, numRows)
+	meta.set(&field{id: fileMetaDataRowGroups, typ: typeList, val: &list{elemType: typeStruct, elems: r.selected}})
+	e := &encoder{}
+	e.writeStruct(meta)
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(e.buf)))
+	r.selected = nil
+	r.pos = int64(len(Magic))
+	return append(append(e.buf, size[:]...), Magic...)
+}
+
+// Close removes the temporary file the input was spooled to.
+func (r *Reader) Close() error {
+	if r.f == nil {
+		return nil
+	}
+	if err := r.f.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Remove(r.f.Name()))
+}
+
+func (r *Reader) readMetadata() error {
+	f, err := ioutil.TempFile("", "pachyderm-parquet")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	r.f = f
+	size, err := io.Copy(f, r.rd)
+	if err != nil {
+		return errors.Wrapf(err, "error reading parquet file")
+	}
+	trailerSize := int64(4 + len(Magic))
+	if size < int64(len(Magic))+trailerSize {
+		return errors.Errorf("invalid parquet file - too short")
+	}
+	header := make([]byte, len(Magic))
+	trailer := make([]byte, trailerSize)
+	if _, err := f.ReadAt(header, 0); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := f.ReadAt(trailer, size-trailerSize); err != nil {
+		return errors.WithStack(err)
+	}
+	if !bytes.Equal(header, Magic) || !bytes.Equal(trailer[4:], Magic) {
+		return errors.Errorf("invalid parquet file - missing magic bytes, encrypted footers aren't supported")
+	}
+	metaSize := int64(binary.LittleEndian.Uint32(trailer[:4]))
+	if metaSize > size-int64(len(Magic))-trailerSize {
+		return errors.Errorf("invalid parquet file - footer size %d is larger than the file", metaSize)
+	}
+	d := &decoder{r: bufio.NewReader(io.NewSectionReader(f, size-trailerSize-metaSize, metaSize))}
+	meta, err := d.readStruct()
+	if err != nil {
+		return errors.Wrapf(err, "error reading parquet metadata")
+	}
+	if meta.get(fileMetaDataEncryptionAlgorithm) != nil || meta.get(fileMetaDataFooterSigningKeyMetadata) != nil {
+		return errors.Errorf("encrypted parquet files can't be split")
+	}
+	if f := meta.get(fileMetaDataRowGroups); f != nil {
+		groups, ok := f.val.(*list)
+		if !ok {
+			return errors.Errorf("invalid parquet metadata - row groups aren't a list")
+		}
+		r.groups = groups.elems
+	}
+	r.meta = meta
+	return nil
+}
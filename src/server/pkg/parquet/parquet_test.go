@@ -0,0 +1,224 @@
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+// testFile builds a parquet file with a single column and a row group for
+// each of chunks. The chunks aren't valid pages, but splitting doesn't decode
+// them.
+func testFile(chunks ...string) []byte {
+	buf := append([]byte(nil), Magic...)
+	groups := &list{elemType: typeStruct}
+	for i, chunk := range chunks {
+		offset := int64(len(buf))
+		buf = append(buf, chunk...)
+		meta := &tstruct{fields: []*field{
+			{id: 1, typ: typeI32, val: int64(6)},
+			{id: 3, typ: typeList, val: &list{elemType: typeBinary, elems: []value{[]byte("col")}}},
+			{id: columnMetaDataTotalCompressedSize, typ: typeI64, val: int64(len(chunk))},
+			{id: columnMetaDataDataPageOffset, typ: typeI64, val: offset},
+		}}
+		groups.elems = append(groups.elems, &tstruct{fields: []*field{
+			{id: rowGroupColumns, typ: typeList, val: &list{elemType: typeStruct, elems: []value{
+				&tstruct{fields: []*field{
+					{id: columnChunkFileOffset, typ: typeI64, val: offset + int64(len(chunk))},
+					{id: columnChunkMetaData, typ: typeStruct, val: meta},
+				}},
+			}}},
+			{id: 2, typ: typeI64, val: int64(len(chunk))},
+			{id: rowGroupNumRows, typ: typeI64, val: int64(i + 1)},
+		}})
+	}
+	e := &encoder{}
+	e.writeStruct(&tstruct{fields: []*field{
+		{id: 1, typ: typeI32, val: int64(1)},
+		{id: 2, typ: typeList, val: &list{elemType: typeStruct, elems: []value{
+			&tstruct{fields: []*field{{id: 4, typ: typeBinary, val: []byte("schema")}}},
+		}}},
+		{id: fileMetaDataNumRows, typ: typeI64, val: int64(0)},
+		{id: fileMetaDataRowGroups, typ: typeList, val: groups},
+		{id: 6, typ: typeBinary, val: []byte("test")},
+	}})
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(e.buf)))
+	return append(append(append(buf, e.buf...), size[:]...), Magic...)
+}
+
+// readFile reads the metadata and the data of each column chunk in a parquet
+// file.
+func readFile(t *testing.T, file []byte) (*tstruct, []string) {
+	r := NewReader(bytes.NewReader(file))
+	defer func() {
+		require.NoError(t, r.Close())
+	}()
+	require.NoError(t, r.readMetadata())
+	var chunks []string
+	for _, g := range r.groups {
+		for _, c := range g.(*tstruct).get(rowGroupColumns).val.(*list).elems {
+			meta := c.(*tstruct).get(columnChunkMetaData).val.(*tstruct)
+			offset, _ := meta.getInt(columnMetaDataDataPageOffset)
+			size, _ := meta.getInt(columnMetaDataTotalCompressedSize)
+			chunks = append(chunks, string(file[offset:offset+size]))
+		}
+	}
+	return r.meta, chunks
+}
+
+func TestSplit(t *testing.T) {
+	file := testFile("foo", "barbar", "bazbazbaz")
+	r := NewReader(bytes.NewReader(file))
+	defer func() {
+		require.NoError(t, r.Close())
+	}()
+	// Split the file into the first row group and the last two.
+	var splits [][]byte
+	split := append([]byte(nil), Magic...)
+	for i := 0; ; i++ {
+		group, err := r.ReadRowGroup()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		split = append(split, group...)
+		if i%2 == 0 {
+			splits = append(splits, append(split, r.Footer()...))
+			split = append([]byte(nil), Magic...)
+		}
+	}
+	require.Equal(t, 2, len(splits))
+
+	meta, chunks := readFile(t, splits[0])
+	require.Equal(t, []string{"foo"}, chunks)
+	numRows, _ := meta.getInt(fileMetaDataNumRows)
+	require.Equal(t, int64(1), numRows)
+	require.Equal(t, []byte("test"), meta.get(6).val)
+
+	meta, chunks = readFile(t, splits[1])
+	require.Equal(t, []string{"barbar", "bazbazbaz"}, chunks)
+	numRows, _ = meta.getInt(fileMetaDataNumRows)
+	require.Equal(t, int64(5), numRows)
+}
+
+func TestRoundTrip(t *testing.T) {
+	file := testFile("foo", "bar")
+	r := NewReader(bytes.NewReader(file))
+	defer func() {
+		require.NoError(t, r.Close())
+	}()
+	require.NoError(t, r.readMetadata())
+	size := binary.LittleEndian.Uint32(file[len(file)-8:])
+	e := &encoder{}
+	e.writeStruct(r.meta)
+	require.Equal(t, file[len(file)-8-int(size):len(file)-8], e.buf)
+}
+
+func TestInvalid(t *testing.T) {
+	r := NewReader(bufio.NewReader(bytes.NewReader([]byte("PAR1 not parquet"))))
+	defer func() {
+		require.NoError(t, r.Close())
+	}()
+	_, err := r.ReadRowGroup()
+	require.YesError(t, err)
+}
+
+// readValues decodes the values in each column of a parquet file, by column
+// name. It only supports the uncompressed, PLAIN encoded, required INT64 and
+// BYTE_ARRAY columns in testdata/rows.parquet.
+func readValues(t *testing.T, file []byte) map[string][]string {
+	meta, err := (&decoder{r: bufio.NewReader(bytes.NewReader(footer(t, file)))}).readStruct()
+	require.NoError(t, err)
+	result := make(map[string][]string)
+	for _, g := range meta.get(fileMetaDataRowGroups).val.(*list).elems {
+		for _, c := range g.(*tstruct).get(rowGroupColumns).val.(*list).elems {
+			colMeta := c.(*tstruct).get(columnChunkMetaData).val.(*tstruct)
+			typ, _ := colMeta.getInt(1)
+			name := string(colMeta.get(3).val.(*list).elems[0].([]byte))
+			offset, _ := colMeta.getInt(columnMetaDataDataPageOffset)
+			size, _ := colMeta.getInt(columnMetaDataTotalCompressedSize)
+			r := bufio.NewReader(bytes.NewReader(file[offset : offset+size]))
+			for {
+				// each page is a thrift PageHeader followed by the page's data
+				pageHeader, err := (&decoder{r: r}).readStruct()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				pageSize, _ := pageHeader.getInt(3)
+				page := make([]byte, pageSize)
+				_, err = io.ReadFull(r, page)
+				require.NoError(t, err)
+				numValues, _ := pageHeader.get(5).val.(*tstruct).getInt(1)
+				for i := int64(0); i < numValues; i++ {
+					switch typ {
+					case 2: // INT64
+						result[name] = append(result[name], fmt.Sprint(int64(binary.LittleEndian.Uint64(page))))
+						page = page[8:]
+					case 6: // BYTE_ARRAY
+						n := binary.LittleEndian.Uint32(page)
+						result[name] = append(result[name], string(page[4:4+n]))
+						page = page[4+n:]
+					default:
+						t.Fatalf("unsupported column type %d", typ)
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// footer returns the serialized metadata of a parquet file.
+func footer(t *testing.T, file []byte) []byte {
+	require.True(t, len(file) >= 12)
+	size := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	return file[len(file)-8-size : len(file)-8]
+}
+
+// TestSplitFile splits a file written by parquet-go (github.com/xitongsys/parquet-go),
+// with a 'name' and a 'value' column and row groups of 1, 2 and 3 rows, and
+// decodes the rows in each split file.
+func TestSplitFile(t *testing.T) {
+	file, err := ioutil.ReadFile("testdata/rows.parquet")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"name":  {"a", "b", "c", "d", "e", "f"},
+		"value": {"0", "10", "20", "30", "40", "50"},
+	}, readValues(t, file))
+
+	r := NewReader(bytes.NewReader(file))
+	defer func() {
+		require.NoError(t, r.Close())
+	}()
+	var splits [][]byte
+	for {
+		group, err := r.ReadRowGroup()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		split := append(append([]byte(nil), Magic...), group...)
+		splits = append(splits, append(split, r.Footer()...))
+	}
+	require.Equal(t, 3, len(splits))
+	expected := []map[string][]string{
+		{"name": {"a"}, "value": {"0"}},
+		{"name": {"b", "c"}, "value": {"10", "20"}},
+		{"name": {"d", "e", "f"}, "value": {"30", "40", "50"}},
+	}
+	for i, split := range splits {
+		require.Equal(t, expected[i], readValues(t, split))
+		meta, _ := readFile(t, split)
+		numRows, _ := meta.getInt(fileMetaDataNumRows)
+		require.Equal(t, int64(i+1), numRows)
+	}
+}
@@ -0,0 +1,322 @@
+package parquet
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// Parquet's metadata is serialized with thrift's compact protocol. Rather
+// than generating code for parquet's thrift definitions, the metadata is
+// decoded into a generic tree so that the few fields we need can be rewritten
+// while everything else round-trips unchanged.
+
+// thrift compact protocol types
+const (
+	typeStop      = 0
+	typeBoolTrue  = 1
+	typeBoolFalse = 2
+	typeByte      = 3
+	typeI16       = 4
+	typeI32       = 5
+	typeI64       = 6
+	typeDouble    = 7
+	typeBinary    = 8
+	typeList      = 9
+	typeSet       = 10
+	typeMap       = 11
+	typeStruct    = 12
+)
+
+// A value is one of bool, int64 (byte and all int types), uint64 (the bits of
+// a double), []byte, *list, *tmap or *tstruct.
+type value interface{}
+
+type field struct {
+	id  int16
+	typ byte
+	val value
+}
+
+type tstruct struct {
+	fields []*field
+}
+
+type list struct {
+	elemType byte
+	elems    []value
+}
+
+type tmap struct {
+	keyType, valType byte
+	keys, vals       []value
+}
+
+func (s *tstruct) get(id int16) *field {
+	for _, f := range s.fields {
+		if f.id == id {
+			return f
+		}
+	}
+	return nil
+}
+
+func (s *tstruct) getInt(id int16) (int64, bool) {
+	if f := s.get(id); f != nil {
+		if v, ok := f.val.(int64); ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// setInt sets an existing int field, or adds it with the given type.
+func (s *tstruct) setInt(id int16, typ byte, v int64) {
+	if f := s.get(id); f != nil {
+		f.val = v
+		return
+	}
+	s.set(&field{id: id, typ: typ, val: v})
+}
+
+// set adds or replaces a field, keeping the fields ordered by id.
+func (s *tstruct) set(f *field) {
+	for i, g := range s.fields {
+		if g.id == f.id {
+			s.fields[i] = f
+			return
+		}
+		if g.id > f.id {
+			s.fields = append(s.fields[:i], append([]*field{f}, s.fields[i:]...)...)
+			return
+		}
+	}
+	s.fields = append(s.fields, f)
+}
+
+func (s *tstruct) remove(id int16) {
+	for i, f := range s.fields {
+		if f.id == id {
+			s.fields = append(s.fields[:i], s.fields[i+1:]...)
+			return
+		}
+	}
+}
+
+// copy returns a shallow copy of s, fields can be replaced in the copy
+// without affecting s.
+func (s *tstruct) copy() *tstruct {
+	result := &tstruct{}
+	for _, f := range s.fields {
+		g := *f
+		result.fields = append(result.fields, &g)
+	}
+	return result
+}
+
+type decoder struct {
+	r *bufio.Reader
+}
+
+func (d *decoder) readStruct() (*tstruct, error) {
+	s := &tstruct{}
+	var id int16
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		typ := b & 0x0f
+		if typ == typeStop {
+			return s, nil
+		}
+		if delta := int16(b >> 4); delta != 0 {
+			id += delta
+		} else {
+			v, err := binary.ReadVarint(d.r)
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		var val value
+		switch typ {
+		case typeBoolTrue:
+			val = true
+		case typeBoolFalse:
+			val = false
+		default:
+			if val, err = d.readValue(typ); err != nil {
+				return nil, err
+			}
+		}
+		s.fields = append(s.fields, &field{id: id, typ: typ, val: val})
+	}
+}
+
+func (d *decoder) readValue(typ byte) (value, error) {
+	switch typ {
+	case typeBoolTrue, typeBoolFalse:
+		// Bools in collections are a single byte.
+		b, err := d.r.ReadByte()
+		return b == typeBoolTrue, err
+	case typeByte:
+		b, err := d.r.ReadByte()
+		return int64(int8(b)), err
+	case typeI16, typeI32, typeI64:
+		return binary.ReadVarint(d.r)
+	case typeDouble:
+		var buf [8]byte
+		if _, err := io.ReadFull(d.r, buf[:]); err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint64(buf[:]), nil
+	case typeBinary:
+		n, err := binary.ReadUvarint(d.r)
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(d.r, buf); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	case typeList, typeSet:
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		l := &list{elemType: b & 0x0f}
+		size := uint64(b >> 4)
+		if size == 15 {
+			if size, err = binary.ReadUvarint(d.r); err != nil {
+				return nil, err
+			}
+		}
+		for i := uint64(0); i < size; i++ {
+			elem, err := d.readValue(l.elemType)
+			if err != nil {
+				return nil, err
+			}
+			l.elems = append(l.elems, elem)
+		}
+		return l, nil
+	case typeMap:
+		size, err := binary.ReadUvarint(d.r)
+		if err != nil {
+			return nil, err
+		}
+		m := &tmap{}
+		if size == 0 {
+			return m, nil
+		}
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		m.keyType, m.valType = b>>4, b&0x0f
+		for i := uint64(0); i < size; i++ {
+			k, err := d.readValue(m.keyType)
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.readValue(m.valType)
+			if err != nil {
+				return nil, err
+			}
+			m.keys, m.vals = append(m.keys, k), append(m.vals, v)
+		}
+		return m, nil
+	case typeStruct:
+		return d.readStruct()
+	default:
+		return nil, errors.Errorf("invalid thrift type %d", typ)
+	}
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) writeVarint(v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	e.buf = append(e.buf, buf[:binary.PutVarint(buf[:], v)]...)
+}
+
+func (e *encoder) writeUvarint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	e.buf = append(e.buf, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+func (e *encoder) writeStruct(s *tstruct) {
+	var id int16
+	for _, f := range s.fields {
+		typ := f.typ
+		if typ == typeBoolTrue || typ == typeBoolFalse {
+			typ = typeBoolFalse
+			if f.val.(bool) {
+				typ = typeBoolTrue
+			}
+		}
+		if delta := f.id - id; delta > 0 && delta <= 15 {
+			e.buf = append(e.buf, byte(delta)<<4|typ)
+		} else {
+			e.buf = append(e.buf, typ)
+			e.writeVarint(int64(f.id))
+		}
+		id = f.id
+		if typ != typeBoolTrue && typ != typeBoolFalse {
+			e.writeValue(typ, f.val)
+		}
+	}
+	e.buf = append(e.buf, typeStop)
+}
+
+func (e *encoder) writeValue(typ byte, val value) {
+	switch typ {
+	case typeBoolTrue, typeBoolFalse:
+		b := byte(typeBoolFalse)
+		if val.(bool) {
+			b = typeBoolTrue
+		}
+		e.buf = append(e.buf, b)
+	case typeByte:
+		e.buf = append(e.buf, byte(val.(int64)))
+	case typeI16, typeI32, typeI64:
+		e.writeVarint(val.(int64))
+	case typeDouble:
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], val.(uint64))
+		e.buf = append(e.buf, buf[:]...)
+	case typeBinary:
+		b := val.([]byte)
+		e.writeUvarint(uint64(len(b)))
+		e.buf = append(e.buf, b...)
+	case typeList, typeSet:
+		l := val.(*list)
+		if len(l.elems) < 15 {
+			e.buf = append(e.buf, byte(len(l.elems))<<4|l.elemType)
+		} else {
+			e.buf = append(e.buf, 0xf0|l.elemType)
+			e.writeUvarint(uint64(len(l.elems)))
+		}
+		for _, elem := range l.elems {
+			e.writeValue(l.elemType, elem)
+		}
+	case typeMap:
+		m := val.(*tmap)
+		e.writeUvarint(uint64(len(m.keys)))
+		if len(m.keys) == 0 {
+			return
+		}
+		e.buf = append(e.buf, m.keyType<<4|m.valType)
+		for i := range m.keys {
+			e.writeValue(m.keyType, m.keys[i])
+			e.writeValue(m.valType, m.vals[i])
+		}
+	case typeStruct:
+		e.writeStruct(val.(*tstruct))
+	}
+}
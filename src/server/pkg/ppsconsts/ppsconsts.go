@@ -21,4 +21,8 @@ const (
 
 	// SpoutMarkerBranch is the branch that spouts use for keeping track of spout marker files
 	SpoutMarkerBranch = "marker"
+
+	// ObjectStorageListingBranch is the branch that object storage inputs use
+	// for keeping track of the objects that have been committed to their repo
+	ObjectStorageListingBranch = "listing"
 )
@@ -11,8 +11,12 @@ import (
 )
 
 const (
-	pipelinesPrefix = "/pipelines"
-	jobsPrefix      = "/jobs"
+	pipelinesPrefix   = "/pipelines"
+	jobsPrefix        = "/jobs"
+	templatesPrefix   = "/templates"
+	queuesPrefix      = "/queues"
+	alertsPrefix      = "/alerts"
+	alertEventsPrefix = "/alert_events"
 )
 
 var (
@@ -50,3 +54,52 @@ func Jobs(etcdClient *etcd.Client, etcdPrefix string) col.Collection {
 		nil,
 	)
 }
+
+// Templates returns a Collection of pipeline templates
+func Templates(etcdClient *etcd.Client, etcdPrefix string) col.Collection {
+	return col.NewCollection(
+		etcdClient,
+		path.Join(etcdPrefix, templatesPrefix),
+		nil,
+		&pps.PipelineTemplateInfo{},
+		nil,
+		nil,
+	)
+}
+
+// Queues returns a Collection of scheduling queues
+func Queues(etcdClient *etcd.Client, etcdPrefix string) col.Collection {
+	return col.NewCollection(
+		etcdClient,
+		path.Join(etcdPrefix, queuesPrefix),
+		nil,
+		&pps.QueueInfo{},
+		nil,
+		nil,
+	)
+}
+
+// Alerts returns a Collection of cluster alerts
+func Alerts(etcdClient *etcd.Client, etcdPrefix string) col.Collection {
+	return col.NewCollection(
+		etcdClient,
+		path.Join(etcdPrefix, alertsPrefix),
+		nil,
+		&pps.AlertInfo{},
+		nil,
+		nil,
+	)
+}
+
+// AlertEvents returns a Collection of the alert events that the PPS master
+// has fired
+func AlertEvents(etcdClient *etcd.Client, etcdPrefix string) col.Collection {
+	return col.NewCollection(
+		etcdClient,
+		path.Join(etcdPrefix, alertEventsPrefix),
+		nil,
+		&pps.AlertEvent{},
+		nil,
+		nil,
+	)
+}
@@ -52,6 +52,12 @@ func NewPipelineManifestReader(path string) (result *PipelineManifestReader, ret
 			return nil, err
 		}
 	}
+	return NewPipelineManifestReaderFromBytes(pipelineBytes), nil
+}
+
+// NewPipelineManifestReaderFromBytes creates a new manifest reader for
+// pipelineBytes, which may be JSON or YAML.
+func NewPipelineManifestReaderFromBytes(pipelineBytes []byte) *PipelineManifestReader {
 	// TODO(msteffen): if we can get the yaml decoder to handle leading tabs, as
 	// in pps/cmds/cmds_test.go, then we can get rid of this
 	idx := bytes.IndexFunc(pipelineBytes, func(r rune) bool {
@@ -60,11 +66,11 @@ func NewPipelineManifestReader(path string) (result *PipelineManifestReader, ret
 	if idx >= 0 && pipelineBytes[idx] == '{' {
 		return &PipelineManifestReader{
 			decoder: serde.NewJSONDecoder(bytes.NewReader(pipelineBytes)),
-		}, nil
+		}
 	}
 	return &PipelineManifestReader{
 		decoder: serde.NewYAMLDecoder(bytes.NewReader(pipelineBytes)),
-	}, nil
+	}
 }
 
 // NextCreatePipelineRequest gets the next request from the manifest reader.
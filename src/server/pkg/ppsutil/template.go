@@ -0,0 +1,164 @@
+package ppsutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// templateParameterRe matches the parameter names that can be referenced as
+// {{.name}} in a template.
+var templateParameterRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// templateZeroValues are the values of parameters of each type that have no
+// default and aren't given.
+var templateZeroValues = map[ppsclient.TemplateParameterType]string{
+	ppsclient.TemplateParameterType_STRING: "",
+	ppsclient.TemplateParameterType_INT:    "0",
+	ppsclient.TemplateParameterType_FLOAT:  "0",
+	ppsclient.TemplateParameterType_BOOL:   "false",
+}
+
+// templateFuncs are the functions, in addition to text/template's builtins,
+// that templates can use.
+var templateFuncs = template.FuncMap{
+	// json encodes a value, so that string parameters can be used in a JSON
+	// spec without worrying about quoting, e.g. "cmd": {{json .cmd}}
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", errors.EnsureStack(err)
+		}
+		return string(data), nil
+	},
+}
+
+func parseTemplate(templateInfo *ppsclient.PipelineTemplateInfo) (*template.Template, error) {
+	t, err := template.New(templateInfo.Template.Name).
+		Funcs(templateFuncs).
+		Option("missingkey=error").
+		Parse(templateInfo.Spec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid template spec")
+	}
+	return t, nil
+}
+
+// parseTemplateParameter converts value to the Go type for param's type, so
+// that templates can compare and do arithmetic on it.
+func parseTemplateParameter(param *ppsclient.TemplateParameter, value string) (interface{}, error) {
+	var result interface{}
+	var err error
+	switch param.Type {
+	case ppsclient.TemplateParameterType_STRING:
+		result = value
+	case ppsclient.TemplateParameterType_INT:
+		result, err = strconv.ParseInt(value, 10, 64)
+	case ppsclient.TemplateParameterType_FLOAT:
+		result, err = strconv.ParseFloat(value, 64)
+	case ppsclient.TemplateParameterType_BOOL:
+		result, err = strconv.ParseBool(value)
+	default:
+		return nil, errors.Errorf("parameter %s has unknown type %v", param.Name, param.Type)
+	}
+	if err != nil {
+		return nil, errors.Errorf("parameter %s must be of type %s, got %q",
+			param.Name, strings.ToLower(param.Type.String()), value)
+	}
+	return result, nil
+}
+
+// ValidatePipelineTemplate checks that templateInfo's spec parses and that its
+// parameters are well formed.
+func ValidatePipelineTemplate(templateInfo *ppsclient.PipelineTemplateInfo) error {
+	if templateInfo.Template == nil || templateInfo.Template.Name == "" {
+		return errors.New("template name cannot be empty")
+	}
+	if templateInfo.Spec == "" {
+		return errors.New("template spec cannot be empty")
+	}
+	names := make(map[string]bool)
+	for _, param := range templateInfo.Parameters {
+		if !templateParameterRe.MatchString(param.Name) {
+			return errors.Errorf("invalid parameter name %q, parameter names may only contain letters, digits and underscores", param.Name)
+		}
+		if names[param.Name] {
+			return errors.Errorf("parameter %s was declared more than once", param.Name)
+		}
+		names[param.Name] = true
+		if param.Required && param.Default != "" {
+			return errors.Errorf("required parameter %s can't have a default", param.Name)
+		}
+		if param.Default != "" {
+			if _, err := parseTemplateParameter(param, param.Default); err != nil {
+				return errors.Wrapf(err, "invalid default")
+			}
+		}
+	}
+	_, err := parseTemplate(templateInfo)
+	return err
+}
+
+// RenderPipelineTemplate fills in templateInfo's spec with params and parses
+// the result as a CreatePipelineRequest. Parameters missing from params take
+// their default value; required parameters must be set. The returned request
+// records the template version and params, so that it can be rendered again
+// when the template is updated.
+func RenderPipelineTemplate(templateInfo *ppsclient.PipelineTemplateInfo, params map[string]string) (*ppsclient.CreatePipelineRequest, error) {
+	t, err := parseTemplate(templateInfo)
+	if err != nil {
+		return nil, err
+	}
+	declared := make(map[string]bool)
+	data := make(map[string]interface{})
+	for _, param := range templateInfo.Parameters {
+		declared[param.Name] = true
+		value, ok := params[param.Name]
+		if !ok {
+			if param.Required {
+				return nil, errors.Errorf("missing required parameter %s", param.Name)
+			}
+			value = param.Default
+			if value == "" {
+				value = templateZeroValues[param.Type]
+			}
+		}
+		if data[param.Name], err = parseTemplateParameter(param, value); err != nil {
+			return nil, err
+		}
+	}
+	var unknown []string
+	for name := range params {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, errors.Errorf("template %s has no parameter(s) %s",
+			templateInfo.Template.Name, strings.Join(unknown, ", "))
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, errors.Wrapf(err, "could not render template %s", templateInfo.Template.Name)
+	}
+	request, err := NewPipelineManifestReaderFromBytes(buf.Bytes()).NextCreatePipelineRequest()
+	if err != nil {
+		return nil, errors.Wrapf(err, "template %s rendered an invalid pipeline", templateInfo.Template.Name)
+	}
+	// Only the given params are recorded, so that re-rendering the pipeline
+	// with a newer version of the template picks up new defaults.
+	request.Template = &ppsclient.TemplateRef{
+		Template: templateInfo.Template,
+		Version:  templateInfo.Version,
+		Params:   params,
+	}
+	return request, nil
+}
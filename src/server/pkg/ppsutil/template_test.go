@@ -0,0 +1,80 @@
+package ppsutil
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestRenderPipelineTemplate(t *testing.T) {
+	templateInfo := &pps.PipelineTemplateInfo{
+		Template: &pps.PipelineTemplate{Name: "edges"},
+		Version:  3,
+		Spec: `{
+  "pipeline": {"name": "{{.name}}"},
+  "transform": {"image": "edges:1.0", "cmd": ["edges", {{json .flag}}]},
+  "parallelism_spec": {"constant": {{.parallelism}}},
+  "input": {"pfs": {"repo": "images", "glob": "/*"}}{{if .stats}},
+  "enable_stats": true{{end}}
+}`,
+		Parameters: []*pps.TemplateParameter{
+			{Name: "name", Required: true},
+			{Name: "flag", Default: `--mode="fast"`},
+			{Name: "parallelism", Type: pps.TemplateParameterType_INT, Default: "1"},
+			{Name: "stats", Type: pps.TemplateParameterType_BOOL},
+		},
+	}
+	require.NoError(t, ValidatePipelineTemplate(templateInfo))
+
+	params := map[string]string{"name": "edges-small", "stats": "true"}
+	request, err := RenderPipelineTemplate(templateInfo, params)
+	require.NoError(t, err)
+	require.Equal(t, "edges-small", request.Pipeline.Name)
+	require.Equal(t, []string{"edges", `--mode="fast"`}, request.Transform.Cmd)
+	require.Equal(t, uint64(1), request.ParallelismSpec.Constant)
+	require.True(t, request.EnableStats)
+	require.Equal(t, "edges", request.Template.Template.Name)
+	require.Equal(t, uint64(3), request.Template.Version)
+	require.Equal(t, params, request.Template.Params)
+
+	// Optional parameters without a default are their type's zero value
+	request, err = RenderPipelineTemplate(templateInfo, map[string]string{"name": "edges-small"})
+	require.NoError(t, err)
+	require.False(t, request.EnableStats)
+
+	_, err = RenderPipelineTemplate(templateInfo, map[string]string{})
+	require.YesError(t, err)
+	require.Matches(t, "missing required parameter name", err.Error())
+	_, err = RenderPipelineTemplate(templateInfo, map[string]string{"name": "a", "parallelism": "two"})
+	require.YesError(t, err)
+	require.Matches(t, "parallelism must be of type int", err.Error())
+	_, err = RenderPipelineTemplate(templateInfo, map[string]string{"name": "a", "image": "b"})
+	require.YesError(t, err)
+	require.Matches(t, "has no parameter\\(s\\) image", err.Error())
+}
+
+func TestValidatePipelineTemplate(t *testing.T) {
+	validate := func(spec string, params ...*pps.TemplateParameter) error {
+		return ValidatePipelineTemplate(&pps.PipelineTemplateInfo{
+			Template:   &pps.PipelineTemplate{Name: "t"},
+			Spec:       spec,
+			Parameters: params,
+		})
+	}
+	require.NoError(t, validate(`{"pipeline": {"name": "{{.name}}"}}`, &pps.TemplateParameter{Name: "name"}))
+	require.YesError(t, validate(""))
+	require.YesError(t, validate(`{"pipeline": {"name": "{{.name"}}`, &pps.TemplateParameter{Name: "name"}))
+	require.YesError(t, validate("{}", &pps.TemplateParameter{Name: "my-param"}))
+	require.YesError(t, validate("{}", &pps.TemplateParameter{Name: "a"}, &pps.TemplateParameter{Name: "a"}))
+	require.YesError(t, validate("{}", &pps.TemplateParameter{
+		Name:    "a",
+		Type:    pps.TemplateParameterType_FLOAT,
+		Default: "one",
+	}))
+	require.YesError(t, validate("{}", &pps.TemplateParameter{
+		Name:     "a",
+		Required: true,
+		Default:  "one",
+	}))
+}
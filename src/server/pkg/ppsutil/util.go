@@ -27,6 +27,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pkg/tracing"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
 
@@ -52,10 +53,22 @@ func PipelineRcName(name string, version uint64) string {
 	return fmt.Sprintf("pipeline-%s-v%d", strings.ToLower(name), version)
 }
 
+// WorkerPoolRcName generates the name of the k8s replication controller that
+// manages the workers of one of a pipeline's worker pools.
+func WorkerPoolRcName(name string, version uint64, pool string) string {
+	return fmt.Sprintf("%s-%s", PipelineRcName(name, version), pool)
+}
+
 // GetRequestsResourceListFromPipeline returns a list of resources that the pipeline,
 // minimally requires.
 func GetRequestsResourceListFromPipeline(pipelineInfo *pps.PipelineInfo) (*v1.ResourceList, error) {
-	return getResourceListFromSpec(pipelineInfo.ResourceRequests)
+	return GetRequestsResourceList(pipelineInfo.ResourceRequests)
+}
+
+// GetRequestsResourceList returns a list of resources from a ResourceSpec that
+// a pod minimally requires.
+func GetRequestsResourceList(requests *pps.ResourceSpec) (*v1.ResourceList, error) {
+	return getResourceListFromSpec(requests)
 }
 
 func getResourceListFromSpec(resources *pps.ResourceSpec) (*v1.ResourceList, error) {
@@ -119,6 +132,44 @@ func GetExpectedNumHashtrees(spec *pps.HashtreeSpec) (int64, error) {
 	return 0, errors.Errorf("unable to interpret HashtreeSpec %+v", spec)
 }
 
+// defaultPreemptionGracePeriod is how long user code has to stop when its
+// worker is preempted, if the pipeline's PreemptionSpec doesn't say.
+const defaultPreemptionGracePeriod = 30 * time.Second
+
+// PreemptionShutdownPeriod is how long a preempted worker has, after its user
+// code's grace period, to save checkpoints and release its subtasks.
+const PreemptionShutdownPeriod = 30 * time.Second
+
+// GetPreemptionGracePeriod returns how long the user code of a pipeline with
+// the PreemptionSpec 'spec' has to stop when its worker is preempted.
+func GetPreemptionGracePeriod(spec *pps.PreemptionSpec) (time.Duration, error) {
+	if spec == nil || spec.GracePeriod == nil {
+		return defaultPreemptionGracePeriod, nil
+	}
+	gracePeriod, err := types.DurationFromProto(spec.GracePeriod)
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	return gracePeriod, nil
+}
+
+// PlusDuration returns x + y, treating nil as zero. It's used to add up the
+// times in ProcessStats.
+func PlusDuration(x, y *types.Duration) (*types.Duration, error) {
+	var sum time.Duration
+	for _, d := range []*types.Duration{x, y} {
+		if d == nil {
+			continue
+		}
+		duration, err := types.DurationFromProto(d)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		sum += duration
+	}
+	return types.DurationProto(sum), nil
+}
+
 // GetPipelineInfo retrieves and returns a valid PipelineInfo from PFS. It does
 // the PFS read/unmarshalling of bytes as well as filling in missing fields
 func GetPipelineInfo(pachClient *client.APIClient, name string, ptr *pps.EtcdPipelineInfo) (*pps.PipelineInfo, error) {
@@ -143,6 +194,9 @@ func GetPipelineInfo(pachClient *client.APIClient, name string, ptr *pps.EtcdPip
 	result.JobCounts = ptr.JobCounts
 	result.LastJobState = ptr.LastJobState
 	result.SpecCommit = ptr.SpecCommit
+	result.ServiceCommit = ptr.ServiceCommit
+	result.AutoscalingStatus = ptr.AutoscalingStatus
+	result.QueueStatus = ptr.QueueStatus
 	return result, nil
 }
 
@@ -208,6 +262,11 @@ func SetPipelineState(ctx context.Context, etcdClient *etcd.Client, pipelinesCol
 				Current:  pipelinePtr.State,
 			}
 		}
+		if to != pps.PipelineState_PIPELINE_CRASHING {
+			pipelinePtr.CrashingSince = nil
+		} else if pipelinePtr.State != to || pipelinePtr.CrashingSince == nil {
+			pipelinePtr.CrashingSince = types.TimestampNow()
+		}
 		pipelinePtr.State = to
 		pipelinePtr.Reason = reason
 		return pipelines.Put(pipeline, pipelinePtr)
@@ -237,6 +296,11 @@ func JobInput(pipelineInfo *pps.PipelineInfo, outputCommitInfo *pfs.CommitInfo)
 				input.Cron.Commit = commit.ID
 			}
 		}
+		if input.ObjectStorage != nil {
+			if commit, ok := branchToCommit[key(input.ObjectStorage.Repo, "master")]; ok {
+				input.ObjectStorage.Commit = commit.ID
+			}
+		}
 		if input.Git != nil {
 			if commit, ok := branchToCommit[key(input.Git.Name, input.Git.Branch)]; ok {
 				input.Git.Commit = commit.ID
@@ -246,6 +310,80 @@ func JobInput(pipelineInfo *pps.PipelineInfo, outputCommitInfo *pfs.CommitInfo)
 	return jobInput
 }
 
+// InputCommit returns the name of a PFS, cron, object storage or git input,
+// and the commit that's set in it for a job. The commit is nil if the input
+// doesn't have one, and the name is empty for other types of input.
+func InputCommit(input *pps.Input) (string, *pfs.Commit) {
+	var name, repo, commit string
+	switch {
+	case input.Pfs != nil:
+		name, repo, commit = input.Pfs.Name, input.Pfs.Repo, input.Pfs.Commit
+	case input.Cron != nil:
+		name, repo, commit = input.Cron.Name, input.Cron.Repo, input.Cron.Commit
+	case input.ObjectStorage != nil:
+		name, repo, commit = input.ObjectStorage.Name, input.ObjectStorage.Repo, input.ObjectStorage.Commit
+	case input.Git != nil:
+		name, repo, commit = input.Git.Name, input.Git.Name, input.Git.Commit
+	default:
+		return "", nil
+	}
+	if commit == "" {
+		return name, nil
+	}
+	return name, client.NewCommit(repo, commit)
+}
+
+// ResolveInputCommits sets the commit of every PFS, cron, object storage and
+// git input to the commit pinned for its repo in commits, or, if the input
+// doesn't already have a commit, to the head of its branch. Inputs whose
+// branch has no commits are left empty, as are cron, object storage and git
+// repos that don't exist yet (pachd creates them with the pipeline), but a
+// missing PFS input repo is an error.
+func ResolveInputCommits(pachClient *client.APIClient, input *pps.Input, commits map[string]string) error {
+	resolve := func(repo, branch, commit string, created bool) (string, error) {
+		if id, ok := commits[repo]; ok {
+			return id, nil
+		}
+		if commit != "" {
+			return commit, nil
+		}
+		branchInfo, err := pachClient.InspectBranch(repo, branch)
+		if err != nil {
+			if !pfsserver.IsBranchNotFoundErr(err) && !pfsserver.IsRepoNotFoundErr(err) {
+				return "", err
+			}
+			if !created {
+				// the branch doesn't exist until it has a commit
+				if _, err := pachClient.InspectRepo(repo); err != nil {
+					return "", err
+				}
+			}
+			return "", nil
+		}
+		if branchInfo.Head == nil {
+			return "", nil
+		}
+		return branchInfo.Head.ID, nil
+	}
+	var err error
+	pps.VisitInput(input, func(input *pps.Input) {
+		if err != nil {
+			return
+		}
+		switch {
+		case input.Pfs != nil:
+			input.Pfs.Commit, err = resolve(input.Pfs.Repo, input.Pfs.Branch, input.Pfs.Commit, false)
+		case input.Cron != nil:
+			input.Cron.Commit, err = resolve(input.Cron.Repo, "master", input.Cron.Commit, true)
+		case input.ObjectStorage != nil:
+			input.ObjectStorage.Commit, err = resolve(input.ObjectStorage.Repo, "master", input.ObjectStorage.Commit, true)
+		case input.Git != nil:
+			input.Git.Commit, err = resolve(input.Git.Name, input.Git.Branch, input.Git.Commit, true)
+		}
+	})
+	return err
+}
+
 // PipelineReqFromInfo converts a PipelineInfo into a CreatePipelineRequest.
 func PipelineReqFromInfo(pipelineInfo *pps.PipelineInfo) *pps.CreatePipelineRequest {
 	return &pps.CreatePipelineRequest{
@@ -263,6 +401,7 @@ func PipelineReqFromInfo(pipelineInfo *pps.PipelineInfo) *pps.CreatePipelineRequ
 		CacheSize:             pipelineInfo.CacheSize,
 		EnableStats:           pipelineInfo.EnableStats,
 		MaxQueueSize:          pipelineInfo.MaxQueueSize,
+		DatumConcurrency:      pipelineInfo.DatumConcurrency,
 		Service:               pipelineInfo.Service,
 		ChunkSpec:             pipelineInfo.ChunkSpec,
 		DatumTimeout:          pipelineInfo.DatumTimeout,
@@ -276,6 +415,14 @@ func PipelineReqFromInfo(pipelineInfo *pps.PipelineInfo) *pps.CreatePipelineRequ
 		Standby:               pipelineInfo.Standby,
 		S3Out:                 pipelineInfo.S3Out,
 		Metadata:              pipelineInfo.Metadata,
+		Condition:             pipelineInfo.Condition,
+		Template:              pipelineInfo.Template,
+		Autoscaling:           pipelineInfo.Autoscaling,
+		Priority:              pipelineInfo.Priority,
+		Queue:                 pipelineInfo.Queue,
+		Alerts:                pipelineInfo.Alerts,
+		WorkerPools:           pipelineInfo.WorkerPools,
+		Preemption:            pipelineInfo.Preemption,
 	}
 }
 
@@ -0,0 +1,66 @@
+package ppsutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+)
+
+func TestResolveInputCommits(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		require.NoError(t, c.CreateRepo("images"))
+		require.NoError(t, c.CreateRepo("labels"))
+		first, err := c.StartCommit("images", "master")
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit("images", first.ID))
+		second, err := c.StartCommit("images", "master")
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit("images", second.ID))
+
+		input := func() *pps.Input {
+			return client.NewCrossInput(
+				client.NewPFSInputOpts("", "images", "master", "/*", "", false),
+				client.NewPFSInputOpts("", "labels", "master", "/*", "", false),
+				&pps.Input{Cron: &pps.CronInput{Name: "tick", Repo: "edges_tick"}},
+			)
+		}
+		// branches resolve to their heads, a repo with no commits and a cron
+		// repo that doesn't exist yet are left empty
+		in := input()
+		require.NoError(t, ppsutil.ResolveInputCommits(c, in, nil))
+		require.Equal(t, second.ID, in.Cross[0].Pfs.Commit)
+		require.Equal(t, "", in.Cross[1].Pfs.Commit)
+		require.Equal(t, "", in.Cross[2].Cron.Commit)
+
+		in = input()
+		require.NoError(t, ppsutil.ResolveInputCommits(c, in, map[string]string{"images": first.ID}))
+		require.Equal(t, first.ID, in.Cross[0].Pfs.Commit)
+
+		// a missing pfs input repo is an error
+		in = client.NewPFSInputOpts("", "missing", "master", "/*", "", false)
+		require.YesError(t, ppsutil.ResolveInputCommits(c, in, nil))
+		return nil
+	}))
+}
+
+func TestPlusDuration(t *testing.T) {
+	sum, err := ppsutil.PlusDuration(types.DurationProto(time.Second), types.DurationProto(2*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, types.DurationProto(3*time.Second), sum)
+	sum, err = ppsutil.PlusDuration(nil, types.DurationProto(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, types.DurationProto(time.Second), sum)
+	sum, err = ppsutil.PlusDuration(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, types.DurationProto(0), sum)
+	_, err = ppsutil.PlusDuration(&types.Duration{Seconds: 1, Nanos: -1}, nil)
+	require.YesError(t, err)
+}
@@ -68,6 +68,10 @@ type PachdSpecificConfiguration struct {
 	DeploymentID               string `env:"CLUSTER_DEPLOYMENT_ID,default="`
 	RequireCriticalServersOnly bool   `env:"REQUIRE_CRITICAL_SERVERS_ONLY",default=false"`
 	MetricsEndpoint            string `env:"METRICS_ENDPOINT",default="`
+	PPSMaxWorkers              uint64 `env:"PPS_MAX_WORKERS,default=0"`
+	// PPSPreemption is only set in the sidecars of preemptible workers, which
+	// stay up after SIGTERM until their worker has stopped.
+	PPSPreemption bool `env:"PPS_PREEMPTION,default=false"`
 	// TODO: Merge this with the worker specific pod name (PPS_POD_NAME) into a global configuration pod name.
 	PachdPodName string `env:"PACHD_POD_NAME,required"`
 }
@@ -101,6 +105,8 @@ type WorkerSpecificConfiguration struct {
 	PPSPipelineName string `env:"PPS_PIPELINE_NAME,required"`
 	// The name of this pod
 	PodName string `env:"PPS_POD_NAME,required"`
+	// The name of the worker pool that this worker belongs to, if any
+	PPSWorkerPool string `env:"PPS_WORKER_POOL,default="`
 }
 
 // FeatureFlags contains the configuration for feature flags.  XXX: if you're
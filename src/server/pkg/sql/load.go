@@ -0,0 +1,248 @@
+package sql
+
+import (
+	"bufio"
+	"bytes"
+	dbsql "database/sql"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// DefaultBatchSize is the default maximum number of rows loaded by a single
+// COPY statement.
+const DefaultBatchSize = 10000
+
+// Load executes the pgdump read from r in tx. r may contain several pgdumps,
+// or the header, rows and footer of a file split with PGDumpReader. SQL
+// statements are executed as they're read, and each block of rows is loaded
+// with COPY, batchSize rows at a time. psql meta-commands, such as \connect,
+// are skipped.
+func Load(tx *dbsql.Tx, r io.Reader, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	l := &loader{
+		tx:        tx,
+		rd:        bufio.NewReader(r),
+		batchSize: batchSize,
+	}
+	return l.load()
+}
+
+// LoadRows is like Load, but it only loads the rows read from r, the other
+// SQL statements are skipped. It's used to load the files that were added to
+// a directory of pgdump split-files, whose header (which creates the tables)
+// was already loaded.
+func LoadRows(tx *dbsql.Tx, r io.Reader, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	l := &loader{
+		tx:        tx,
+		rd:        bufio.NewReader(r),
+		batchSize: batchSize,
+		rowsOnly:  true,
+	}
+	return l.load()
+}
+
+type loader struct {
+	tx        *dbsql.Tx
+	rd        *bufio.Reader
+	batchSize int
+	// rowsOnly skips the statements outside of COPYs.
+	rowsOnly bool
+	// stmts holds the SQL statements read since the last COPY.
+	stmts bytes.Buffer
+	// copyStmt is the COPY statement of the rows being read, and copy is the
+	// COPY that they are currently loaded with.
+	copyStmt string
+	copy     *dbsql.Stmt
+	rows     int
+}
+
+func (l *loader) load() (retErr error) {
+	defer func() {
+		if l.copy != nil {
+			if err := l.copy.Close(); err != nil && retErr == nil {
+				retErr = errors.WithStack(err)
+			}
+		}
+	}()
+	for {
+		line, err := l.rd.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return errors.Wrapf(err, "error reading pgdump")
+		}
+		if line != "" {
+			// Like PGDumpReader, handle pgdump files with \r\n line endings.
+			line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+			if err := l.readLine(line); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	if l.copyStmt != "" {
+		return errors.Errorf("invalid pgdump - missing end of rows for %q", l.copyStmt)
+	}
+	return l.execStmts()
+}
+
+func (l *loader) readLine(line string) error {
+	if l.copyStmt != "" {
+		if line == `\.` {
+			l.copyStmt = ""
+			return l.finishCopy()
+		}
+		return l.copyRow(line)
+	}
+	switch {
+	case strings.HasPrefix(line, `\`):
+		return nil
+	case strings.HasPrefix(line, "COPY") && strings.HasSuffix(strings.TrimSpace(line), "FROM stdin;"):
+		if err := l.execStmts(); err != nil {
+			return err
+		}
+		l.copyStmt = line
+		return nil
+	default:
+		l.stmts.WriteString(line)
+		l.stmts.WriteByte('\n')
+		return nil
+	}
+}
+
+// execStmts executes the statements read since the last COPY, they're sent
+// together so that statements spanning several lines don't need to be parsed.
+func (l *loader) execStmts() error {
+	defer l.stmts.Reset()
+	if l.rowsOnly || strings.TrimSpace(l.stmts.String()) == "" {
+		return nil
+	}
+	if _, err := l.tx.Exec(l.stmts.String()); err != nil {
+		return errors.Wrapf(err, "error executing pgdump statements")
+	}
+	return nil
+}
+
+func (l *loader) copyRow(line string) error {
+	if l.copy == nil {
+		stmt, err := l.tx.Prepare(l.copyStmt)
+		if err != nil {
+			return errors.Wrapf(err, "error starting %q", l.copyStmt)
+		}
+		l.copy = stmt
+	}
+	values, err := parseRow(line)
+	if err != nil {
+		return err
+	}
+	if _, err := l.copy.Exec(values...); err != nil {
+		return errors.Wrapf(err, "error copying row")
+	}
+	l.rows++
+	if l.rows >= l.batchSize {
+		return l.finishCopy()
+	}
+	return nil
+}
+
+// finishCopy finishes the COPY of the current batch of rows.
+func (l *loader) finishCopy() error {
+	if l.copy == nil {
+		return nil
+	}
+	stmt := l.copy
+	l.copy = nil
+	l.rows = 0
+	if _, err := stmt.Exec(); err != nil {
+		stmt.Close()
+		return errors.Wrapf(err, "error copying rows")
+	}
+	return errors.WithStack(stmt.Close())
+}
+
+// parseRow parses a row in COPY's text format, columns are separated by tabs,
+// \N is NULL and special characters are escaped with backslashes.
+func parseRow(line string) ([]interface{}, error) {
+	var values []interface{}
+	for _, column := range strings.Split(line, "\t") {
+		if column == `\N` {
+			values = append(values, nil)
+			continue
+		}
+		value, err := unescape(column)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func unescape(column string) (string, error) {
+	if !strings.Contains(column, `\`) {
+		return column, nil
+	}
+	var result strings.Builder
+	for i := 0; i < len(column); i++ {
+		c := column[i]
+		if c != '\\' {
+			result.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(column) {
+			return "", errors.Errorf("invalid pgdump row - trailing backslash in %q", column)
+		}
+		switch c = column[i]; c {
+		case 'b':
+			result.WriteByte('\b')
+		case 'f':
+			result.WriteByte('\f')
+		case 'n':
+			result.WriteByte('\n')
+		case 'r':
+			result.WriteByte('\r')
+		case 't':
+			result.WriteByte('\t')
+		case 'v':
+			result.WriteByte('\v')
+		case 'x':
+			// \x followed by one or two hex digits
+			j := i + 1
+			for j < len(column) && j < i+3 && isHex(column[j]) {
+				j++
+			}
+			if j == i+1 {
+				result.WriteByte(c)
+				continue
+			}
+			n, _ := strconv.ParseUint(column[i+1:j], 16, 8)
+			result.WriteByte(byte(n))
+			i = j - 1
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			// one to three octal digits
+			j := i + 1
+			for j < len(column) && j < i+3 && column[j] >= '0' && column[j] <= '7' {
+				j++
+			}
+			n, _ := strconv.ParseUint(column[i:j], 8, 16)
+			result.WriteByte(byte(n))
+			i = j - 1
+		default:
+			result.WriteByte(c)
+		}
+	}
+	return result.String(), nil
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,124 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestParseRow(t *testing.T) {
+	values, err := parseRow("1\tfoo\\tbar\\nbaz\t\\N\t\\\\x01\t\\101\\x42\t")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"1", "foo\tbar\nbaz", nil, `\x01`, "AB", ""}, values)
+
+	_, err = parseRow("1\tfoo\\")
+	require.YesError(t, err)
+}
+
+// dump is a pgdump of a table with three rows, including the meta-commands
+// and comments that pg_dump writes.
+const dump = `--
+-- PostgreSQL database dump
+--
+
+SET statement_timeout = 0;
+\connect - postgres
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    name text
+);
+
+COPY public.users (id, name) FROM stdin;
+1	alice
+2	bob\tsmith
+3	\N
+\.
+
+CREATE INDEX users_name ON public.users USING btree (name);
+`
+
+const (
+	dumpHeader = "--\n-- PostgreSQL database dump\n--\n\nSET statement_timeout = 0;\n" +
+		"CREATE TABLE public.users (\n    id integer NOT NULL,\n    name text\n);\n\n"
+	dumpCopy   = "COPY public.users (id, name) FROM stdin;"
+	dumpFooter = "\nCREATE INDEX users_name ON public.users USING btree (name);\n"
+)
+
+// testLoad runs Load on a transaction of a sqlmock DB, with the expectations
+// that expect sets.
+func testLoad(t *testing.T, r io.Reader, batchSize int, expect func(mock sqlmock.Sqlmock)) error {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectBegin()
+	expect(mock)
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	err = Load(tx, r, batchSize)
+	require.NoError(t, mock.ExpectationsWereMet())
+	return err
+}
+
+// expectRows expects a COPY of rows, which is finished after the last row.
+func expectRows(mock sqlmock.Sqlmock, rows ...[]driver.Value) {
+	copy := mock.ExpectPrepare(dumpCopy).WillBeClosed()
+	for _, row := range rows {
+		copy.ExpectExec().WithArgs(row...).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	copy.ExpectExec().WillReturnResult(sqlmock.NewResult(0, int64(len(rows))))
+}
+
+func TestLoad(t *testing.T) {
+	alice := []driver.Value{"1", "alice"}
+	bob := []driver.Value{"2", "bob\tsmith"}
+	nobody := []driver.Value{"3", nil}
+
+	// The rows are split into COPYs of 2 rows, the \connect meta-command is
+	// skipped and \. ends the last COPY.
+	require.NoError(t, testLoad(t, strings.NewReader(dump), 2, func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(dumpHeader).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectRows(mock, alice, bob)
+		expectRows(mock, nobody)
+		mock.ExpectExec(dumpFooter).WillReturnResult(sqlmock.NewResult(0, 0))
+	}))
+
+	// When the last batch is full, \. doesn't start another COPY.
+	require.NoError(t, testLoad(t, strings.NewReader(dump), 3, func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(dumpHeader).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectRows(mock, alice, bob, nobody)
+		mock.ExpectExec(dumpFooter).WillReturnResult(sqlmock.NewResult(0, 0))
+	}))
+
+	// The rows of a split file, without a header or footer, are loaded with
+	// the COPY statement that precedes them.
+	split := dumpCopy + "\n2\tbob\\tsmith\r\n3\t\\N\r\n\\.\r\n"
+	require.NoError(t, testLoad(t, strings.NewReader(split), 0, func(mock sqlmock.Sqlmock) {
+		expectRows(mock, bob, nobody)
+	}))
+
+	// Rows without a \. are an error, and their COPY isn't finished.
+	err := testLoad(t, strings.NewReader(dumpCopy+"\n1\talice\n"), 0, func(mock sqlmock.Sqlmock) {
+		copy := mock.ExpectPrepare(dumpCopy).WillBeClosed()
+		copy.ExpectExec().WithArgs(alice...).WillReturnResult(sqlmock.NewResult(0, 1))
+	})
+	require.YesError(t, err)
+	require.Matches(t, "missing end of rows", err.Error())
+}
+
+func TestLoadRows(t *testing.T) {
+	// Only the rows are loaded, the header and footer statements are skipped.
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectBegin()
+	expectRows(mock, []driver.Value{"1", "alice"}, []driver.Value{"2", "bob\tsmith"}, []driver.Value{"3", nil})
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, LoadRows(tx, strings.NewReader(dump), 0))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -414,6 +414,7 @@ type createBranchFunc func(context.Context, *pfs.CreateBranchRequest) (*types.Em
 type inspectBranchFunc func(context.Context, *pfs.InspectBranchRequest) (*pfs.BranchInfo, error)
 type listBranchFunc func(context.Context, *pfs.ListBranchRequest) (*pfs.BranchInfos, error)
 type deleteBranchFunc func(context.Context, *pfs.DeleteBranchRequest) (*types.Empty, error)
+type setBranchSchemaFunc func(context.Context, *pfs.SetBranchSchemaRequest) (*types.Empty, error)
 type putFileFunc func(pfs.API_PutFileServer) error
 type copyFileFunc func(context.Context, *pfs.CopyFileRequest) (*types.Empty, error)
 type getFileFunc func(*pfs.GetFileRequest, pfs.API_GetFileServer) error
@@ -449,6 +450,7 @@ type mockCreateBranch struct{ handler createBranchFunc }
 type mockInspectBranch struct{ handler inspectBranchFunc }
 type mockListBranch struct{ handler listBranchFunc }
 type mockDeleteBranch struct{ handler deleteBranchFunc }
+type mockSetBranchSchema struct{ handler setBranchSchemaFunc }
 type mockPutFile struct{ handler putFileFunc }
 type mockCopyFile struct{ handler copyFileFunc }
 type mockGetFile struct{ handler getFileFunc }
@@ -484,6 +486,7 @@ func (mock *mockCreateBranch) Use(cb createBranchFunc)         { mock.handler =
 func (mock *mockInspectBranch) Use(cb inspectBranchFunc)       { mock.handler = cb }
 func (mock *mockListBranch) Use(cb listBranchFunc)             { mock.handler = cb }
 func (mock *mockDeleteBranch) Use(cb deleteBranchFunc)         { mock.handler = cb }
+func (mock *mockSetBranchSchema) Use(cb setBranchSchemaFunc)   { mock.handler = cb }
 func (mock *mockPutFile) Use(cb putFileFunc)                   { mock.handler = cb }
 func (mock *mockCopyFile) Use(cb copyFileFunc)                 { mock.handler = cb }
 func (mock *mockGetFile) Use(cb getFileFunc)                   { mock.handler = cb }
@@ -525,6 +528,7 @@ type mockPFSServer struct {
 	InspectBranch    mockInspectBranch
 	ListBranch       mockListBranch
 	DeleteBranch     mockDeleteBranch
+	SetBranchSchema  mockSetBranchSchema
 	PutFile          mockPutFile
 	CopyFile         mockCopyFile
 	GetFile          mockGetFile
@@ -646,6 +650,12 @@ func (api *pfsServerAPI) DeleteBranch(ctx context.Context, req *pfs.DeleteBranch
 	}
 	return nil, errors.Errorf("unhandled pachd mock pfs.DeleteBranch")
 }
+func (api *pfsServerAPI) SetBranchSchema(ctx context.Context, req *pfs.SetBranchSchemaRequest) (*types.Empty, error) {
+	if api.mock.SetBranchSchema.handler != nil {
+		return api.mock.SetBranchSchema.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.SetBranchSchema")
+}
 func (api *pfsServerAPI) PutFile(serv pfs.API_PutFileServer) error {
 	if api.mock.PutFile.handler != nil {
 		return api.mock.PutFile.handler(serv)
@@ -756,6 +766,8 @@ type inspectJobFunc func(context.Context, *pps.InspectJobRequest) (*pps.JobInfo,
 type listJobFunc func(context.Context, *pps.ListJobRequest) (*pps.JobInfos, error)
 type listJobStreamFunc func(*pps.ListJobRequest, pps.API_ListJobStreamServer) error
 type flushJobFunc func(*pps.FlushJobRequest, pps.API_FlushJobServer) error
+type diffJobFunc func(context.Context, *pps.DiffJobRequest) (*pps.JobDiff, error)
+type rerunJobFunc func(context.Context, *pps.RerunJobRequest) (*pps.RerunJobResponse, error)
 type deleteJobFunc func(context.Context, *pps.DeleteJobRequest) (*types.Empty, error)
 type stopJobFunc func(context.Context, *pps.StopJobRequest) (*types.Empty, error)
 type updateJobStateFunc func(context.Context, *pps.UpdateJobStateRequest) (*types.Empty, error)
@@ -764,6 +776,7 @@ type listDatumFunc func(context.Context, *pps.ListDatumRequest) (*pps.ListDatumR
 type listDatumStreamFunc func(*pps.ListDatumRequest, pps.API_ListDatumStreamServer) error
 type restartDatumFunc func(context.Context, *pps.RestartDatumRequest) (*types.Empty, error)
 type createPipelineFunc func(context.Context, *pps.CreatePipelineRequest) (*types.Empty, error)
+type dryRunPipelineFunc func(context.Context, *pps.DryRunPipelineRequest) (*pps.DryRunPipelineResponse, error)
 type inspectPipelineFunc func(context.Context, *pps.InspectPipelineRequest) (*pps.PipelineInfo, error)
 type listPipelineFunc func(context.Context, *pps.ListPipelineRequest) (*pps.PipelineInfos, error)
 type deletePipelineFunc func(context.Context, *pps.DeletePipelineRequest) (*types.Empty, error)
@@ -771,10 +784,25 @@ type startPipelineFunc func(context.Context, *pps.StartPipelineRequest) (*types.
 type stopPipelineFunc func(context.Context, *pps.StopPipelineRequest) (*types.Empty, error)
 type runPipelineFunc func(context.Context, *pps.RunPipelineRequest) (*types.Empty, error)
 type runCronFunc func(context.Context, *pps.RunCronRequest) (*types.Empty, error)
+type inspectDAGFunc func(context.Context, *pps.InspectDAGRequest) (*pps.DAGInfo, error)
 type createSecretFunc func(context.Context, *pps.CreateSecretRequest) (*types.Empty, error)
 type deleteSecretFunc func(context.Context, *pps.DeleteSecretRequest) (*types.Empty, error)
 type inspectSecretFunc func(context.Context, *pps.InspectSecretRequest) (*pps.SecretInfo, error)
 type listSecretFunc func(context.Context, *types.Empty) (*pps.SecretInfos, error)
+type createPipelineTemplateFunc func(context.Context, *pps.CreatePipelineTemplateRequest) (*types.Empty, error)
+type inspectPipelineTemplateFunc func(context.Context, *pps.InspectPipelineTemplateRequest) (*pps.PipelineTemplateInfo, error)
+type listPipelineTemplateFunc func(context.Context, *pps.ListPipelineTemplateRequest) (*pps.PipelineTemplateInfos, error)
+type deletePipelineTemplateFunc func(context.Context, *pps.DeletePipelineTemplateRequest) (*types.Empty, error)
+type createPipelineFromTemplateFunc func(context.Context, *pps.CreatePipelineFromTemplateRequest) (*types.Empty, error)
+type createQueueFunc func(context.Context, *pps.CreateQueueRequest) (*types.Empty, error)
+type inspectQueueFunc func(context.Context, *pps.InspectQueueRequest) (*pps.QueueInfo, error)
+type listQueueFunc func(context.Context, *pps.ListQueueRequest) (*pps.QueueInfos, error)
+type deleteQueueFunc func(context.Context, *pps.DeleteQueueRequest) (*types.Empty, error)
+type createAlertFunc func(context.Context, *pps.CreateAlertRequest) (*types.Empty, error)
+type inspectAlertFunc func(context.Context, *pps.InspectAlertRequest) (*pps.AlertInfo, error)
+type listAlertFunc func(context.Context, *pps.ListAlertRequest) (*pps.AlertInfos, error)
+type deleteAlertFunc func(context.Context, *pps.DeleteAlertRequest) (*types.Empty, error)
+type listUsageFunc func(context.Context, *pps.ListUsageRequest) (*pps.UsageInfos, error)
 type deleteAllPPSFunc func(context.Context, *types.Empty) (*types.Empty, error)
 type getLogsFunc func(*pps.GetLogsRequest, pps.API_GetLogsServer) error
 type garbageCollectFunc func(context.Context, *pps.GarbageCollectRequest) (*pps.GarbageCollectResponse, error)
@@ -785,6 +813,8 @@ type mockInspectJob struct{ handler inspectJobFunc }
 type mockListJob struct{ handler listJobFunc }
 type mockListJobStream struct{ handler listJobStreamFunc }
 type mockFlushJob struct{ handler flushJobFunc }
+type mockDiffJob struct{ handler diffJobFunc }
+type mockRerunJob struct{ handler rerunJobFunc }
 type mockDeleteJob struct{ handler deleteJobFunc }
 type mockStopJob struct{ handler stopJobFunc }
 type mockUpdateJobState struct{ handler updateJobStateFunc }
@@ -793,6 +823,7 @@ type mockListDatum struct{ handler listDatumFunc }
 type mockListDatumStream struct{ handler listDatumStreamFunc }
 type mockRestartDatum struct{ handler restartDatumFunc }
 type mockCreatePipeline struct{ handler createPipelineFunc }
+type mockDryRunPipeline struct{ handler dryRunPipelineFunc }
 type mockInspectPipeline struct{ handler inspectPipelineFunc }
 type mockListPipeline struct{ handler listPipelineFunc }
 type mockDeletePipeline struct{ handler deletePipelineFunc }
@@ -800,78 +831,131 @@ type mockStartPipeline struct{ handler startPipelineFunc }
 type mockStopPipeline struct{ handler stopPipelineFunc }
 type mockRunPipeline struct{ handler runPipelineFunc }
 type mockRunCron struct{ handler runCronFunc }
+type mockInspectDAG struct{ handler inspectDAGFunc }
 type mockCreateSecret struct{ handler createSecretFunc }
 type mockDeleteSecret struct{ handler deleteSecretFunc }
 type mockInspectSecret struct{ handler inspectSecretFunc }
 type mockListSecret struct{ handler listSecretFunc }
+type mockCreatePipelineTemplate struct{ handler createPipelineTemplateFunc }
+type mockInspectPipelineTemplate struct{ handler inspectPipelineTemplateFunc }
+type mockListPipelineTemplate struct{ handler listPipelineTemplateFunc }
+type mockDeletePipelineTemplate struct{ handler deletePipelineTemplateFunc }
+type mockCreatePipelineFromTemplate struct {
+	handler createPipelineFromTemplateFunc
+}
+type mockCreateQueue struct{ handler createQueueFunc }
+type mockInspectQueue struct{ handler inspectQueueFunc }
+type mockListQueue struct{ handler listQueueFunc }
+type mockDeleteQueue struct{ handler deleteQueueFunc }
+type mockCreateAlert struct{ handler createAlertFunc }
+type mockInspectAlert struct{ handler inspectAlertFunc }
+type mockListAlert struct{ handler listAlertFunc }
+type mockDeleteAlert struct{ handler deleteAlertFunc }
+type mockListUsage struct{ handler listUsageFunc }
 type mockDeleteAllPPS struct{ handler deleteAllPPSFunc }
 type mockGetLogs struct{ handler getLogsFunc }
 type mockGarbageCollect struct{ handler garbageCollectFunc }
 type mockActivateAuthPPS struct{ handler activateAuthPPSFunc }
 
-func (mock *mockCreateJob) Use(cb createJobFunc)             { mock.handler = cb }
-func (mock *mockInspectJob) Use(cb inspectJobFunc)           { mock.handler = cb }
-func (mock *mockListJob) Use(cb listJobFunc)                 { mock.handler = cb }
-func (mock *mockListJobStream) Use(cb listJobStreamFunc)     { mock.handler = cb }
-func (mock *mockFlushJob) Use(cb flushJobFunc)               { mock.handler = cb }
-func (mock *mockDeleteJob) Use(cb deleteJobFunc)             { mock.handler = cb }
-func (mock *mockStopJob) Use(cb stopJobFunc)                 { mock.handler = cb }
-func (mock *mockUpdateJobState) Use(cb updateJobStateFunc)   { mock.handler = cb }
-func (mock *mockInspectDatum) Use(cb inspectDatumFunc)       { mock.handler = cb }
-func (mock *mockListDatum) Use(cb listDatumFunc)             { mock.handler = cb }
-func (mock *mockListDatumStream) Use(cb listDatumStreamFunc) { mock.handler = cb }
-func (mock *mockRestartDatum) Use(cb restartDatumFunc)       { mock.handler = cb }
-func (mock *mockCreatePipeline) Use(cb createPipelineFunc)   { mock.handler = cb }
-func (mock *mockInspectPipeline) Use(cb inspectPipelineFunc) { mock.handler = cb }
-func (mock *mockListPipeline) Use(cb listPipelineFunc)       { mock.handler = cb }
-func (mock *mockDeletePipeline) Use(cb deletePipelineFunc)   { mock.handler = cb }
-func (mock *mockStartPipeline) Use(cb startPipelineFunc)     { mock.handler = cb }
-func (mock *mockStopPipeline) Use(cb stopPipelineFunc)       { mock.handler = cb }
-func (mock *mockRunPipeline) Use(cb runPipelineFunc)         { mock.handler = cb }
-func (mock *mockRunCron) Use(cb runCronFunc)                 { mock.handler = cb }
-func (mock *mockCreateSecret) Use(cb createSecretFunc)       { mock.handler = cb }
-func (mock *mockDeleteSecret) Use(cb deleteSecretFunc)       { mock.handler = cb }
-func (mock *mockInspectSecret) Use(cb inspectSecretFunc)     { mock.handler = cb }
-func (mock *mockListSecret) Use(cb listSecretFunc)           { mock.handler = cb }
-func (mock *mockDeleteAllPPS) Use(cb deleteAllPPSFunc)       { mock.handler = cb }
-func (mock *mockGetLogs) Use(cb getLogsFunc)                 { mock.handler = cb }
-func (mock *mockGarbageCollect) Use(cb garbageCollectFunc)   { mock.handler = cb }
-func (mock *mockActivateAuthPPS) Use(cb activateAuthPPSFunc) { mock.handler = cb }
+func (mock *mockCreateJob) Use(cb createJobFunc)                                   { mock.handler = cb }
+func (mock *mockInspectJob) Use(cb inspectJobFunc)                                 { mock.handler = cb }
+func (mock *mockListJob) Use(cb listJobFunc)                                       { mock.handler = cb }
+func (mock *mockListJobStream) Use(cb listJobStreamFunc)                           { mock.handler = cb }
+func (mock *mockFlushJob) Use(cb flushJobFunc)                                     { mock.handler = cb }
+func (mock *mockDiffJob) Use(cb diffJobFunc)                                       { mock.handler = cb }
+func (mock *mockRerunJob) Use(cb rerunJobFunc)                                     { mock.handler = cb }
+func (mock *mockDeleteJob) Use(cb deleteJobFunc)                                   { mock.handler = cb }
+func (mock *mockStopJob) Use(cb stopJobFunc)                                       { mock.handler = cb }
+func (mock *mockUpdateJobState) Use(cb updateJobStateFunc)                         { mock.handler = cb }
+func (mock *mockInspectDatum) Use(cb inspectDatumFunc)                             { mock.handler = cb }
+func (mock *mockListDatum) Use(cb listDatumFunc)                                   { mock.handler = cb }
+func (mock *mockListDatumStream) Use(cb listDatumStreamFunc)                       { mock.handler = cb }
+func (mock *mockRestartDatum) Use(cb restartDatumFunc)                             { mock.handler = cb }
+func (mock *mockCreatePipeline) Use(cb createPipelineFunc)                         { mock.handler = cb }
+func (mock *mockDryRunPipeline) Use(cb dryRunPipelineFunc)                         { mock.handler = cb }
+func (mock *mockInspectPipeline) Use(cb inspectPipelineFunc)                       { mock.handler = cb }
+func (mock *mockListPipeline) Use(cb listPipelineFunc)                             { mock.handler = cb }
+func (mock *mockDeletePipeline) Use(cb deletePipelineFunc)                         { mock.handler = cb }
+func (mock *mockStartPipeline) Use(cb startPipelineFunc)                           { mock.handler = cb }
+func (mock *mockStopPipeline) Use(cb stopPipelineFunc)                             { mock.handler = cb }
+func (mock *mockRunPipeline) Use(cb runPipelineFunc)                               { mock.handler = cb }
+func (mock *mockRunCron) Use(cb runCronFunc)                                       { mock.handler = cb }
+func (mock *mockInspectDAG) Use(cb inspectDAGFunc)                                 { mock.handler = cb }
+func (mock *mockCreateSecret) Use(cb createSecretFunc)                             { mock.handler = cb }
+func (mock *mockDeleteSecret) Use(cb deleteSecretFunc)                             { mock.handler = cb }
+func (mock *mockInspectSecret) Use(cb inspectSecretFunc)                           { mock.handler = cb }
+func (mock *mockListSecret) Use(cb listSecretFunc)                                 { mock.handler = cb }
+func (mock *mockCreatePipelineTemplate) Use(cb createPipelineTemplateFunc)         { mock.handler = cb }
+func (mock *mockInspectPipelineTemplate) Use(cb inspectPipelineTemplateFunc)       { mock.handler = cb }
+func (mock *mockListPipelineTemplate) Use(cb listPipelineTemplateFunc)             { mock.handler = cb }
+func (mock *mockDeletePipelineTemplate) Use(cb deletePipelineTemplateFunc)         { mock.handler = cb }
+func (mock *mockCreatePipelineFromTemplate) Use(cb createPipelineFromTemplateFunc) { mock.handler = cb }
+func (mock *mockCreateQueue) Use(cb createQueueFunc)                               { mock.handler = cb }
+func (mock *mockInspectQueue) Use(cb inspectQueueFunc)                             { mock.handler = cb }
+func (mock *mockListQueue) Use(cb listQueueFunc)                                   { mock.handler = cb }
+func (mock *mockDeleteQueue) Use(cb deleteQueueFunc)                               { mock.handler = cb }
+func (mock *mockCreateAlert) Use(cb createAlertFunc)                               { mock.handler = cb }
+func (mock *mockInspectAlert) Use(cb inspectAlertFunc)                             { mock.handler = cb }
+func (mock *mockListAlert) Use(cb listAlertFunc)                                   { mock.handler = cb }
+func (mock *mockDeleteAlert) Use(cb deleteAlertFunc)                               { mock.handler = cb }
+func (mock *mockListUsage) Use(cb listUsageFunc)                                   { mock.handler = cb }
+func (mock *mockDeleteAllPPS) Use(cb deleteAllPPSFunc)                             { mock.handler = cb }
+func (mock *mockGetLogs) Use(cb getLogsFunc)                                       { mock.handler = cb }
+func (mock *mockGarbageCollect) Use(cb garbageCollectFunc)                         { mock.handler = cb }
+func (mock *mockActivateAuthPPS) Use(cb activateAuthPPSFunc)                       { mock.handler = cb }
 
 type ppsServerAPI struct {
 	mock *mockPPSServer
 }
 
 type mockPPSServer struct {
-	api             ppsServerAPI
-	CreateJob       mockCreateJob
-	InspectJob      mockInspectJob
-	ListJob         mockListJob
-	ListJobStream   mockListJobStream
-	FlushJob        mockFlushJob
-	DeleteJob       mockDeleteJob
-	StopJob         mockStopJob
-	UpdateJobState  mockUpdateJobState
-	InspectDatum    mockInspectDatum
-	ListDatum       mockListDatum
-	ListDatumStream mockListDatumStream
-	RestartDatum    mockRestartDatum
-	CreatePipeline  mockCreatePipeline
-	InspectPipeline mockInspectPipeline
-	ListPipeline    mockListPipeline
-	DeletePipeline  mockDeletePipeline
-	StartPipeline   mockStartPipeline
-	StopPipeline    mockStopPipeline
-	RunPipeline     mockRunPipeline
-	RunCron         mockRunCron
-	CreateSecret    mockCreateSecret
-	DeleteSecret    mockDeleteSecret
-	InspectSecret   mockInspectSecret
-	ListSecret      mockListSecret
-	DeleteAll       mockDeleteAllPPS
-	GetLogs         mockGetLogs
-	GarbageCollect  mockGarbageCollect
-	ActivateAuth    mockActivateAuthPPS
+	api                        ppsServerAPI
+	CreateJob                  mockCreateJob
+	InspectJob                 mockInspectJob
+	ListJob                    mockListJob
+	ListJobStream              mockListJobStream
+	FlushJob                   mockFlushJob
+	DiffJob                    mockDiffJob
+	RerunJob                   mockRerunJob
+	DeleteJob                  mockDeleteJob
+	StopJob                    mockStopJob
+	UpdateJobState             mockUpdateJobState
+	InspectDatum               mockInspectDatum
+	ListDatum                  mockListDatum
+	ListDatumStream            mockListDatumStream
+	RestartDatum               mockRestartDatum
+	CreatePipeline             mockCreatePipeline
+	DryRunPipeline             mockDryRunPipeline
+	InspectPipeline            mockInspectPipeline
+	ListPipeline               mockListPipeline
+	DeletePipeline             mockDeletePipeline
+	StartPipeline              mockStartPipeline
+	StopPipeline               mockStopPipeline
+	RunPipeline                mockRunPipeline
+	RunCron                    mockRunCron
+	InspectDAG                 mockInspectDAG
+	CreateSecret               mockCreateSecret
+	DeleteSecret               mockDeleteSecret
+	InspectSecret              mockInspectSecret
+	ListSecret                 mockListSecret
+	CreatePipelineTemplate     mockCreatePipelineTemplate
+	InspectPipelineTemplate    mockInspectPipelineTemplate
+	ListPipelineTemplate       mockListPipelineTemplate
+	DeletePipelineTemplate     mockDeletePipelineTemplate
+	CreatePipelineFromTemplate mockCreatePipelineFromTemplate
+	CreateQueue                mockCreateQueue
+	InspectQueue               mockInspectQueue
+	ListQueue                  mockListQueue
+	DeleteQueue                mockDeleteQueue
+	CreateAlert                mockCreateAlert
+	InspectAlert               mockInspectAlert
+	ListAlert                  mockListAlert
+	DeleteAlert                mockDeleteAlert
+	ListUsage                  mockListUsage
+	DeleteAll                  mockDeleteAllPPS
+	GetLogs                    mockGetLogs
+	GarbageCollect             mockGarbageCollect
+	ActivateAuth               mockActivateAuthPPS
 }
 
 func (api *ppsServerAPI) CreateJob(ctx context.Context, req *pps.CreateJobRequest) (*pps.Job, error) {
@@ -904,6 +988,18 @@ func (api *ppsServerAPI) FlushJob(req *pps.FlushJobRequest, serv pps.API_FlushJo
 	}
 	return errors.Errorf("unhandled pachd mock pps.FlushJob")
 }
+func (api *ppsServerAPI) DiffJob(ctx context.Context, req *pps.DiffJobRequest) (*pps.JobDiff, error) {
+	if api.mock.DiffJob.handler != nil {
+		return api.mock.DiffJob.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.DiffJob")
+}
+func (api *ppsServerAPI) RerunJob(ctx context.Context, req *pps.RerunJobRequest) (*pps.RerunJobResponse, error) {
+	if api.mock.RerunJob.handler != nil {
+		return api.mock.RerunJob.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.RerunJob")
+}
 func (api *ppsServerAPI) DeleteJob(ctx context.Context, req *pps.DeleteJobRequest) (*types.Empty, error) {
 	if api.mock.DeleteJob.handler != nil {
 		return api.mock.DeleteJob.handler(ctx, req)
@@ -952,6 +1048,12 @@ func (api *ppsServerAPI) CreatePipeline(ctx context.Context, req *pps.CreatePipe
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.CreatePipeline")
 }
+func (api *ppsServerAPI) DryRunPipeline(ctx context.Context, req *pps.DryRunPipelineRequest) (*pps.DryRunPipelineResponse, error) {
+	if api.mock.DryRunPipeline.handler != nil {
+		return api.mock.DryRunPipeline.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.DryRunPipeline")
+}
 func (api *ppsServerAPI) InspectPipeline(ctx context.Context, req *pps.InspectPipelineRequest) (*pps.PipelineInfo, error) {
 	if api.mock.InspectPipeline.handler != nil {
 		return api.mock.InspectPipeline.handler(ctx, req)
@@ -994,6 +1096,12 @@ func (api *ppsServerAPI) RunCron(ctx context.Context, req *pps.RunCronRequest) (
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.RunCron")
 }
+func (api *ppsServerAPI) InspectDAG(ctx context.Context, req *pps.InspectDAGRequest) (*pps.DAGInfo, error) {
+	if api.mock.InspectDAG.handler != nil {
+		return api.mock.InspectDAG.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.InspectDAG")
+}
 func (api *ppsServerAPI) CreateSecret(ctx context.Context, req *pps.CreateSecretRequest) (*types.Empty, error) {
 	if api.mock.CreateSecret.handler != nil {
 		return api.mock.CreateSecret.handler(ctx, req)
@@ -1018,6 +1126,90 @@ func (api *ppsServerAPI) ListSecret(ctx context.Context, in *types.Empty) (*pps.
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.ListSecret")
 }
+func (api *ppsServerAPI) CreatePipelineTemplate(ctx context.Context, req *pps.CreatePipelineTemplateRequest) (*types.Empty, error) {
+	if api.mock.CreatePipelineTemplate.handler != nil {
+		return api.mock.CreatePipelineTemplate.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.CreatePipelineTemplate")
+}
+func (api *ppsServerAPI) InspectPipelineTemplate(ctx context.Context, req *pps.InspectPipelineTemplateRequest) (*pps.PipelineTemplateInfo, error) {
+	if api.mock.InspectPipelineTemplate.handler != nil {
+		return api.mock.InspectPipelineTemplate.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.InspectPipelineTemplate")
+}
+func (api *ppsServerAPI) ListPipelineTemplate(ctx context.Context, req *pps.ListPipelineTemplateRequest) (*pps.PipelineTemplateInfos, error) {
+	if api.mock.ListPipelineTemplate.handler != nil {
+		return api.mock.ListPipelineTemplate.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.ListPipelineTemplate")
+}
+func (api *ppsServerAPI) DeletePipelineTemplate(ctx context.Context, req *pps.DeletePipelineTemplateRequest) (*types.Empty, error) {
+	if api.mock.DeletePipelineTemplate.handler != nil {
+		return api.mock.DeletePipelineTemplate.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.DeletePipelineTemplate")
+}
+func (api *ppsServerAPI) CreatePipelineFromTemplate(ctx context.Context, req *pps.CreatePipelineFromTemplateRequest) (*types.Empty, error) {
+	if api.mock.CreatePipelineFromTemplate.handler != nil {
+		return api.mock.CreatePipelineFromTemplate.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.CreatePipelineFromTemplate")
+}
+func (api *ppsServerAPI) CreateQueue(ctx context.Context, req *pps.CreateQueueRequest) (*types.Empty, error) {
+	if api.mock.CreateQueue.handler != nil {
+		return api.mock.CreateQueue.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.CreateQueue")
+}
+func (api *ppsServerAPI) InspectQueue(ctx context.Context, req *pps.InspectQueueRequest) (*pps.QueueInfo, error) {
+	if api.mock.InspectQueue.handler != nil {
+		return api.mock.InspectQueue.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.InspectQueue")
+}
+func (api *ppsServerAPI) ListQueue(ctx context.Context, req *pps.ListQueueRequest) (*pps.QueueInfos, error) {
+	if api.mock.ListQueue.handler != nil {
+		return api.mock.ListQueue.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.ListQueue")
+}
+func (api *ppsServerAPI) DeleteQueue(ctx context.Context, req *pps.DeleteQueueRequest) (*types.Empty, error) {
+	if api.mock.DeleteQueue.handler != nil {
+		return api.mock.DeleteQueue.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.DeleteQueue")
+}
+func (api *ppsServerAPI) CreateAlert(ctx context.Context, req *pps.CreateAlertRequest) (*types.Empty, error) {
+	if api.mock.CreateAlert.handler != nil {
+		return api.mock.CreateAlert.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.CreateAlert")
+}
+func (api *ppsServerAPI) InspectAlert(ctx context.Context, req *pps.InspectAlertRequest) (*pps.AlertInfo, error) {
+	if api.mock.InspectAlert.handler != nil {
+		return api.mock.InspectAlert.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.InspectAlert")
+}
+func (api *ppsServerAPI) ListAlert(ctx context.Context, req *pps.ListAlertRequest) (*pps.AlertInfos, error) {
+	if api.mock.ListAlert.handler != nil {
+		return api.mock.ListAlert.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.ListAlert")
+}
+func (api *ppsServerAPI) DeleteAlert(ctx context.Context, req *pps.DeleteAlertRequest) (*types.Empty, error) {
+	if api.mock.DeleteAlert.handler != nil {
+		return api.mock.DeleteAlert.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.DeleteAlert")
+}
+func (api *ppsServerAPI) ListUsage(ctx context.Context, req *pps.ListUsageRequest) (*pps.UsageInfos, error) {
+	if api.mock.ListUsage.handler != nil {
+		return api.mock.ListUsage.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.ListUsage")
+}
 func (api *ppsServerAPI) DeleteAll(ctx context.Context, req *types.Empty) (*types.Empty, error) {
 	if api.mock.DeleteAll.handler != nil {
 		return api.mock.DeleteAll.handler(ctx, req)
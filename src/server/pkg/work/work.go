@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"sync"
 	"sync/atomic"
 
 	etcd "github.com/coreos/etcd/clientv3"
@@ -22,6 +23,16 @@ const (
 	claimPrefix   = "/claim"
 )
 
+// ErrPreempted is returned by a ProcessFunc when the worker is shutting down
+// before it could finish the subtask. The subtask is marked as preempted
+// rather than failed, and is processed again by another worker.
+var ErrPreempted = errors.New("subtask preempted")
+
+// pending returns whether a subtask in state still needs to be processed.
+func pending(state State) bool {
+	return state == State_RUNNING || state == State_PREEMPTED
+}
+
 // TaskQueue manages a set of parallel tasks, and provides an interface for running tasks.
 // Priority of tasks (and therefore subtasks) is based on task creation time, so tasks created
 // earlier will be prioritized over tasks that were created later.
@@ -164,7 +175,7 @@ func (m *Master) RunSubtasksChan(subtaskChan chan *Task, collectFunc CollectFunc
 				return err
 			}
 			// Check that the subtask state is terminal.
-			if subtaskInfo.State == State_RUNNING {
+			if pending(subtaskInfo.State) {
 				return nil
 			}
 			if collectFunc != nil {
@@ -248,6 +259,21 @@ func (tq *TaskQueue) deleteAllTasks() error {
 	return err
 }
 
+// PendingSubtasks calls f with each subtask in the task namespace that hasn't
+// been processed yet, including subtasks that are being processed. It doesn't
+// need a TaskQueue, so that the size of a queue can be monitored by processes
+// that don't run tasks.
+func PendingSubtasks(ctx context.Context, etcdClient *etcd.Client, etcdPrefix string, taskNamespace string, f func(*Task) error) error {
+	subtaskCol := newTaskEtcd(etcdClient, etcdPrefix, taskNamespace).subtaskCol
+	subtaskInfo := &TaskInfo{}
+	return subtaskCol.ReadOnly(ctx).List(subtaskInfo, col.DefaultOptions, func(string) error {
+		if !pending(subtaskInfo.State) {
+			return nil
+		}
+		return f(subtaskInfo.Task)
+	})
+}
+
 // Worker is a worker that will process subtasks in a task.
 // A worker watches the task collection for tasks to be created / deleted and appropriately
 // runs / deletes tasks in the internal task queue with a function that watches the
@@ -256,6 +282,12 @@ func (tq *TaskQueue) deleteAllTasks() error {
 // in the task.
 type Worker struct {
 	*taskEtcd
+
+	// mu guards preempted, which stops the worker from claiming subtasks, and
+	// processing tracks the subtasks that the worker is processing.
+	mu         sync.Mutex
+	preempted  bool
+	processing sync.WaitGroup
 }
 
 // NewWorker creates a new worker.
@@ -288,6 +320,39 @@ func (w *Worker) Run(ctx context.Context, processFunc ProcessFunc) error {
 	})
 }
 
+// Preempt stops the worker from claiming new subtasks, and waits for the
+// subtasks that it's processing to finish, or for ctx to be done. The
+// ProcessFunc of those subtasks should return ErrPreempted promptly, so that
+// they're processed again by another worker.
+func (w *Worker) Preempt(ctx context.Context) error {
+	w.mu.Lock()
+	w.preempted = true
+	w.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		w.processing.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// startSubtask returns false if the worker has been preempted, otherwise it
+// tracks a subtask until the returned func is called.
+func (w *Worker) startSubtask() (func(), bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.preempted {
+		return nil, false
+	}
+	w.processing.Add(1)
+	return w.processing.Done, true
+}
+
 func (w *Worker) taskFunc(task *Task, taskEntry *taskEntry, processFunc ProcessFunc) error {
 	claimWatch, err := w.claimCol.ReadOnly(taskEntry.ctx).WatchOne(task.ID, watch.WithFilterPut())
 	if err != nil {
@@ -328,6 +393,11 @@ func (w *Worker) taskFunc(task *Task, taskEntry *taskEntry, processFunc ProcessF
 
 func (w *Worker) subtaskFunc(subtaskKey string, processFunc ProcessFunc) subtaskFunc {
 	return func(ctx context.Context) {
+		done, ok := w.startSubtask()
+		if !ok {
+			return
+		}
+		defer done()
 		if err := func() error {
 			// (bryce) this should be refactored to have the check and claim in the same stm.
 			// there is a rare race condition that does not affect correctness, but it is less
@@ -338,7 +408,7 @@ func (w *Worker) subtaskFunc(subtaskKey string, processFunc ProcessFunc) subtask
 			}); err != nil {
 				return err
 			}
-			if subtaskInfo.State != State_RUNNING {
+			if !pending(subtaskInfo.State) {
 				return nil
 			}
 			return w.claimCol.Claim(ctx, subtaskKey, &Claim{}, func(claimCtx context.Context) (retErr error) {
@@ -349,22 +419,35 @@ func (w *Worker) subtaskFunc(subtaskKey string, processFunc ProcessFunc) subtask
 						retErr = nil
 						return
 					}
+					preempted := errors.Is(retErr, ErrPreempted)
 					subtaskInfo := &TaskInfo{}
 					if _, err := col.NewSTM(claimCtx, w.etcdClient, func(stm col.STM) error {
-						return w.subtaskCol.ReadWrite(stm).Update(subtaskKey, subtaskInfo, func() error {
+						if err := w.subtaskCol.ReadWrite(stm).Update(subtaskKey, subtaskInfo, func() error {
 							// (bryce) remove when check and claim are in the same stm.
-							if subtaskInfo.State != State_RUNNING {
+							if !pending(subtaskInfo.State) {
 								return nil
 							}
 							subtaskInfo.Task = subtask
 							subtaskInfo.State = State_SUCCESS
-							if retErr != nil {
+							if preempted {
+								subtaskInfo.State = State_PREEMPTED
+								subtaskInfo.Reason = ErrPreempted.Error()
+								retErr = nil
+							} else if retErr != nil {
 								subtaskInfo.State = State_FAILURE
 								subtaskInfo.Reason = retErr.Error()
 								retErr = nil
 							}
 							return nil
-						})
+						}); err != nil {
+							return err
+						}
+						if !preempted {
+							return nil
+						}
+						// Release the claim, so that another worker can process the
+						// subtask right away.
+						return w.claimCol.ReadWrite(stm).Delete(subtaskKey)
 					}); retErr == nil {
 						retErr = err
 					}
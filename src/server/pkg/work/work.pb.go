@@ -30,18 +30,23 @@ const (
 	State_RUNNING State = 0
 	State_SUCCESS State = 1
 	State_FAILURE State = 2
+	// PREEMPTED subtasks were interrupted by their worker shutting down. They
+	// aren't done, and are processed again by another worker.
+	State_PREEMPTED State = 3
 )
 
 var State_name = map[int32]string{
 	0: "RUNNING",
 	1: "SUCCESS",
 	2: "FAILURE",
+	3: "PREEMPTED",
 }
 
 var State_value = map[string]int32{
-	"RUNNING": 0,
-	"SUCCESS": 1,
-	"FAILURE": 2,
+	"RUNNING":   0,
+	"SUCCESS":   1,
+	"FAILURE":   2,
+	"PREEMPTED": 3,
 }
 
 func (x State) String() string {
@@ -267,28 +272,29 @@ func init() {
 func init() { proto.RegisterFile("server/pkg/work/work.proto", fileDescriptor_58a68e4647f78187) }
 
 var fileDescriptor_58a68e4647f78187 = []byte{
-	// 332 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0xcb, 0x6a, 0xe3, 0x30,
-	0x14, 0x86, 0xc7, 0x1e, 0xe7, 0x76, 0x0c, 0x43, 0x10, 0x21, 0x64, 0xc2, 0xe0, 0x49, 0xbd, 0x32,
-	0x5d, 0xd8, 0xe0, 0xbe, 0x40, 0x73, 0x6b, 0x6b, 0x28, 0x59, 0xc8, 0xc9, 0xa6, 0x3b, 0xc5, 0x56,
-	0x1c, 0xe3, 0xc4, 0x32, 0x92, 0xd2, 0x92, 0x37, 0xec, 0xb2, 0x4f, 0x50, 0x8a, 0x9f, 0xa4, 0x48,
-	0x6e, 0x69, 0xe9, 0x46, 0xfc, 0x97, 0xc3, 0xc7, 0x91, 0x04, 0x63, 0x41, 0xf9, 0x23, 0xe5, 0x41,
-	0x55, 0x64, 0xc1, 0x13, 0xe3, 0x85, 0x3e, 0xfc, 0x8a, 0x33, 0xc9, 0x90, 0xa5, 0xf4, 0x78, 0x90,
-	0xb1, 0x8c, 0xe9, 0x20, 0x50, 0xaa, 0xe9, 0xc6, 0x7f, 0x33, 0xc6, 0xb2, 0x03, 0x0d, 0xb4, 0xdb,
-	0x9e, 0x76, 0x01, 0x29, 0xcf, 0x4d, 0xe5, 0xde, 0x81, 0xb5, 0x26, 0xa2, 0x40, 0x43, 0x30, 0xf3,
-	0x74, 0x64, 0x4c, 0x0c, 0xaf, 0x37, 0x6b, 0xd7, 0xaf, 0xff, 0xcd, 0x68, 0x81, 0xcd, 0x3c, 0x45,
-	0x1e, 0x58, 0x29, 0x91, 0x64, 0x64, 0x4e, 0x0c, 0xcf, 0x0e, 0x07, 0x7e, 0x43, 0xf2, 0x3f, 0x49,
-	0xfe, 0xb4, 0x3c, 0x63, 0x3d, 0xe1, 0x52, 0xe8, 0x2a, 0x52, 0x54, 0xee, 0x18, 0x72, 0xc0, 0x92,
-	0x44, 0x14, 0x9a, 0x67, 0x87, 0xe0, 0xeb, 0x3d, 0x55, 0x8b, 0x75, 0x8e, 0x2e, 0xa0, 0x25, 0x24,
-	0x91, 0x54, 0x63, 0xff, 0x84, 0x76, 0x33, 0x10, 0xab, 0x08, 0x37, 0x0d, 0x1a, 0x42, 0x9b, 0x53,
-	0x22, 0x58, 0x39, 0xfa, 0xad, 0x96, 0xc2, 0x1f, 0xce, 0xed, 0x40, 0x6b, 0x7e, 0x20, 0xf9, 0xd1,
-	0xf5, 0xa0, 0xbb, 0xa6, 0x42, 0x2e, 0x88, 0x24, 0xe8, 0x1f, 0xf4, 0x2a, 0xce, 0x12, 0x2a, 0x04,
-	0x6d, 0x2e, 0xd1, 0xc5, 0x5f, 0xc1, 0xa5, 0x0f, 0x2d, 0x8d, 0x46, 0x36, 0x74, 0xf0, 0x66, 0xb5,
-	0x8a, 0x56, 0xb7, 0xfd, 0x5f, 0xca, 0xc4, 0x9b, 0xf9, 0x7c, 0x19, 0xc7, 0x7d, 0x43, 0x99, 0x9b,
-	0x69, 0x74, 0xbf, 0xc1, 0xcb, 0xbe, 0x39, 0xbb, 0x7e, 0xae, 0x1d, 0xe3, 0xa5, 0x76, 0x8c, 0xb7,
-	0xda, 0x31, 0x1e, 0xc2, 0x2c, 0x97, 0xfb, 0xd3, 0xd6, 0x4f, 0xd8, 0x31, 0xa8, 0x48, 0xb2, 0x3f,
-	0xa7, 0x94, 0x7f, 0x57, 0x82, 0x27, 0xc1, 0x8f, 0x7f, 0xd9, 0xb6, 0xf5, 0xfb, 0x5c, 0xbd, 0x07,
-	0x00, 0x00, 0xff, 0xff, 0xec, 0xad, 0x45, 0x2a, 0xb1, 0x01, 0x00, 0x00,
+	// 344 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0x4d, 0x6b, 0xdb, 0x30,
+	0x18, 0xc7, 0x67, 0xc7, 0x79, 0x93, 0xd9, 0x30, 0x22, 0x84, 0x2c, 0x0c, 0x2f, 0xf3, 0xc9, 0xec,
+	0x60, 0x83, 0x77, 0x1e, 0x34, 0x2f, 0x6e, 0x6b, 0x68, 0x43, 0x90, 0x93, 0x4b, 0x6f, 0x8a, 0xad,
+	0x38, 0xc6, 0x89, 0x65, 0x24, 0xa5, 0x25, 0xdf, 0xb0, 0xc7, 0x7e, 0x82, 0x52, 0xfc, 0x49, 0x8a,
+	0xe4, 0x96, 0x96, 0x5e, 0xc4, 0xff, 0xe5, 0xe1, 0xc7, 0x23, 0x09, 0x8c, 0x39, 0x61, 0xf7, 0x84,
+	0xf9, 0x55, 0x91, 0xf9, 0x0f, 0x94, 0x15, 0xea, 0xf0, 0x2a, 0x46, 0x05, 0x85, 0x86, 0xd4, 0xe3,
+	0x41, 0x46, 0x33, 0xaa, 0x02, 0x5f, 0xaa, 0xa6, 0x1b, 0xff, 0xcc, 0x28, 0xcd, 0x0e, 0xc4, 0x57,
+	0x6e, 0x7b, 0xda, 0xf9, 0xb8, 0x3c, 0x37, 0x95, 0x73, 0x0d, 0x8c, 0x35, 0xe6, 0x05, 0x1c, 0x02,
+	0x3d, 0x4f, 0x47, 0xda, 0x44, 0x73, 0xfb, 0xb3, 0x4e, 0xfd, 0xfc, 0x5b, 0x8f, 0x16, 0x48, 0xcf,
+	0x53, 0xe8, 0x02, 0x23, 0xc5, 0x02, 0x8f, 0xf4, 0x89, 0xe6, 0x9a, 0xc1, 0xc0, 0x6b, 0x48, 0xde,
+	0x3b, 0xc9, 0x9b, 0x96, 0x67, 0xa4, 0x26, 0x1c, 0x02, 0x7a, 0x92, 0x14, 0x95, 0x3b, 0x0a, 0x6d,
+	0x60, 0x08, 0xcc, 0x0b, 0xc5, 0x33, 0x03, 0xe0, 0xa9, 0x3d, 0x65, 0x8b, 0x54, 0x0e, 0xff, 0x80,
+	0x36, 0x17, 0x58, 0x10, 0x85, 0xfd, 0x11, 0x98, 0xcd, 0x40, 0x2c, 0x23, 0xd4, 0x34, 0x70, 0x08,
+	0x3a, 0x8c, 0x60, 0x4e, 0xcb, 0x51, 0x4b, 0x2e, 0x85, 0xde, 0x9c, 0xd3, 0x05, 0xed, 0xf9, 0x01,
+	0xe7, 0x47, 0xc7, 0x05, 0xbd, 0x35, 0xe1, 0x62, 0x81, 0x05, 0x86, 0xbf, 0x40, 0xbf, 0x62, 0x34,
+	0x21, 0x9c, 0x93, 0xe6, 0x12, 0x3d, 0xf4, 0x11, 0xfc, 0xfd, 0x0f, 0xda, 0x0a, 0x0d, 0x4d, 0xd0,
+	0x45, 0x9b, 0xe5, 0x32, 0x5a, 0x5e, 0x59, 0xdf, 0xa4, 0x89, 0x37, 0xf3, 0x79, 0x18, 0xc7, 0x96,
+	0x26, 0xcd, 0xe5, 0x34, 0xba, 0xd9, 0xa0, 0xd0, 0xd2, 0xe1, 0x77, 0xd0, 0x5f, 0xa1, 0x30, 0xbc,
+	0x5d, 0xad, 0xc3, 0x85, 0xd5, 0x9a, 0x5d, 0x3c, 0xd6, 0xb6, 0xf6, 0x54, 0xdb, 0xda, 0x4b, 0x6d,
+	0x6b, 0x77, 0x41, 0x96, 0x8b, 0xfd, 0x69, 0xeb, 0x25, 0xf4, 0xe8, 0x57, 0x38, 0xd9, 0x9f, 0x53,
+	0xc2, 0x3e, 0x2b, 0xce, 0x12, 0xff, 0xcb, 0x37, 0x6d, 0x3b, 0xea, 0xb9, 0xfe, 0xbd, 0x0e, 0x00,
+	0xa4, 0x2a, 0xc6, 0x9e, 0xc0, 0x01, 0x00, 0x00,
 }
 
 func (m *Task) Marshal() (dAtA []byte, err error) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -200,3 +201,96 @@ func TestRunZeroSubtasks(t *testing.T) {
 		})
 	}))
 }
+
+func TestPendingSubtasks(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		pending := func() int {
+			var count int
+			require.NoError(t, PendingSubtasks(context.Background(), env.EtcdClient, "", "", func(*Task) error {
+				count++
+				return nil
+			}))
+			return count
+		}
+		require.Equal(t, 0, pending())
+		tq, err := NewTaskQueue(context.Background(), env.EtcdClient, "", "")
+		require.NoError(t, err)
+		var subtasks []*Task
+		for i := 0; i < 3; i++ {
+			data, err := serializeTestData(&TestData{})
+			require.NoError(t, err)
+			subtasks = append(subtasks, &Task{ID: strconv.Itoa(i), Data: data})
+		}
+		var eg errgroup.Group
+		eg.Go(func() error {
+			return tq.RunTaskBlock(context.Background(), func(m *Master) error {
+				return m.RunSubtasks(subtasks, nil)
+			})
+		})
+		require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+			if n := pending(); n != 3 {
+				return errors.Errorf("expected 3 pending subtasks, got %d", n)
+			}
+			return nil
+		})
+		// Processed subtasks are no longer pending.
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go NewWorker(env.EtcdClient, "", "").Run(ctx, func(_ context.Context, subtask *Task) error {
+			return processSubtask(t, subtask)
+		})
+		require.NoError(t, eg.Wait())
+		require.Equal(t, 0, pending())
+		return nil
+	}))
+}
+
+func TestPreempt(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		tq, err := NewTaskQueue(context.Background(), env.EtcdClient, "", "")
+		require.NoError(t, err)
+		data, err := serializeTestData(&TestData{})
+		require.NoError(t, err)
+		var states []State
+		var eg errgroup.Group
+		eg.Go(func() error {
+			return tq.RunTaskBlock(context.Background(), func(m *Master) error {
+				return m.RunSubtasks([]*Task{{ID: "0", Data: data}}, func(_ context.Context, subtaskInfo *TaskInfo) error {
+					states = append(states, subtaskInfo.State)
+					return nil
+				})
+			})
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		// The first worker is preempted while it processes the subtask.
+		preempted := NewWorker(env.EtcdClient, "", "")
+		claimed, release := make(chan struct{}), make(chan struct{})
+		var once sync.Once
+		go preempted.Run(ctx, func(_ context.Context, _ *Task) error {
+			once.Do(func() { close(claimed) })
+			<-release
+			return ErrPreempted
+		})
+		<-claimed
+		var preemptEg errgroup.Group
+		preemptEg.Go(func() error {
+			return preempted.Preempt(ctx)
+		})
+		close(release)
+		require.NoError(t, preemptEg.Wait())
+		// The subtask is still pending, and is processed by another worker.
+		var count int
+		require.NoError(t, PendingSubtasks(ctx, env.EtcdClient, "", "", func(*Task) error {
+			count++
+			return nil
+		}))
+		require.Equal(t, 1, count)
+		go NewWorker(env.EtcdClient, "", "").Run(ctx, func(_ context.Context, subtask *Task) error {
+			return processSubtask(t, subtask)
+		})
+		require.NoError(t, eg.Wait())
+		require.Equal(t, []State{State_SUCCESS}, states)
+		return nil
+	}))
+}
@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	pachdclient "github.com/pachyderm/pachyderm/src/client"
@@ -29,6 +32,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/tabwriter"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 	"github.com/pachyderm/pachyderm/src/server/pps/pretty"
+	"github.com/pachyderm/pachyderm/src/server/worker/local"
 
 	prompt "github.com/c-bata/go-prompt"
 	units "github.com/docker/go-units"
@@ -326,6 +330,96 @@ $ {{alias}} foo@XXX -p bar -p baz`,
 	shell.RegisterCompletionFunc(stopJob, shell.JobCompletion)
 	commands = append(commands, cmdutil.CreateAlias(stopJob, "stop job"))
 
+	var diffLimit int64
+	diffJob := &cobra.Command{
+		Use:   "{{alias}} <job-a> <job-b>",
+		Short: "Show the differences between two jobs.",
+		Long: `Show the differences between two jobs: the fields of their pipelines' specs that changed, the input commits they read, the datums that were added, removed or changed, and, once both jobs have finished, the files that differ in their output commits.
+
+Datums are matched by the paths of their input files, and changed if the content of any of those files changed.`,
+		Example: `
+# Show why job 2 produced different output from job 1
+$ {{alias}} 1 2
+
+# Show up to 10 datums and files of each kind
+$ {{alias}} 1 2 --limit 10`,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			jobDiff, err := client.DiffJob(args[0], args[1], diffLimit)
+			if err != nil {
+				cmdutil.ErrorAndExit("error from DiffJob: %s", err.Error())
+			}
+			if raw {
+				return encoder(output).EncodeProto(jobDiff)
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			pretty.PrintJobDiff(os.Stdout, jobDiff)
+			return nil
+		}),
+	}
+	diffJob.Flags().Int64Var(&diffLimit, "limit", 100, "The most datums and files of each kind (added, removed, changed) to show.")
+	diffJob.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(diffJob, shell.JobCompletion)
+	commands = append(commands, cmdutil.CreateAlias(diffJob, "diff job"))
+
+	var specPatchPath string
+	var rerunPipeline string
+	rerunJob := &cobra.Command{
+		Use:   "{{alias}} <job>",
+		Short: "Rerun a job's inputs with a modified pipeline spec.",
+		Long: `Rerun a job's inputs with a modified pipeline spec, to try out a change before updating the pipeline.
+
+The job's pipeline spec is patched with --with-spec, a JSON merge patch (RFC 7386), and created as a new scratch pipeline, named "<pipeline>-rerun-<job>" by default. Each of the scratch pipeline's inputs reads a branch, named after the scratch pipeline, that points at the commit the job read, so it processes exactly the same data. The original pipeline and its output are untouched.
+
+The scratch pipeline doesn't egress its output or fire alerts. Delete it, and its branches, once you're done.`,
+		Example: `
+# Rerun a job with a different image
+$ echo '{"transform": {"image": "edges:v2"}}' > patch.json
+$ {{alias}} 0f3ec27a --with-spec patch.json
+
+# Compare the rerun to the original job
+$ pachctl list job -p edges-rerun-0f3ec27a
+$ pachctl diff job 0f3ec27a <rerun-job>`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			var specPatch string
+			if specPatchPath != "" {
+				var err error
+				if specPatch, err = readTemplateSpec(specPatchPath); err != nil {
+					return err
+				}
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			response, err := client.RerunJob(args[0], specPatch, rerunPipeline)
+			if err != nil {
+				cmdutil.ErrorAndExit("error from RerunJob: %s", err.Error())
+			}
+			fmt.Printf("Created scratch pipeline %s\n", response.Pipeline.Name)
+			for _, branch := range response.Branches {
+				fmt.Printf("Created branch %s@%s\n", branch.Repo.Name, branch.Name)
+			}
+			fmt.Printf("Run 'pachctl list job -p %s' to find the rerun job, and 'pachctl diff job %s <job>' to compare it to the original.\n", response.Pipeline.Name, args[0])
+			fmt.Printf("When you're done, run 'pachctl delete pipeline %s'", response.Pipeline.Name)
+			for _, branch := range response.Branches {
+				fmt.Printf(" and 'pachctl delete branch %s@%s'", branch.Repo.Name, branch.Name)
+			}
+			fmt.Println(".")
+			return nil
+		}),
+	}
+	rerunJob.Flags().StringVar(&specPatchPath, "with-spec", "", "A JSON merge patch to apply to the job's pipeline spec, it can be a url or local file. - reads from stdin.")
+	rerunJob.Flags().StringVar(&rerunPipeline, "pipeline", "", "The name of the scratch pipeline, defaults to <pipeline>-rerun-<job>.")
+	shell.RegisterCompletionFunc(rerunJob, shell.JobCompletion)
+	commands = append(commands, cmdutil.CreateAlias(rerunJob, "rerun job"))
+
 	datumDocs := &cobra.Command{
 		Short: "Docs for datums.",
 		Long: `Datums are the small independent units of processing for Pachyderm jobs.
@@ -570,10 +664,33 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	var registry string
 	var username string
 	var pipelinePath string
+	var templateName string
+	var params []string
+	var dryRun bool
+	var dryRunCommits []string
+	var dryRunSample int64
 	createPipeline := &cobra.Command{
 		Short: "Create a new pipeline.",
-		Long:  "Create a new pipeline from a pipeline specification. For details on the format, see http://docs.pachyderm.io/en/latest/reference/pipeline_spec.html.",
+		Long:  "Create a new pipeline from a pipeline specification, or from a pipeline template. For details on the format, see http://docs.pachyderm.io/en/latest/reference/pipeline_spec.html.",
+		Example: `
+# Create a pipeline from a spec
+$ {{alias}} -f pipeline.json
+
+# Create a pipeline from the template "edges", filling in its parameters
+$ {{alias}} --template edges --param name=edges-small --param parallelism=2
+
+# Check a spec, and preview the datums it would process, without creating it
+$ {{alias}} -f pipeline.json --dry-run`,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) (retErr error) {
+			if dryRun {
+				if templateName != "" {
+					return errors.New("--dry-run cannot be used with --template")
+				}
+				return dryRunPipelineHelper(pipelinePath, false, false, dryRunCommits, dryRunSample, raw, output)
+			}
+			if templateName != "" {
+				return templatePipelineHelper(templateName, params, false, false)
+			}
 			return pipelineHelper(false, build, pushImages, registry, username, pipelinePath, false)
 		}),
 	}
@@ -582,13 +699,51 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	createPipeline.Flags().BoolVarP(&pushImages, "push-images", "p", false, "If true, push local docker images into the docker registry.")
 	createPipeline.Flags().StringVarP(&registry, "registry", "r", "index.docker.io", "The registry to push images to.")
 	createPipeline.Flags().StringVarP(&username, "username", "u", "", "The username to push images as.")
+	createPipeline.Flags().StringVar(&templateName, "template", "", "Create the pipeline from this pipeline template, instead of from a spec.")
+	createPipeline.Flags().StringArrayVar(&params, "param", []string{}, "A parameter for --template, in the form <name>=<value>.")
+	createPipeline.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the spec and preview the datums the pipeline would process, without creating it.")
+	createPipeline.Flags().StringArrayVar(&dryRunCommits, "commit", []string{}, "With --dry-run, compute datums from this commit of an input repo, in the form <repo>@<commit>, instead of the head of the input's branch.")
+	createPipeline.Flags().Int64Var(&dryRunSample, "sample", 10, "With --dry-run, the number of datums to list.")
+	createPipeline.Flags().AddFlagSet(outputFlags)
 	commands = append(commands, cmdutil.CreateAlias(createPipeline, "create pipeline"))
 
 	var reprocess bool
+	var allFromTemplate bool
 	updatePipeline := &cobra.Command{
 		Short: "Update an existing Pachyderm pipeline.",
-		Long:  "Update a Pachyderm pipeline with a new pipeline specification. For details on the format, see http://docs.pachyderm.io/en/latest/reference/pipeline_spec.html.",
+		Long:  "Update a Pachyderm pipeline with a new pipeline specification, or with the latest version of a pipeline template. For details on the format, see http://docs.pachyderm.io/en/latest/reference/pipeline_spec.html.",
+		Example: `
+# Update a pipeline from a spec
+$ {{alias}} -f pipeline.json
+
+# Update the pipeline rendered from the template "edges" with these parameters
+$ {{alias}} --template edges --param name=edges-small --param parallelism=4
+
+# Update every pipeline created from the template "edges" to its latest
+# version, keeping each pipeline's parameters
+$ {{alias}} --template edges --all
+
+# Preview how many datums an update would process, and how many it would skip
+$ {{alias}} -f pipeline.json --dry-run`,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) (retErr error) {
+			if dryRun {
+				if templateName != "" {
+					return errors.New("--dry-run cannot be used with --template")
+				}
+				return dryRunPipelineHelper(pipelinePath, true, reprocess, dryRunCommits, dryRunSample, raw, output)
+			}
+			if allFromTemplate {
+				if templateName == "" {
+					return errors.New("--all can only be used with --template")
+				}
+				if len(params) > 0 {
+					return errors.New("--param cannot be used with --all")
+				}
+				return upgradeTemplatePipelines(templateName, reprocess)
+			}
+			if templateName != "" {
+				return templatePipelineHelper(templateName, params, true, reprocess)
+			}
 			return pipelineHelper(reprocess, build, pushImages, registry, username, pipelinePath, true)
 		}),
 	}
@@ -598,6 +753,13 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	updatePipeline.Flags().StringVarP(&registry, "registry", "r", "index.docker.io", "The registry to push images to.")
 	updatePipeline.Flags().StringVarP(&username, "username", "u", "", "The username to push images as.")
 	updatePipeline.Flags().BoolVar(&reprocess, "reprocess", false, "If true, reprocess datums that were already processed by previous version of the pipeline.")
+	updatePipeline.Flags().StringVar(&templateName, "template", "", "Update the pipeline from this pipeline template, instead of from a spec.")
+	updatePipeline.Flags().StringArrayVar(&params, "param", []string{}, "A parameter for --template, in the form <name>=<value>.")
+	updatePipeline.Flags().BoolVar(&allFromTemplate, "all", false, "Update every pipeline that was created from --template, with the parameters it was created with.")
+	updatePipeline.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the spec and preview the datums the update would process and skip, without updating the pipeline.")
+	updatePipeline.Flags().StringArrayVar(&dryRunCommits, "commit", []string{}, "With --dry-run, compute datums from this commit of an input repo, in the form <repo>@<commit>, instead of the head of the input's branch.")
+	updatePipeline.Flags().Int64Var(&dryRunSample, "sample", 10, "With --dry-run, the number of datums to list.")
+	updatePipeline.Flags().AddFlagSet(outputFlags)
 	commands = append(commands, cmdutil.CreateAlias(updatePipeline, "update pipeline"))
 
 	runPipeline := &cobra.Command{
@@ -655,6 +817,79 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	}
 	commands = append(commands, cmdutil.CreateAlias(runCron, "run cron"))
 
+	var localBranch string
+	var localCommits []string
+	var localDocker bool
+	var localDir string
+	runLocal := &cobra.Command{
+		Use:   "{{alias}} <pipeline.json>",
+		Short: "Run a pipeline's code on this machine, without Kubernetes.",
+		Long: `Run a pipeline's code on this machine, without Kubernetes. Its datums are computed from the current inputs in pachd, downloaded into /pfs-style directories and processed one at a time by the transform's cmd, run as a local process or, with --docker, in the pipeline's image. The output is written to a scratch branch of the pipeline's output repo (created if it doesn't exist), so the pipeline's real output is untouched.
+
+The local process runs in the current directory, and finds its inputs and output directory through the environment variables pachyderm sets (e.g. $PACH_OUTPUT_DIR) rather than at /pfs. Uploading output requires pachd to serve the object API, as dev clusters do ('pachctl deploy local' or --expose-object-api).`,
+		Example: `
+# Run the pipeline in edges.json on the head of its inputs' branches
+$ {{alias}} edges.json
+
+# Run it in its docker image, on a specific commit of the "images" repo
+$ {{alias}} edges.json --docker --commit images@6d5f2a
+
+# Look at the output
+$ pachctl list file edges@local`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			pipelineReader, err := ppsutil.NewPipelineManifestReader(args[0])
+			if err != nil {
+				return err
+			}
+			request, err := pipelineReader.NextCreatePipelineRequest()
+			if err != nil {
+				return err
+			}
+			if _, err := pipelineReader.NextCreatePipelineRequest(); !errors.Is(err, io.EOF) {
+				return errors.Errorf("%s must contain exactly one pipeline", args[0])
+			}
+			commits := make(map[string]string)
+			for _, arg := range localCommits {
+				commit, err := cmdutil.ParseCommit(arg)
+				if err != nil {
+					return err
+				}
+				if commit.ID == "" {
+					return errors.Errorf("invalid commit %q, must be <repo>@<commit>", arg)
+				}
+				commits[commit.Repo.Name] = commit.ID
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			result, err := local.Run(client, request, &local.Options{
+				Branch:  localBranch,
+				Commits: commits,
+				Docker:  localDocker,
+				Dir:     localDir,
+				// pachctl's --verbose also prints each step of processing a datum
+				Verbose: logrus.GetLevel() >= logrus.DebugLevel,
+			})
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			if result.Commit == nil {
+				fmt.Println("The pipeline has no datums, no output was written.")
+				return nil
+			}
+			fmt.Printf("Processed %d datums, output written to %s@%s (branch %s).\n",
+				result.Datums, result.Commit.Repo.Name, result.Commit.ID, localBranch)
+			return nil
+		}),
+	}
+	runLocal.Flags().StringVar(&localBranch, "branch", local.DefaultBranch, "The branch of the output repo to write output to.")
+	runLocal.Flags().StringArrayVar(&localCommits, "commit", []string{}, "Use this commit of an input repo, in the form <repo>@<commit>, instead of the head of the input's branch.")
+	runLocal.Flags().BoolVar(&localDocker, "docker", false, "Run the transform in the pipeline's image with 'docker run', instead of as a local process.")
+	runLocal.Flags().StringVar(&localDir, "dir", "", "The directory to download datums into, a temporary directory is used if unset.")
+	commands = append(commands, cmdutil.CreateAlias(runLocal, "run local"))
+
 	inspectPipeline := &cobra.Command{
 		Use:   "{{alias}} <pipeline>",
 		Short: "Return info about a pipeline.",
@@ -1032,6 +1267,551 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	}
 	commands = append(commands, cmdutil.CreateAlias(listSecret, "list secret"))
 
+	templateDocs := &cobra.Command{
+		Short: "Docs for pipeline templates.",
+		Long: `Pipeline templates are pipeline specs with parameters.
+
+A template's spec is a pipeline spec, in JSON or YAML, with Go template actions
+that refer to its parameters, e.g. {{.image}}. Pipelines are created from a
+template with 'create pipeline --template', and record the template version
+they were created from, so they can be updated to the template's latest version
+with 'update pipeline --template <template> --all'.
+
+When auth is activated, only cluster admins can create, update and delete
+templates.`,
+	}
+	commands = append(commands, cmdutil.CreateDocsAlias(templateDocs, "template", " template$"))
+
+	var templatePath string
+	var templateDescription string
+	var templateParams []string
+	templateCommand := func(update bool) *cobra.Command {
+		cmd := &cobra.Command{
+			Use: "{{alias}} <template>",
+			Example: `
+# Create a template with a required string parameter "name" and an int
+# parameter "parallelism" that defaults to 1
+$ {{alias}} edges -f edges.json --param name --param parallelism:int=1`,
+			Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+				parameters, err := parseTemplateParameters(templateParams)
+				if err != nil {
+					return err
+				}
+				spec, err := readTemplateSpec(templatePath)
+				if err != nil {
+					return err
+				}
+				client, err := pachdclient.NewOnUserMachine("user")
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				return client.CreatePipelineTemplate(args[0], spec, parameters, templateDescription, update)
+			}),
+		}
+		cmd.Flags().StringVarP(&templatePath, "file", "f", "-", "The file containing the template's spec, it can be a url or local file. - reads from stdin.")
+		cmd.Flags().StringVarP(&templateDescription, "description", "d", "", "A description of the template.")
+		cmd.Flags().StringArrayVar(&templateParams, "param", []string{}, "A template parameter, in the form <name>[:<type>][=<default>]. Types are string (the default), int, float and bool. Parameters without '=' are required, <name>= is an optional parameter whose default is its type's zero value.")
+		return cmd
+	}
+	createTemplate := templateCommand(false)
+	createTemplate.Short = "Create a new pipeline template."
+	createTemplate.Long = "Create a new pipeline template from a spec with Go template actions, e.g. {{.image}}, that refer to its parameters."
+	commands = append(commands, cmdutil.CreateAlias(createTemplate, "create template"))
+
+	updateTemplate := templateCommand(true)
+	updateTemplate.Short = "Update a pipeline template."
+	updateTemplate.Long = "Update a pipeline template, which increments its version. Pipelines created from the template aren't changed until they're updated with 'update pipeline --template'."
+	commands = append(commands, cmdutil.CreateAlias(updateTemplate, "update template"))
+
+	inspectTemplate := &cobra.Command{
+		Use:   "{{alias}} <template>",
+		Short: "Return info about a pipeline template.",
+		Long:  "Return info about a pipeline template.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			templateInfo, err := client.InspectPipelineTemplate(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return encoder(output).EncodeProto(templateInfo)
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			return pretty.PrintDetailedPipelineTemplateInfo(os.Stdout, templateInfo)
+		}),
+	}
+	inspectTemplate.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectTemplate, "inspect template"))
+
+	listTemplate := &cobra.Command{
+		Short: "Return info about all pipeline templates.",
+		Long:  "Return info about all pipeline templates.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			templateInfos, err := client.ListPipelineTemplate()
+			if err != nil {
+				return err
+			}
+			if raw {
+				e := encoder(output)
+				for _, templateInfo := range templateInfos {
+					if err := e.EncodeProto(templateInfo); err != nil {
+						return err
+					}
+				}
+				return nil
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.PipelineTemplateHeader)
+			for _, templateInfo := range templateInfos {
+				pretty.PrintPipelineTemplateInfo(writer, templateInfo, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	listTemplate.Flags().AddFlagSet(outputFlags)
+	listTemplate.Flags().AddFlagSet(fullTimestampsFlags)
+	commands = append(commands, cmdutil.CreateAlias(listTemplate, "list template"))
+
+	deleteTemplate := &cobra.Command{
+		Use:   "{{alias}} <template>",
+		Short: "Delete a pipeline template.",
+		Long:  "Delete a pipeline template. Pipelines that were created from the template aren't deleted.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.DeletePipelineTemplate(args[0])
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(deleteTemplate, "delete template"))
+
+	queueDocs := &cobra.Command{
+		Short: "Docs for scheduling queues.",
+		Long: `Queues limit the workers, cpu and memory that pipelines can use.
+
+A pipeline's workers count against the queue named by its 'queue' field
+("default" if unset). The PPS master only scales up a pipeline if its queue has
+room for it, otherwise the pipeline waits. Waiting pipelines are admitted in
+order of their 'priority', then of their queue's usage relative to its weight,
+then of how long they've been waiting.
+
+When auth is activated, only cluster admins can create, update or delete
+queues.`,
+	}
+	commands = append(commands, cmdutil.CreateDocsAlias(queueDocs, "queue", " queue$"))
+
+	var queueDescription string
+	var queueWeight uint64
+	var queueMaxWorkers uint64
+	var queueMaxCPU float32
+	var queueMaxMemory string
+	queueCommand := func(update bool) *cobra.Command {
+		cmd := &cobra.Command{
+			Use: "{{alias}} <queue>",
+			Example: `
+# Create a queue whose pipelines can use at most 20 workers and 40 cpus
+$ {{alias}} backfill --max-workers 20 --max-cpu 40 --weight 1`,
+			Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+				client, err := pachdclient.NewOnUserMachine("user")
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				request := &ppsclient.CreateQueueRequest{
+					Queue:       pachdclient.NewQueue(args[0]),
+					Description: queueDescription,
+					Weight:      queueWeight,
+					MaxWorkers:  queueMaxWorkers,
+					Update:      update,
+				}
+				if queueMaxCPU != 0 || queueMaxMemory != "" {
+					request.MaxResources = &ppsclient.ResourceSpec{
+						Cpu:    queueMaxCPU,
+						Memory: queueMaxMemory,
+					}
+				}
+				return client.CreateQueue(request)
+			}),
+		}
+		cmd.Flags().StringVarP(&queueDescription, "description", "d", "", "A description of the queue.")
+		cmd.Flags().Uint64Var(&queueWeight, "weight", 1, "The queue's share of the cluster's workers, relative to other queues.")
+		cmd.Flags().Uint64Var(&queueMaxWorkers, "max-workers", 0, "The maximum number of workers the queue's pipelines can run (0 is unlimited).")
+		cmd.Flags().Float32Var(&queueMaxCPU, "max-cpu", 0, "The maximum number of cpus the queue's pipelines can request (0 is unlimited).")
+		cmd.Flags().StringVar(&queueMaxMemory, "max-memory", "", "The maximum memory the queue's pipelines can request, e.g. 64Gi (empty is unlimited).")
+		return cmd
+	}
+	createQueue := queueCommand(false)
+	createQueue.Short = "Create a new scheduling queue."
+	createQueue.Long = "Create a new scheduling queue, which limits the workers, cpu and memory of the pipelines in it."
+	commands = append(commands, cmdutil.CreateAlias(createQueue, "create queue"))
+
+	updateQueue := queueCommand(true)
+	updateQueue.Short = "Update a scheduling queue."
+	updateQueue.Long = "Update a scheduling queue. Running pipelines aren't scaled down if the queue's new limits are lower than its usage."
+	commands = append(commands, cmdutil.CreateAlias(updateQueue, "update queue"))
+
+	inspectQueue := &cobra.Command{
+		Use:   "{{alias}} <queue>",
+		Short: "Return info about a scheduling queue.",
+		Long:  "Return info about a scheduling queue, including the pipelines running in it and the pipelines waiting for it.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			queueInfo, err := client.InspectQueue(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return encoder(output).EncodeProto(queueInfo)
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			return pretty.PrintDetailedQueueInfo(os.Stdout, queueInfo)
+		}),
+	}
+	inspectQueue.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectQueue, "inspect queue"))
+
+	listQueue := &cobra.Command{
+		Short: "Return info about all scheduling queues.",
+		Long:  "Return info about all scheduling queues, with their usage and the number of pipelines running in them and waiting for them.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			queueInfos, err := client.ListQueue()
+			if err != nil {
+				return err
+			}
+			if raw {
+				e := encoder(output)
+				for _, queueInfo := range queueInfos {
+					if err := e.EncodeProto(queueInfo); err != nil {
+						return err
+					}
+				}
+				return nil
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.QueueHeader)
+			for _, queueInfo := range queueInfos {
+				pretty.PrintQueueInfo(writer, queueInfo)
+			}
+			return writer.Flush()
+		}),
+	}
+	listQueue.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(listQueue, "list queue"))
+
+	deleteQueue := &cobra.Command{
+		Use:   "{{alias}} <queue>",
+		Short: "Delete a scheduling queue.",
+		Long:  "Delete a scheduling queue. Pipelines in the queue are no longer limited by it.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.DeleteQueue(args[0])
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(deleteQueue, "delete queue"))
+
+	alertDocs := &cobra.Command{
+		Short: "Docs for alerts.",
+		Long: `Alerts post a JSON payload to a webhook when a pipeline fails.
+
+An alert has one or more rules, each of which fires on one condition: a job
+failing, a job's datum failure rate exceeding a threshold, a job running
+longer than a duration, or a pipeline crashlooping for longer than a duration.
+Alerts apply to the pipelines they name, or to every pipeline if they don't
+name any. A pipeline can also set its own alerts in the 'alerts' field of its
+spec. Each event is delivered at least once, and failed deliveries are retried
+with backoff.`,
+	}
+	commands = append(commands, cmdutil.CreateDocsAlias(alertDocs, "alert", " alert$"))
+
+	var alertDescription string
+	var alertWebhook string
+	var alertRules []string
+	var alertPipelines []string
+	alertCommand := func(update bool) *cobra.Command {
+		cmd := &cobra.Command{
+			Use: "{{alias}} <alert>",
+			Example: `
+# Post to a webhook when any pipeline's job fails or a pipeline crashloops
+# for more than 5 minutes
+$ {{alias}} oncall --webhook https://hooks.example.com/pachyderm --on job-failure --on crashing=5m
+
+# Post when more than 10% of a job's datums fail, or a job runs for more than
+# an hour, in the edges and montage pipelines
+$ {{alias}} slow-edges --webhook https://hooks.example.com/pachyderm --on datum-failure-rate=0.1 --on job-duration=1h --pipeline edges --pipeline montage`,
+			Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+				rules, err := parseAlertRules(alertRules)
+				if err != nil {
+					return err
+				}
+				client, err := pachdclient.NewOnUserMachine("user")
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				request := &ppsclient.CreateAlertRequest{
+					Alert:       pachdclient.NewAlert(args[0]),
+					Description: alertDescription,
+					Rules:       rules,
+					WebhookURL:  alertWebhook,
+					Update:      update,
+				}
+				for _, pipeline := range alertPipelines {
+					request.Pipelines = append(request.Pipelines, pachdclient.NewPipeline(pipeline))
+				}
+				return client.CreateAlert(request)
+			}),
+		}
+		cmd.Flags().StringVarP(&alertDescription, "description", "d", "", "A description of the alert.")
+		cmd.Flags().StringVar(&alertWebhook, "webhook", "", "The http(s) URL to post the alert's events to.")
+		cmd.Flags().StringSliceVar(&alertRules, "on", nil, "A condition to fire on: job-failure, datum-failure-rate=<fraction>, job-duration=<duration> or crashing[=<duration>]. May be repeated.")
+		cmd.Flags().StringSliceVarP(&alertPipelines, "pipeline", "p", nil, "A pipeline to watch (all pipelines if unset). May be repeated.")
+		return cmd
+	}
+	createAlert := alertCommand(false)
+	createAlert.Short = "Create a new alert."
+	createAlert.Long = "Create a new alert, which posts to a webhook when pipelines fail."
+	commands = append(commands, cmdutil.CreateAlias(createAlert, "create alert"))
+
+	updateAlert := alertCommand(true)
+	updateAlert.Short = "Update an alert."
+	updateAlert.Long = "Update an alert, replacing its rules, webhook and pipelines."
+	commands = append(commands, cmdutil.CreateAlias(updateAlert, "update alert"))
+
+	inspectAlert := &cobra.Command{
+		Use:   "{{alias}} <alert>",
+		Short: "Return info about an alert.",
+		Long:  "Return info about an alert, including the events it has fired recently and whether they were delivered.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			alertInfo, err := client.InspectAlert(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return encoder(output).EncodeProto(alertInfo)
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			return pretty.PrintDetailedAlertInfo(os.Stdout, alertInfo)
+		}),
+	}
+	inspectAlert.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectAlert, "inspect alert"))
+
+	listAlert := &cobra.Command{
+		Short: "Return info about all alerts.",
+		Long:  "Return info about all alerts, with their rules and the pipelines they watch.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			alertInfos, err := client.ListAlert()
+			if err != nil {
+				return err
+			}
+			if raw {
+				e := encoder(output)
+				for _, alertInfo := range alertInfos {
+					if err := e.EncodeProto(alertInfo); err != nil {
+						return err
+					}
+				}
+				return nil
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.AlertHeader)
+			for _, alertInfo := range alertInfos {
+				pretty.PrintAlertInfo(writer, alertInfo, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	listAlert.Flags().AddFlagSet(outputFlags)
+	listAlert.Flags().AddFlagSet(fullTimestampsFlags)
+	commands = append(commands, cmdutil.CreateAlias(listAlert, "list alert"))
+
+	deleteAlert := &cobra.Command{
+		Use:   "{{alias}} <alert>",
+		Short: "Delete an alert.",
+		Long:  "Delete an alert, along with any of its events that haven't been delivered.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.DeleteAlert(args[0])
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(deleteAlert, "delete alert"))
+
+	var usageFrom, usageTo, usageLabel string
+	var usagePipelines []string
+	listUsage := &cobra.Command{
+		Short: "Return the resources used by pipelines' jobs.",
+		Long: `Return the cpu time, peak memory and data used by the jobs that finished in a time range, for each pipeline or for each value of a pipeline label.
+
+CPU time and peak memory are sampled from the cgroup of each worker's user container while it runs user code.`,
+		Example: `
+# Return each pipeline's usage over the last week
+$ {{alias}} --from 168h
+
+# Return the usage of each team in September, from the pipelines' "team" label
+$ {{alias}} --label team --from 2020-09-01T00:00:00Z --to 2020-10-01T00:00:00Z`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			now := time.Now()
+			request := &ppsclient.ListUsageRequest{Label: usageLabel}
+			var err error
+			if request.From, err = parseUsageTime(usageFrom, now); err != nil {
+				return err
+			}
+			if request.To, err = parseUsageTime(usageTo, now); err != nil {
+				return err
+			}
+			for _, pipeline := range usagePipelines {
+				request.Pipelines = append(request.Pipelines, pachdclient.NewPipeline(pipeline))
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			usageInfos, err := client.ListUsage(request)
+			if err != nil {
+				return err
+			}
+			if raw {
+				e := encoder(output)
+				for _, usageInfo := range usageInfos {
+					if err := e.EncodeProto(usageInfo); err != nil {
+						return err
+					}
+				}
+				return nil
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.UsageHeader)
+			for _, usageInfo := range usageInfos {
+				pretty.PrintUsageInfo(writer, usageInfo)
+			}
+			return writer.Flush()
+		}),
+	}
+	listUsage.Flags().StringVar(&usageFrom, "from", "", "Only count jobs that finished at or after this time, either an RFC 3339 timestamp or a duration before now, e.g. 24h.")
+	listUsage.Flags().StringVar(&usageTo, "to", "", "Only count jobs that finished before this time, either an RFC 3339 timestamp or a duration before now (default now).")
+	listUsage.Flags().StringVarP(&usageLabel, "label", "l", "", "Group usage by the value of this pipeline label, rather than by pipeline.")
+	listUsage.Flags().StringSliceVarP(&usagePipelines, "pipeline", "p", nil, "Only return the usage of this pipeline. May be repeated.")
+	listUsage.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(listUsage, "list usage"))
+
+	var dagFrom, dagFormat string
+	drawDAG := &cobra.Command{
+		Short: "Draw the DAG of repos and pipelines.",
+		Long:  "Draw the DAG of repos and pipelines, in graphviz (dot) or mermaid format. Repos are drawn as cylinders and pipelines as boxes, which are red if the pipeline or its last job failed.",
+		Example: `
+# Render the DAG as an SVG with graphviz
+$ {{alias}} | dot -Tsvg > dag.svg
+
+# Draw the part of the DAG downstream of repo "images" as a mermaid flowchart
+$ {{alias}} --from images --format mermaid`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			var printDAG func(io.Writer, []*ppsclient.DAGNode)
+			switch dagFormat {
+			case "dot":
+				printDAG = pretty.PrintDAGDot
+			case "mermaid":
+				printDAG = pretty.PrintDAGMermaid
+			default:
+				return errors.Errorf("unrecognized format %q, must be one of: dot, mermaid", dagFormat)
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			nodes, err := client.InspectDAG(dagFrom)
+			if err != nil {
+				return err
+			}
+			printDAG(os.Stdout, nodes)
+			return nil
+		}),
+	}
+	drawDAG.Flags().StringVar(&dagFrom, "from", "", "Only draw this repo and the repos and pipelines downstream of it.")
+	drawDAG.MarkFlagCustom("from", "__pachctl_get_repo")
+	drawDAG.Flags().StringVar(&dagFormat, "format", "dot", "The output format, either \"dot\" or \"mermaid\".")
+	commands = append(commands, cmdutil.CreateAlias(drawDAG, "draw dag"))
+
+	inspectImpact := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Return the pipelines that a commit to a repo would run.",
+		Long: `Return the pipelines that a commit to a repo would run, in the order they would run, with how long each pipeline's last job took.
+
+Pipelines run in stages: a pipeline's stage is one more than the latest stage of its inputs, so pipelines in the same stage can run at the same time.`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			nodes, err := client.InspectDAG(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return encoder(output).EncodeProto(&ppsclient.DAGInfo{Nodes: nodes})
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.ImpactHeader)
+			for _, node := range nodes {
+				if node.Pipeline != nil {
+					pretty.PrintImpactNode(writer, node)
+				}
+			}
+			return writer.Flush()
+		}),
+	}
+	inspectImpact.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectImpact, "inspect impact"))
+
 	var memory string
 	garbageCollect := &cobra.Command{
 		Short: "Garbage collect unused data.",
@@ -1194,6 +1974,228 @@ func pipelineHelper(reprocess bool, build bool, pushImages bool, registry, usern
 	return nil
 }
 
+// dryRunPipelineHelper validates each pipeline spec in pipelinePath and
+// prints a preview of the datums it would process, without creating or
+// updating it. commits are of the form <repo>@<commit>.
+func dryRunPipelineHelper(pipelinePath string, update bool, reprocess bool, commits []string, sample int64, raw bool, output string) error {
+	if output != "" && !raw {
+		return errors.New("cannot set --output (-o) without --raw")
+	}
+	inputCommits, err := cmdutil.ParseCommits(commits)
+	if err != nil {
+		return err
+	}
+	for _, commit := range inputCommits {
+		if commit.ID == "" {
+			return errors.Errorf("invalid commit %s, must be of the form <repo>@<commit>", commit.Repo.Name)
+		}
+	}
+	if sample <= 0 {
+		sample = -1 // 0 would mean the server's default
+	}
+	pipelineReader, err := ppsutil.NewPipelineManifestReader(pipelinePath)
+	if err != nil {
+		return err
+	}
+	pc, err := pachdclient.NewOnUserMachine("user")
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to pachd")
+	}
+	defer pc.Close()
+	e := encoder(output)
+	for {
+		request, err := pipelineReader.NextCreatePipelineRequest()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if request.Pipeline == nil || request.Pipeline.Name == "" {
+			return errors.New("no pipeline `name` specified")
+		}
+		request.Update = update
+		request.Reprocess = reprocess
+		response, err := pc.DryRunPipeline(request, inputCommits, sample)
+		if err != nil {
+			return errors.Wrapf(err, "pipeline %s", request.Pipeline.Name)
+		}
+		if raw {
+			if err := e.EncodeProto(response); err != nil {
+				return err
+			}
+			continue
+		}
+		pretty.PrintDryRunPipeline(os.Stdout, request.Pipeline.Name, response)
+	}
+}
+
+// templatePipelineHelper creates, or updates, the pipeline rendered from the
+// template templateName with params, which are of the form <name>=<value>.
+func templatePipelineHelper(templateName string, params []string, update bool, reprocess bool) error {
+	values := make(map[string]string)
+	for _, param := range params {
+		parts := strings.SplitN(param, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("invalid parameter %q, parameters must be of the form <name>=<value>", param)
+		}
+		values[parts[0]] = parts[1]
+	}
+	pc, err := pachdclient.NewOnUserMachine("user")
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to pachd")
+	}
+	defer pc.Close()
+	return pc.CreatePipelineFromTemplate(templateName, values, update, reprocess)
+}
+
+// upgradeTemplatePipelines updates every pipeline that was created from the
+// template templateName to the template's latest version, rendering it with
+// the same parameters it was created with.
+func upgradeTemplatePipelines(templateName string, reprocess bool) error {
+	pc, err := pachdclient.NewOnUserMachine("user")
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to pachd")
+	}
+	defer pc.Close()
+	templateInfo, err := pc.InspectPipelineTemplate(templateName)
+	if err != nil {
+		return err
+	}
+	pipelineInfos, err := pc.ListPipeline()
+	if err != nil {
+		return err
+	}
+	for _, pipelineInfo := range pipelineInfos {
+		ref := pipelineInfo.Template
+		if ref == nil || ref.Template.Name != templateName || ref.Version == templateInfo.Version {
+			continue
+		}
+		fmt.Printf("Updating pipeline %s from version %d to %d of template %s.\n",
+			pipelineInfo.Pipeline.Name, ref.Version, templateInfo.Version, templateName)
+		if err := pc.CreatePipelineFromTemplate(templateName, ref.Params, true, reprocess); err != nil {
+			return errors.Wrapf(err, "could not update pipeline %s", pipelineInfo.Pipeline.Name)
+		}
+	}
+	return nil
+}
+
+// parseTemplateParameters parses template parameter declarations of the form
+// <name>[:<type>][=<default>]. Parameters declared without '=' are required,
+// "<name>=" declares an optional parameter without a default.
+func parseTemplateParameters(decls []string) ([]*ppsclient.TemplateParameter, error) {
+	var result []*ppsclient.TemplateParameter
+	for _, decl := range decls {
+		param := &ppsclient.TemplateParameter{}
+		nameAndType := decl
+		if i := strings.Index(decl, "="); i >= 0 {
+			nameAndType, param.Default = decl[:i], decl[i+1:]
+		} else {
+			param.Required = true
+		}
+		param.Name = nameAndType
+		if i := strings.Index(nameAndType, ":"); i >= 0 {
+			param.Name = nameAndType[:i]
+			t, ok := ppsclient.TemplateParameterType_value[strings.ToUpper(nameAndType[i+1:])]
+			if !ok {
+				return nil, errors.Errorf("invalid type %q for parameter %s, types are string, int, float and bool", nameAndType[i+1:], param.Name)
+			}
+			param.Type = ppsclient.TemplateParameterType(t)
+		}
+		result = append(result, param)
+	}
+	return result, nil
+}
+
+// parseAlertRules parses alert rules of the form <condition>[=<threshold>],
+// e.g. "job-failure", "datum-failure-rate=0.1" or "crashing=5m".
+func parseAlertRules(specs []string) ([]*ppsclient.AlertRule, error) {
+	var result []*ppsclient.AlertRule
+	for _, spec := range specs {
+		name, threshold := spec, ""
+		if i := strings.Index(spec, "="); i >= 0 {
+			name, threshold = spec[:i], spec[i+1:]
+		}
+		rule := &ppsclient.AlertRule{}
+		switch name {
+		case "job-failure":
+			rule.Condition = ppsclient.AlertCondition_ALERT_JOB_FAILURE
+		case "datum-failure-rate":
+			rule.Condition = ppsclient.AlertCondition_ALERT_DATUM_FAILURE_RATE
+			rate, err := strconv.ParseFloat(threshold, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid failure rate %q in %q, must be a fraction such as 0.1", threshold, spec)
+			}
+			rule.FailureRate = rate
+		case "job-duration", "crashing":
+			rule.Condition = ppsclient.AlertCondition_ALERT_JOB_DURATION
+			if name == "crashing" {
+				rule.Condition = ppsclient.AlertCondition_ALERT_PIPELINE_CRASHING
+				if threshold == "" {
+					break
+				}
+			}
+			duration, err := time.ParseDuration(threshold)
+			if err != nil {
+				return nil, errors.Errorf("invalid duration %q in %q, must be a duration such as 1h", threshold, spec)
+			}
+			rule.Duration = types.DurationProto(duration)
+		default:
+			return nil, errors.Errorf("unknown alert condition %q, conditions are job-failure, datum-failure-rate, job-duration and crashing", name)
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+// parseUsageTime parses a time passed to 'list usage', which is either an RFC
+// 3339 timestamp or a duration before now. It returns nil for "".
+func parseUsageTime(value string, now time.Time) (*types.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		duration, durationErr := time.ParseDuration(value)
+		if durationErr != nil {
+			return nil, errors.Errorf("invalid time %q, must be an RFC 3339 timestamp such as 2020-09-01T00:00:00Z or a duration such as 24h", value)
+		}
+		t = now.Add(-duration)
+	}
+	return types.TimestampProto(t)
+}
+
+// readTemplateSpec reads a template's spec from a url, a local file, or stdin
+// if path is "-".
+func readTemplateSpec(path string) (result string, retErr error) {
+	var specBytes []byte
+	if path == "-" {
+		fmt.Print("Reading from stdin.\n")
+		var err error
+		if specBytes, err = ioutil.ReadAll(os.Stdin); err != nil {
+			return "", errors.EnsureStack(err)
+		}
+	} else if u, err := url.Parse(path); err == nil && u.Scheme != "" {
+		resp, err := http.Get(u.String())
+		if err != nil {
+			return "", errors.EnsureStack(err)
+		}
+		defer func() {
+			if err := resp.Body.Close(); err != nil && retErr == nil {
+				retErr = err
+			}
+		}()
+		if specBytes, err = ioutil.ReadAll(resp.Body); err != nil {
+			return "", errors.EnsureStack(err)
+		}
+	} else {
+		var err error
+		if specBytes, err = ioutil.ReadFile(path); err != nil {
+			return "", errors.EnsureStack(err)
+		}
+	}
+	return string(specBytes), nil
+}
+
 func dockerBuildHelper(request *ppsclient.CreatePipelineRequest, build bool, registry, username, pipelineParentPath string) error {
 	// create docker client
 	dockerClient, err := docker.NewClientFromEnv()
@@ -30,9 +30,12 @@ import (
 	"os"
 	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
 	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+	"github.com/pachyderm/pachyderm/src/server/pps/pretty"
 )
 
 const badJSON1 = `
@@ -940,3 +943,40 @@ func TestNoWarningTagSpecified(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "", stderr)
 }
+
+func TestParseAlertRules(t *testing.T) {
+	specs := []string{"job-failure", "datum-failure-rate=0.1", "job-duration=1h", "crashing", "crashing=5m"}
+	rules, err := parseAlertRules(specs)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(rules))
+	require.Equal(t, ppsclient.AlertCondition_ALERT_DATUM_FAILURE_RATE, rules[1].Condition)
+	require.Equal(t, 0.1, rules[1].FailureRate)
+	require.Equal(t, int64(time.Hour/time.Second), rules[2].Duration.Seconds)
+	require.Nil(t, rules[3].Duration)
+	// rules print the way they're parsed
+	var printed []string
+	for _, rule := range rules {
+		printed = append(printed, pretty.AlertRule(rule))
+	}
+	require.Equal(t, []string{"job-failure", "datum-failure-rate=0.1", "job-duration=1h0m0s", "crashing", "crashing=5m0s"}, printed)
+
+	for _, spec := range []string{"job-failed", "datum-failure-rate", "job-duration=soon", "crashing=5"} {
+		_, err := parseAlertRules([]string{spec})
+		require.YesError(t, err)
+	}
+}
+
+func TestParseUsageTime(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	ts, err := parseUsageTime("", now)
+	require.NoError(t, err)
+	require.Nil(t, ts)
+	ts, err = parseUsageTime("24h", now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-24*time.Hour).Unix(), ts.Seconds)
+	ts, err = parseUsageTime("2020-09-01T00:00:00Z", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC).Unix(), ts.Seconds)
+	_, err = parseUsageTime("last week", now)
+	require.YesError(t, err)
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	units "github.com/docker/go-units"
 	"github.com/fatih/color"
@@ -29,6 +31,16 @@ const (
 	DatumHeader = "ID\tSTATUS\tTIME\t\n"
 	// SecretHeader is the header for secrets
 	SecretHeader = "NAME\tTYPE\tCREATED\t\n"
+	// PipelineTemplateHeader is the header for pipeline templates.
+	PipelineTemplateHeader = "NAME\tVERSION\tPARAMETERS\tCREATED\tDESCRIPTION\t\n"
+	// QueueHeader is the header for queues.
+	QueueHeader = "NAME\tWEIGHT\tWORKERS\tCPU\tMEMORY\tRUNNING\tWAITING\t\n"
+	// AlertHeader is the header for alerts.
+	AlertHeader = "NAME\tRULES\tPIPELINES\tWEBHOOK\tCREATED\t\n"
+	// UsageHeader is the header for resource usage.
+	UsageHeader = "NAME\tPIPELINES\tJOBS\tDATUMS\tCPU TIME\tPEAK MEMORY\tPROCESS TIME\tDL\tUL\t\n"
+	// ImpactHeader is the header for the pipelines affected by a commit.
+	ImpactHeader = "STAGE\tPIPELINE\tINPUTS\tSTATE\tLAST JOB\tDURATION\t\n"
 	// jobReasonLen is the amount of the job reason that we print
 	jobReasonLen = 25
 )
@@ -138,7 +150,9 @@ Data Uploaded: {{prettySize .Stats.UploadBytes}}
 Download Time: {{prettyDuration .Stats.DownloadTime}}
 Process Time: {{prettyDuration .Stats.ProcessTime}}
 Upload Time: {{prettyDuration .Stats.UploadTime}}
-Datum Timeout: {{.DatumTimeout}}
+{{ if .Stats.CpuTime }}CPU Time: {{prettyCPUTime .Stats.CpuTime}}
+Peak Memory: {{prettySize .Stats.PeakMemoryBytes}}
+{{end}}Datum Timeout: {{.DatumTimeout}}
 Job Timeout: {{.JobTimeout}}
 Worker Status:
 {{workerStatus .}}Restarts: {{.Restart}}
@@ -163,7 +177,9 @@ Transform:
 {{prettyTransform .Transform}} {{if .OutputCommit}}
 Output Commit: {{.OutputCommit.ID}} {{end}} {{ if .StatsCommit }}
 Stats Commit: {{.StatsCommit.ID}} {{end}} {{ if .Egress }}
-Egress: {{.Egress.URL}} {{end}}
+Egress: {{egressTargets .Egress}} {{end}} {{ if .EgressStatus }}
+Egress Status:
+{{egressStatus .EgressStatus}}{{end}}
 `)
 	if err != nil {
 		return err
@@ -201,7 +217,9 @@ Reason: {{.Reason}}
 Workers Available: {{.WorkersAvailable}}/{{.WorkersRequested}}
 Stopped: {{ .Stopped }}
 Parallelism Spec: {{.ParallelismSpec}}
-{{ if .ResourceRequests }}ResourceRequests:
+{{ if .Autoscaling }}Autoscaling: {{prettyAutoscaling .Autoscaling .AutoscalingStatus}}
+{{end}}{{ if .QueueStatus }}Queue: {{prettyQueueStatus .QueueStatus}}
+{{end}}{{ if .ResourceRequests }}ResourceRequests:
   CPU: {{ .ResourceRequests.Cpu }}
   Memory: {{ .ResourceRequests.Memory }} {{end}}
 {{ if .ResourceLimits }}ResourceLimits:
@@ -214,11 +232,18 @@ Datum Timeout: {{.DatumTimeout}}
 Job Timeout: {{.JobTimeout}}
 Input:
 {{pipelineInput .PipelineInfo}}
-{{ if .GithookURL }}Githook URL: {{.GithookURL}} {{end}}
+{{ if .Condition }}Condition: {{prettyCondition .Condition}}
+{{end}}{{ if .Alerts }}Alerts: {{prettyAlertSpec .Alerts}}
+{{end}}{{ if .WorkerPools }}Worker Pools:
+{{range .WorkerPools}}  {{prettyWorkerPool .}}
+{{end}}{{end}}{{ if .Preemption }}Preemption: {{prettyPreemption .Preemption}}
+{{end}}{{ if .Template }}Template: {{prettyTemplateRef .Template}}
+{{end}}{{ if .GithookURL }}Githook URL: {{.GithookURL}} {{end}}
 Output Branch: {{.OutputBranch}}
-Transform:
+{{ if .ServiceCommit }}Service Commit: {{.ServiceCommit.ID}}
+{{end}}Transform:
 {{prettyTransform .Transform}}
-{{ if .Egress }}Egress: {{.Egress.URL}} {{end}}
+{{ if .Egress }}Egress: {{egressTargets .Egress}} {{end}}
 {{if .RecentError}} Recent Error: {{.RecentError}} {{end}}
 Job Counts:
 {{jobCounts .JobCounts}}
@@ -283,6 +308,11 @@ func PrintDetailedDatumInfo(w io.Writer, datumInfo *ppsclient.DatumInfo) {
 	}
 	fmt.Fprintf(w, "Upload Time\t%s\n", uploadTime)
 
+	if datumInfo.Stats.CpuTime != nil {
+		fmt.Fprintf(w, "CPU Time\t%s\n", prettyCPUTime(datumInfo.Stats.CpuTime))
+		fmt.Fprintf(w, "Peak Memory\t%s\n", pretty.Size(datumInfo.Stats.PeakMemoryBytes))
+	}
+
 	fmt.Fprintf(w, "PFS State:\n")
 	tw := ansiterm.NewTabWriter(w, 10, 1, 3, ' ', 0)
 	PrintFileHeader(tw)
@@ -302,6 +332,280 @@ func PrintSecretInfo(w io.Writer, secretInfo *ppsclient.SecretInfo) {
 	fmt.Fprintf(w, "%s\t%s\t%s\t\n", secretInfo.Secret.Name, secretInfo.Type, pretty.Ago(secretInfo.CreationTimestamp))
 }
 
+// PrintPipelineTemplateInfo pretty-prints pipeline template info.
+func PrintPipelineTemplateInfo(w io.Writer, templateInfo *ppsclient.PipelineTemplateInfo, fullTimestamps bool) {
+	var params []string
+	for _, param := range templateInfo.Parameters {
+		params = append(params, param.Name)
+	}
+	fmt.Fprintf(w, "%s\t%d\t%s\t", templateInfo.Template.Name, templateInfo.Version, strings.Join(params, ", "))
+	if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", templateInfo.CreatedAt.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(templateInfo.CreatedAt))
+	}
+	fmt.Fprintf(w, "%s\t\n", templateInfo.Description)
+}
+
+// PrintDetailedPipelineTemplateInfo pretty-prints detailed pipeline template
+// info, including its parameters and spec.
+func PrintDetailedPipelineTemplateInfo(w io.Writer, templateInfo *ppsclient.PipelineTemplateInfo) error {
+	template, err := template.New("PipelineTemplateInfo").Funcs(funcMap).Parse(
+		`Name: {{.Template.Name}}{{if .Description}}
+Description: {{.Description}}{{end}}
+Version: {{.Version}}
+Created: {{prettyAgo .CreatedAt}}
+Parameters:
+{{range .Parameters}}  {{.Name}} ({{templateParameterType .Type}}){{if .Required}}, required{{else if .Default}}, default: {{.Default}}{{end}}{{if .Description}}: {{.Description}}{{end}}
+{{end}}Spec:
+{{.Spec}}
+`)
+	if err != nil {
+		return err
+	}
+	return template.Execute(w, templateInfo)
+}
+
+// PrintQueueInfo pretty-prints queue info. Each of the queue's usage columns
+// is shown as used/limit.
+func PrintQueueInfo(w io.Writer, queueInfo *ppsclient.QueueInfo) {
+	usage := queueInfo.Usage
+	if usage == nil {
+		usage = &ppsclient.QueueUsage{}
+	}
+	limits := queueInfo.MaxResources
+	if limits == nil {
+		limits = &ppsclient.ResourceSpec{}
+	}
+	maxWorkers := "-"
+	if queueInfo.MaxWorkers > 0 {
+		maxWorkers = fmt.Sprintf("%d", queueInfo.MaxWorkers)
+	}
+	maxCPU := "-"
+	if limits.Cpu > 0 {
+		maxCPU = fmt.Sprintf("%g", limits.Cpu)
+	}
+	maxMemory := "-"
+	if limits.Memory != "" {
+		maxMemory = limits.Memory
+	}
+	fmt.Fprintf(w, "%s\t%d\t%d/%s\t%g/%s\t%s/%s\t%d\t%d\t\n", queueInfo.Queue.Name,
+		queueInfo.Weight, usage.Workers, maxWorkers, usage.Cpu, maxCPU,
+		prettyMemory(usage.Memory), maxMemory, len(usage.Running), len(usage.Waiting))
+}
+
+// PrintDetailedQueueInfo pretty-prints detailed queue info, including the
+// pipelines running in the queue and waiting for it, in the order they'll be
+// admitted.
+func PrintDetailedQueueInfo(w io.Writer, queueInfo *ppsclient.QueueInfo) error {
+	template, err := template.New("QueueInfo").Funcs(funcMap).Parse(
+		`Name: {{.Queue.Name}}{{if .Description}}
+Description: {{.Description}}{{end}}{{if .CreatedAt}}
+Created: {{prettyAgo .CreatedAt}}{{end}}
+Weight: {{.Weight}}
+Max Workers: {{if .MaxWorkers}}{{.MaxWorkers}}{{else}}unlimited{{end}}{{if .MaxResources}}{{if .MaxResources.Cpu}}
+Max CPU: {{.MaxResources.Cpu}}{{end}}{{if .MaxResources.Memory}}
+Max Memory: {{.MaxResources.Memory}}{{end}}{{end}}{{with .Usage}}
+Usage: {{.Workers}} workers, {{.Cpu}} cpus, {{prettyMemory .Memory}} memory
+Running:
+{{range .Running}}  {{.Pipeline.Name}} (priority {{.Status.Priority}}, {{.Status.Workers}} workers)
+{{end}}Waiting:
+{{range .Waiting}}  {{.Pipeline.Name}} (priority {{.Status.Priority}}, {{.Status.Workers}} workers, since {{prettyAgo .Status.WaitingSince}}): {{.Status.Reason}}
+{{end}}{{end}}`)
+	if err != nil {
+		return err
+	}
+	return template.Execute(w, queueInfo)
+}
+
+// PrintImpactNode pretty-prints a pipeline in the DAG downstream of a repo.
+// Pipelines with the same stage don't depend on each other.
+func PrintImpactNode(w io.Writer, node *ppsclient.DAGNode) {
+	var inputs []string
+	for _, input := range node.Inputs {
+		inputs = append(inputs, input.Name)
+	}
+	lastJob, duration := "-", "-"
+	if node.LastJob != nil {
+		lastJob = fmt.Sprintf("%s %s", node.LastJob.ID, JobState(node.LastJobState))
+	}
+	if node.LastJobDuration != nil {
+		duration = pretty.Duration(node.LastJobDuration)
+	}
+	fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t\n", node.Depth, node.Pipeline.Name,
+		strings.Join(inputs, ", "), pipelineState(node.State), lastJob, duration)
+}
+
+// PrintDAGDot prints the DAG of repos and pipelines as a graphviz digraph.
+// Pipelines are boxes and are red if the pipeline or its last job failed.
+func PrintDAGDot(w io.Writer, nodes []*ppsclient.DAGNode) {
+	fmt.Fprintf(w, "digraph pachyderm {\n")
+	fmt.Fprintf(w, "  rankdir=LR;\n")
+	for _, node := range nodes {
+		attrs := "shape=cylinder"
+		if node.Pipeline != nil {
+			attrs = "shape=box"
+			if dagNodeFailed(node) {
+				attrs += ", color=red"
+			}
+		}
+		fmt.Fprintf(w, "  %q [%s];\n", node.Repo.Name, attrs)
+	}
+	dagEdges(nodes, func(from, to int) {
+		fmt.Fprintf(w, "  %q -> %q;\n", nodes[from].Repo.Name, nodes[to].Repo.Name)
+	})
+	fmt.Fprintf(w, "}\n")
+}
+
+// PrintDAGMermaid prints the DAG of repos and pipelines as a mermaid
+// flowchart. Repos are cylinders and pipelines are boxes, which are red if
+// the pipeline or its last job failed.
+func PrintDAGMermaid(w io.Writer, nodes []*ppsclient.DAGNode) {
+	// repo names can contain characters that mermaid doesn't allow in ids, so
+	// nodes are numbered and labelled with the repo name
+	fmt.Fprintf(w, "graph LR\n")
+	var failed []string
+	for i, node := range nodes {
+		if node.Pipeline == nil {
+			fmt.Fprintf(w, "  n%d[(\"%s\")]\n", i, node.Repo.Name)
+			continue
+		}
+		fmt.Fprintf(w, "  n%d[\"%s\"]\n", i, node.Repo.Name)
+		if dagNodeFailed(node) {
+			failed = append(failed, fmt.Sprintf("n%d", i))
+		}
+	}
+	dagEdges(nodes, func(from, to int) {
+		fmt.Fprintf(w, "  n%d --> n%d\n", from, to)
+	})
+	if len(failed) > 0 {
+		fmt.Fprintf(w, "  classDef failed stroke:#f00\n")
+		fmt.Fprintf(w, "  class %s failed\n", strings.Join(failed, ","))
+	}
+}
+
+// PrintDryRunPipeline pretty-prints the result of a pipeline dry run: the
+// commits its datums were computed from, how many it would process, and a
+// sample of them.
+func PrintDryRunPipeline(w io.Writer, pipeline string, response *ppsclient.DryRunPipelineResponse) {
+	action := "create"
+	if response.Update {
+		action = "update"
+	}
+	fmt.Fprintf(w, "Pipeline: %s (%s)\n", pipeline, action)
+	fmt.Fprintf(w, "Input: %s\n", ShorthandInput(response.Input))
+	fmt.Fprintf(w, "Input Commits:\n")
+	ppsclient.VisitInput(response.Input, func(input *ppsclient.Input) {
+		var repo, commit string
+		switch {
+		case input.Pfs != nil:
+			repo, commit = input.Pfs.Repo, input.Pfs.Commit
+		case input.Cron != nil:
+			repo, commit = input.Cron.Repo, input.Cron.Commit
+		case input.ObjectStorage != nil:
+			repo, commit = input.ObjectStorage.Repo, input.ObjectStorage.Commit
+		case input.Git != nil:
+			repo, commit = input.Git.Name, input.Git.Commit
+		default:
+			return
+		}
+		if commit == "" {
+			commit = "(no commits)"
+		}
+		fmt.Fprintf(w, "  %s@%s\n", repo, commit)
+	})
+	fmt.Fprintf(w, "Datums: %d (%d to process, %d skipped)\n", response.Datums, response.Processed, response.Skipped)
+	if len(response.Sample) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Sample:\n")
+	for i, datumInfo := range response.Sample {
+		state := "process"
+		if datumInfo.State == ppsclient.DatumState_SKIPPED {
+			state = "skip"
+		}
+		var files []string
+		for _, fileInfo := range datumInfo.Data {
+			files = append(files, fmt.Sprintf("%s@%s:%s", fileInfo.File.Commit.Repo.Name, fileInfo.File.Commit.ID, fileInfo.File.Path))
+		}
+		fmt.Fprintf(w, "  %d. %s %s\n", i+1, state, strings.Join(files, ", "))
+	}
+	if int64(len(response.Sample)) < response.Datums {
+		fmt.Fprintf(w, "  ... %d more\n", response.Datums-int64(len(response.Sample)))
+	}
+}
+
+// PrintJobDiff pretty-prints the differences between two jobs: their specs,
+// their input commits, their datums and their outputs.
+func PrintJobDiff(w io.Writer, diff *ppsclient.JobDiff) {
+	fmt.Fprintf(w, "Jobs: %s -> %s\n", diff.A.ID, diff.B.ID)
+	fmt.Fprintf(w, "Spec Changes:\n")
+	if len(diff.SpecChanges) == 0 {
+		fmt.Fprintf(w, "  none\n")
+	}
+	for _, change := range diff.SpecChanges {
+		fmt.Fprintf(w, "  %s: %s -> %s\n", change.Path, diffValue(change.A), diffValue(change.B))
+	}
+	fmt.Fprintf(w, "Input Changes:\n")
+	if len(diff.InputChanges) == 0 {
+		fmt.Fprintf(w, "  none\n")
+	}
+	for _, change := range diff.InputChanges {
+		fmt.Fprintf(w, "  %s: %s -> %s\n", change.Name, diffCommit(change.A), diffCommit(change.B))
+	}
+	fmt.Fprintf(w, "Datums: %d added, %d removed, %d changed, %d unchanged\n",
+		diff.DatumsAdded, diff.DatumsRemoved, diff.DatumsChanged, diff.DatumsUnchanged)
+	printDiffDatums(w, "+", diff.AddedDatums, diff.DatumsAdded)
+	printDiffDatums(w, "-", diff.RemovedDatums, diff.DatumsRemoved)
+	printDiffDatums(w, "~", diff.ChangedDatums, diff.DatumsChanged)
+	if !diff.OutputsCompared {
+		fmt.Fprintf(w, "Output: not compared, both jobs must finish first\n")
+		return
+	}
+	fmt.Fprintf(w, "Output:\n")
+	if len(diff.AddedFiles)+len(diff.RemovedFiles)+len(diff.ChangedFiles) == 0 {
+		fmt.Fprintf(w, "  no changes\n")
+	}
+	for _, files := range []struct {
+		prefix string
+		files  []*pfsclient.FileInfo
+	}{{"+", diff.AddedFiles}, {"-", diff.RemovedFiles}, {"~", diff.ChangedFiles}} {
+		for _, fileInfo := range files.files {
+			fmt.Fprintf(w, "  %s %s\n", files.prefix, fileInfo.File.Path)
+		}
+	}
+}
+
+func printDiffDatums(w io.Writer, prefix string, datums []*ppsclient.DatumInfo, total int64) {
+	for _, datumInfo := range datums {
+		var files []string
+		for _, fileInfo := range datumInfo.Data {
+			files = append(files, fmt.Sprintf("%s@%s:%s", fileInfo.File.Commit.Repo.Name, fileInfo.File.Commit.ID, fileInfo.File.Path))
+		}
+		fmt.Fprintf(w, "  %s %s\n", prefix, strings.Join(files, ", "))
+	}
+	if int64(len(datums)) < total {
+		fmt.Fprintf(w, "  %s ... %d more\n", prefix, total-int64(len(datums)))
+	}
+}
+
+func diffValue(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	return value
+}
+
+func diffCommit(commit *pfsclient.Commit) string {
+	if commit == nil {
+		return "(none)"
+	}
+	if commit.ID == "" {
+		return commit.Repo.Name + "@(no commits)"
+	}
+	return commit.Repo.Name + "@" + commit.ID
+}
+
 // PrintFileHeader prints the header for a pfs file.
 func PrintFileHeader(w io.Writer) {
 	fmt.Fprintf(w, "  REPO\tCOMMIT\tPATH\t\n")
@@ -373,6 +677,28 @@ func pipelineState(pipelineState ppsclient.PipelineState) string {
 	return "-"
 }
 
+func dagNodeFailed(node *ppsclient.DAGNode) bool {
+	return node.State == ppsclient.PipelineState_PIPELINE_FAILURE ||
+		node.State == ppsclient.PipelineState_PIPELINE_CRASHING ||
+		node.LastJobState == ppsclient.JobState_JOB_FAILURE
+}
+
+// dagEdges calls f with the indexes of the ends of each edge between nodes.
+// Inputs that aren't in nodes are skipped.
+func dagEdges(nodes []*ppsclient.DAGNode, f func(from, to int)) {
+	index := make(map[string]int)
+	for i, node := range nodes {
+		index[node.Repo.Name] = i
+	}
+	for i, node := range nodes {
+		for _, input := range node.Inputs {
+			if from, ok := index[input.Name]; ok {
+				f(from, i)
+			}
+		}
+	}
+}
+
 func jobInput(jobInfo PrintableJobInfo) string {
 	if jobInfo.Input == nil {
 		return ""
@@ -415,6 +741,236 @@ func jobCounts(counts map[int32]int32) string {
 	return buffer.String()
 }
 
+func egressTargets(egress *ppsclient.Egress) string {
+	var targets []string
+	for _, target := range ppsclient.EgressTargets(egress) {
+		targets = append(targets, ppsclient.EgressTarget(target))
+	}
+	return strings.Join(targets, ", ")
+}
+
+func prettyCondition(condition *ppsclient.PipelineCondition) string {
+	var checks []string
+	if condition.Input != "" {
+		checks = append(checks, "input: "+condition.Input)
+	}
+	if condition.Glob != "" {
+		checks = append(checks, "glob: "+condition.Glob)
+	}
+	if condition.MinSizeBytes != 0 {
+		checks = append(checks, "min size: "+units.BytesSize(float64(condition.MinSizeBytes)))
+	}
+	if condition.MaxSizeBytes != 0 {
+		checks = append(checks, "max size: "+units.BytesSize(float64(condition.MaxSizeBytes)))
+	}
+	if condition.Description != "" {
+		checks = append(checks, "description: "+condition.Description)
+	}
+	return strings.Join(checks, ", ")
+}
+
+func prettyAutoscaling(autoscaling *ppsclient.Autoscaling, status *ppsclient.AutoscalingStatus) string {
+	result := fmt.Sprintf("%d-%d workers, %d datums per worker", autoscaling.MinWorkers,
+		autoscaling.MaxWorkers, autoscaling.TargetDatumsPerWorker)
+	if autoscaling.ScaleDownDelay != nil {
+		result += ", scale down delay " + pretty.Duration(autoscaling.ScaleDownDelay)
+	}
+	if status != nil {
+		result += fmt.Sprintf("\n  Workers: %d (%s %s)", status.Workers, status.Reason, pretty.Ago(status.LastScaled))
+	}
+	return result
+}
+
+func prettyTemplateRef(ref *ppsclient.TemplateRef) string {
+	var params []string
+	for name, value := range ref.Params {
+		params = append(params, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(params)
+	result := fmt.Sprintf("%s@%d", ref.Template.Name, ref.Version)
+	if len(params) > 0 {
+		result += " (" + strings.Join(params, ", ") + ")"
+	}
+	return result
+}
+
+func prettyQueueStatus(status *ppsclient.QueueStatus) string {
+	result := fmt.Sprintf("%s, priority %d", status.Queue, status.Priority)
+	if status.Waiting {
+		return result + fmt.Sprintf(", waiting for %d workers since %s: %s",
+			status.Workers, pretty.Ago(status.WaitingSince), status.Reason)
+	}
+	return result + fmt.Sprintf(", %d workers", status.Workers)
+}
+
+// AlertRule returns a rule in the form it's passed to 'pachctl create alert
+// --on', e.g. "datum-failure-rate=0.1".
+func AlertRule(rule *ppsclient.AlertRule) string {
+	name := strings.Replace(strings.ToLower(strings.TrimPrefix(rule.Condition.String(), "ALERT_")), "_", "-", -1)
+	switch rule.Condition {
+	case ppsclient.AlertCondition_ALERT_PIPELINE_CRASHING:
+		name = "crashing"
+		if rule.Duration != nil {
+			name += "=" + alertRuleDuration(rule.Duration)
+		}
+	case ppsclient.AlertCondition_ALERT_DATUM_FAILURE_RATE:
+		name += fmt.Sprintf("=%g", rule.FailureRate)
+	case ppsclient.AlertCondition_ALERT_JOB_DURATION:
+		name += "=" + alertRuleDuration(rule.Duration)
+	}
+	return name
+}
+
+func alertRuleDuration(d *types.Duration) string {
+	duration, _ := types.DurationFromProto(d)
+	return duration.String()
+}
+
+func prettyAlertRules(rules []*ppsclient.AlertRule) string {
+	var result []string
+	for _, rule := range rules {
+		result = append(result, AlertRule(rule))
+	}
+	return strings.Join(result, ", ")
+}
+
+func prettyAlertSpec(spec *ppsclient.AlertSpec) string {
+	return fmt.Sprintf("%s -> %s", prettyAlertRules(spec.Rules), spec.WebhookURL)
+}
+
+// prettyWorkerPool describes a worker pool and the datums it processes, e.g.
+// "large (2 workers): size >= 1.0GB, glob /videos/*".
+func prettyWorkerPool(pool *ppsclient.WorkerPool) string {
+	workers := pool.Parallelism
+	if workers == 0 {
+		workers = 1
+	}
+	var rules []string
+	if pool.MinSizeBytes != 0 {
+		rules = append(rules, fmt.Sprintf("size >= %s", pretty.Size(pool.MinSizeBytes)))
+	}
+	if pool.MaxSizeBytes != 0 {
+		rules = append(rules, fmt.Sprintf("size <= %s", pretty.Size(pool.MaxSizeBytes)))
+	}
+	if pool.Glob != "" {
+		rules = append(rules, fmt.Sprintf("glob %s", pool.Glob))
+	}
+	result := fmt.Sprintf("%s (%d workers): %s", pool.Name, workers, strings.Join(rules, ", "))
+	if pool.ResourceRequests != nil {
+		result += fmt.Sprintf(", requests cpu %v memory %s", pool.ResourceRequests.Cpu, pool.ResourceRequests.Memory)
+		if pool.ResourceRequests.Gpu != nil {
+			result += fmt.Sprintf(" gpu %d", pool.ResourceRequests.Gpu.Number)
+		}
+	}
+	return result
+}
+
+// prettyPreemption describes a pipeline's PreemptionSpec, e.g.
+// "grace period 30s, checkpointing".
+func prettyPreemption(spec *ppsclient.PreemptionSpec) string {
+	result := "default grace period"
+	if spec.GracePeriod != nil {
+		gracePeriod, err := types.DurationFromProto(spec.GracePeriod)
+		if err != nil {
+			return err.Error()
+		}
+		result = fmt.Sprintf("grace period %v", gracePeriod)
+	}
+	if spec.Checkpoint {
+		result += ", checkpointing"
+	}
+	return result
+}
+
+// PrintAlertInfo pretty-prints alert info.
+func PrintAlertInfo(w io.Writer, alertInfo *ppsclient.AlertInfo, fullTimestamps bool) {
+	pipelines := "all"
+	if len(alertInfo.Pipelines) > 0 {
+		var names []string
+		for _, pipeline := range alertInfo.Pipelines {
+			names = append(names, pipeline.Name)
+		}
+		pipelines = strings.Join(names, ", ")
+	}
+	created := pretty.Ago(alertInfo.CreatedAt)
+	if fullTimestamps {
+		created = alertInfo.CreatedAt.String()
+	}
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n", alertInfo.Alert.Name,
+		prettyAlertRules(alertInfo.Rules), pipelines, alertInfo.WebhookURL, created)
+}
+
+// PrintDetailedAlertInfo pretty-prints detailed alert info, including the
+// most recent events it has fired and whether they've been delivered.
+func PrintDetailedAlertInfo(w io.Writer, alertInfo *ppsclient.AlertInfo) error {
+	template, err := template.New("AlertInfo").Funcs(funcMap).Parse(
+		`Name: {{.Alert.Name}}{{if .Description}}
+Description: {{.Description}}{{end}}{{if .CreatedAt}}
+Created: {{prettyAgo .CreatedAt}}{{end}}
+Rules: {{prettyAlertRules .Rules}}
+Webhook: {{.WebhookURL}}
+Pipelines:{{if .Pipelines}}{{range .Pipelines}} {{.Name}}{{end}}{{else}} all{{end}}
+Events:
+{{range .Events}}  {{prettyAgo .FiredAt}}: {{.Message}} ({{if .Delivered}}delivered{{else if .LastError}}{{.Attempts}} failed attempts, last error: {{.LastError}}{{else}}pending{{end}})
+{{end}}`)
+	if err != nil {
+		return err
+	}
+	return template.Execute(w, alertInfo)
+}
+
+// prettyCPUTime prints cpu time to the second, as it's used to charge back
+// usage and a rough human duration isn't precise enough.
+func prettyCPUTime(d *types.Duration) string {
+	duration, _ := types.DurationFromProto(d)
+	return duration.Round(time.Second).String()
+}
+
+// PrintUsageInfo pretty-prints the resources used by a pipeline, or a group
+// of pipelines. Peak memory is the highest of any job.
+func PrintUsageInfo(w io.Writer, usageInfo *ppsclient.UsageInfo) {
+	name := usageInfo.Group
+	if name == "" {
+		name = "-"
+	}
+	total := usageInfo.Total
+	if total == nil {
+		total = &ppsclient.ProcessStats{}
+	}
+	fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", name,
+		len(usageInfo.Pipelines), usageInfo.Jobs, usageInfo.DataProcessed,
+		prettyCPUTime(total.CpuTime), pretty.Size(total.PeakMemoryBytes),
+		prettyCPUTime(total.ProcessTime), pretty.Size(total.DownloadBytes),
+		pretty.Size(total.UploadBytes))
+}
+
+func prettyMemory(bytes int64) string {
+	return pretty.Size(uint64(bytes))
+}
+
+func templateParameterType(t ppsclient.TemplateParameterType) string {
+	return strings.ToLower(t.String())
+}
+
+func egressStatus(statuses []*ppsclient.EgressStatus) string {
+	var buffer bytes.Buffer
+	for _, status := range statuses {
+		state := "pending"
+		if status.Error != "" {
+			state = "failed: " + status.Error
+		} else if status.Finished != nil {
+			state = "done"
+		}
+		fmt.Fprintf(&buffer, "  %s: %s, %d files uploaded (%s), %d files deleted",
+			status.Target, state, status.FilesUploaded, pretty.Size(status.BytesUploaded), status.FilesDeleted)
+		if status.BaseCommit != nil {
+			fmt.Fprintf(&buffer, ", since %s", status.BaseCommit.ID)
+		}
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+}
+
 func prettyTransform(transform *ppsclient.Transform) (string, error) {
 	result, err := json.MarshalIndent(transform, "", "  ")
 	if err != nil {
@@ -450,21 +1006,36 @@ func ShorthandInput(input *ppsclient.Input) string {
 		return "(" + strings.Join(subInput, " ∪ ") + ")"
 	case input.Cron != nil:
 		return fmt.Sprintf("%s:%s", input.Cron.Name, input.Cron.Spec)
+	case input.ObjectStorage != nil:
+		return fmt.Sprintf("%s:%s", input.ObjectStorage.Name, input.ObjectStorage.URL)
 	}
 	return ""
 }
 
 var funcMap = template.FuncMap{
-	"pipelineState":        pipelineState,
-	"jobState":             JobState,
-	"datumState":           datumState,
-	"workerStatus":         workerStatus,
-	"pipelineInput":        pipelineInput,
-	"jobInput":             jobInput,
-	"prettyAgo":            pretty.Ago,
-	"prettyTimeDifference": pretty.TimeDifference,
-	"prettyDuration":       pretty.Duration,
-	"prettySize":           pretty.Size,
-	"jobCounts":            jobCounts,
-	"prettyTransform":      prettyTransform,
+	"pipelineState":         pipelineState,
+	"jobState":              JobState,
+	"datumState":            datumState,
+	"workerStatus":          workerStatus,
+	"pipelineInput":         pipelineInput,
+	"jobInput":              jobInput,
+	"prettyAgo":             pretty.Ago,
+	"prettyTimeDifference":  pretty.TimeDifference,
+	"prettyDuration":        pretty.Duration,
+	"prettySize":            pretty.Size,
+	"jobCounts":             jobCounts,
+	"prettyTransform":       prettyTransform,
+	"egressTargets":         egressTargets,
+	"egressStatus":          egressStatus,
+	"prettyCondition":       prettyCondition,
+	"prettyTemplateRef":     prettyTemplateRef,
+	"prettyAutoscaling":     prettyAutoscaling,
+	"prettyQueueStatus":     prettyQueueStatus,
+	"prettyMemory":          prettyMemory,
+	"prettyAlertRules":      prettyAlertRules,
+	"prettyCPUTime":         prettyCPUTime,
+	"prettyAlertSpec":       prettyAlertSpec,
+	"prettyWorkerPool":      prettyWorkerPool,
+	"prettyPreemption":      prettyPreemption,
+	"templateParameterType": templateParameterType,
 }
@@ -0,0 +1,570 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+)
+
+const (
+	// alertInterval is how often the PPS master evaluates alert rules and
+	// delivers pending alert events.
+	alertInterval = 30 * time.Second
+	// alertJobs is the number of each pipeline's most recent jobs that job
+	// rules are evaluated against.
+	alertJobs = 10
+	// alertLookback is how long after a job finishes that it can still fire
+	// an alert, so that a new rule doesn't fire for every job in the past.
+	alertLookback = time.Hour
+	// alertRetention is how long events are kept after they fire. An event
+	// isn't fired again while it's kept.
+	alertRetention = 24 * time.Hour
+	// alertMaxAttempts is how many times the PPS master tries to deliver an
+	// event before giving up. Attempts back off exponentially from
+	// alertMinBackoff to alertMaxBackoff.
+	alertMaxAttempts = 10
+	alertMinBackoff  = 10 * time.Second
+	alertMaxBackoff  = 10 * time.Minute
+	// alertTimeout is how long a webhook has to respond to an event.
+	alertTimeout = 10 * time.Second
+	// alertEventsShown is the number of recent events that InspectAlert
+	// returns.
+	alertEventsShown = 20
+)
+
+// alertTarget is a set of alert rules that apply to a pipeline: either a
+// cluster alert or the pipeline's own rules (in which case alert is empty).
+type alertTarget struct {
+	alert      string
+	rules      []*pps.AlertRule
+	webhookURL string
+	// since is when the rules were created, jobs that finished before then
+	// don't fire
+	since time.Time
+}
+
+// pipelineAlerts are a pipeline's own alert rules, from its spec.
+type pipelineAlerts struct {
+	spec *pps.AlertSpec
+	// since is when the pipeline version with the rules was created
+	since time.Time
+}
+
+// alertAppliesTo returns true if 'alertInfo' applies to 'pipeline'.
+func alertAppliesTo(alertInfo *pps.AlertInfo, pipeline string) bool {
+	if len(alertInfo.Pipelines) == 0 {
+		return true
+	}
+	for _, p := range alertInfo.Pipelines {
+		if p.Name == pipeline {
+			return true
+		}
+	}
+	return false
+}
+
+// alertTargets returns the cluster alerts that apply to 'pipeline', followed
+// by the pipeline's own alert rules in 'own', if any.
+func alertTargets(alerts []*pps.AlertInfo, pipeline string, own *pipelineAlerts) []*alertTarget {
+	var result []*alertTarget
+	for _, alertInfo := range alerts {
+		if !alertAppliesTo(alertInfo, pipeline) {
+			continue
+		}
+		since, _ := types.TimestampFromProto(alertInfo.CreatedAt)
+		result = append(result, &alertTarget{
+			alert:      alertInfo.Alert.Name,
+			rules:      alertInfo.Rules,
+			webhookURL: alertInfo.WebhookURL,
+			since:      since,
+		})
+	}
+	if own != nil && own.spec != nil && len(own.spec.Rules) > 0 {
+		result = append(result, &alertTarget{
+			rules:      own.spec.Rules,
+			webhookURL: own.spec.WebhookURL,
+			since:      own.since,
+		})
+	}
+	return result
+}
+
+func (t *alertTarget) has(conditions ...pps.AlertCondition) bool {
+	for _, rule := range t.rules {
+		for _, condition := range conditions {
+			if rule.Condition == condition {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// alertEventID identifies an event, so that each job fires at most one event
+// per rule, and each crashing pipeline fires one event until it recovers.
+// It's hashed because pipeline and alert names can't be used in etcd keys
+// as-is.
+func alertEventID(alert, pipeline string, condition pps.AlertCondition, job string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{alert, pipeline, condition.String(), job}, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+func newAlertEvent(target *alertTarget, pipeline string, condition pps.AlertCondition, job *pps.Job, now time.Time, format string, args ...interface{}) *pps.AlertEvent {
+	var jobID string
+	if job != nil {
+		job = proto.Clone(job).(*pps.Job)
+		jobID = job.ID
+	}
+	firedAt, _ := types.TimestampProto(now)
+	return &pps.AlertEvent{
+		ID:          alertEventID(target.alert, pipeline, condition, jobID),
+		Alert:       target.alert,
+		Pipeline:    client.NewPipeline(pipeline),
+		Job:         job,
+		Condition:   condition,
+		Message:     fmt.Sprintf(format, args...),
+		WebhookURL:  target.webhookURL,
+		FiredAt:     firedAt,
+		NextAttempt: firedAt,
+	}
+}
+
+// jobAlerts returns the events that 'jobPtr' fires for 'target's job rules.
+// Running jobs can fire datum failure rate and job duration events before
+// they finish.
+func jobAlerts(target *alertTarget, jobPtr *pps.EtcdJobInfo, now time.Time) []*pps.AlertEvent {
+	if jobPtr.Started == nil {
+		return nil
+	}
+	started, err := types.TimestampFromProto(jobPtr.Started)
+	if err != nil {
+		return nil
+	}
+	finished, done := now, ppsutil.IsTerminal(jobPtr.State)
+	if done {
+		if finished, err = types.TimestampFromProto(jobPtr.Finished); err != nil {
+			return nil
+		}
+		if finished.Before(target.since) || now.Sub(finished) > alertLookback {
+			return nil
+		}
+	}
+	pipeline := jobPtr.Pipeline.Name
+	var result []*pps.AlertEvent
+	for _, rule := range target.rules {
+		switch rule.Condition {
+		case pps.AlertCondition_ALERT_JOB_FAILURE:
+			if jobPtr.State == pps.JobState_JOB_FAILURE {
+				result = append(result, newAlertEvent(target, pipeline, rule.Condition, jobPtr.Job, now,
+					"job %s failed: %s", jobPtr.Job.ID, jobPtr.Reason))
+			}
+		case pps.AlertCondition_ALERT_DATUM_FAILURE_RATE:
+			if jobPtr.DataTotal > 0 && float64(jobPtr.DataFailed)/float64(jobPtr.DataTotal) > rule.FailureRate {
+				result = append(result, newAlertEvent(target, pipeline, rule.Condition, jobPtr.Job, now,
+					"%d of %d datums failed in job %s, more than %g%%", jobPtr.DataFailed, jobPtr.DataTotal,
+					jobPtr.Job.ID, rule.FailureRate*100))
+			}
+		case pps.AlertCondition_ALERT_JOB_DURATION:
+			max, err := types.DurationFromProto(rule.Duration)
+			if err != nil || finished.Sub(started) <= max {
+				continue
+			}
+			ran := "has been running for"
+			if done {
+				ran = "ran for"
+			}
+			result = append(result, newAlertEvent(target, pipeline, rule.Condition, jobPtr.Job, now,
+				"job %s %s %v, longer than %v", jobPtr.Job.ID, ran, finished.Sub(started).Round(time.Second), max))
+		}
+	}
+	return result
+}
+
+// crashingAlerts returns the events that 'pipeline' fires for 'target's
+// crashing rules, given that it's been crashing since 'since'.
+func crashingAlerts(target *alertTarget, pipeline, reason string, since, now time.Time) []*pps.AlertEvent {
+	var result []*pps.AlertEvent
+	for _, rule := range target.rules {
+		if rule.Condition != pps.AlertCondition_ALERT_PIPELINE_CRASHING {
+			continue
+		}
+		var max time.Duration
+		if rule.Duration != nil {
+			var err error
+			if max, err = types.DurationFromProto(rule.Duration); err != nil {
+				continue
+			}
+		}
+		if now.Sub(since) >= max {
+			result = append(result, newAlertEvent(target, pipeline, rule.Condition, nil, now,
+				"pipeline %s has been crashing for %v: %s", pipeline, now.Sub(since).Round(time.Second), reason))
+		}
+	}
+	return result
+}
+
+// alertBackoff returns how long to wait before retrying an event that has
+// failed to be delivered 'attempts' times.
+func alertBackoff(attempts int64) time.Duration {
+	backoff := alertMinBackoff
+	for i := int64(1); i < attempts && backoff < alertMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > alertMaxBackoff {
+		return alertMaxBackoff
+	}
+	return backoff
+}
+
+// alertPayload is the JSON body that's posted to an alert's webhook.
+type alertPayload struct {
+	ID        string `json:"id"`
+	Alert     string `json:"alert,omitempty"`
+	Condition string `json:"condition"`
+	Pipeline  string `json:"pipeline"`
+	Job       string `json:"job,omitempty"`
+	Message   string `json:"message"`
+	FiredAt   string `json:"fired_at"`
+}
+
+// postAlert posts 'event' to its webhook.
+func postAlert(ctx context.Context, event *pps.AlertEvent) error {
+	payload := alertPayload{
+		ID:        event.ID,
+		Alert:     event.Alert,
+		Condition: strings.ToLower(strings.TrimPrefix(event.Condition.String(), "ALERT_")),
+		Pipeline:  event.Pipeline.Name,
+		Message:   event.Message,
+	}
+	if event.Job != nil {
+		payload.Job = event.Job.ID
+	}
+	if firedAt, err := types.TimestampFromProto(event.FiredAt); err == nil {
+		payload.FiredAt = firedAt.Format(time.RFC3339)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal alert event %s", event.ID)
+	}
+	ctx, cancel := context.WithTimeout(ctx, alertTimeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPost, event.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "could not create request for webhook %q", event.WebhookURL)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "could not post alert event %s", event.ID)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func validateWebhookURL(webhookURL string) error {
+	if webhookURL == "" {
+		return errors.New("webhook_url must be set")
+	}
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid webhook_url")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("webhook_url %q must be an http or https URL", webhookURL)
+	}
+	return nil
+}
+
+func validateAlertRules(rules []*pps.AlertRule, webhookURL string) error {
+	if len(rules) == 0 {
+		return errors.New("at least one alert rule must be set")
+	}
+	for _, rule := range rules {
+		switch rule.Condition {
+		case pps.AlertCondition_ALERT_DATUM_FAILURE_RATE:
+			if rule.FailureRate <= 0 || rule.FailureRate > 1 {
+				return errors.Errorf("failure_rate must be greater than 0 and at most 1, got %g", rule.FailureRate)
+			}
+		case pps.AlertCondition_ALERT_JOB_DURATION:
+			if d, err := types.DurationFromProto(rule.Duration); err != nil || d <= 0 {
+				return errors.New("job duration rules must have a positive duration")
+			}
+		case pps.AlertCondition_ALERT_PIPELINE_CRASHING:
+			if rule.Duration == nil {
+				continue
+			}
+			if d, err := types.DurationFromProto(rule.Duration); err != nil || d < 0 {
+				return errors.New("pipeline crashing rules can't have a negative duration")
+			}
+		case pps.AlertCondition_ALERT_JOB_FAILURE:
+		default:
+			return errors.Errorf("unknown alert condition %v", rule.Condition)
+		}
+	}
+	return validateWebhookURL(webhookURL)
+}
+
+func validateAlert(alertInfo *pps.AlertInfo) error {
+	if alertInfo.Alert == nil || alertInfo.Alert.Name == "" {
+		return errors.New("alert name cannot be empty")
+	}
+	if err := ancestry.ValidateName(alertInfo.Alert.Name); err != nil {
+		return errors.Wrapf(err, "invalid alert name")
+	}
+	for _, pipeline := range alertInfo.Pipelines {
+		if pipeline == nil || pipeline.Name == "" {
+			return errors.New("pipeline names cannot be empty")
+		}
+	}
+	return validateAlertRules(alertInfo.Rules, alertInfo.WebhookURL)
+}
+
+// listAlerts returns every cluster alert, without its events.
+func (a *apiServer) listAlerts(ctx context.Context) ([]*pps.AlertInfo, error) {
+	var result []*pps.AlertInfo
+	alertInfo := &pps.AlertInfo{}
+	if err := a.alerts.ReadOnly(ctx).List(alertInfo, col.DefaultOptions, func(string) error {
+		result = append(result, proto.Clone(alertInfo).(*pps.AlertInfo))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Alert.Name < result[j].Alert.Name
+	})
+	return result, nil
+}
+
+// listAlertEvents returns every alert event.
+func (a *apiServer) listAlertEvents(ctx context.Context) ([]*pps.AlertEvent, error) {
+	var result []*pps.AlertEvent
+	event := &pps.AlertEvent{}
+	if err := a.alertEvents.ReadOnly(ctx).List(event, col.DefaultOptions, func(string) error {
+		result = append(result, proto.Clone(event).(*pps.AlertEvent))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// runAlerts evaluates alert rules and delivers alert events until the PPS
+// master's context is cancelled.
+func (a *apiServer) runAlerts(pachClient *client.APIClient) {
+	// specs caches each pipeline's own alert rules by spec commit
+	specs := make(map[string]*pipelineAlerts)
+	ticker := time.NewTicker(alertInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pachClient.Ctx().Done():
+			return
+		case <-ticker.C:
+			var err error
+			if specs, err = a.evaluateAlerts(pachClient, specs); err != nil {
+				log.Errorf("PPS master: error evaluating alerts: %v", err)
+			}
+			if err := a.deliverAlerts(pachClient.Ctx()); err != nil {
+				log.Errorf("PPS master: error delivering alerts: %v", err)
+			}
+		}
+	}
+}
+
+// evaluateAlerts evaluates the alert rules of every pipeline and records the
+// events that fire. It returns the pipelines' own alert rules by spec commit,
+// to be passed back in on the next call.
+func (a *apiServer) evaluateAlerts(pachClient *client.APIClient, specs map[string]*pipelineAlerts) (map[string]*pipelineAlerts, error) {
+	ctx := pachClient.Ctx()
+	alerts, err := a.listAlerts(ctx)
+	if err != nil {
+		return specs, err
+	}
+	pipelinePtrs := make(map[string]*pps.EtcdPipelineInfo)
+	pipelinePtr := &pps.EtcdPipelineInfo{}
+	if err := a.pipelines.ReadOnly(ctx).List(pipelinePtr, col.DefaultOptions, func(name string) error {
+		pipelinePtrs[name] = proto.Clone(pipelinePtr).(*pps.EtcdPipelineInfo)
+		return nil
+	}); err != nil {
+		return specs, err
+	}
+
+	now := time.Now()
+	newSpecs := make(map[string]*pipelineAlerts)
+	var events []*pps.AlertEvent
+	// recovered are the crashing events of pipelines that aren't crashing
+	// anymore, which can fire again
+	var recovered []string
+	for name, ptr := range pipelinePtrs {
+		spec, ok := specs[ptr.SpecCommit.ID]
+		if !ok {
+			if err := a.sudo(pachClient, func(superUserClient *client.APIClient) error {
+				pipelineInfo, err := ppsutil.GetPipelineInfo(superUserClient, name, ptr)
+				if err != nil {
+					return err
+				}
+				spec = &pipelineAlerts{spec: pipelineInfo.Alerts}
+				spec.since, _ = types.TimestampFromProto(pipelineInfo.CreatedAt)
+				return nil
+			}); err != nil {
+				return specs, err
+			}
+		}
+		newSpecs[ptr.SpecCommit.ID] = spec
+
+		targets := alertTargets(alerts, name, spec)
+		// crashing_since is recorded with the pipeline's state, so it survives
+		// the PPS master restarting
+		crashing := ptr.State == pps.PipelineState_PIPELINE_CRASHING
+		crashingSince := now
+		if crashing && ptr.CrashingSince != nil {
+			crashingSince, _ = types.TimestampFromProto(ptr.CrashingSince)
+		}
+		for _, target := range targets {
+			if crashing {
+				events = append(events, crashingAlerts(target, name, ptr.Reason, crashingSince, now)...)
+			} else if target.has(pps.AlertCondition_ALERT_PIPELINE_CRASHING) {
+				recovered = append(recovered, alertEventID(target.alert, name, pps.AlertCondition_ALERT_PIPELINE_CRASHING, ""))
+			}
+		}
+
+		var jobTargets []*alertTarget
+		for _, target := range targets {
+			if target.has(pps.AlertCondition_ALERT_JOB_FAILURE, pps.AlertCondition_ALERT_DATUM_FAILURE_RATE, pps.AlertCondition_ALERT_JOB_DURATION) {
+				jobTargets = append(jobTargets, target)
+			}
+		}
+		if len(jobTargets) == 0 {
+			continue
+		}
+		// jobs are listed newest first
+		var n int
+		jobPtr := &pps.EtcdJobInfo{}
+		if err := a.jobs.ReadOnly(ctx).GetByIndex(ppsdb.JobsPipelineIndex, client.NewPipeline(name), jobPtr, col.DefaultOptions, func(string) error {
+			for _, target := range jobTargets {
+				events = append(events, jobAlerts(target, jobPtr, now)...)
+			}
+			if n++; n >= alertJobs {
+				return errutil.ErrBreak
+			}
+			return nil
+		}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+			return newSpecs, err
+		}
+	}
+	if len(events) == 0 && len(recovered) == 0 {
+		return newSpecs, nil
+	}
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		alertEvents := a.alertEvents.ReadWrite(stm)
+		for _, event := range events {
+			var existing pps.AlertEvent
+			if err := alertEvents.Get(event.ID, &existing); err == nil {
+				continue // already fired
+			} else if !col.IsErrNotFound(err) {
+				return err
+			}
+			if err := alertEvents.Put(event.ID, event); err != nil {
+				return err
+			}
+		}
+		for _, id := range recovered {
+			var existing pps.AlertEvent
+			if err := alertEvents.Get(id, &existing); err != nil {
+				if col.IsErrNotFound(err) {
+					continue
+				}
+				return err
+			}
+			// an event that's still being delivered is kept until it's done
+			if existing.Delivered || existing.Attempts >= alertMaxAttempts {
+				if err := alertEvents.Delete(id); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return newSpecs, err
+	}
+	return newSpecs, nil
+}
+
+// deliverAlerts posts the alert events that are due to their webhooks, and
+// deletes events that are older than alertRetention.
+func (a *apiServer) deliverAlerts(ctx context.Context) error {
+	events, err := a.listAlertEvents(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, event := range events {
+		firedAt, _ := types.TimestampFromProto(event.FiredAt)
+		if now.Sub(firedAt) > alertRetention {
+			if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+				return a.alertEvents.ReadWrite(stm).Delete(event.ID)
+			}); err != nil && !col.IsErrNotFound(err) {
+				return err
+			}
+			continue
+		}
+		if event.Delivered || event.Attempts >= alertMaxAttempts {
+			continue
+		}
+		if next, err := types.TimestampFromProto(event.NextAttempt); err == nil && now.Before(next) {
+			continue
+		}
+		err := postAlert(ctx, event)
+		event.Attempts++
+		if err == nil {
+			event.Delivered = true
+			event.LastError = ""
+		} else {
+			event.LastError = err.Error()
+			event.NextAttempt, _ = types.TimestampProto(now.Add(alertBackoff(event.Attempts)))
+			if event.Attempts >= alertMaxAttempts {
+				log.Errorf("PPS master: giving up on delivering alert %s for pipeline %s after %d attempts: %v",
+					event.ID, event.Pipeline.Name, event.Attempts, err)
+			}
+		}
+		if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+			alertEvents := a.alertEvents.ReadWrite(stm)
+			// the event's alert may have been deleted while it was delivered
+			var existing pps.AlertEvent
+			if err := alertEvents.Get(event.ID, &existing); err != nil {
+				if col.IsErrNotFound(err) {
+					return nil
+				}
+				return err
+			}
+			return alertEvents.Put(event.ID, event)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func timestamp(t time.Time) *types.Timestamp {
+	ts, _ := types.TimestampProto(t)
+	return ts
+}
+
+func TestAlertTargets(t *testing.T) {
+	alerts := []*pps.AlertInfo{
+		{Alert: &pps.Alert{Name: "all"}, Rules: []*pps.AlertRule{{}}},
+		{Alert: &pps.Alert{Name: "edges-only"}, Rules: []*pps.AlertRule{{}}, Pipelines: []*pps.Pipeline{client.NewPipeline("edges")}},
+	}
+	spec := &pps.AlertSpec{Rules: []*pps.AlertRule{{}}, WebhookURL: "http://hooks/montage"}
+	created := time.Now().Add(-time.Hour)
+	targets := alertTargets(alerts, "montage", &pipelineAlerts{spec: spec, since: created})
+	require.Equal(t, 2, len(targets))
+	require.Equal(t, "all", targets[0].alert)
+	require.Equal(t, "", targets[1].alert)
+	require.Equal(t, "http://hooks/montage", targets[1].webhookURL)
+	// the pipeline's rules don't fire for jobs from before they were created
+	require.Equal(t, created, targets[1].since)
+	require.Equal(t, 2, len(alertTargets(alerts, "edges", nil)))
+	require.Equal(t, 2, len(alertTargets(alerts, "edges", &pipelineAlerts{})))
+}
+
+func TestJobAlerts(t *testing.T) {
+	now := time.Now()
+	target := &alertTarget{
+		alert: "ops",
+		rules: []*pps.AlertRule{
+			{Condition: pps.AlertCondition_ALERT_JOB_FAILURE},
+			{Condition: pps.AlertCondition_ALERT_DATUM_FAILURE_RATE, FailureRate: 0.1},
+			{Condition: pps.AlertCondition_ALERT_JOB_DURATION, Duration: types.DurationProto(time.Hour)},
+		},
+		since: now.Add(-24 * time.Hour),
+	}
+	job := func(state pps.JobState, started, finished time.Duration) *pps.EtcdJobInfo {
+		jobPtr := &pps.EtcdJobInfo{
+			Job:       client.NewJob("j1"),
+			Pipeline:  client.NewPipeline("edges"),
+			State:     state,
+			Started:   timestamp(now.Add(-started)),
+			DataTotal: 100,
+		}
+		if finished > 0 {
+			jobPtr.Finished = timestamp(now.Add(-finished))
+		}
+		return jobPtr
+	}
+
+	// a failed job fires once per matching rule
+	failed := job(pps.JobState_JOB_FAILURE, 10*time.Minute, time.Minute)
+	failed.Reason = "datum failed"
+	failed.DataFailed = 20
+	events := jobAlerts(target, failed, now)
+	require.Equal(t, 2, len(events))
+	require.Equal(t, pps.AlertCondition_ALERT_JOB_FAILURE, events[0].Condition)
+	require.Equal(t, "job j1 failed: datum failed", events[0].Message)
+	require.Equal(t, pps.AlertCondition_ALERT_DATUM_FAILURE_RATE, events[1].Condition)
+	require.Equal(t, "j1", events[1].Job.ID)
+	require.NotEqual(t, events[0].ID, events[1].ID)
+	// events are identified by their alert, pipeline, condition and job
+	require.Equal(t, events[0].ID, jobAlerts(target, failed, now.Add(time.Minute))[0].ID)
+
+	// a job that's been running too long fires before it finishes
+	events = jobAlerts(target, job(pps.JobState_JOB_RUNNING, 2*time.Hour, 0), now)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, "job j1 has been running for 2h0m0s, longer than 1h0m0s", events[0].Message)
+	require.Equal(t, 0, len(jobAlerts(target, job(pps.JobState_JOB_SUCCESS, 30*time.Minute, time.Minute), now)))
+
+	// jobs that finished before the lookback, or before the alert was
+	// created, don't fire
+	require.Equal(t, 0, len(jobAlerts(target, job(pps.JobState_JOB_FAILURE, 3*time.Hour, 2*time.Hour), now)))
+	target.since = now.Add(-30 * time.Second)
+	require.Equal(t, 0, len(jobAlerts(target, failed, now)))
+	require.Equal(t, 0, len(jobAlerts(target, &pps.EtcdJobInfo{State: pps.JobState_JOB_STARTING}, now)))
+}
+
+func TestCrashingAlerts(t *testing.T) {
+	now := time.Now()
+	target := &alertTarget{rules: []*pps.AlertRule{
+		{Condition: pps.AlertCondition_ALERT_PIPELINE_CRASHING, Duration: types.DurationProto(5 * time.Minute)},
+	}}
+	require.Equal(t, 0, len(crashingAlerts(target, "edges", "image pull failed", now.Add(-time.Minute), now)))
+	events := crashingAlerts(target, "edges", "image pull failed", now.Add(-10*time.Minute), now)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, "pipeline edges has been crashing for 10m0s: image pull failed", events[0].Message)
+	require.Nil(t, events[0].Job)
+}
+
+func TestAlertBackoff(t *testing.T) {
+	require.Equal(t, 10*time.Second, alertBackoff(1))
+	require.Equal(t, 20*time.Second, alertBackoff(2))
+	require.Equal(t, 80*time.Second, alertBackoff(4))
+	require.Equal(t, alertMaxBackoff, alertBackoff(alertMaxAttempts))
+}
+
+func TestValidateAlertRules(t *testing.T) {
+	url := "https://hooks.example.com/pachyderm"
+	require.NoError(t, validateAlertRules([]*pps.AlertRule{
+		{Condition: pps.AlertCondition_ALERT_JOB_FAILURE},
+		{Condition: pps.AlertCondition_ALERT_PIPELINE_CRASHING},
+	}, url))
+	require.YesError(t, validateAlertRules(nil, url))
+	require.YesError(t, validateAlertRules([]*pps.AlertRule{{}}, ""))
+	require.YesError(t, validateAlertRules([]*pps.AlertRule{{}}, "hooks.example.com"))
+	require.YesError(t, validateAlertRules([]*pps.AlertRule{
+		{Condition: pps.AlertCondition_ALERT_DATUM_FAILURE_RATE, FailureRate: 1.5},
+	}, url))
+	require.YesError(t, validateAlertRules([]*pps.AlertRule{
+		{Condition: pps.AlertCondition_ALERT_JOB_DURATION},
+	}, url))
+}
+
+func TestPostAlert(t *testing.T) {
+	var payload alertPayload
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	target := &alertTarget{alert: "ops", webhookURL: server.URL}
+	event := newAlertEvent(target, "edges", pps.AlertCondition_ALERT_JOB_FAILURE, client.NewJob("j1"), time.Now(), "job %s failed", "j1")
+	require.NoError(t, postAlert(context.Background(), event))
+	require.Equal(t, event.ID, payload.ID)
+	require.Equal(t, "ops", payload.Alert)
+	require.Equal(t, "job_failure", payload.Condition)
+	require.Equal(t, "edges", payload.Pipeline)
+	require.Equal(t, "j1", payload.Job)
+	require.Equal(t, "job j1 failed", payload.Message)
+
+	status = http.StatusServiceUnavailable
+	require.YesError(t, postAlert(context.Background(), event))
+}
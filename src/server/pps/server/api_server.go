@@ -9,6 +9,7 @@ import (
 	"math"
 	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/auth"
@@ -30,10 +32,12 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/log"
 	"github.com/pachyderm/pachyderm/src/server/pkg/lokiutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/metrics"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
@@ -118,9 +122,16 @@ type apiServer struct {
 	httpPort               uint16
 	peerPort               uint16
 	gcPercent              int
+	// maxWorkers is the number of workers that the cluster's queues share, 0 if
+	// unlimited
+	maxWorkers uint64
 	// collections
-	pipelines col.Collection
-	jobs      col.Collection
+	pipelines   col.Collection
+	jobs        col.Collection
+	templates   col.Collection
+	queues      col.Collection
+	alerts      col.Collection
+	alertEvents col.Collection
 }
 
 func merge(from, to map[string]bool) {
@@ -143,6 +154,11 @@ func validateNames(names map[string]bool, input *pps.Input) error {
 			return errors.Errorf(`name "%s" was used more than once`, input.Cron.Name)
 		}
 		names[input.Cron.Name] = true
+	case input.ObjectStorage != nil:
+		if names[input.ObjectStorage.Name] {
+			return errors.Errorf(`name "%s" was used more than once`, input.ObjectStorage.Name)
+		}
+		names[input.ObjectStorage.Name] = true
 	case input.Union != nil:
 		for _, input := range input.Union {
 			namesCopy := make(map[string]bool)
@@ -271,6 +287,27 @@ func (a *apiServer) validateInput(pachClient *client.APIClient, pipelineName str
 					return errors.Wrapf(err, "error parsing cron-spec")
 				}
 			}
+			if input.ObjectStorage != nil {
+				if set {
+					return errors.Errorf("multiple input types set")
+				}
+				set = true
+				if len(input.ObjectStorage.Name) == 0 {
+					return errors.Errorf("input must specify a name")
+				}
+				if _, err := obj.ParseURL(input.ObjectStorage.URL); err != nil {
+					return err
+				}
+				if input.ObjectStorage.PollInterval != nil {
+					interval, err := types.DurationFromProto(input.ObjectStorage.PollInterval)
+					if err != nil {
+						return err
+					}
+					if interval <= 0 {
+						return errors.Errorf("poll_interval must be positive")
+					}
+				}
+			}
 			if input.Git != nil {
 				if set {
 					return errors.Errorf("multiple input types set")
@@ -299,6 +336,14 @@ func validateTransform(transform *pps.Transform) error {
 	if transform.Image == "" {
 		return errors.Errorf("pipeline transform must contain an image")
 	}
+	if transform.ServerMode {
+		if len(transform.Cmd) == 0 {
+			return errors.Errorf("pipeline transform in server mode must contain a cmd")
+		}
+		if len(transform.Stdin) > 0 {
+			return errors.Errorf("pipeline transform in server mode can't have stdin, datums are sent to it over stdin")
+		}
+	}
 	return nil
 }
 
@@ -536,6 +581,7 @@ func (a *apiServer) UpdateJobStateInTransaction(txnCtx *txnenv.TransactionContex
 	jobPtr.DataRecovered = request.DataRecovered
 	jobPtr.DataTotal = request.DataTotal
 	jobPtr.Stats = request.Stats
+	jobPtr.EgressStatus = request.EgressStatus
 
 	return ppsutil.UpdateJobState(a.pipelines.ReadWrite(txnCtx.Stm), jobs, jobPtr, request.State, request.Reason)
 }
@@ -797,6 +843,7 @@ func (a *apiServer) jobInfoFromPtr(pachClient *client.APIClient, jobPtr *pps.Etc
 		Reason:        jobPtr.Reason,
 		Started:       jobPtr.Started,
 		Finished:      jobPtr.Finished,
+		EgressStatus:  jobPtr.EgressStatus,
 	}
 	commitInfo, err := pachClient.InspectCommit(jobPtr.OutputCommit.Repo.Name, jobPtr.OutputCommit.ID)
 	if err != nil {
@@ -942,6 +989,141 @@ func (a *apiServer) FlushJob(request *pps.FlushJobRequest, resp pps.API_FlushJob
 	})
 }
 
+// inspectJobSpec returns a job, with its inputs' commits, and the spec of the
+// pipeline that created it.
+func (a *apiServer) inspectJobSpec(pachClient *client.APIClient, job *pps.Job) (*pps.JobInfo, *pps.CreatePipelineRequest, error) {
+	jobInfo, err := a.InspectJob(pachClient.Ctx(), &pps.InspectJobRequest{Job: job, Full: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	var pipelineInfo *pps.PipelineInfo
+	if err := a.sudo(pachClient, func(superUserClient *client.APIClient) error {
+		pipelineInfo, err = ppsutil.GetPipelineInfo(superUserClient, jobInfo.Pipeline.Name,
+			&pps.EtcdPipelineInfo{SpecCommit: jobInfo.SpecCommit})
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+	return jobInfo, ppsutil.PipelineReqFromInfo(pipelineInfo), nil
+}
+
+// DiffJob implements the protobuf pps.DiffJob RPC
+func (a *apiServer) DiffJob(ctx context.Context, request *pps.DiffJobRequest) (response *pps.JobDiff, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	if request.A == nil || request.B == nil {
+		return nil, errors.Errorf("must specify two jobs to diff")
+	}
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = defaultDiffLimit
+	}
+
+	aInfo, aSpec, err := a.inspectJobSpec(pachClient, request.A)
+	if err != nil {
+		return nil, err
+	}
+	bInfo, bSpec, err := a.inspectJobSpec(pachClient, request.B)
+	if err != nil {
+		return nil, err
+	}
+	// The specs are read as the superuser, so their webhook URLs are hidden
+	// from non-admins, as in InspectPipeline
+	if err := redactAlertWebhookURLs(pachClient, "DiffJob with alerts.webhook_url", aSpec.Alerts, bSpec.Alerts); err != nil {
+		return nil, err
+	}
+	response = &pps.JobDiff{A: aInfo.Job, B: bInfo.Job}
+	if response.SpecChanges, err = specChanges(aSpec, bSpec); err != nil {
+		return nil, err
+	}
+	response.InputChanges = inputChanges(aInfo.Input, bInfo.Input)
+
+	aDatums, err := jobDatums(pachClient, aInfo.Input)
+	if err != nil {
+		return nil, err
+	}
+	bDatums, err := jobDatums(pachClient, bInfo.Input)
+	if err != nil {
+		return nil, err
+	}
+	diffDatums(response, aDatums, bDatums, limit)
+
+	// Outputs can only be compared once both jobs have finished writing them.
+	if ppsutil.IsTerminal(aInfo.State) && ppsutil.IsTerminal(bInfo.State) {
+		newFiles, oldFiles, err := pachClient.DiffFile(
+			bInfo.OutputCommit.Repo.Name, bInfo.OutputCommit.ID, "",
+			aInfo.OutputCommit.Repo.Name, aInfo.OutputCommit.ID, "", false)
+		if err != nil {
+			return nil, err
+		}
+		response.OutputsCompared = true
+		diffOutputs(response, newFiles, oldFiles, limit)
+	}
+	return response, nil
+}
+
+// RerunJob implements the protobuf pps.RerunJob RPC
+func (a *apiServer) RerunJob(ctx context.Context, request *pps.RerunJobRequest) (response *pps.RerunJobResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "RerunJob")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	if request.Job == nil {
+		return nil, errors.Errorf("must specify a job to rerun")
+	}
+
+	jobInfo, spec, err := a.inspectJobSpec(pachClient, request.Job)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Spout != nil {
+		return nil, errors.Errorf("jobs created by spout pipelines can't be rerun")
+	}
+	name := scratchPipelineName(jobInfo.Pipeline.Name, jobInfo.Job.ID)
+	if request.Pipeline != nil && request.Pipeline.Name != "" {
+		name = request.Pipeline.Name
+	}
+	if _, err := a.inspectPipeline(pachClient, name); err == nil {
+		return nil, newErrPipelineExists(name)
+	} else if !isNotFoundErr(err) {
+		return nil, err
+	}
+	spec, err = rerunSpec(spec, request.SpecPatch, name)
+	if err != nil {
+		return nil, err
+	}
+	branches, err := pinInputs(spec.Input, jobInfo.Input, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []*pfs.Branch
+	defer func() {
+		// the scratch branches are only read by the scratch pipeline, so
+		// they're deleted if it isn't created
+		if retErr == nil {
+			return
+		}
+		for _, branch := range created {
+			if err := pachClient.DeleteBranch(branch.Repo.Name, branch.Name, false); err != nil {
+				logrus.Errorf("could not delete scratch branch %s@%s: %v", branch.Repo.Name, branch.Name, err)
+			}
+		}
+	}()
+	for _, b := range branches {
+		if err := pachClient.CreateBranch(b.branch.Repo.Name, b.branch.Name, b.commit, nil); err != nil {
+			return nil, err
+		}
+		created = append(created, b.branch)
+	}
+	if _, err := a.CreatePipeline(ctx, spec); err != nil {
+		return nil, err
+	}
+	return &pps.RerunJobResponse{Pipeline: spec.Pipeline, Branches: created}, nil
+}
+
 // DeleteJob implements the protobuf pps.DeleteJob RPC
 func (a *apiServer) DeleteJob(ctx context.Context, request *pps.DeleteJobRequest) (response *types.Empty, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
@@ -1353,6 +1535,7 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 	// Authorize request and get list of pods containing logs we're interested in
 	// (based on pipeline and job filters)
 	var rcName, containerName string
+	var poolPods []v1.Pod
 	if request.Pipeline == nil && request.Job == nil {
 		if len(request.DataFilters) > 0 || request.Datum != nil {
 			return errors.Errorf("must specify the Job or Pipeline that the datum is from to get logs for it")
@@ -1409,6 +1592,10 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 		if err != nil {
 			return err
 		}
+		// The pipeline's worker pools have their own RCs
+		if poolPods, err = a.workerPoolPods(pipelineInfo); err != nil {
+			return err
+		}
 	}
 
 	// Get pods managed by the RC we're scraping (either pipeline or pachd)
@@ -1416,6 +1603,7 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 	if err != nil {
 		return errors.Wrapf(err, "could not get pods in rc \"%s\" containing logs", rcName)
 	}
+	pods = append(pods, poolPods...)
 	if len(pods) == 0 {
 		return errors.Errorf("no pods belonging to the rc \"%s\" were found", rcName)
 	}
@@ -1768,6 +1956,23 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 			return err
 		}
 	}
+	if pipelineInfo.Preemption != nil {
+		if pipelineInfo.Service != nil || pipelineInfo.Spout != nil {
+			return errors.Errorf("preemption isn't supported in services or spouts")
+		}
+		gracePeriod, err := ppsutil.GetPreemptionGracePeriod(pipelineInfo.Preemption)
+		if err != nil {
+			return err
+		}
+		if gracePeriod < 0 {
+			return errors.Errorf("preemption grace_period can't be negative, got %v", gracePeriod)
+		}
+	}
+	if pipelineInfo.Egress != nil {
+		if err := validateEgress(pipelineInfo.Egress); err != nil {
+			return err
+		}
+	}
 	if pipelineInfo.PodSpec != "" && !json.Valid([]byte(pipelineInfo.PodSpec)) {
 		return errors.Errorf("malformed PodSpec")
 	}
@@ -1784,6 +1989,80 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 		if !validServiceTypes[v1.ServiceType(pipelineInfo.Service.Type)] {
 			return errors.Errorf("the following service type %s is not allowed", pipelineInfo.Service.Type)
 		}
+		if probe := pipelineInfo.Service.ReadinessProbe; probe != nil {
+			if !pipelineInfo.Service.RollingUpdate {
+				return errors.Errorf("service readiness_probe requires rolling_update")
+			}
+			for _, d := range []*types.Duration{probe.Period, probe.Timeout} {
+				if d == nil {
+					continue
+				}
+				if duration, err := types.DurationFromProto(d); err != nil || duration <= 0 {
+					return errors.Errorf("service readiness_probe period and timeout must be positive durations")
+				}
+			}
+		}
+	}
+	if pipelineInfo.Spout != nil && pipelineInfo.Spout.Service != nil && pipelineInfo.Spout.Service.RollingUpdate {
+		return errors.Errorf("rolling updates are only supported for service pipelines, not spouts")
+	}
+	if pipelineInfo.Transform.ServerMode && (pipelineInfo.Service != nil || pipelineInfo.Spout != nil) {
+		return errors.Errorf("server mode isn't supported in services or spouts")
+	}
+	if pipelineInfo.DatumConcurrency < 1 {
+		return errors.Errorf("datum_concurrency must be at least 1, got %d", pipelineInfo.DatumConcurrency)
+	}
+	if pipelineInfo.DatumConcurrency > 1 && (pipelineInfo.Service != nil || pipelineInfo.Spout != nil) {
+		return errors.Errorf("datum_concurrency isn't supported in services or spouts")
+	}
+	if pipelineInfo.DatumConcurrency > 1 && pipelineInfo.Transform.ServerMode {
+		// the user code server processes one datum at a time
+		return errors.Errorf("datum_concurrency isn't supported in server mode")
+	}
+	if pipelineInfo.Autoscaling != nil {
+		if err := validateAutoscaling(pipelineInfo); err != nil {
+			return err
+		}
+	}
+	if pipelineInfo.Alerts != nil {
+		// inspect pipeline hides the webhook URL from non-admins, so an empty
+		// URL keeps the current version's, and specs that it returns can be
+		// updated
+		oldWebhookURL, err := a.pipelineWebhookURL(pachClient, pipelineInfo.Pipeline.Name)
+		if err != nil {
+			return err
+		}
+		if pipelineInfo.Alerts.WebhookURL == "" {
+			pipelineInfo.Alerts.WebhookURL = oldWebhookURL
+		}
+		if err := validateAlertRules(pipelineInfo.Alerts.Rules, pipelineInfo.Alerts.WebhookURL); err != nil {
+			return errors.Wrapf(err, "invalid alerts")
+		}
+		// pachd posts to the webhook from inside the cluster, so, as with
+		// CreateAlert, only admins can set or change it
+		if pipelineInfo.Alerts.WebhookURL != oldWebhookURL {
+			if err := checkIsAdmin(pachClient, "CreatePipeline with alerts.webhook_url"); err != nil {
+				return err
+			}
+		}
+	}
+	if pipelineInfo.Queue != "" && pipelineInfo.Queue != defaultQueue {
+		if _, err := a.inspectQueue(pachClient.Ctx(), pipelineInfo.Queue); err != nil {
+			return err
+		}
+	}
+	if pipelineInfo.Condition != nil {
+		if pipelineInfo.Service != nil || pipelineInfo.Spout != nil {
+			return errors.Errorf("conditions aren't supported in services or spouts")
+		}
+		if err := validateCondition(pipelineInfo.Condition, pipelineInfo.Input); err != nil {
+			return err
+		}
+	}
+	if len(pipelineInfo.WorkerPools) > 0 {
+		if err := validateWorkerPools(pipelineInfo); err != nil {
+			return err
+		}
 	}
 	if pipelineInfo.Spout != nil {
 		if pipelineInfo.EnableStats {
@@ -1798,6 +2077,113 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 		if pipelineInfo.Spout.Service == nil && pipelineInfo.Input != nil {
 			return errors.Errorf("spout pipelines (without a service) must not have an input")
 		}
+		if pipelineInfo.Spout.Source != nil {
+			if err := validateSpoutSource(pipelineInfo.Spout); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateEgress(egress *pps.Egress) error {
+	if len(egress.Targets) > 0 {
+		if egress.URL != "" || egress.SQL != nil || egress.Incremental || egress.DeleteRemoved {
+			return errors.New("egress targets can't be combined with a top-level egress destination")
+		}
+		for _, target := range egress.Targets {
+			if len(target.Targets) > 0 {
+				return errors.New("egress targets can't have targets")
+			}
+			if err := validateEgress(target); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if egress.SQL != nil {
+		if egress.URL != "" {
+			return errors.New("egress can't have both a URL and SQL")
+		}
+		if egress.SQL.URL == "" {
+			return errors.New("SQL egress must specify a URL")
+		}
+		if egress.SQL.BatchSize < 0 {
+			return errors.New("SQL egress batch size cannot be negative")
+		}
+		if egress.Incremental {
+			return errors.New("SQL egress is always incremental")
+		}
+	} else if egress.URL == "" {
+		return errors.New("egress must specify a URL, SQL or targets")
+	}
+	if egress.DeleteRemoved && !egress.Incremental {
+		return errors.New("egress delete_removed requires incremental")
+	}
+	return nil
+}
+
+func validateCondition(condition *pps.PipelineCondition, input *pps.Input) error {
+	if condition.Glob == "" && condition.MinSizeBytes == 0 && condition.MaxSizeBytes == 0 && condition.Description == "" {
+		return errors.New("condition must specify a glob, size or description")
+	}
+	if condition.MaxSizeBytes != 0 && condition.MinSizeBytes > condition.MaxSizeBytes {
+		return errors.New("condition min_size_bytes can't be greater than max_size_bytes")
+	}
+	if condition.Description != "" {
+		if _, err := regexp.Compile(condition.Description); err != nil {
+			return errors.Wrapf(err, "error parsing condition description")
+		}
+	}
+	if condition.Input != "" {
+		found := false
+		pps.VisitInput(input, func(input *pps.Input) {
+			if name, _ := ppsutil.InputCommit(input); name == condition.Input {
+				found = true
+			}
+		})
+		if !found {
+			return errors.Errorf("condition input %q is not an input of the pipeline", condition.Input)
+		}
+	}
+	return nil
+}
+
+func validateSpoutSource(spout *pps.Spout) error {
+	source := spout.Source
+	set := 0
+	for _, s := range []bool{source.Kafka != nil, source.NATS != nil, source.HTTP != nil} {
+		if s {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.Errorf("spout source must set exactly one of kafka, nats or http")
+	}
+	if spout.Transactional {
+		return errors.Errorf("spouts with a source can't be transactional")
+	}
+	if source.MaxMessages < 0 || source.MaxBytes < 0 {
+		return errors.Errorf("spout source batch limits must not be negative")
+	}
+	if source.MaxDelay != nil {
+		if _, err := types.DurationFromProto(source.MaxDelay); err != nil {
+			return errors.Wrapf(err, "invalid spout source max_delay")
+		}
+	}
+	switch {
+	case source.Kafka != nil:
+		if len(source.Kafka.Brokers) == 0 || source.Kafka.Topic == "" {
+			return errors.Errorf("kafka spout source must set brokers and topic")
+		}
+	case source.NATS != nil:
+		if source.NATS.URL == "" || source.NATS.Subject == "" {
+			return errors.Errorf("nats spout source must set url and subject")
+		}
+	case source.HTTP != nil:
+		if source.HTTP.Port == 0 && spout.Service == nil {
+			return errors.Errorf("http spout source must set port or the spout must have a service")
+		}
 	}
 	return nil
 }
@@ -1811,6 +2197,9 @@ func branchProvenance(input *pps.Input) []*pfs.Branch {
 		if input.Cron != nil {
 			result = append(result, client.NewBranch(input.Cron.Repo, "master"))
 		}
+		if input.ObjectStorage != nil {
+			result = append(result, client.NewBranch(input.ObjectStorage.Repo, "master"))
+		}
 		if input.Git != nil {
 			result = append(result, client.NewBranch(input.Git.Name, input.Git.Branch))
 		}
@@ -1949,6 +2338,8 @@ func (a *apiServer) fixPipelineInputRepoACLs(pachClient *client.APIClient, pipel
 				repo = input.Pfs.Repo
 			case input.Cron != nil:
 				repo = input.Cron.Repo
+			case input.ObjectStorage != nil:
+				repo = input.ObjectStorage.Repo
 			case input.Git != nil:
 				repo = input.Git.Name
 			default:
@@ -1978,6 +2369,8 @@ func (a *apiServer) fixPipelineInputRepoACLs(pachClient *client.APIClient, pipel
 				repo = input.Pfs.Repo
 			case input.Cron != nil:
 				repo = input.Cron.Repo
+			case input.ObjectStorage != nil:
+				repo = input.ObjectStorage.Repo
 			case input.Git != nil:
 				repo = input.Git.Name
 			default:
@@ -2051,6 +2444,11 @@ func (a *apiServer) fixPipelineInputRepoACLs(pachClient *client.APIClient, pipel
 // the parallelism spec in CreatePipelineRequest.Parallelism into a constant
 // that can be stored in EtcdPipelineInfo.Parallelism
 func getExpectedNumWorkers(kc *kube.Clientset, pipelineInfo *pps.PipelineInfo) (int, error) {
+	if pipelineInfo.Autoscaling != nil {
+		// the worker master splits datums between the most workers the
+		// pipeline can scale up to, the PPS master decides how many of them run
+		return int(pipelineInfo.Autoscaling.MaxWorkers), nil
+	}
 	switch pspec := pipelineInfo.ParallelismSpec; {
 	case pspec == nil, pspec.Constant == 0 && pspec.Coefficient == 0:
 		return 1, nil
@@ -2122,38 +2520,7 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 	if request.Salt == "" || request.Reprocess {
 		request.Salt = uuid.NewWithoutDashes()
 	}
-	pipelineInfo := &pps.PipelineInfo{
-		Pipeline:              request.Pipeline,
-		Version:               1,
-		Transform:             request.Transform,
-		TFJob:                 request.TFJob,
-		ParallelismSpec:       request.ParallelismSpec,
-		HashtreeSpec:          request.HashtreeSpec,
-		Input:                 request.Input,
-		OutputBranch:          request.OutputBranch,
-		Egress:                request.Egress,
-		CreatedAt:             now(),
-		ResourceRequests:      request.ResourceRequests,
-		ResourceLimits:        request.ResourceLimits,
-		SidecarResourceLimits: request.SidecarResourceLimits,
-		Description:           request.Description,
-		CacheSize:             request.CacheSize,
-		EnableStats:           request.EnableStats,
-		Salt:                  request.Salt,
-		MaxQueueSize:          request.MaxQueueSize,
-		Service:               request.Service,
-		Spout:                 request.Spout,
-		ChunkSpec:             request.ChunkSpec,
-		DatumTimeout:          request.DatumTimeout,
-		JobTimeout:            request.JobTimeout,
-		Standby:               request.Standby,
-		DatumTries:            request.DatumTries,
-		SchedulingSpec:        request.SchedulingSpec,
-		PodSpec:               request.PodSpec,
-		PodPatch:              request.PodPatch,
-		S3Out:                 request.S3Out,
-		Metadata:              request.Metadata,
-	}
+	pipelineInfo := newPipelineInfo(request)
 	if err := setPipelineDefaults(pipelineInfo); err != nil {
 		return nil, err
 	}
@@ -2173,6 +2540,15 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 				visitErr = err
 			}
 		}
+		if input.ObjectStorage != nil {
+			if _, err := pachClient.PfsAPIClient.CreateRepo(pachClient.Ctx(),
+				&pfs.CreateRepoRequest{
+					Repo:        client.NewRepo(input.ObjectStorage.Repo),
+					Description: fmt.Sprintf("Object storage input repo for pipeline %s.", request.Pipeline.Name),
+				}); err != nil && !isAlreadyExistsErr(err) {
+				visitErr = err
+			}
+		}
 		if input.Git != nil {
 			if _, err := pachClient.PfsAPIClient.CreateRepo(pachClient.Ctx(),
 				&pfs.CreateRepoRequest{
@@ -2286,6 +2662,7 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 				pipelinePtr.Reason = ""
 				// Update pipeline parallelism
 				pipelinePtr.Parallelism = uint64(parallelism)
+				pipelinePtr.AutoscalingStatus = nil
 				return nil
 			})
 		}); err != nil {
@@ -2438,31 +2815,191 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 	return &types.Empty{}, nil
 }
 
-// setPipelineDefaults sets the default values for a pipeline info
-func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) error {
-	now := time.Now()
-	if pipelineInfo.Transform.Image == "" {
-		pipelineInfo.Transform.Image = DefaultUserImage
+// DryRunPipeline implements the protobuf pps.DryRunPipeline RPC
+func (a *apiServer) DryRunPipeline(ctx context.Context, request *pps.DryRunPipelineRequest) (response *pps.DryRunPipelineResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	if request.Pipeline == nil {
+		return nil, errors.New("a pipeline spec is required")
 	}
-	pps.VisitInput(pipelineInfo.Input, func(input *pps.Input) {
-		if input.Pfs != nil {
-			if input.Pfs.Branch == "" {
-				input.Pfs.Branch = "master"
-			}
-			if input.Pfs.Name == "" {
-				input.Pfs.Name = input.Pfs.Repo
-			}
-		}
-		if input.Cron != nil {
-			if input.Cron.Start == nil {
-				start, _ := types.TimestampProto(now)
-				input.Cron.Start = start
-			}
-			if input.Cron.Repo == "" {
-				input.Cron.Repo = fmt.Sprintf("%s_%s", pipelineInfo.Pipeline.Name, input.Cron.Name)
+	// Defaults are set on a copy of the spec, as in CreatePipeline
+	pipelineRequest := proto.Clone(request.Pipeline).(*pps.CreatePipelineRequest)
+	if err := a.validatePipelineRequest(pipelineRequest); err != nil {
+		return nil, err
+	}
+	pachClient := a.env.GetPachClient(ctx)
+	pipelineInfo := newPipelineInfo(pipelineRequest)
+	if err := setPipelineDefaults(pipelineInfo); err != nil {
+		return nil, err
+	}
+	if err := a.validatePipeline(pachClient, pipelineInfo); err != nil {
+		return nil, err
+	}
+	pps.SortInput(pipelineInfo.Input) // datum hashes depend on the input order
+
+	// An update that doesn't reprocess keeps the pipeline's salt, so datums
+	// that the current version has processed are skipped
+	response = &pps.DryRunPipelineResponse{}
+	var processed map[string]bool
+	oldPipelineInfo, err := a.inspectPipeline(pachClient, pipelineInfo.Pipeline.Name)
+	switch {
+	case err != nil && !isNotFoundErr(err):
+		return nil, err
+	case err == nil && !pipelineRequest.Update:
+		return nil, newErrPipelineExists(pipelineInfo.Pipeline.Name)
+	case err == nil:
+		response.Update = true
+		if !pipelineRequest.Reprocess {
+			pipelineInfo.Salt = oldPipelineInfo.Salt
+			if processed, err = a.processedDatums(pachClient, pipelineInfo.Salt); err != nil {
+				return nil, err
 			}
 		}
-		if input.Git != nil {
+	}
+
+	commits := make(map[string]string)
+	for _, commit := range request.InputCommits {
+		commits[commit.Repo.Name] = commit.ID
+	}
+	if err := ppsutil.ResolveInputCommits(pachClient, pipelineInfo.Input, commits); err != nil {
+		return nil, err
+	}
+	response.Input = pipelineInfo.Input
+	dit, err := datum.NewIterator(pachClient, pipelineInfo.Input)
+	if err != nil {
+		return nil, err
+	}
+	sample := int(request.Sample)
+	if sample == 0 {
+		sample = 10
+	}
+	for dit.Next() {
+		inputs := dit.Datum()
+		id := workercommon.HashDatum(pipelineInfo.Pipeline.Name, pipelineInfo.Salt, inputs)
+		state := pps.DatumState_STARTING
+		if processed[id] {
+			state = pps.DatumState_SKIPPED
+			response.Skipped++
+		} else {
+			response.Processed++
+		}
+		if len(response.Sample) < sample {
+			datumInfo := &pps.DatumInfo{State: state}
+			// A new pipeline gets a random salt, so its datum IDs aren't known
+			if response.Update {
+				datumInfo.Datum = &pps.Datum{ID: id}
+			}
+			for _, input := range inputs {
+				datumInfo.Data = append(datumInfo.Data, input.FileInfo)
+			}
+			response.Sample = append(response.Sample, datumInfo)
+		}
+	}
+	response.Datums = int64(dit.Len())
+	return response, nil
+}
+
+// processedDatums returns the IDs of the datums that pipelines with the given
+// salt have processed, which are the tags of the datums' hashtrees.
+func (a *apiServer) processedDatums(pachClient *client.APIClient, salt string) (map[string]bool, error) {
+	tags, err := pachClient.ObjectAPIClient.ListTags(pachClient.Ctx(), &pfs.ListTagsRequest{
+		Prefix: client.DatumTagPrefix(salt),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(grpcutil.ScrubGRPC(err), "error listing tagged objects")
+	}
+	result := make(map[string]bool)
+	for {
+		resp, err := tags.Recv()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		} else if err != nil {
+			return nil, grpcutil.ScrubGRPC(err)
+		}
+		result[resp.Tag.Name] = true
+	}
+}
+
+// newPipelineInfo returns the PipelineInfo for a new pipeline created by
+// request, before defaults are set.
+func newPipelineInfo(request *pps.CreatePipelineRequest) *pps.PipelineInfo {
+	return &pps.PipelineInfo{
+		Pipeline:              request.Pipeline,
+		Version:               1,
+		Transform:             request.Transform,
+		TFJob:                 request.TFJob,
+		ParallelismSpec:       request.ParallelismSpec,
+		HashtreeSpec:          request.HashtreeSpec,
+		Input:                 request.Input,
+		OutputBranch:          request.OutputBranch,
+		Egress:                request.Egress,
+		CreatedAt:             now(),
+		ResourceRequests:      request.ResourceRequests,
+		ResourceLimits:        request.ResourceLimits,
+		SidecarResourceLimits: request.SidecarResourceLimits,
+		Description:           request.Description,
+		CacheSize:             request.CacheSize,
+		EnableStats:           request.EnableStats,
+		Salt:                  request.Salt,
+		MaxQueueSize:          request.MaxQueueSize,
+		DatumConcurrency:      request.DatumConcurrency,
+		Service:               request.Service,
+		Spout:                 request.Spout,
+		ChunkSpec:             request.ChunkSpec,
+		DatumTimeout:          request.DatumTimeout,
+		JobTimeout:            request.JobTimeout,
+		Standby:               request.Standby,
+		DatumTries:            request.DatumTries,
+		SchedulingSpec:        request.SchedulingSpec,
+		PodSpec:               request.PodSpec,
+		PodPatch:              request.PodPatch,
+		S3Out:                 request.S3Out,
+		Metadata:              request.Metadata,
+		Condition:             request.Condition,
+		Template:              request.Template,
+		Autoscaling:           request.Autoscaling,
+		Priority:              request.Priority,
+		Queue:                 request.Queue,
+		Alerts:                request.Alerts,
+		WorkerPools:           request.WorkerPools,
+		Preemption:            request.Preemption,
+	}
+}
+
+// setPipelineDefaults sets the default values for a pipeline info
+func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) error {
+	now := time.Now()
+	if pipelineInfo.Transform.Image == "" {
+		pipelineInfo.Transform.Image = DefaultUserImage
+	}
+	pps.VisitInput(pipelineInfo.Input, func(input *pps.Input) {
+		if input.Pfs != nil {
+			if input.Pfs.Branch == "" {
+				input.Pfs.Branch = "master"
+			}
+			if input.Pfs.Name == "" {
+				input.Pfs.Name = input.Pfs.Repo
+			}
+		}
+		if input.Cron != nil {
+			if input.Cron.Start == nil {
+				start, _ := types.TimestampProto(now)
+				input.Cron.Start = start
+			}
+			if input.Cron.Repo == "" {
+				input.Cron.Repo = fmt.Sprintf("%s_%s", pipelineInfo.Pipeline.Name, input.Cron.Name)
+			}
+		}
+		if input.ObjectStorage != nil {
+			if input.ObjectStorage.Repo == "" {
+				input.ObjectStorage.Repo = fmt.Sprintf("%s_%s", pipelineInfo.Pipeline.Name, input.ObjectStorage.Name)
+			}
+			if input.ObjectStorage.Glob == "" {
+				input.ObjectStorage.Glob = "/*"
+			}
+		}
+		if input.Git != nil {
 			if input.Git.Branch == "" {
 				input.Git.Branch = "master"
 			}
@@ -2487,6 +3024,21 @@ func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) error {
 	if pipelineInfo.DatumTries == 0 {
 		pipelineInfo.DatumTries = DefaultDatumTries
 	}
+	if pipelineInfo.DatumConcurrency == 0 {
+		pipelineInfo.DatumConcurrency = 1
+	}
+	if pipelineInfo.Autoscaling != nil {
+		if pipelineInfo.Autoscaling.MinWorkers == 0 {
+			// the worker master runs in a worker, so there must be at least one
+			pipelineInfo.Autoscaling.MinWorkers = 1
+		}
+		if pipelineInfo.Autoscaling.TargetDatumsPerWorker == 0 {
+			pipelineInfo.Autoscaling.TargetDatumsPerWorker = defaultDatumsPerWorker
+		}
+		if pipelineInfo.Autoscaling.ScaleDownDelay == nil {
+			pipelineInfo.Autoscaling.ScaleDownDelay = types.DurationProto(defaultScaleDownDelay)
+		}
+	}
 	if pipelineInfo.Service != nil {
 		if pipelineInfo.Service.Type == "" {
 			pipelineInfo.Service.Type = string(v1.ServiceTypeNodePort)
@@ -2495,15 +3047,48 @@ func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) error {
 	if pipelineInfo.Spout != nil && pipelineInfo.Spout.Service != nil && pipelineInfo.Spout.Service.Type == "" {
 		pipelineInfo.Spout.Service.Type = string(v1.ServiceTypeNodePort)
 	}
+	if pipelineInfo.Spout != nil && pipelineInfo.Spout.Source != nil &&
+		pipelineInfo.Spout.Source.Kafka != nil && pipelineInfo.Spout.Marker == "" {
+		// Kafka sources keep their offsets in the marker
+		pipelineInfo.Spout.Marker = "offsets"
+	}
 	return nil
 }
 
-// InspectPipeline implements the protobuf pps.InspectPipeline RPC
+// pipelineWebhookURL returns the alerts webhook URL of the current version of
+// the pipeline 'name', or "" if it doesn't exist or doesn't have alerts.
+func (a *apiServer) pipelineWebhookURL(pachClient *client.APIClient, name string) (string, error) {
+	pipelinePtr := &pps.EtcdPipelineInfo{}
+	if err := a.pipelines.ReadOnly(pachClient.Ctx()).Get(name, pipelinePtr); err != nil {
+		if col.IsErrNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	pipelineInfo, err := ppsutil.GetPipelineInfo(pachClient, name, pipelinePtr)
+	if err != nil {
+		return "", err
+	}
+	if pipelineInfo.Alerts == nil {
+		return "", nil
+	}
+	return pipelineInfo.Alerts.WebhookURL, nil
+}
+
+// InspectPipeline implements the protobuf pps.InspectPipeline RPC. Alert
+// webhook URLs are only returned to admins.
 func (a *apiServer) InspectPipeline(ctx context.Context, request *pps.InspectPipelineRequest) (response *pps.PipelineInfo, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 	pachClient := a.env.GetPachClient(ctx)
-	return a.inspectPipeline(pachClient, request.Pipeline.Name)
+	pipelineInfo, err := a.inspectPipeline(pachClient, request.Pipeline.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := redactPipelineWebhookURLs(pachClient, pipelineInfo); err != nil {
+		return nil, err
+	}
+	return pipelineInfo, nil
 }
 
 // inspectPipeline contains the functional implementation of InspectPipeline.
@@ -2585,7 +3170,8 @@ func (a *apiServer) inspectPipeline(pachClient *client.APIClient, name string) (
 	return pipelineInfo, nil
 }
 
-// ListPipeline implements the protobuf pps.ListPipeline RPC
+// ListPipeline implements the protobuf pps.ListPipeline RPC. Alert webhook
+// URLs are only returned to admins.
 func (a *apiServer) ListPipeline(ctx context.Context, request *pps.ListPipelineRequest) (response *pps.PipelineInfos, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) {
@@ -2608,6 +3194,9 @@ func (a *apiServer) ListPipeline(ctx context.Context, request *pps.ListPipelineR
 	}); err != nil {
 		return nil, err
 	}
+	if err := redactPipelineWebhookURLs(pachClient, pipelineInfos.PipelineInfo...); err != nil {
+		return nil, err
+	}
 	return pipelineInfos, nil
 }
 
@@ -2831,7 +3420,7 @@ func (a *apiServer) deletePipeline(pachClient *client.APIClient, request *pps.De
 		}
 		return nil
 	})
-	// Delete cron input repos
+	// Delete cron and object storage input repos
 	if !request.KeepRepo {
 		pps.VisitInput(pipelineInfo.Input, func(input *pps.Input) {
 			if input.Cron != nil {
@@ -2839,6 +3428,11 @@ func (a *apiServer) deletePipeline(pachClient *client.APIClient, request *pps.De
 					return pachClient.DeleteRepo(input.Cron.Repo, request.Force)
 				})
 			}
+			if input.ObjectStorage != nil {
+				eg.Go(func() error {
+					return pachClient.DeleteRepo(input.ObjectStorage.Repo, request.Force)
+				})
+			}
 		})
 	}
 	if err := eg.Wait(); err != nil {
@@ -3168,6 +3762,54 @@ func (a *apiServer) RunCron(ctx context.Context, request *pps.RunCronRequest) (r
 	return &types.Empty{}, nil
 }
 
+// InspectDAG implements the protobuf pps.InspectDAG RPC
+func (a *apiServer) InspectDAG(ctx context.Context, request *pps.InspectDAGRequest) (response *pps.DAGInfo, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	pachClient := a.env.GetPachClient(ctx)
+	var from string
+	if request.From != nil {
+		if _, err := pachClient.InspectRepo(request.From.Name); err != nil {
+			return nil, err
+		}
+		from = request.From.Name
+	}
+
+	// the edges of the DAG come from the provenance of each pipeline's output
+	// branch
+	pipelineInfos := make(map[string]*pps.PipelineInfo)
+	inputs := make(map[string][]string)
+	if err := a.listPipeline(pachClient, &pps.ListPipelineRequest{}, func(pipelineInfo *pps.PipelineInfo) error {
+		branchInfo, err := pachClient.InspectBranch(pipelineInfo.Pipeline.Name, pipelineInfo.OutputBranch)
+		if err != nil {
+			return err
+		}
+		pipelineInfos[pipelineInfo.Pipeline.Name] = pipelineInfo
+		inputs[pipelineInfo.Pipeline.Name] = branchInputs(branchInfo)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	nodes := dagNodes(pipelineInfos, inputs, from)
+	for _, node := range nodes {
+		if node.Pipeline == nil {
+			continue
+		}
+		// jobs are listed newest first
+		if err := a.listJob(pachClient, node.Pipeline, nil, nil, -1, false, func(jobInfo *pps.JobInfo) error {
+			node.LastJob = jobInfo.Job
+			node.LastJobState = jobInfo.State
+			node.LastJobDuration = jobDuration(jobInfo)
+			return errutil.ErrBreak
+		}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+			return nil, err
+		}
+	}
+	return &pps.DAGInfo{Nodes: nodes}, nil
+}
+
 // CreateSecret implements the protobuf pps.CreateSecret RPC
 func (a *apiServer) CreateSecret(ctx context.Context, request *pps.CreateSecretRequest) (response *types.Empty, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
@@ -3274,37 +3916,556 @@ func (a *apiServer) ListSecret(ctx context.Context, in *types.Empty) (response *
 	}, nil
 }
 
-// DeleteAll implements the protobuf pps.DeleteAll RPC
-func (a *apiServer) DeleteAll(ctx context.Context, request *types.Empty) (response *types.Empty, retErr error) {
+// CreatePipelineTemplate implements the protobuf pps.CreatePipelineTemplate RPC.
+// Templates are shared by the whole cluster, so only admins can create or
+// update them.
+func (a *apiServer) CreatePipelineTemplate(ctx context.Context, request *pps.CreatePipelineTemplateRequest) (response *types.Empty, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "CreatePipelineTemplate")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
 	pachClient := a.env.GetPachClient(ctx)
 	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if err := checkIsAdmin(pachClient, "CreatePipelineTemplate"); err != nil {
+		return nil, err
+	}
 
-	// check if the caller is authorized -- they must be an admin
-	if me, err := pachClient.WhoAmI(ctx, &auth.WhoAmIRequest{}); err == nil {
-		var isAdmin bool
-		for _, s := range me.ClusterRoles.Roles {
-			if s == auth.ClusterRole_SUPER {
-				isAdmin = true
-				break
+	templateInfo := &pps.PipelineTemplateInfo{
+		Template:    request.Template,
+		Description: request.Description,
+		Spec:        request.Spec,
+		Parameters:  request.Parameters,
+		CreatedAt:   now(),
+	}
+	if err := ppsutil.ValidatePipelineTemplate(templateInfo); err != nil {
+		return nil, errors.Wrapf(err, "invalid pipeline template")
+	}
+	if err := ancestry.ValidateName(templateInfo.Template.Name); err != nil {
+		return nil, errors.Wrapf(err, "invalid template name")
+	}
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		templates := a.templates.ReadWrite(stm)
+		var prev pps.PipelineTemplateInfo
+		if err := templates.Get(request.Template.Name, &prev); err != nil {
+			if !col.IsErrNotFound(err) {
+				return err
+			}
+		} else if !request.Update {
+			return errors.Errorf("template %s already exists", request.Template.Name)
+		}
+		// only the latest version of a template is stored, pipelines record
+		// the version they were rendered from
+		templateInfo.Version = prev.Version + 1
+		return templates.Put(request.Template.Name, templateInfo)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// InspectPipelineTemplate implements the protobuf pps.InspectPipelineTemplate RPC
+func (a *apiServer) InspectPipelineTemplate(ctx context.Context, request *pps.InspectPipelineTemplateRequest) (response *pps.PipelineTemplateInfo, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	return a.inspectPipelineTemplate(ctx, request.Template)
+}
+
+func (a *apiServer) inspectPipelineTemplate(ctx context.Context, template *pps.PipelineTemplate) (*pps.PipelineTemplateInfo, error) {
+	if template == nil || template.Name == "" {
+		return nil, errors.New("template name cannot be empty")
+	}
+	templateInfo := &pps.PipelineTemplateInfo{}
+	if err := a.templates.ReadOnly(ctx).Get(template.Name, templateInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("template %s not found", template.Name)
+		}
+		return nil, err
+	}
+	return templateInfo, nil
+}
+
+// ListPipelineTemplate implements the protobuf pps.ListPipelineTemplate RPC
+func (a *apiServer) ListPipelineTemplate(ctx context.Context, request *pps.ListPipelineTemplateRequest) (response *pps.PipelineTemplateInfos, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	response = &pps.PipelineTemplateInfos{}
+	templateInfo := &pps.PipelineTemplateInfo{}
+	if err := a.templates.ReadOnly(ctx).List(templateInfo, col.DefaultOptions, func(string) error {
+		response.TemplateInfo = append(response.TemplateInfo, proto.Clone(templateInfo).(*pps.PipelineTemplateInfo))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeletePipelineTemplate implements the protobuf pps.DeletePipelineTemplate RPC.
+// Pipelines that were created from the template are left as they are. Like
+// CreatePipelineTemplate, it's only available to admins.
+func (a *apiServer) DeletePipelineTemplate(ctx context.Context, request *pps.DeletePipelineTemplateRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "DeletePipelineTemplate")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if err := checkIsAdmin(pachClient, "DeletePipelineTemplate"); err != nil {
+		return nil, err
+	}
+
+	if request.Template == nil || request.Template.Name == "" {
+		return nil, errors.New("template name cannot be empty")
+	}
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		return a.templates.ReadWrite(stm).Delete(request.Template.Name)
+	}); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("template %s not found", request.Template.Name)
+		}
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// CreatePipelineFromTemplate implements the protobuf
+// pps.CreatePipelineFromTemplate RPC
+func (a *apiServer) CreatePipelineFromTemplate(ctx context.Context, request *pps.CreatePipelineFromTemplateRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "CreatePipelineFromTemplate")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+
+	templateInfo, err := a.inspectPipelineTemplate(ctx, request.Template)
+	if err != nil {
+		return nil, err
+	}
+	createRequest, err := ppsutil.RenderPipelineTemplate(templateInfo, request.Params)
+	if err != nil {
+		return nil, err
+	}
+	createRequest.Update = request.Update
+	createRequest.Reprocess = request.Reprocess
+	return a.CreatePipeline(ctx, createRequest)
+}
+
+// checkIsAdmin returns an error unless the caller of pachClient is a cluster
+// admin, or auth isn't activated. 'op' names the operation in the error.
+func checkIsAdmin(pachClient *client.APIClient, op string) error {
+	me, err := pachClient.WhoAmI(pachClient.Ctx(), &auth.WhoAmIRequest{})
+	if err != nil {
+		if auth.IsErrNotActivated(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "error during authorization check")
+	}
+	for _, s := range me.ClusterRoles.Roles {
+		if s == auth.ClusterRole_SUPER {
+			return nil
+		}
+	}
+	return &auth.ErrNotAuthorized{
+		Subject: me.Username,
+		AdminOp: op,
+	}
+}
+
+// CreateQueue implements the protobuf pps.CreateQueue RPC. Queues limit the
+// pipelines of every user, so only admins can create or update them.
+func (a *apiServer) CreateQueue(ctx context.Context, request *pps.CreateQueueRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "CreateQueue")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if err := checkIsAdmin(pachClient, "CreateQueue"); err != nil {
+		return nil, err
+	}
+
+	queueInfo := &pps.QueueInfo{
+		Queue:        request.Queue,
+		Description:  request.Description,
+		Weight:       request.Weight,
+		MaxWorkers:   request.MaxWorkers,
+		MaxResources: request.MaxResources,
+		CreatedAt:    now(),
+	}
+	if queueInfo.Weight == 0 {
+		queueInfo.Weight = 1
+	}
+	if err := validateQueue(queueInfo); err != nil {
+		return nil, errors.Wrapf(err, "invalid queue")
+	}
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		queues := a.queues.ReadWrite(stm)
+		var prev pps.QueueInfo
+		if err := queues.Get(request.Queue.Name, &prev); err != nil {
+			if !col.IsErrNotFound(err) {
+				return err
 			}
+		} else if !request.Update {
+			return errors.Errorf("queue %s already exists", request.Queue.Name)
+		} else {
+			queueInfo.CreatedAt = prev.CreatedAt
 		}
+		return queues.Put(request.Queue.Name, queueInfo)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// InspectQueue implements the protobuf pps.InspectQueue RPC
+func (a *apiServer) InspectQueue(ctx context.Context, request *pps.InspectQueueRequest) (response *pps.QueueInfo, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	if request.Queue == nil || request.Queue.Name == "" {
+		return nil, errors.New("queue name cannot be empty")
+	}
+	queueInfos, err := a.listQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, queueInfo := range queueInfos {
+		if queueInfo.Queue.Name == request.Queue.Name {
+			return queueInfo, nil
+		}
+	}
+	if request.Queue.Name == defaultQueue {
+		// the default queue always exists, even if nothing is using it
+		return &pps.QueueInfo{Queue: request.Queue, Weight: 1, Usage: &pps.QueueUsage{}}, nil
+	}
+	return nil, errors.Errorf("queue %s not found", request.Queue.Name)
+}
+
+// ListQueue implements the protobuf pps.ListQueue RPC
+func (a *apiServer) ListQueue(ctx context.Context, request *pps.ListQueueRequest) (response *pps.QueueInfos, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 
-		if !isAdmin {
-			return nil, &auth.ErrNotAuthorized{
-				Subject: me.Username,
-				AdminOp: "DeleteAll",
+	queueInfos, err := a.listQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pps.QueueInfos{QueueInfo: queueInfos}, nil
+}
+
+// DeleteQueue implements the protobuf pps.DeleteQueue RPC. Pipelines in the
+// queue keep running, without a quota, until they're moved to another queue.
+// Like CreateQueue, it's only available to admins.
+func (a *apiServer) DeleteQueue(ctx context.Context, request *pps.DeleteQueueRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "DeleteQueue")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if err := checkIsAdmin(pachClient, "DeleteQueue"); err != nil {
+		return nil, err
+	}
+
+	if request.Queue == nil || request.Queue.Name == "" {
+		return nil, errors.New("queue name cannot be empty")
+	}
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		return a.queues.ReadWrite(stm).Delete(request.Queue.Name)
+	}); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("queue %s not found", request.Queue.Name)
+		}
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// CreateAlert implements the protobuf pps.CreateAlert RPC. pachd posts to the
+// alert's webhook from inside the cluster, so only admins can create or update
+// alerts.
+func (a *apiServer) CreateAlert(ctx context.Context, request *pps.CreateAlertRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "CreateAlert")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if err := checkIsAdmin(pachClient, "CreateAlert"); err != nil {
+		return nil, err
+	}
+
+	alertInfo := &pps.AlertInfo{
+		Alert:       request.Alert,
+		Description: request.Description,
+		Rules:       request.Rules,
+		WebhookURL:  request.WebhookURL,
+		Pipelines:   request.Pipelines,
+		CreatedAt:   now(),
+	}
+	if err := validateAlert(alertInfo); err != nil {
+		return nil, errors.Wrapf(err, "invalid alert")
+	}
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		alerts := a.alerts.ReadWrite(stm)
+		var prev pps.AlertInfo
+		if err := alerts.Get(request.Alert.Name, &prev); err != nil {
+			if !col.IsErrNotFound(err) {
+				return err
 			}
+		} else if !request.Update {
+			return errors.Errorf("alert %s already exists", request.Alert.Name)
+		}
+		return alerts.Put(request.Alert.Name, alertInfo)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// InspectAlert implements the protobuf pps.InspectAlert RPC. The alert's
+// webhook URL is only returned to admins.
+func (a *apiServer) InspectAlert(ctx context.Context, request *pps.InspectAlertRequest) (response *pps.AlertInfo, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+
+	if request.Alert == nil || request.Alert.Name == "" {
+		return nil, errors.New("alert name cannot be empty")
+	}
+	alertInfo := &pps.AlertInfo{}
+	if err := a.alerts.ReadOnly(ctx).Get(request.Alert.Name, alertInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("alert %s not found", request.Alert.Name)
+		}
+		return nil, err
+	}
+	events, err := a.listAlertEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.Alert == request.Alert.Name {
+			alertInfo.Events = append(alertInfo.Events, event)
 		}
-	} else if !auth.IsErrNotActivated(err) {
-		return nil, errors.Wrapf(err, "error during authorization check")
+	}
+	sort.Slice(alertInfo.Events, func(i, j int) bool {
+		ti, tj := alertInfo.Events[i].FiredAt, alertInfo.Events[j].FiredAt
+		if ti.Seconds != tj.Seconds {
+			return ti.Seconds > tj.Seconds
+		}
+		return ti.Nanos > tj.Nanos
+	})
+	if len(alertInfo.Events) > alertEventsShown {
+		alertInfo.Events = alertInfo.Events[:alertEventsShown]
+	}
+	if err := redactWebhookURLs(pachClient, alertInfo); err != nil {
+		return nil, err
+	}
+	return alertInfo, nil
+}
+
+// ListAlert implements the protobuf pps.ListAlert RPC. Webhook URLs are only
+// returned to admins.
+func (a *apiServer) ListAlert(ctx context.Context, request *pps.ListAlertRequest) (response *pps.AlertInfos, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+
+	alertInfos, err := a.listAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := redactWebhookURLs(pachClient, alertInfos...); err != nil {
+		return nil, err
+	}
+	return &pps.AlertInfos{AlertInfo: alertInfos}, nil
+}
+
+// redactWebhookURLs clears the webhook URLs of 'alertInfos' and their events
+// unless the caller of pachClient is an admin, as webhook URLs often contain
+// tokens.
+func redactWebhookURLs(pachClient *client.APIClient, alertInfos ...*pps.AlertInfo) error {
+	if err := checkIsAdmin(pachClient, "InspectAlert"); err == nil {
+		return nil
+	} else if !auth.IsErrNotAuthorized(err) {
+		return err
+	}
+	for _, alertInfo := range alertInfos {
+		alertInfo.WebhookURL = ""
+		for _, event := range alertInfo.Events {
+			event.WebhookURL = ""
+		}
+	}
+	return nil
+}
+
+// redactPipelineWebhookURLs clears the webhook URLs of the alerts in
+// 'pipelineInfos' unless the caller of pachClient is an admin, like
+// redactWebhookURLs does for cluster alerts.
+func redactPipelineWebhookURLs(pachClient *client.APIClient, pipelineInfos ...*pps.PipelineInfo) error {
+	var alerts []*pps.AlertSpec
+	for _, pipelineInfo := range pipelineInfos {
+		alerts = append(alerts, pipelineInfo.Alerts)
+	}
+	return redactAlertWebhookURLs(pachClient, "InspectPipeline with alerts.webhook_url", alerts...)
+}
+
+// redactAlertWebhookURLs clears the webhook URLs of 'alerts', which may be
+// nil, unless the caller of pachClient is an admin. 'operation' is reported
+// by the admin check.
+func redactAlertWebhookURLs(pachClient *client.APIClient, operation string, alerts ...*pps.AlertSpec) error {
+	var hasAlerts bool
+	for _, alert := range alerts {
+		hasAlerts = hasAlerts || alert != nil
+	}
+	if !hasAlerts {
+		return nil
+	}
+	if err := checkIsAdmin(pachClient, operation); err == nil {
+		return nil
+	} else if !auth.IsErrNotAuthorized(err) {
+		return err
+	}
+	for _, alert := range alerts {
+		if alert != nil {
+			alert.WebhookURL = ""
+		}
+	}
+	return nil
+}
+
+// DeleteAlert implements the protobuf pps.DeleteAlert RPC. The alert's
+// pending events are deleted too, so they're not delivered. Like CreateAlert,
+// it's only available to admins.
+func (a *apiServer) DeleteAlert(ctx context.Context, request *pps.DeleteAlertRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "DeleteAlert")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if err := checkIsAdmin(pachClient, "DeleteAlert"); err != nil {
+		return nil, err
+	}
+
+	if request.Alert == nil || request.Alert.Name == "" {
+		return nil, errors.New("alert name cannot be empty")
+	}
+	events, err := a.listAlertEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		if err := a.alerts.ReadWrite(stm).Delete(request.Alert.Name); err != nil {
+			return err
+		}
+		alertEvents := a.alertEvents.ReadWrite(stm)
+		for _, event := range events {
+			if event.Alert == request.Alert.Name {
+				if err := alertEvents.Delete(event.ID); err != nil && !col.IsErrNotFound(err) {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("alert %s not found", request.Alert.Name)
+		}
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// ListUsage implements the protobuf pps.ListUsage RPC
+func (a *apiServer) ListUsage(ctx context.Context, request *pps.ListUsageRequest) (response *pps.UsageInfos, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	pachClient := a.env.GetPachClient(ctx)
+	var from, to time.Time
+	var err error
+	if request.From != nil {
+		if from, err = types.TimestampFromProto(request.From); err != nil {
+			return nil, err
+		}
+	}
+	if request.To != nil {
+		if to, err = types.TimestampFromProto(request.To); err != nil {
+			return nil, err
+		}
+	}
+	if !to.IsZero() && !from.Before(to) {
+		return nil, errors.Errorf("invalid time range, 'from' must be before 'to'")
+	}
+	pipelines := make(map[string]bool)
+	for _, pipeline := range request.Pipelines {
+		pipelines[pipeline.Name] = true
+	}
+
+	usages := make(map[string]*pps.UsageInfo)
+	jobStats := make(map[string][]*pps.ProcessStats)
+	if err := a.listPipeline(pachClient, &pps.ListPipelineRequest{}, func(pipelineInfo *pps.PipelineInfo) error {
+		if len(pipelines) > 0 && !pipelines[pipelineInfo.Pipeline.Name] {
+			return nil
+		}
+		group := usageGroup(pipelineInfo, request.Label)
+		usage, ok := usages[group]
+		if !ok {
+			usage = &pps.UsageInfo{Group: group, Total: &pps.ProcessStats{}}
+			usages[group] = usage
+		}
+		usage.Pipelines = append(usage.Pipelines, pipelineInfo.Pipeline)
+		return a.listJob(pachClient, pipelineInfo.Pipeline, nil, nil, -1, false, func(jobInfo *pps.JobInfo) error {
+			if !ppsutil.IsTerminal(jobInfo.State) || !jobFinishedIn(jobInfo, from, to) {
+				return nil
+			}
+			jobStats[group] = append(jobStats[group], jobInfo.Stats)
+			return addJobUsage(usage, jobInfo)
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	response = &pps.UsageInfos{}
+	for group, usage := range usages {
+		usage.PerJob = aggregateStats(jobStats[group])
+		response.UsageInfo = append(response.UsageInfo, usage)
+	}
+	sort.Slice(response.UsageInfo, func(i, j int) bool {
+		return response.UsageInfo[i].Group < response.UsageInfo[j].Group
+	})
+	return response, nil
+}
+
+// DeleteAll implements the protobuf pps.DeleteAll RPC
+func (a *apiServer) DeleteAll(ctx context.Context, request *types.Empty) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+
+	// check if the caller is authorized -- they must be an admin
+	if err := checkIsAdmin(pachClient, "DeleteAll"); err != nil {
+		return nil, err
 	}
 
 	if _, err := a.DeletePipeline(ctx, &pps.DeletePipelineRequest{All: true, Force: true}); err != nil {
 		return nil, err
 	}
 
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		a.templates.ReadWrite(stm).DeleteAll()
+		a.queues.ReadWrite(stm).DeleteAll()
+		a.alerts.ReadWrite(stm).DeleteAll()
+		a.alertEvents.ReadWrite(stm).DeleteAll()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
 	if err := a.env.GetKubeClient().CoreV1().Secrets(a.namespace).DeleteCollection(&metav1.DeleteOptions{}, metav1.ListOptions{
 		LabelSelector: "secret-source=pachyderm-user",
 	}); err != nil {
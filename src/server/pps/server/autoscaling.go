@@ -0,0 +1,255 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/worker/pipeline/transform"
+)
+
+const (
+	// autoscalingInterval is how often the PPS master checks the task queue of
+	// pipelines with autoscaling.
+	autoscalingInterval    = 10 * time.Second
+	defaultScaleDownDelay  = time.Minute
+	defaultDatumsPerWorker = 1
+)
+
+var (
+	autoscalingPendingDatums = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pachyderm",
+			Subsystem: "pps",
+			Name:      "autoscaling_pending_datums",
+			Help:      "Number of datums waiting to be processed by pipelines with autoscaling",
+		},
+		[]string{"pipeline"},
+	)
+	autoscalingWorkers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pachyderm",
+			Subsystem: "pps",
+			Name:      "autoscaling_workers",
+			Help:      "Number of workers chosen by autoscaling",
+		},
+		[]string{"pipeline"},
+	)
+	autoscalingDecisions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pachyderm",
+			Subsystem: "pps",
+			Name:      "autoscaling_decisions",
+			Help:      "Number of times autoscaling resized a pipeline, by direction (up|down)",
+		},
+		[]string{"pipeline", "direction"},
+	)
+)
+
+func init() {
+	for _, metric := range []prometheus.Collector{
+		autoscalingPendingDatums,
+		autoscalingWorkers,
+		autoscalingDecisions,
+	} {
+		if err := prometheus.Register(metric); err != nil {
+			// metrics may be redundantly registered; ignore these errors
+			if !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+				log.Errorf("error registering prometheus metric: %v", err)
+			}
+		}
+	}
+}
+
+// autoscalingWorkerCount returns the number of workers that should run for a
+// pipeline with autoscaling, before any decision has been made or if the last
+// decision is outside of the pipeline's bounds (because the pipeline was
+// updated).
+func autoscalingWorkerCount(autoscaling *pps.Autoscaling, status *pps.AutoscalingStatus) uint64 {
+	workers := autoscaling.MinWorkers
+	if status != nil && status.Workers != 0 {
+		workers = status.Workers
+	}
+	if workers < autoscaling.MinWorkers {
+		workers = autoscaling.MinWorkers
+	}
+	if workers > autoscaling.MaxWorkers {
+		workers = autoscaling.MaxWorkers
+	}
+	return workers
+}
+
+// desiredWorkers returns the number of workers needed to give each worker
+// autoscaling.TargetDatumsPerWorker of the pending datums, within the bounds
+// of autoscaling.
+func desiredWorkers(autoscaling *pps.Autoscaling, pending int64) uint64 {
+	target := int64(autoscaling.TargetDatumsPerWorker)
+	if target == 0 {
+		target = defaultDatumsPerWorker
+	}
+	var workers uint64
+	if pending > 0 {
+		workers = uint64((pending + target - 1) / target)
+	}
+	if workers < autoscaling.MinWorkers {
+		workers = autoscaling.MinWorkers
+	}
+	if workers > autoscaling.MaxWorkers {
+		workers = autoscaling.MaxWorkers
+	}
+	return workers
+}
+
+// scaleDecision returns the number of workers that a pipeline currently
+// running 'current' workers should run, given the number of pending datums,
+// and the reason for the change. Pipelines are scaled up as soon as more
+// workers are needed, but are only scaled down once fewer workers have been
+// needed since 'busySince' + the pipeline's scale down delay, so that a brief
+// lull between jobs doesn't kill workers that are about to be needed again.
+func scaleDecision(autoscaling *pps.Autoscaling, current uint64, pending int64, busySince, now time.Time) (uint64, string, error) {
+	desired := desiredWorkers(autoscaling, pending)
+	switch {
+	case desired > current:
+		return desired, fmt.Sprintf("scaled up from %d workers for %d pending datums", current, pending), nil
+	case desired < current:
+		delay := defaultScaleDownDelay
+		if autoscaling.ScaleDownDelay != nil {
+			var err error
+			if delay, err = types.DurationFromProto(autoscaling.ScaleDownDelay); err != nil {
+				return 0, "", errors.EnsureStack(err)
+			}
+		}
+		if now.Sub(busySince) < delay {
+			return current, "", nil
+		}
+		return desired, fmt.Sprintf("scaled down from %d workers for %d pending datums", current, pending), nil
+	}
+	return current, "", nil
+}
+
+// autoscaler holds the state that autoscalePipeline keeps between ticks.
+type autoscaler struct {
+	autoscaling *pps.Autoscaling
+	// busySince is the last time the pipeline needed at least as many workers
+	// as it was running
+	busySince time.Time
+}
+
+// reset restarts the scale down delay, e.g. because the pipeline isn't
+// running.
+func (s *autoscaler) reset(now time.Time) {
+	s.busySince = now
+}
+
+// scale returns the number of workers that a pipeline currently running
+// 'current' workers should run, and the reason for the change (see
+// scaleDecision). The scale down delay only restarts when the pipeline needs
+// at least 'current' workers, not when a scale down is held back by the delay.
+func (s *autoscaler) scale(current uint64, pending int64, now time.Time) (uint64, string, error) {
+	if desiredWorkers(s.autoscaling, pending) >= current {
+		s.busySince = now
+	}
+	return scaleDecision(s.autoscaling, current, pending, s.busySince, now)
+}
+
+// autoscalePipeline periodically counts the datums pending in the task queue
+// of a pipeline with autoscaling, and records the number of workers the
+// pipeline should run in its EtcdPipelineInfo. The pipeline controller then
+// resizes the pipeline's RC (see scaleUpPipeline). It's a helper function
+// called by monitorPipeline, and returns when pachClient's context is done.
+func (a *apiServer) autoscalePipeline(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
+	pipelineName := pipelineInfo.Pipeline.Name
+	defer func() {
+		autoscalingPendingDatums.DeleteLabelValues(pipelineName)
+		autoscalingWorkers.DeleteLabelValues(pipelineName)
+	}()
+	scaler := &autoscaler{autoscaling: pipelineInfo.Autoscaling, busySince: time.Now()}
+	ticker := time.NewTicker(autoscalingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-pachClient.Ctx().Done():
+			return pachClient.Ctx().Err()
+		}
+		pending, err := transform.PendingDatums(pachClient.Ctx(), a.env.GetEtcdClient(), a.etcdPrefix, pipelineInfo, "")
+		if err != nil {
+			return err
+		}
+		autoscalingPendingDatums.WithLabelValues(pipelineName).Set(float64(pending))
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		if err := a.pipelines.ReadOnly(pachClient.Ctx()).Get(pipelineName, pipelinePtr); err != nil {
+			return err
+		}
+		current := autoscalingWorkerCount(pipelineInfo.Autoscaling, pipelinePtr.AutoscalingStatus)
+		autoscalingWorkers.WithLabelValues(pipelineName).Set(float64(current))
+		if pipelinePtr.State != pps.PipelineState_PIPELINE_RUNNING {
+			// standby and paused pipelines have no workers to resize
+			scaler.reset(time.Now())
+			continue
+		}
+		workers, reason, err := scaler.scale(current, pending, time.Now())
+		if err != nil {
+			return err
+		}
+		if workers == current {
+			continue
+		}
+		direction := "up"
+		if workers < current {
+			direction = "down"
+		}
+		log.Infof("PPS master: autoscaling %q: %s", pipelineName, reason)
+		// writing the pipeline pointer triggers the pipeline controller, which
+		// resizes the RC
+		if _, err := col.NewSTM(pachClient.Ctx(), a.env.GetEtcdClient(), func(stm col.STM) error {
+			return a.pipelines.ReadWrite(stm).Update(pipelineName, pipelinePtr, func() error {
+				pipelinePtr.AutoscalingStatus = &pps.AutoscalingStatus{
+					Workers:       workers,
+					PendingDatums: pending,
+					LastScaled:    now(),
+					Reason:        reason,
+				}
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+		autoscalingDecisions.WithLabelValues(pipelineName, direction).Inc()
+		autoscalingWorkers.WithLabelValues(pipelineName).Set(float64(workers))
+	}
+}
+
+func validateAutoscaling(pipelineInfo *pps.PipelineInfo) error {
+	autoscaling := pipelineInfo.Autoscaling
+	if pipelineInfo.Service != nil || pipelineInfo.Spout != nil {
+		return errors.Errorf("autoscaling isn't supported in services or spouts")
+	}
+	if spec := pipelineInfo.ParallelismSpec; spec != nil && (spec.Constant != 0 || spec.Coefficient != 0) {
+		return errors.Errorf("autoscaling can't be used with a parallelism_spec")
+	}
+	if autoscaling.MaxWorkers == 0 {
+		return errors.Errorf("autoscaling.max_workers must be set")
+	}
+	if autoscaling.MinWorkers > autoscaling.MaxWorkers {
+		return errors.Errorf("autoscaling.min_workers (%d) can't be greater than autoscaling.max_workers (%d)",
+			autoscaling.MinWorkers, autoscaling.MaxWorkers)
+	}
+	if autoscaling.ScaleDownDelay != nil {
+		delay, err := types.DurationFromProto(autoscaling.ScaleDownDelay)
+		if err != nil {
+			return errors.Wrapf(err, "invalid autoscaling.scale_down_delay")
+		}
+		if delay < 0 {
+			return errors.Errorf("autoscaling.scale_down_delay can't be negative")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestDesiredWorkers(t *testing.T) {
+	autoscaling := &pps.Autoscaling{MinWorkers: 2, MaxWorkers: 10, TargetDatumsPerWorker: 5}
+	require.Equal(t, uint64(2), desiredWorkers(autoscaling, 0))
+	require.Equal(t, uint64(2), desiredWorkers(autoscaling, 6))
+	require.Equal(t, uint64(3), desiredWorkers(autoscaling, 11))
+	require.Equal(t, uint64(10), desiredWorkers(autoscaling, 1000))
+
+	// The status is clamped to the bounds, which may have changed since it was
+	// recorded.
+	require.Equal(t, uint64(2), autoscalingWorkerCount(autoscaling, nil))
+	require.Equal(t, uint64(7), autoscalingWorkerCount(autoscaling, &pps.AutoscalingStatus{Workers: 7}))
+	require.Equal(t, uint64(10), autoscalingWorkerCount(autoscaling, &pps.AutoscalingStatus{Workers: 20}))
+}
+
+func TestScaleDecision(t *testing.T) {
+	autoscaling := &pps.Autoscaling{
+		MinWorkers:            1,
+		MaxWorkers:            10,
+		TargetDatumsPerWorker: 10,
+		ScaleDownDelay:        types.DurationProto(time.Minute),
+	}
+	now := time.Now()
+
+	// Pipelines scale up immediately.
+	workers, reason, err := scaleDecision(autoscaling, 1, 45, now, now)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), workers)
+	require.Equal(t, "scaled up from 1 workers for 45 pending datums", reason)
+
+	// Pipelines only scale down after the delay.
+	workers, reason, err = scaleDecision(autoscaling, 5, 0, now.Add(-30*time.Second), now)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), workers)
+	require.Equal(t, "", reason)
+	workers, reason, err = scaleDecision(autoscaling, 5, 15, now.Add(-2*time.Minute), now)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), workers)
+	require.Equal(t, "scaled down from 5 workers for 15 pending datums", reason)
+
+	workers, _, err = scaleDecision(autoscaling, 2, 15, now.Add(-2*time.Minute), now)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), workers)
+}
+
+func TestAutoscaler(t *testing.T) {
+	autoscaling := &pps.Autoscaling{
+		MinWorkers:            1,
+		MaxWorkers:            10,
+		TargetDatumsPerWorker: 10,
+		ScaleDownDelay:        types.DurationProto(time.Minute),
+	}
+	start := time.Now()
+	scaler := &autoscaler{autoscaling: autoscaling, busySince: start}
+	// tick runs one iteration of autoscalePipeline, 'tick' intervals after start
+	current := uint64(1)
+	tick := func(tick int, pending int64) uint64 {
+		workers, _, err := scaler.scale(current, pending, start.Add(time.Duration(tick)*autoscalingInterval))
+		require.NoError(t, err)
+		current = workers
+		return workers
+	}
+
+	require.Equal(t, uint64(5), tick(1, 45))
+	require.Equal(t, uint64(5), tick(2, 45))
+	// The pipeline goes idle after tick 2, and keeps its workers until the
+	// delay has passed, even though each tick holds back the scale down
+	for i := 3; i < 8; i++ {
+		require.Equal(t, uint64(5), tick(i, 0))
+	}
+	require.Equal(t, uint64(1), tick(8, 0))
+	require.Equal(t, uint64(1), tick(9, 0))
+
+	// Work that needs the current workers restarts the delay
+	require.Equal(t, uint64(3), tick(10, 30))
+	require.Equal(t, uint64(3), tick(14, 10))
+	require.Equal(t, uint64(3), tick(15, 30))
+	for i := 16; i < 21; i++ {
+		require.Equal(t, uint64(3), tick(i, 10))
+	}
+	require.Equal(t, uint64(1), tick(21, 10))
+}
+
+func TestValidateAutoscaling(t *testing.T) {
+	validate := func(pipelineInfo *pps.PipelineInfo) error {
+		if pipelineInfo.Autoscaling == nil {
+			pipelineInfo.Autoscaling = &pps.Autoscaling{MinWorkers: 1, MaxWorkers: 4}
+		}
+		return validateAutoscaling(pipelineInfo)
+	}
+	require.NoError(t, validate(&pps.PipelineInfo{}))
+	require.YesError(t, validate(&pps.PipelineInfo{Autoscaling: &pps.Autoscaling{MinWorkers: 1}}))
+	require.YesError(t, validate(&pps.PipelineInfo{Autoscaling: &pps.Autoscaling{MinWorkers: 5, MaxWorkers: 4}}))
+	require.YesError(t, validate(&pps.PipelineInfo{ParallelismSpec: &pps.ParallelismSpec{Constant: 2}}))
+	require.YesError(t, validate(&pps.PipelineInfo{Service: &pps.Service{}}))
+	require.YesError(t, validate(&pps.PipelineInfo{Autoscaling: &pps.Autoscaling{
+		MinWorkers:     1,
+		MaxWorkers:     4,
+		ScaleDownDelay: types.DurationProto(-time.Second),
+	}}))
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/dag"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+)
+
+// branchInputs returns the repos that a pipeline's output branch is directly
+// provenant on, leaving out the spec repo.
+func branchInputs(branchInfo *pfs.BranchInfo) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, branch := range branchInfo.DirectProvenance {
+		repo := branch.Repo.Name
+		if repo == ppsconsts.SpecRepo || seen[repo] {
+			continue
+		}
+		seen[repo] = true
+		result = append(result, repo)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// dagNodes builds the DAG of repos from the input repos of each pipeline in
+// pipelineInfos. If from is set, only from and the repos downstream of it are
+// returned. Nodes are sorted by depth and then by name, so each node comes
+// after its inputs.
+func dagNodes(pipelineInfos map[string]*pps.PipelineInfo, inputs map[string][]string, from string) []*pps.DAGNode {
+	parents := make(map[string][]string)
+	for pipeline, repos := range inputs {
+		parents[pipeline] = repos
+		for _, repo := range repos {
+			if _, ok := parents[repo]; !ok {
+				parents[repo] = nil
+			}
+		}
+	}
+	d := dag.NewDAG(parents)
+	names := d.Sorted()
+	if from != "" {
+		names = d.Descendants(from, nil)
+	}
+	include := make(map[string]bool)
+	for _, name := range names {
+		include[name] = true
+	}
+
+	// a node's depth is one more than its deepest input, ignoring inputs that
+	// aren't downstream of from
+	depths := make(map[string]int64)
+	var depth func(string) int64
+	depth = func(name string) int64 {
+		if result, ok := depths[name]; ok {
+			return result
+		}
+		var result int64
+		for _, parent := range parents[name] {
+			if include[parent] && depth(parent)+1 > result {
+				result = depth(parent) + 1
+			}
+		}
+		depths[name] = result
+		return result
+	}
+
+	var result []*pps.DAGNode
+	for _, name := range names {
+		node := &pps.DAGNode{
+			Repo:  client.NewRepo(name),
+			Depth: depth(name),
+		}
+		for _, parent := range parents[name] {
+			node.Inputs = append(node.Inputs, client.NewRepo(parent))
+		}
+		if pipelineInfo, ok := pipelineInfos[name]; ok {
+			node.Pipeline = pipelineInfo.Pipeline
+			node.State = pipelineInfo.State
+			node.LastJobState = pipelineInfo.LastJobState
+		}
+		result = append(result, node)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Depth != result[j].Depth {
+			return result[i].Depth < result[j].Depth
+		}
+		return result[i].Repo.Name < result[j].Repo.Name
+	})
+	return result
+}
+
+// jobDuration returns how long a job ran, or how long it's been running if
+// it hasn't finished.
+func jobDuration(jobInfo *pps.JobInfo) *types.Duration {
+	if jobInfo.Started == nil {
+		return nil
+	}
+	started, err := types.TimestampFromProto(jobInfo.Started)
+	if err != nil {
+		return nil
+	}
+	finished := time.Now()
+	if jobInfo.Finished != nil {
+		if finished, err = types.TimestampFromProto(jobInfo.Finished); err != nil {
+			return nil
+		}
+	}
+	return types.DurationProto(finished.Sub(started))
+}
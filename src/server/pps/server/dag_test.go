@@ -0,0 +1,93 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+)
+
+func nodeDepths(nodes []*pps.DAGNode) map[string]int64 {
+	result := make(map[string]int64)
+	for _, node := range nodes {
+		result[node.Repo.Name] = node.Depth
+	}
+	return result
+}
+
+func nodeNames(nodes []*pps.DAGNode) []string {
+	var result []string
+	for _, node := range nodes {
+		result = append(result, node.Repo.Name)
+	}
+	return result
+}
+
+func TestBranchInputs(t *testing.T) {
+	branchInfo := &pfs.BranchInfo{
+		DirectProvenance: []*pfs.Branch{
+			client.NewBranch("labels", "master"),
+			client.NewBranch(ppsconsts.SpecRepo, "edges"),
+			client.NewBranch("images", "master"),
+			client.NewBranch("images", "staging"),
+		},
+	}
+	require.Equal(t, []string{"images", "labels"}, branchInputs(branchInfo))
+}
+
+func TestDAGNodes(t *testing.T) {
+	// images -> edges -> montage <- images, plus an unrelated labels -> stats
+	pipelineInfos := map[string]*pps.PipelineInfo{
+		"edges": {
+			Pipeline:     client.NewPipeline("edges"),
+			State:        pps.PipelineState_PIPELINE_RUNNING,
+			LastJobState: pps.JobState_JOB_SUCCESS,
+		},
+		"montage": {
+			Pipeline: client.NewPipeline("montage"),
+			State:    pps.PipelineState_PIPELINE_STANDBY,
+		},
+		"stats": {
+			Pipeline: client.NewPipeline("stats"),
+			State:    pps.PipelineState_PIPELINE_PAUSED,
+		},
+	}
+	inputs := map[string][]string{
+		"edges":   {"images"},
+		"montage": {"edges", "images"},
+		"stats":   {"labels"},
+	}
+
+	nodes := dagNodes(pipelineInfos, inputs, "")
+	require.Equal(t, []string{"images", "labels", "edges", "stats", "montage"}, nodeNames(nodes))
+	require.Equal(t, map[string]int64{"images": 0, "labels": 0, "edges": 1, "stats": 1, "montage": 2}, nodeDepths(nodes))
+	require.Nil(t, nodes[0].Pipeline)
+	require.Equal(t, "edges", nodes[2].Pipeline.Name)
+	require.Equal(t, pps.PipelineState_PIPELINE_RUNNING, nodes[2].State)
+	require.Equal(t, pps.JobState_JOB_SUCCESS, nodes[2].LastJobState)
+	require.Equal(t, 2, len(nodes[4].Inputs))
+
+	// a commit to images runs edges and then montage
+	nodes = dagNodes(pipelineInfos, inputs, "images")
+	require.Equal(t, []string{"images", "edges", "montage"}, nodeNames(nodes))
+	// a commit to edges only runs montage, which is the first stage
+	nodes = dagNodes(pipelineInfos, inputs, "edges")
+	require.Equal(t, map[string]int64{"edges": 0, "montage": 1}, nodeDepths(nodes))
+	// a repo that no pipeline reads from is a DAG of its own
+	nodes = dagNodes(pipelineInfos, inputs, "unused")
+	require.Equal(t, []string{"unused"}, nodeNames(nodes))
+}
+
+func TestJobDuration(t *testing.T) {
+	require.Nil(t, jobDuration(&pps.JobInfo{}))
+	duration := jobDuration(&pps.JobInfo{
+		Started:  &types.Timestamp{Seconds: 100},
+		Finished: &types.Timestamp{Seconds: 190},
+	})
+	require.Equal(t, int64(90), duration.Seconds)
+}
@@ -0,0 +1,362 @@
+package server
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/gogo/protobuf/jsonpb"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/datum"
+)
+
+const (
+	// defaultDiffLimit is the most datums and files of each kind that DiffJob
+	// returns by default.
+	defaultDiffLimit = 100
+)
+
+// specJSON returns a pipeline spec as a JSON object. The salt is left out, as
+// it's random and doesn't affect the job's output.
+func specJSON(request *pps.CreatePipelineRequest) (map[string]interface{}, error) {
+	marshaller := &jsonpb.Marshaler{OrigName: true}
+	spec, err := marshaller.MarshalToString(request)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(spec), &result); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	delete(result, "salt")
+	return result, nil
+}
+
+// jsonValue returns value as JSON, or "" if it's nil.
+func jsonValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	result, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(result)
+}
+
+// diffJSON appends the paths at which the JSON values a and b differ to
+// result. Objects and arrays are compared field by field.
+func diffJSON(path string, a, b interface{}, result []*pps.SpecChange) []*pps.SpecChange {
+	aObject, aIsObject := a.(map[string]interface{})
+	bObject, bIsObject := b.(map[string]interface{})
+	if aIsObject && bIsObject {
+		keys := make(map[string]bool)
+		for key := range aObject {
+			keys[key] = true
+		}
+		for key := range bObject {
+			keys[key] = true
+		}
+		var sorted []string
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		for _, key := range sorted {
+			field := key
+			if path != "" {
+				field = path + "." + key
+			}
+			result = diffJSON(field, aObject[key], bObject[key], result)
+		}
+		return result
+	}
+	aArray, aIsArray := a.([]interface{})
+	bArray, bIsArray := b.([]interface{})
+	if aIsArray && bIsArray {
+		for i := 0; i < len(aArray) || i < len(bArray); i++ {
+			var aElem, bElem interface{}
+			if i < len(aArray) {
+				aElem = aArray[i]
+			}
+			if i < len(bArray) {
+				bElem = bArray[i]
+			}
+			result = diffJSON(fmt.Sprintf("%s[%d]", path, i), aElem, bElem, result)
+		}
+		return result
+	}
+	if !reflect.DeepEqual(a, b) {
+		result = append(result, &pps.SpecChange{Path: path, A: jsonValue(a), B: jsonValue(b)})
+	}
+	return result
+}
+
+// specChanges returns the fields that differ between two pipeline specs.
+func specChanges(a, b *pps.CreatePipelineRequest) ([]*pps.SpecChange, error) {
+	aSpec, err := specJSON(a)
+	if err != nil {
+		return nil, err
+	}
+	bSpec, err := specJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	return diffJSON("", aSpec, bSpec, nil), nil
+}
+
+// inputCommits returns the commit that each of a job's inputs read, by input
+// name.
+func inputCommits(input *pps.Input) map[string]*pfs.Commit {
+	result := make(map[string]*pfs.Commit)
+	pps.VisitInput(input, func(input *pps.Input) {
+		if name, commit := ppsutil.InputCommit(input); name != "" {
+			result[name] = commit
+		}
+	})
+	return result
+}
+
+func sameCommit(a, b *pfs.Commit) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Repo.Name == b.Repo.Name && a.ID == b.ID
+}
+
+// inputChanges returns the inputs that read different commits in two jobs.
+func inputChanges(a, b *pps.Input) []*pps.InputChange {
+	aCommits, bCommits := inputCommits(a), inputCommits(b)
+	names := make(map[string]bool)
+	for name := range aCommits {
+		names[name] = true
+	}
+	for name := range bCommits {
+		names[name] = true
+	}
+	var result []*pps.InputChange
+	for name := range names {
+		aCommit, aOK := aCommits[name]
+		bCommit, bOK := bCommits[name]
+		if aOK == bOK && sameCommit(aCommit, bCommit) {
+			continue
+		}
+		result = append(result, &pps.InputChange{Name: name, A: aCommit, B: bCommit})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// jobDatum is a datum of a job, identified by the paths of its input files.
+type jobDatum struct {
+	key  string
+	hash string
+	data []*pfs.FileInfo
+}
+
+func newJobDatum(inputs []*common.Input) *jobDatum {
+	result := &jobDatum{}
+	var keys, hashes []string
+	for _, input := range inputs {
+		keys = append(keys, input.Name+":"+input.FileInfo.File.Path)
+		hashes = append(hashes, hex.EncodeToString(input.FileInfo.Hash))
+		result.data = append(result.data, input.FileInfo)
+	}
+	result.key = strings.Join(keys, ",")
+	result.hash = strings.Join(hashes, ",")
+	return result
+}
+
+// jobDatums returns the datums of a job's input, by key.
+func jobDatums(pachClient *client.APIClient, input *pps.Input) (map[string]*jobDatum, error) {
+	dit, err := datum.NewIterator(pachClient, input)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*jobDatum)
+	for dit.Next() {
+		d := newJobDatum(dit.Datum())
+		result[d.key] = d
+	}
+	return result, nil
+}
+
+// diffDatums counts the datums that were added, removed and changed between
+// two jobs' datums, and adds up to limit of each to diff.
+func diffDatums(diff *pps.JobDiff, a, b map[string]*jobDatum, limit int) {
+	sample := func(datums []*pps.DatumInfo, d *jobDatum) []*pps.DatumInfo {
+		if len(datums) >= limit {
+			return datums
+		}
+		return append(datums, &pps.DatumInfo{Data: d.data})
+	}
+	var keys []string
+	for key := range b {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		aDatum, ok := a[key]
+		switch {
+		case !ok:
+			diff.DatumsAdded++
+			diff.AddedDatums = sample(diff.AddedDatums, b[key])
+		case aDatum.hash != b[key].hash:
+			diff.DatumsChanged++
+			diff.ChangedDatums = sample(diff.ChangedDatums, b[key])
+		default:
+			diff.DatumsUnchanged++
+		}
+	}
+	keys = keys[:0]
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		diff.DatumsRemoved++
+		diff.RemovedDatums = sample(diff.RemovedDatums, a[key])
+	}
+}
+
+// diffOutputs sorts the files returned by DiffFile into the files that were
+// added, removed and changed, and adds up to limit of each to diff.
+func diffOutputs(diff *pps.JobDiff, newFiles, oldFiles []*pfs.FileInfo, limit int) {
+	old := make(map[string]bool)
+	for _, fileInfo := range oldFiles {
+		if fileInfo.FileType == pfs.FileType_FILE {
+			old[fileInfo.File.Path] = true
+		}
+	}
+	changed := make(map[string]bool)
+	for _, fileInfo := range newFiles {
+		if fileInfo.FileType != pfs.FileType_FILE {
+			continue
+		}
+		if old[fileInfo.File.Path] {
+			changed[fileInfo.File.Path] = true
+			if len(diff.ChangedFiles) < limit {
+				diff.ChangedFiles = append(diff.ChangedFiles, fileInfo)
+			}
+		} else if len(diff.AddedFiles) < limit {
+			diff.AddedFiles = append(diff.AddedFiles, fileInfo)
+		}
+	}
+	for _, fileInfo := range oldFiles {
+		if fileInfo.FileType == pfs.FileType_FILE && !changed[fileInfo.File.Path] && len(diff.RemovedFiles) < limit {
+			diff.RemovedFiles = append(diff.RemovedFiles, fileInfo)
+		}
+	}
+}
+
+// scratchPipelineName returns the default name of the scratch pipeline that
+// reruns a job.
+func scratchPipelineName(pipeline, jobID string) string {
+	if len(jobID) > 8 {
+		jobID = jobID[:8]
+	}
+	return fmt.Sprintf("%s-rerun-%s", pipeline, jobID)
+}
+
+// rerunSpec applies patch, a JSON merge patch, to a job's pipeline spec and
+// renames it to name. The scratch pipeline doesn't egress its output, fire
+// alerts or follow a template, and gets a new salt.
+func rerunSpec(spec *pps.CreatePipelineRequest, patch string, name string) (*pps.CreatePipelineRequest, error) {
+	marshaller := &jsonpb.Marshaler{OrigName: true}
+	original, err := marshaller.MarshalToString(spec)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	patched := []byte(original)
+	if patch != "" {
+		if patched, err = jsonpatch.MergePatch(patched, []byte(patch)); err != nil {
+			return nil, errors.Wrapf(err, "invalid spec patch")
+		}
+	}
+	result := &pps.CreatePipelineRequest{}
+	if err := jsonpb.UnmarshalString(string(patched), result); err != nil {
+		return nil, errors.Wrapf(err, "invalid spec patch")
+	}
+	result.Pipeline = client.NewPipeline(name)
+	result.Salt = ""
+	result.Egress = nil
+	result.Alerts = nil
+	result.Template = nil
+	result.Update = false
+	result.Reprocess = false
+	return result, nil
+}
+
+// scratchBranch is a branch that a scratch pipeline reads one of its inputs
+// from, which points at the commit that the rerun job read.
+type scratchBranch struct {
+	branch *pfs.Branch
+	commit string
+}
+
+// pinInputs points each input of a scratch pipeline's spec that the job read
+// at a scratch branch, named after the pipeline, whose head is the commit the
+// job read. Cron and object storage inputs are read as PFS inputs of the same
+// repo. Inputs that the job didn't have read their branch as usual.
+func pinInputs(input *pps.Input, jobInput *pps.Input, name string) ([]*scratchBranch, error) {
+	commits := inputCommits(jobInput)
+	var result []*scratchBranch
+	// branches are the scratch branches by repo, a repo that's read at two
+	// commits gets a branch per input
+	branches := make(map[string]*scratchBranch)
+	scratch := func(inputName, repo string) (string, bool) {
+		commit := commits[inputName]
+		if commit == nil {
+			return "", false
+		}
+		if existing, ok := branches[repo]; ok && existing.commit == commit.ID {
+			return existing.branch.Name, true
+		}
+		branch := &scratchBranch{branch: client.NewBranch(repo, name), commit: commit.ID}
+		if _, ok := branches[repo]; ok {
+			branch.branch.Name = name + "-" + inputName
+		} else {
+			branches[repo] = branch
+		}
+		result = append(result, branch)
+		return branch.branch.Name, true
+	}
+	var err error
+	pps.VisitInput(input, func(input *pps.Input) {
+		switch {
+		case input.Pfs != nil:
+			if branch, ok := scratch(input.Pfs.Name, input.Pfs.Repo); ok {
+				input.Pfs.Branch = branch
+				input.Pfs.Commit = ""
+			}
+		case input.Cron != nil:
+			if branch, ok := scratch(input.Cron.Name, input.Cron.Repo); ok {
+				input.Pfs = &pps.PFSInput{Name: input.Cron.Name, Repo: input.Cron.Repo, Branch: branch, Glob: "/*"}
+				input.Cron = nil
+			}
+		case input.ObjectStorage != nil:
+			if branch, ok := scratch(input.ObjectStorage.Name, input.ObjectStorage.Repo); ok {
+				input.Pfs = &pps.PFSInput{Name: input.ObjectStorage.Name, Repo: input.ObjectStorage.Repo, Branch: branch, Glob: input.ObjectStorage.Glob}
+				input.ObjectStorage = nil
+			}
+		case input.Git != nil:
+			err = errors.Errorf("jobs with git inputs can't be rerun")
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
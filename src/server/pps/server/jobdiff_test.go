@@ -0,0 +1,174 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestSpecChanges(t *testing.T) {
+	a := &pps.CreatePipelineRequest{
+		Pipeline:  client.NewPipeline("edges"),
+		Transform: &pps.Transform{Image: "edges:v1", Cmd: []string{"python3", "edges.py"}},
+		Input:     client.NewPFSInput("images", "/*"),
+		Salt:      "abc",
+	}
+	b := &pps.CreatePipelineRequest{
+		Pipeline:     client.NewPipeline("edges"),
+		Transform:    &pps.Transform{Image: "edges:v2", Cmd: []string{"python3", "edges.py", "--fast"}},
+		Input:        client.NewPFSInput("images", "/*"),
+		Salt:         "def",
+		DatumTimeout: nil,
+		DatumTries:   3,
+	}
+	changes, err := specChanges(a, b)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(changes))
+	// int64s are strings in JSON
+	require.Equal(t, &pps.SpecChange{Path: "datum_tries", A: "", B: `"3"`}, changes[0])
+	require.Equal(t, &pps.SpecChange{Path: "transform.cmd[2]", A: "", B: `"--fast"`}, changes[1])
+	require.Equal(t, &pps.SpecChange{Path: "transform.image", A: `"edges:v1"`, B: `"edges:v2"`}, changes[2])
+
+	changes, err = specChanges(a, a)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(changes))
+}
+
+func TestInputChanges(t *testing.T) {
+	pfsInput := func(repo, commit string) *pps.Input {
+		return &pps.Input{Pfs: &pps.PFSInput{Name: repo, Repo: repo, Branch: "master", Commit: commit, Glob: "/*"}}
+	}
+	a := client.NewCrossInput(pfsInput("images", "1"), pfsInput("labels", "1"))
+	b := client.NewCrossInput(pfsInput("images", "2"), pfsInput("labels", "1"))
+	changes := inputChanges(a, b)
+	require.Equal(t, 1, len(changes))
+	require.Equal(t, "images", changes[0].Name)
+	require.Equal(t, "1", changes[0].A.ID)
+	require.Equal(t, "2", changes[0].B.ID)
+
+	// inputs that only one of the jobs had
+	changes = inputChanges(a, pfsInput("images", "1"))
+	require.Equal(t, 1, len(changes))
+	require.Equal(t, "labels", changes[0].Name)
+	require.Nil(t, changes[0].B)
+}
+
+func TestDiffDatums(t *testing.T) {
+	datum := func(path, hash string) *jobDatum {
+		return &jobDatum{
+			key:  "images:" + path,
+			hash: hash,
+			data: []*pfs.FileInfo{{File: client.NewFile("images", "1", path)}},
+		}
+	}
+	datums := func(ds ...*jobDatum) map[string]*jobDatum {
+		result := make(map[string]*jobDatum)
+		for _, d := range ds {
+			result[d.key] = d
+		}
+		return result
+	}
+	a := datums(datum("/a", "1"), datum("/b", "1"), datum("/c", "1"), datum("/d", "1"))
+	b := datums(datum("/a", "1"), datum("/b", "2"), datum("/e", "1"), datum("/f", "1"))
+	diff := &pps.JobDiff{}
+	diffDatums(diff, a, b, 1)
+	require.Equal(t, int64(2), diff.DatumsAdded)
+	require.Equal(t, int64(2), diff.DatumsRemoved)
+	require.Equal(t, int64(1), diff.DatumsChanged)
+	require.Equal(t, int64(1), diff.DatumsUnchanged)
+	// only limit datums of each kind are returned, in order
+	require.Equal(t, 1, len(diff.AddedDatums))
+	require.Equal(t, "/e", diff.AddedDatums[0].Data[0].File.Path)
+	require.Equal(t, 1, len(diff.RemovedDatums))
+	require.Equal(t, "/c", diff.RemovedDatums[0].Data[0].File.Path)
+	require.Equal(t, "/b", diff.ChangedDatums[0].Data[0].File.Path)
+}
+
+func TestDiffOutputs(t *testing.T) {
+	file := func(path string) *pfs.FileInfo {
+		return &pfs.FileInfo{File: client.NewFile("edges", "1", path), FileType: pfs.FileType_FILE}
+	}
+	dir := &pfs.FileInfo{File: client.NewFile("edges", "1", "/"), FileType: pfs.FileType_DIR}
+	diff := &pps.JobDiff{}
+	diffOutputs(diff, []*pfs.FileInfo{dir, file("/a"), file("/b")}, []*pfs.FileInfo{dir, file("/b"), file("/c")}, 100)
+	require.Equal(t, 1, len(diff.AddedFiles))
+	require.Equal(t, "/a", diff.AddedFiles[0].File.Path)
+	require.Equal(t, 1, len(diff.ChangedFiles))
+	require.Equal(t, "/b", diff.ChangedFiles[0].File.Path)
+	require.Equal(t, 1, len(diff.RemovedFiles))
+	require.Equal(t, "/c", diff.RemovedFiles[0].File.Path)
+}
+
+func TestScratchPipelineName(t *testing.T) {
+	require.Equal(t, "edges-rerun-0f3ec27a", scratchPipelineName("edges", "0f3ec27a9b2c4d5e"))
+	require.Equal(t, "edges-rerun-abc", scratchPipelineName("edges", "abc"))
+}
+
+func TestRerunSpec(t *testing.T) {
+	spec := &pps.CreatePipelineRequest{
+		Pipeline:  client.NewPipeline("edges"),
+		Transform: &pps.Transform{Image: "edges:v1", Cmd: []string{"python3", "edges.py"}},
+		Input:     client.NewPFSInput("images", "/*"),
+		Egress:    &pps.Egress{URL: "s3://bucket/dir"},
+		Salt:      "abc",
+	}
+	result, err := rerunSpec(spec, `{"transform": {"image": "edges:v2"}, "datum_tries": 5}`, "edges-rerun-1")
+	require.NoError(t, err)
+	require.Equal(t, "edges-rerun-1", result.Pipeline.Name)
+	require.Equal(t, "edges:v2", result.Transform.Image)
+	require.Equal(t, []string{"python3", "edges.py"}, result.Transform.Cmd)
+	require.Equal(t, int64(5), result.DatumTries)
+	require.Nil(t, result.Egress)
+	require.Equal(t, "", result.Salt)
+	// the original spec is untouched
+	require.Equal(t, "edges:v1", spec.Transform.Image)
+
+	_, err = rerunSpec(spec, `{"transform": `, "edges-rerun-1")
+	require.YesError(t, err)
+}
+
+func TestPinInputs(t *testing.T) {
+	input := client.NewCrossInput(
+		&pps.Input{Pfs: &pps.PFSInput{Name: "images", Repo: "images", Branch: "master", Glob: "/*"}},
+		&pps.Input{Cron: &pps.CronInput{Name: "tick", Repo: "edges_tick", Spec: "@every 1m"}},
+	)
+	jobInput := client.NewCrossInput(
+		&pps.Input{Pfs: &pps.PFSInput{Name: "images", Repo: "images", Branch: "master", Commit: "c1", Glob: "/*"}},
+		&pps.Input{Cron: &pps.CronInput{Name: "tick", Repo: "edges_tick", Commit: "c2", Spec: "@every 1m"}},
+	)
+	branches, err := pinInputs(input, jobInput, "edges-rerun-1")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(branches))
+	require.Equal(t, "images", branches[0].branch.Repo.Name)
+	require.Equal(t, "edges-rerun-1", branches[0].branch.Name)
+	require.Equal(t, "c1", branches[0].commit)
+	require.Equal(t, "edges_tick", branches[1].branch.Repo.Name)
+	require.Equal(t, "c2", branches[1].commit)
+	require.Equal(t, "edges-rerun-1", input.Cross[0].Pfs.Branch)
+	// cron inputs are read as pfs inputs, so no new ticks are added
+	require.Nil(t, input.Cross[1].Cron)
+	require.Equal(t, "edges-rerun-1", input.Cross[1].Pfs.Branch)
+	require.Equal(t, "/*", input.Cross[1].Pfs.Glob)
+
+	// a repo read at two commits gets a branch for each input
+	input = client.NewCrossInput(
+		&pps.Input{Pfs: &pps.PFSInput{Name: "left", Repo: "images", Branch: "master", Glob: "/*"}},
+		&pps.Input{Pfs: &pps.PFSInput{Name: "right", Repo: "images", Branch: "v1", Glob: "/*"}},
+	)
+	jobInput = client.NewCrossInput(
+		&pps.Input{Pfs: &pps.PFSInput{Name: "left", Repo: "images", Branch: "master", Commit: "c1", Glob: "/*"}},
+		&pps.Input{Pfs: &pps.PFSInput{Name: "right", Repo: "images", Branch: "v1", Commit: "c2", Glob: "/*"}},
+	)
+	branches, err = pinInputs(input, jobInput, "edges-rerun-1")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(branches))
+	require.Equal(t, "edges-rerun-1", input.Cross[0].Pfs.Branch)
+	require.Equal(t, "edges-rerun-1-right", input.Cross[1].Pfs.Branch)
+
+	_, err = pinInputs(&pps.Input{Git: &pps.GitInput{Name: "repo", URL: "https://github.com/a/b.git"}},
+		&pps.Input{Git: &pps.GitInput{Name: "repo", URL: "https://github.com/a/b.git", Commit: "c1"}}, "edges-rerun-1")
+	require.YesError(t, err)
+}
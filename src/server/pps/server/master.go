@@ -94,8 +94,16 @@ func (a *apiServer) master() {
 			defer kubePipelineWatch.Stop()
 		}
 
+		queueTicker := time.NewTicker(queueInterval)
+		defer queueTicker.Stop()
+		go a.runAlerts(pachClient)
+
 		for {
 			select {
+			case <-queueTicker.C:
+				if err := a.scheduleWaitingPipelines(pachClient); err != nil {
+					log.Errorf("PPS master: error scheduling waiting pipelines: %v", err)
+				}
 			case event := <-pipelineWatcher.Watch():
 				if event.Err != nil {
 					return errors.Wrapf(event.Err, "event err")
@@ -304,7 +312,28 @@ func (a *apiServer) monitorPipeline(pachClient *client.APIClient, pipelineInfo *
 				}, backoff.NewInfiniteBackOff(), notifyCtx(pachClient.Ctx(), "cron for "+in.Cron.Name))
 			})
 		}
+		if in.ObjectStorage != nil {
+			eg.Go(func() error {
+				return backoff.RetryNotify(func() error {
+					return a.makeObjectStorageCommits(pachClient, in)
+				}, backoff.NewInfiniteBackOff(), notifyCtx(pachClient.Ctx(), "object storage for "+in.ObjectStorage.Name))
+			})
+		}
 	})
+	if pipelineInfo.Autoscaling != nil {
+		eg.Go(func() error {
+			return backoff.RetryNotify(func() error {
+				return a.autoscalePipeline(pachClient, pipelineInfo)
+			}, backoff.NewInfiniteBackOff(), notifyCtx(pachClient.Ctx(), "autoscaling"))
+		})
+	}
+	if len(pipelineInfo.WorkerPools) > 0 {
+		eg.Go(func() error {
+			return backoff.RetryNotify(func() error {
+				return a.autoscaleWorkerPools(pachClient, pipelineInfo)
+			}, backoff.NewInfiniteBackOff(), notifyCtx(pachClient.Ctx(), "worker pool autoscaling"))
+		})
+	}
 	if pipelineInfo.Standby {
 		// Capacity 1 gives us a bit of buffer so we don't needlessly go into
 		// standby when SubscribeCommit takes too long to return.
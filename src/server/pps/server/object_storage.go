@@ -0,0 +1,218 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	pfsServer "github.com/pachyderm/pachyderm/src/server/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+)
+
+const (
+	defaultObjectStoragePollInterval = time.Minute
+	// objectListingFile is the file, on ppsconsts.ObjectStorageListingBranch of
+	// an object storage input's repo, that records the objects that have been
+	// committed to the repo.
+	objectListingFile = "listing"
+)
+
+// objectVersion identifies the version of an object that was committed.
+type objectVersion struct {
+	ETag    string    `json:"etag,omitempty"`
+	ModTime time.Time `json:"mtime"`
+	Size    int64     `json:"size"`
+}
+
+// changed returns true if v is a different version of the object than old.
+// ETags are used when the object store provides them, as an object's mtime can
+// change without its contents changing.
+func (v objectVersion) changed(old objectVersion) bool {
+	if v.ETag != "" || old.ETag != "" {
+		return v.ETag != old.ETag
+	}
+	return v.Size != old.Size || !v.ModTime.Equal(old.ModTime)
+}
+
+// objectListing maps the names of objects to their versions.
+type objectListing map[string]objectVersion
+
+// makeObjectStorageCommits polls the bucket prefix of a single object storage
+// input, and commits the objects that changed to the input's repo. It's a
+// helper function called by monitorPipeline.
+func (a *apiServer) makeObjectStorageCommits(pachClient *client.APIClient, in *pps.Input) error {
+	interval := defaultObjectStoragePollInterval
+	if in.ObjectStorage.PollInterval != nil {
+		var err error
+		if interval, err = types.DurationFromProto(in.ObjectStorage.PollInterval); err != nil {
+			return err
+		}
+	}
+	url, err := obj.ParseURL(in.ObjectStorage.URL)
+	if err != nil {
+		return err
+	}
+	objClient, err := obj.NewClientFromURLAndSecret(url, false)
+	if err != nil {
+		return err
+	}
+	for {
+		if err := syncObjectStorage(pachClient, objClient, url, in.ObjectStorage.Repo); err != nil {
+			return err
+		}
+		select {
+		case <-time.After(interval):
+		case <-pachClient.Ctx().Done():
+			return pachClient.Ctx().Err()
+		}
+	}
+}
+
+// syncObjectStorage lists the objects under url and makes a commit to repo
+// that writes the objects that are new or changed since the last listing, and
+// deletes the objects that were removed. The listing is recorded in repo in a
+// commit that's finished in the same transaction, so that a restarted pachd
+// doesn't commit every object again.
+func syncObjectStorage(pachClient *client.APIClient, objClient obj.Client, url *obj.ObjectStoreURL, repo string) (retErr error) {
+	// make sure there aren't unfinished commits on the branches, which would be
+	// left by a sync that failed
+	for _, branch := range []string{"master", ppsconsts.ObjectStorageListingBranch} {
+		commitInfo, err := pachClient.InspectCommit(repo, branch)
+		if err != nil && !pfsServer.IsNoHeadErr(err) && !isNotFoundErr(err) {
+			return err
+		} else if commitInfo != nil && commitInfo.Finished == nil {
+			if err := pachClient.DeleteCommit(repo, commitInfo.Commit.ID); err != nil {
+				return err
+			}
+		}
+	}
+	prev, err := readObjectListing(pachClient, repo)
+	if err != nil {
+		return err
+	}
+	listing := make(objectListing)
+	if err := obj.WalkInfo(pachClient.Ctx(), objClient, url.Object, func(info *obj.ObjectInfo) error {
+		listing[info.Name] = objectVersion{
+			ETag:    info.ETag,
+			ModTime: info.ModTime,
+			Size:    info.Size,
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	var changed, removed []string
+	for name, version := range listing {
+		if old, ok := prev[name]; !ok || version.changed(old) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range prev {
+		if _, ok := listing[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	if len(changed) == 0 && len(removed) == 0 {
+		return nil
+	}
+	sort.Strings(changed)
+	sort.Strings(removed)
+
+	var commits []*pfs.Commit
+	defer func() {
+		if retErr != nil {
+			for _, commit := range commits {
+				pachClient.DeleteCommit(repo, commit.ID)
+			}
+		}
+	}()
+	commit, err := pachClient.StartCommit(repo, "master")
+	if err != nil {
+		return err
+	}
+	commits = append(commits, commit)
+	listingCommit, err := pachClient.StartCommit(repo, ppsconsts.ObjectStorageListingBranch)
+	if err != nil {
+		return err
+	}
+	commits = append(commits, listingCommit)
+	for _, name := range removed {
+		if err := pachClient.DeleteFile(repo, commit.ID, objectPath(url.Object, name)); err != nil && !isNotFoundErr(err) {
+			return err
+		}
+	}
+	for _, name := range changed {
+		if err := func() (retErr error) {
+			r, err := objClient.Reader(pachClient.Ctx(), name, 0, 0)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := r.Close(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+			_, err = pachClient.PutFileOverwrite(repo, commit.ID, objectPath(url.Object, name), r, 0)
+			return err
+		}(); err != nil {
+			return errors.Wrapf(err, "error committing object %s", name)
+		}
+	}
+	if err := writeObjectListing(pachClient, repo, listingCommit.ID, listing); err != nil {
+		return err
+	}
+	_, err = pachClient.ExecuteInTransaction(func(c *client.APIClient) error {
+		for _, commit := range commits {
+			if err := c.FinishCommit(repo, commit.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// objectPath returns the path in the input's repo of the object name, which
+// is its name relative to the input's prefix.
+func objectPath(prefix, name string) string {
+	p := strings.Trim(strings.TrimPrefix(name, prefix), "/")
+	if p == "" {
+		// the prefix is the object itself
+		return path.Base(name)
+	}
+	return p
+}
+
+func readObjectListing(pachClient *client.APIClient, repo string) (objectListing, error) {
+	listing := make(objectListing)
+	var buf bytes.Buffer
+	if err := pachClient.GetFile(repo, ppsconsts.ObjectStorageListingBranch, objectListingFile, 0, 0, &buf); err != nil {
+		if isNotFoundErr(err) || pfsServer.IsNoHeadErr(err) {
+			return listing, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(buf.Bytes(), &listing); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return listing, nil
+}
+
+func writeObjectListing(pachClient *client.APIClient, repo string, commitID string, listing objectListing) error {
+	data, err := json.Marshal(listing)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err = pachClient.PutFileOverwrite(repo, commitID, objectListingFile, bytes.NewReader(data), 0)
+	return err
+}
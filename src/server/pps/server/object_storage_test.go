@@ -0,0 +1,102 @@
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+)
+
+func TestSyncObjectStorage(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		require.NoError(t, c.CreateRepo("bucket"))
+		root := filepath.Join(env.Directory, "objects")
+		write := func(name, data string) {
+			p := filepath.Join(root, "prefix", name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+			require.NoError(t, ioutil.WriteFile(p, []byte(data), 0644))
+		}
+		read := func(commitID, p string) string {
+			var buf bytes.Buffer
+			require.NoError(t, c.GetFile("bucket", commitID, p, 0, 0, &buf))
+			return buf.String()
+		}
+		commits := func() int {
+			commitInfos, err := c.ListCommit("bucket", "master", "", 0)
+			require.NoError(t, err)
+			return len(commitInfos)
+		}
+		objClient, err := obj.NewLocalClient(root)
+		require.NoError(t, err)
+		url := &obj.ObjectStoreURL{Store: "local", Object: "prefix"}
+
+		write("a", "a")
+		write("dir/b", "b")
+		require.NoError(t, syncObjectStorage(c, objClient, url, "bucket"))
+		require.Equal(t, 1, commits())
+		require.Equal(t, "a", read("master", "a"))
+		require.Equal(t, "b", read("master", "dir/b"))
+
+		// Nothing is committed if no objects changed.
+		require.NoError(t, syncObjectStorage(c, objClient, url, "bucket"))
+		require.Equal(t, 1, commits())
+
+		// Only new and changed objects are written, and removed objects are
+		// deleted.
+		first, err := c.InspectCommit("bucket", "master")
+		require.NoError(t, err)
+		write("a", "aa")
+		require.NoError(t, os.Chtimes(filepath.Join(root, "prefix", "a"), time.Now(), time.Now().Add(time.Minute)))
+		write("c", "c")
+		require.NoError(t, os.Remove(filepath.Join(root, "prefix", "dir", "b")))
+		require.NoError(t, syncObjectStorage(c, objClient, url, "bucket"))
+		require.Equal(t, 2, commits())
+		require.Equal(t, "aa", read("master", "a"))
+		require.Equal(t, "c", read("master", "c"))
+		_, err = c.InspectFile("bucket", "master", "dir/b")
+		require.YesError(t, err)
+		newFiles, _, err := c.DiffFile("bucket", "master", "", "bucket", first.Commit.ID, "", false)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(newFiles))
+
+		// The listing is persisted, so a restart doesn't commit every object
+		// again.
+		listing, err := readObjectListing(c, "bucket")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(listing))
+		require.NoError(t, syncObjectStorage(c, objClient, url, "bucket"))
+		require.Equal(t, 2, commits())
+
+		// A sync that dies before its commits are finished leaves them open,
+		// they're deleted by the next sync, which commits the objects once.
+		write("d", "d")
+		_, err = c.StartCommit("bucket", "master")
+		require.NoError(t, err)
+		_, err = c.StartCommit("bucket", ppsconsts.ObjectStorageListingBranch)
+		require.NoError(t, err)
+		require.NoError(t, syncObjectStorage(c, objClient, url, "bucket"))
+		require.Equal(t, 3, commits())
+		require.Equal(t, "d", read("master", "d"))
+		require.NoError(t, syncObjectStorage(c, objClient, url, "bucket"))
+		require.Equal(t, 3, commits())
+		listingInfo, err := c.InspectCommit("bucket", ppsconsts.ObjectStorageListingBranch)
+		require.NoError(t, err)
+		require.NotNil(t, listingInfo.Finished)
+		return nil
+	}))
+}
+
+func TestObjectPath(t *testing.T) {
+	require.Equal(t, "a/b", objectPath("dir", "dir/a/b"))
+	require.Equal(t, "a/b", objectPath("dir/", "dir/a/b"))
+	require.Equal(t, "a/b", objectPath("", "a/b"))
+	require.Equal(t, "file", objectPath("dir/file", "dir/file"))
+}
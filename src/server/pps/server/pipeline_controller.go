@@ -137,7 +137,10 @@ func (a *apiServer) step(pachClient *client.APIClient, pipeline string, keyVer,
 		}
 		// default: scale down if standby hasn't propagated to kube RC yet
 		op.startPipelineMonitor()
-		return op.scaleDownPipeline()
+		if err := op.scaleDownPipeline(); err != nil {
+			return err
+		}
+		return op.releaseQueue()
 	case pps.PipelineState_PIPELINE_PAUSED:
 		if !op.rcIsFresh() {
 			return op.restartPipeline("stale RC") // step() will be called again after etcd write
@@ -155,7 +158,10 @@ func (a *apiServer) step(pachClient *client.APIClient, pipeline string, keyVer,
 		op.stopPipelineMonitor()
 		op.stopCrashingPipelineMonitor()
 		// default: scale down if pause/standby hasn't propagated to etcd yet
-		return op.scaleDownPipeline()
+		if err := op.scaleDownPipeline(); err != nil {
+			return err
+		}
+		return op.releaseQueue()
 	case pps.PipelineState_PIPELINE_FAILURE:
 		// pipeline fails if it encounters an unrecoverable error
 		if err := op.finishPipelineOutputCommits(); err != nil {
@@ -242,7 +248,7 @@ func (op *pipelineOp) getRC(expectation rcExpectation) (retErr error) {
 
 	kubeClient := op.apiServer.env.GetKubeClient()
 	namespace := op.apiServer.namespace
-	selector := fmt.Sprintf("%s=%s", pipelineNameLabel, op.name)
+	selector := mainRcSelector(op.name)
 
 	// count error types separately, so that this only errors if the pipeline is
 	// stuck and not changing
@@ -565,20 +571,29 @@ func (op *pipelineOp) scaleUpPipeline() (retErr error) {
 	}()
 
 	// compute target pipeline parallelism
-	parallelism := int(op.ptr.Parallelism)
+	parallelism := op.numWorkers()
 	if parallelism == 0 {
 		log.Errorf("PPS master: error getting number of workers (defaulting to 1 worker)")
 		parallelism = 1
 	}
+	// check that the pipeline fits in its queue (0 if it must wait)
+	parallelism, err := op.admitPipeline(parallelism)
+	if err != nil {
+		return err
+	}
 
 	// update pipeline RC
-	return op.updateRC(func(rc *v1.ReplicationController) {
+	if err := op.updateRC(func(rc *v1.ReplicationController) {
 		if rc.Spec.Replicas != nil && *op.rc.Spec.Replicas == int32(parallelism) {
 			return // prior attempt succeeded
 		}
 		rc.Spec.Replicas = new(int32)
 		*rc.Spec.Replicas = int32(parallelism)
-	})
+	}); err != nil {
+		return err
+	}
+	// worker pools wait with the pipeline if it doesn't fit in its queue
+	return op.scaleWorkerPools(parallelism > 0)
 }
 
 // scaleDownPipeline edits the RC associated with op's pipeline & spins down the
@@ -598,12 +613,15 @@ func (op *pipelineOp) scaleDownPipeline() (retErr error) {
 		tracing.FinishAnySpan(span)
 	}()
 
-	return op.updateRC(func(rc *v1.ReplicationController) {
+	if err := op.updateRC(func(rc *v1.ReplicationController) {
 		if rc.Spec.Replicas != nil && *op.rc.Spec.Replicas == 0 {
 			return // prior attempt succeeded
 		}
 		rc.Spec.Replicas = &zero
-	})
+	}); err != nil {
+		return err
+	}
+	return op.scaleWorkerPools(false)
 }
 
 // restartPipeline updates the RC/service associated with op's pipeline, and
@@ -668,8 +686,18 @@ func (op *pipelineOp) failPipeline(reason string) error {
 	return errors.Errorf("failing pipeline %q: %v", op.name, reason)
 }
 
+// numWorkers returns the number of workers op's pipeline should run when it's
+// scaled up. For pipelines with autoscaling, this is the last number of
+// workers chosen by autoscalePipeline.
+func (op *pipelineOp) numWorkers() int {
+	if op.pipelineInfo.Autoscaling != nil {
+		return int(autoscalingWorkerCount(op.pipelineInfo.Autoscaling, op.ptr.AutoscalingStatus))
+	}
+	return int(op.ptr.Parallelism)
+}
+
 func (op *pipelineOp) allWorkersUp() (bool, error) {
-	parallelism := int(op.ptr.Parallelism)
+	parallelism := op.numWorkers()
 	if parallelism == 0 {
 		parallelism = 1
 	}
@@ -680,5 +708,6 @@ func (op *pipelineOp) allWorkersUp() (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return parallelism == len(workerStatus), nil
+	// worker pools' workers register with the pipeline's workers
+	return parallelism+numWorkerPoolWorkers(op.pipelineInfo, op.ptr) == len(workerStatus), nil
 }
@@ -0,0 +1,481 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+const (
+	// defaultQueue is the queue of pipelines that don't set one. It has no
+	// quota unless it's created explicitly.
+	defaultQueue = "default"
+	// queueInterval is how often the PPS master re-checks pipelines that are
+	// waiting for capacity in their queue.
+	queueInterval = 10 * time.Second
+)
+
+var (
+	queueWorkers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pachyderm",
+			Subsystem: "pps",
+			Name:      "queue_workers",
+			Help:      "Number of workers used by the running pipelines in a queue",
+		},
+		[]string{"queue"},
+	)
+	queueWaitingPipelines = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pachyderm",
+			Subsystem: "pps",
+			Name:      "queue_waiting_pipelines",
+			Help:      "Number of pipelines waiting for capacity in a queue",
+		},
+		[]string{"queue"},
+	)
+)
+
+func init() {
+	for _, metric := range []prometheus.Collector{
+		queueWorkers,
+		queueWaitingPipelines,
+	} {
+		if err := prometheus.Register(metric); err != nil {
+			// metrics may be redundantly registered; ignore these errors
+			if !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+				log.Errorf("error registering prometheus metric: %v", err)
+			}
+		}
+	}
+}
+
+// queueName returns the name of the queue that a pipeline with the given
+// 'queue' field belongs to.
+func queueName(queue string) string {
+	if queue == "" {
+		return defaultQueue
+	}
+	return queue
+}
+
+// queueClaim is a pipeline's claim on its queue, as recorded in its
+// EtcdPipelineInfo.
+type queueClaim struct {
+	pipeline string
+	state    pps.PipelineState
+	status   *pps.QueueStatus
+}
+
+// running returns true if the claim's pipeline has been admitted and is
+// holding its workers.
+func (c *queueClaim) running() bool {
+	return c.status != nil && !c.status.Waiting &&
+		(c.state == pps.PipelineState_PIPELINE_RUNNING || c.state == pps.PipelineState_PIPELINE_CRASHING)
+}
+
+// waiting returns true if the claim's pipeline is trying to scale up but
+// hasn't been admitted yet.
+func (c *queueClaim) waiting() bool {
+	return c.status != nil && c.status.Waiting && c.state == pps.PipelineState_PIPELINE_RUNNING
+}
+
+func addUsage(usage *pps.QueueUsage, status *pps.QueueStatus) {
+	usage.Workers += status.Workers
+	usage.Cpu += status.Cpu
+	usage.Memory += status.Memory
+}
+
+func formatMemory(bytes int64) string {
+	return resource.NewQuantity(bytes, resource.BinarySI).String()
+}
+
+// exceedsQuota returns why 'status' doesn't fit in 'queueInfo' alongside
+// 'usage', or "" if it fits. A nil queueInfo (a queue that was never created)
+// has no quota.
+func exceedsQuota(queueInfo *pps.QueueInfo, usage *pps.QueueUsage, status *pps.QueueStatus) string {
+	if queueInfo == nil {
+		return ""
+	}
+	if usage == nil {
+		usage = &pps.QueueUsage{}
+	}
+	name := queueInfo.Queue.Name
+	if max := queueInfo.MaxWorkers; max > 0 && usage.Workers+status.Workers > max {
+		return fmt.Sprintf("queue %q is using %d of %d workers, pipeline needs %d",
+			name, usage.Workers, max, status.Workers)
+	}
+	if r := queueInfo.MaxResources; r != nil {
+		if max := float64(r.Cpu); max > 0 && usage.Cpu+status.Cpu > max {
+			return fmt.Sprintf("queue %q is using %g of %g cpus, pipeline needs %g",
+				name, usage.Cpu, max, status.Cpu)
+		}
+		if r.Memory != "" {
+			// max_resources is validated by CreateQueue
+			max, err := resource.ParseQuantity(r.Memory)
+			if err == nil && usage.Memory+status.Memory > max.Value() {
+				return fmt.Sprintf("queue %q is using %s of %s memory, pipeline needs %s",
+					name, formatMemory(usage.Memory), r.Memory, formatMemory(status.Memory))
+			}
+		}
+	}
+	return ""
+}
+
+// queueShare is the number of workers a queue is using per unit of weight.
+// When queues compete for the cluster's workers, the queue using the smallest
+// share goes first.
+func queueShare(queueInfo *pps.QueueInfo, usage *pps.QueueUsage) float64 {
+	weight := uint64(1)
+	if queueInfo != nil && queueInfo.Weight > 0 {
+		weight = queueInfo.Weight
+	}
+	if usage == nil {
+		return 0
+	}
+	return float64(usage.Workers) / float64(weight)
+}
+
+// queueOrder returns true if 'a' should be admitted before 'b': pipelines with
+// a higher priority go first, then pipelines in the queue using the smallest
+// share of the cluster, then the pipeline that has been waiting longest.
+func queueOrder(queues map[string]*pps.QueueInfo, usage map[string]*pps.QueueUsage, a, b *queueClaim) bool {
+	if a.status.Priority != b.status.Priority {
+		return a.status.Priority > b.status.Priority
+	}
+	if qa, qb := a.status.Queue, b.status.Queue; qa != qb {
+		sa, sb := queueShare(queues[qa], usage[qa]), queueShare(queues[qb], usage[qb])
+		if sa != sb {
+			return sa < sb
+		}
+	}
+	// a pipeline that isn't waiting yet goes after every waiting pipeline
+	switch ta, tb := a.status.WaitingSince, b.status.WaitingSince; {
+	case ta != nil && tb == nil:
+		return true
+	case ta == nil && tb != nil:
+		return false
+	case ta != nil && tb != nil && (ta.Seconds != tb.Seconds || ta.Nanos != tb.Nanos):
+		if ta.Seconds != tb.Seconds {
+			return ta.Seconds < tb.Seconds
+		}
+		return ta.Nanos < tb.Nanos
+	}
+	return a.pipeline < b.pipeline
+}
+
+// queueUsage sums the claims of the running pipelines in each queue,
+// excluding 'exclude'.
+func queueUsage(claims []*queueClaim, exclude string) (map[string]*pps.QueueUsage, uint64) {
+	usage := make(map[string]*pps.QueueUsage)
+	var total uint64
+	for _, c := range claims {
+		if c.pipeline == exclude || !c.running() {
+			continue
+		}
+		if usage[c.status.Queue] == nil {
+			usage[c.status.Queue] = &pps.QueueUsage{}
+		}
+		addUsage(usage[c.status.Queue], c.status)
+		total += c.status.Workers
+	}
+	return usage, total
+}
+
+// scheduleDecision returns whether the pipeline making 'claim' may scale up,
+// given the claims of every pipeline and the cluster's 'maxWorkers' (0 if
+// unlimited), and if not, why. 'holding' is true if the pipeline is already
+// running, in which case it's only checked against its quota (a pipeline is
+// never preempted by a pipeline that's waiting).
+func scheduleDecision(queues map[string]*pps.QueueInfo, maxWorkers uint64, claims []*queueClaim, claim *queueClaim, holding bool) (bool, string) {
+	usage, total := queueUsage(claims, claim.pipeline)
+	queue := claim.status.Queue
+	if reason := exceedsQuota(queues[queue], usage[queue], claim.status); reason != "" {
+		return false, reason
+	}
+	if maxWorkers > 0 && total+claim.status.Workers > maxWorkers {
+		return false, fmt.Sprintf("cluster is using %d of %d workers, pipeline needs %d",
+			total, maxWorkers, claim.status.Workers)
+	}
+	if holding {
+		return true, ""
+	}
+	for _, c := range claims {
+		if c.pipeline == claim.pipeline || !c.waiting() {
+			continue
+		}
+		if c.status.Queue == queue {
+			if exceedsQuota(queues[queue], nil, c.status) != "" {
+				continue // c can never run, so it shouldn't block anything
+			}
+		} else {
+			// pipelines in other queues only compete for the cluster's workers,
+			// and only if their own queue has room for them
+			if maxWorkers == 0 || c.status.Workers > maxWorkers ||
+				exceedsQuota(queues[c.status.Queue], usage[c.status.Queue], c.status) != "" {
+				continue
+			}
+		}
+		if queueOrder(queues, usage, c, claim) {
+			return false, fmt.Sprintf("waiting behind pipeline %q", c.pipeline)
+		}
+	}
+	return true, ""
+}
+
+// readQueueState reads every queue and the queue claim of every pipeline.
+func (a *apiServer) readQueueState(ctx context.Context) (map[string]*pps.QueueInfo, []*queueClaim, error) {
+	queues := make(map[string]*pps.QueueInfo)
+	queueInfo := &pps.QueueInfo{}
+	if err := a.queues.ReadOnly(ctx).List(queueInfo, col.DefaultOptions, func(string) error {
+		queues[queueInfo.Queue.Name] = proto.Clone(queueInfo).(*pps.QueueInfo)
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	var claims []*queueClaim
+	pipelinePtr := &pps.EtcdPipelineInfo{}
+	if err := a.pipelines.ReadOnly(ctx).List(pipelinePtr, col.DefaultOptions, func(name string) error {
+		if pipelinePtr.QueueStatus != nil {
+			claims = append(claims, &queueClaim{
+				pipeline: name,
+				state:    pipelinePtr.State,
+				status:   proto.Clone(pipelinePtr.QueueStatus).(*pps.QueueStatus),
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	return queues, claims, nil
+}
+
+// queueStatus returns the claim that a pipeline makes on its queue when it
+// runs 'workers' workers. The pipeline's worker pools are claimed at their full
+// size, as they grow and shrink with their pending datums without going
+// through the queue (see autoscaleWorkerPools).
+func queueStatus(pipelineInfo *pps.PipelineInfo, workers int) *pps.QueueStatus {
+	status := &pps.QueueStatus{
+		Queue:    queueName(pipelineInfo.Queue),
+		Priority: pipelineInfo.Priority,
+		Workers:  uint64(workers),
+	}
+	addResourceRequests(status, pipelineInfo.ResourceRequests, workers)
+	for _, pool := range pipelineInfo.WorkerPools {
+		size := int(workerPoolSize(pool))
+		status.Workers += uint64(size)
+		status.WorkerPoolWorkers += uint64(size)
+		requests := pool.ResourceRequests
+		if requests == nil {
+			requests = pipelineInfo.ResourceRequests // see workerPoolOptions
+		}
+		addResourceRequests(status, requests, size)
+	}
+	return status
+}
+
+// addResourceRequests adds the resources requested by 'workers' workers to
+// 'status'.
+func addResourceRequests(status *pps.QueueStatus, r *pps.ResourceSpec, workers int) {
+	if r == nil {
+		return
+	}
+	status.Cpu += float64(r.Cpu) * float64(workers)
+	if r.Memory != "" {
+		if memory, err := resource.ParseQuantity(r.Memory); err == nil {
+			status.Memory += memory.Value() * int64(workers)
+		}
+	}
+}
+
+// admitPipeline checks whether op's pipeline may scale up to 'workers'
+// workers, records its claim in its EtcdPipelineInfo, and returns the number
+// of workers that the pipeline should run: 'workers' if it was admitted, the
+// number it's already running if it's running and can't grow, or 0 if it must
+// wait.
+func (op *pipelineOp) admitPipeline(workers int) (int, error) {
+	ctx := op.pachClient.Ctx()
+	queues, claims, err := op.apiServer.readQueueState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	prev := op.ptr.QueueStatus
+	holding := prev != nil && !prev.Waiting
+	status := queueStatus(op.pipelineInfo, workers)
+	ok, reason := scheduleDecision(queues, op.apiServer.maxWorkers, claims,
+		&queueClaim{pipeline: op.name, state: op.ptr.State, status: status}, holding)
+	switch {
+	case ok:
+	case holding && prev.Queue == status.Queue:
+		log.Infof("PPS master: %q can't grow to %d workers: %s", op.name, workers, reason)
+		return int(prev.Workers - prev.WorkerPoolWorkers), nil
+	default:
+		log.Infof("PPS master: %q is waiting for capacity: %s", op.name, reason)
+		status.Waiting = true
+		status.Reason = reason
+		status.WaitingSince = now()
+		if prev != nil && prev.Waiting {
+			status.WaitingSince = prev.WaitingSince
+		}
+		workers = 0
+	}
+	if proto.Equal(status, prev) {
+		return workers, nil
+	}
+	// writing the pipeline pointer triggers another step(), which finds the
+	// claim unchanged
+	if _, err := col.NewSTM(ctx, op.apiServer.env.GetEtcdClient(), func(stm col.STM) error {
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		return op.apiServer.pipelines.ReadWrite(stm).Update(op.name, pipelinePtr, func() error {
+			pipelinePtr.QueueStatus = status
+			return nil
+		})
+	}); err != nil {
+		return 0, err
+	}
+	op.ptr.QueueStatus = status
+	return workers, nil
+}
+
+// releaseQueue clears the queue claim of op's pipeline once it has been scaled
+// down, so that its workers no longer count against its queue.
+func (op *pipelineOp) releaseQueue() error {
+	if op.ptr.QueueStatus == nil {
+		return nil
+	}
+	_, err := col.NewSTM(op.pachClient.Ctx(), op.apiServer.env.GetEtcdClient(), func(stm col.STM) error {
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		return op.apiServer.pipelines.ReadWrite(stm).Update(op.name, pipelinePtr, func() error {
+			pipelinePtr.QueueStatus = nil
+			return nil
+		})
+	})
+	return err
+}
+
+// scheduleWaitingPipelines re-runs step() for every pipeline that's waiting
+// for capacity, in the order that they should be admitted, and updates the
+// queue metrics. It's called periodically by the PPS master, as capacity is
+// freed by pipelines that go into standby, are paused or are deleted.
+func (a *apiServer) scheduleWaitingPipelines(pachClient *client.APIClient) error {
+	queues, claims, err := a.readQueueState(pachClient.Ctx())
+	if err != nil {
+		return err
+	}
+	usage, _ := queueUsage(claims, "")
+	var waiting []*queueClaim
+	waitingCounts := make(map[string]int)
+	for _, c := range claims {
+		if c.waiting() {
+			waiting = append(waiting, c)
+			waitingCounts[c.status.Queue]++
+		}
+	}
+	queueWorkers.Reset()
+	queueWaitingPipelines.Reset()
+	for name, u := range usage {
+		queueWorkers.WithLabelValues(name).Set(float64(u.Workers))
+	}
+	for name, count := range waitingCounts {
+		queueWaitingPipelines.WithLabelValues(name).Set(float64(count))
+	}
+	sort.Slice(waiting, func(i, j int) bool {
+		return queueOrder(queues, usage, waiting[i], waiting[j])
+	})
+	for _, c := range waiting {
+		if err := a.step(pachClient, c.pipeline, 0, 0); err != nil {
+			log.Errorf("PPS master: %v", err)
+		}
+	}
+	return nil
+}
+
+// inspectQueue returns the QueueInfo of 'name', without its usage.
+func (a *apiServer) inspectQueue(ctx context.Context, name string) (*pps.QueueInfo, error) {
+	queueInfo := &pps.QueueInfo{}
+	if err := a.queues.ReadOnly(ctx).Get(name, queueInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("queue %s not found", name)
+		}
+		return nil, err
+	}
+	return queueInfo, nil
+}
+
+// listQueues returns every queue with its usage. Queues that pipelines
+// reference but that weren't created (such as the default queue) are included
+// if they're in use.
+func (a *apiServer) listQueues(ctx context.Context) ([]*pps.QueueInfo, error) {
+	queues, claims, err := a.readQueueState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range claims {
+		if (c.running() || c.waiting()) && queues[c.status.Queue] == nil {
+			queues[c.status.Queue] = &pps.QueueInfo{
+				Queue:  &pps.Queue{Name: c.status.Queue},
+				Weight: 1,
+			}
+		}
+	}
+	for _, queueInfo := range queues {
+		queueInfo.Usage = &pps.QueueUsage{}
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		return queueOrder(queues, nil, claims[i], claims[j])
+	})
+	for _, c := range claims {
+		queuedPipeline := &pps.QueuedPipeline{Pipeline: client.NewPipeline(c.pipeline), Status: c.status}
+		usage := queues[c.status.Queue].GetUsage()
+		switch {
+		case c.running():
+			addUsage(usage, c.status)
+			usage.Running = append(usage.Running, queuedPipeline)
+		case c.waiting():
+			usage.Waiting = append(usage.Waiting, queuedPipeline)
+		}
+	}
+	result := make([]*pps.QueueInfo, 0, len(queues))
+	for _, queueInfo := range queues {
+		result = append(result, queueInfo)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Queue.Name < result[j].Queue.Name
+	})
+	return result, nil
+}
+
+func validateQueue(queueInfo *pps.QueueInfo) error {
+	if queueInfo.Queue == nil || queueInfo.Queue.Name == "" {
+		return errors.New("queue name cannot be empty")
+	}
+	if err := ancestry.ValidateName(queueInfo.Queue.Name); err != nil {
+		return errors.Wrapf(err, "invalid queue name")
+	}
+	if r := queueInfo.MaxResources; r != nil {
+		if r.Cpu < 0 {
+			return errors.Errorf("max_resources.cpu can't be negative")
+		}
+		if r.Memory != "" {
+			if _, err := resource.ParseQuantity(r.Memory); err != nil {
+				return errors.Wrapf(err, "could not parse max_resources.memory")
+			}
+		}
+		if r.Gpu != nil || r.Disk != "" {
+			return errors.Errorf("queues can only limit cpu and memory")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func runningClaim(pipeline, queue string, workers uint64) *queueClaim {
+	return &queueClaim{
+		pipeline: pipeline,
+		state:    pps.PipelineState_PIPELINE_RUNNING,
+		status:   &pps.QueueStatus{Queue: queue, Workers: workers},
+	}
+}
+
+func waitingClaim(pipeline, queue string, priority int64, workers uint64, since int64) *queueClaim {
+	return &queueClaim{
+		pipeline: pipeline,
+		state:    pps.PipelineState_PIPELINE_RUNNING,
+		status: &pps.QueueStatus{
+			Queue:        queue,
+			Priority:     priority,
+			Workers:      workers,
+			Waiting:      true,
+			WaitingSince: &types.Timestamp{Seconds: since},
+		},
+	}
+}
+
+func TestScheduleDecisionQuota(t *testing.T) {
+	queues := map[string]*pps.QueueInfo{
+		"backfill": {
+			Queue:        &pps.Queue{Name: "backfill"},
+			MaxWorkers:   10,
+			MaxResources: &pps.ResourceSpec{Cpu: 8, Memory: "16Gi"},
+		},
+	}
+	claims := []*queueClaim{
+		runningClaim("a", "backfill", 6),
+		runningClaim("b", "default", 100),
+		// standby pipelines don't hold workers
+		{pipeline: "c", state: pps.PipelineState_PIPELINE_STANDBY, status: &pps.QueueStatus{Queue: "backfill", Workers: 4}},
+	}
+	ok, _ := scheduleDecision(queues, 0, claims, runningClaim("d", "backfill", 4), false)
+	require.True(t, ok)
+	ok, reason := scheduleDecision(queues, 0, claims, runningClaim("d", "backfill", 5), false)
+	require.False(t, ok)
+	require.Equal(t, `queue "backfill" is using 6 of 10 workers, pipeline needs 5`, reason)
+
+	// a pipeline's own claim doesn't count against it
+	ok, _ = scheduleDecision(queues, 0, claims, runningClaim("a", "backfill", 10), true)
+	require.True(t, ok)
+
+	claims[0].status.Cpu = 6
+	d := runningClaim("d", "backfill", 1)
+	d.status.Cpu = 3
+	ok, reason = scheduleDecision(queues, 0, claims, d, false)
+	require.False(t, ok)
+	require.Equal(t, `queue "backfill" is using 6 of 8 cpus, pipeline needs 3`, reason)
+
+	d.status.Cpu = 1
+	d.status.Memory = 17 << 30
+	ok, reason = scheduleDecision(queues, 0, claims, d, false)
+	require.False(t, ok)
+	require.Equal(t, `queue "backfill" is using 0 of 16Gi memory, pipeline needs 17Gi`, reason)
+
+	// the default queue has no quota, but the cluster's workers are shared
+	ok, _ = scheduleDecision(queues, 0, claims, runningClaim("e", "default", 50), false)
+	require.True(t, ok)
+	ok, reason = scheduleDecision(queues, 110, claims, runningClaim("e", "default", 5), false)
+	require.False(t, ok)
+	require.Equal(t, "cluster is using 106 of 110 workers, pipeline needs 5", reason)
+}
+
+func TestScheduleDecisionOrder(t *testing.T) {
+	queues := map[string]*pps.QueueInfo{
+		"etl": {Queue: &pps.Queue{Name: "etl"}, MaxWorkers: 10},
+	}
+	claims := []*queueClaim{
+		runningClaim("a", "etl", 6),
+		waitingClaim("b", "etl", 5, 2, 100),
+	}
+	// new pipelines wait behind waiting pipelines with the same or a higher
+	// priority, even if they fit
+	ok, reason := scheduleDecision(queues, 0, claims, runningClaim("c", "etl", 1), false)
+	require.False(t, ok)
+	require.Equal(t, `waiting behind pipeline "b"`, reason)
+	c := waitingClaim("c", "etl", 10, 1, 200)
+	ok, _ = scheduleDecision(queues, 0, claims, c, false)
+	require.True(t, ok)
+
+	// pipelines in other queues don't compete unless the cluster is limited
+	ok, _ = scheduleDecision(queues, 0, claims, runningClaim("d", "other", 1), false)
+	require.True(t, ok)
+	ok, _ = scheduleDecision(queues, 20, claims, runningClaim("d", "other", 1), false)
+	require.False(t, ok)
+
+	// a pipeline that can never fit in its queue doesn't block others
+	claims = append(claims, waitingClaim("e", "etl", 100, 11, 50))
+	ok, reason = scheduleDecision(queues, 0, claims, waitingClaim("f", "etl", 50, 1, 60), false)
+	require.True(t, ok, reason)
+}
+
+func TestQueueOrder(t *testing.T) {
+	queues := map[string]*pps.QueueInfo{
+		"heavy": {Queue: &pps.Queue{Name: "heavy"}, Weight: 4},
+		"light": {Queue: &pps.Queue{Name: "light"}, Weight: 1},
+	}
+	usage := map[string]*pps.QueueUsage{
+		"heavy": {Workers: 8},
+		"light": {Workers: 4},
+	}
+	// higher priority first
+	require.True(t, queueOrder(queues, usage, waitingClaim("a", "light", 2, 1, 200), waitingClaim("b", "heavy", 1, 1, 100)))
+	// then the queue using the smallest share of its weight (8/4 < 4/1)
+	require.True(t, queueOrder(queues, usage, waitingClaim("a", "heavy", 1, 1, 200), waitingClaim("b", "light", 1, 1, 100)))
+	// then the pipeline that has waited longest
+	require.True(t, queueOrder(queues, usage, waitingClaim("a", "light", 1, 1, 100), waitingClaim("b", "light", 1, 1, 200)))
+	require.True(t, queueOrder(queues, usage, waitingClaim("b", "light", 1, 1, 100), runningClaim("a", "light", 1)))
+}
+
+func TestValidateQueue(t *testing.T) {
+	validate := func(queueInfo *pps.QueueInfo) error {
+		queueInfo.Queue = &pps.Queue{Name: "q"}
+		return validateQueue(queueInfo)
+	}
+	require.NoError(t, validate(&pps.QueueInfo{MaxResources: &pps.ResourceSpec{Cpu: 2, Memory: "1Gi"}}))
+	require.YesError(t, validate(&pps.QueueInfo{MaxResources: &pps.ResourceSpec{Memory: "lots"}}))
+	require.YesError(t, validate(&pps.QueueInfo{MaxResources: &pps.ResourceSpec{Cpu: -1}}))
+	require.YesError(t, validate(&pps.QueueInfo{MaxResources: &pps.ResourceSpec{Gpu: &pps.GPUSpec{Number: 1}}}))
+	require.YesError(t, validateQueue(&pps.QueueInfo{Queue: &pps.Queue{Name: "a/b"}}))
+}
+
+func TestQueueStatusWorkerPools(t *testing.T) {
+	pipelineInfo := &pps.PipelineInfo{
+		Queue:            "gpu",
+		ResourceRequests: &pps.ResourceSpec{Cpu: 1, Memory: "1Gi"},
+		WorkerPools: []*pps.WorkerPool{
+			{Name: "large", Parallelism: 2, ResourceRequests: &pps.ResourceSpec{Cpu: 4, Memory: "8Gi"}},
+			// pools without their own requests use the pipeline's
+			{Name: "small"},
+		},
+	}
+	status := queueStatus(pipelineInfo, 3)
+	require.Equal(t, "gpu", status.Queue)
+	require.Equal(t, uint64(6), status.Workers)
+	require.Equal(t, uint64(3), status.WorkerPoolWorkers)
+	require.Equal(t, float64(3+8+1), status.Cpu)
+	require.Equal(t, int64((3+16+1)<<30), status.Memory)
+
+	// pools count against the queue's quota, and the cluster's max workers
+	queues := map[string]*pps.QueueInfo{"gpu": {Queue: &pps.Queue{Name: "gpu"}, MaxWorkers: 5}}
+	claim := &queueClaim{pipeline: "a", state: pps.PipelineState_PIPELINE_RUNNING, status: status}
+	ok, _ := scheduleDecision(queues, 0, nil, claim, false)
+	require.False(t, ok)
+	ok, _ = scheduleDecision(nil, 5, nil, claim, false)
+	require.False(t, ok)
+	ok, _ = scheduleDecision(nil, 6, nil, claim, false)
+	require.True(t, ok)
+}
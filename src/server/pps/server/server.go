@@ -37,6 +37,7 @@ func NewAPIServer(
 	httpPort uint16,
 	peerPort uint16,
 	gcPercent int,
+	maxWorkers uint64,
 ) (APIServer, error) {
 	apiServer := &apiServer{
 		Logger:                 log.NewLogger("pps.API"),
@@ -57,6 +58,10 @@ func NewAPIServer(
 		workerUsesRoot:         workerUsesRoot,
 		pipelines:              ppsdb.Pipelines(env.GetEtcdClient(), etcdPrefix),
 		jobs:                   ppsdb.Jobs(env.GetEtcdClient(), etcdPrefix),
+		templates:              ppsdb.Templates(env.GetEtcdClient(), etcdPrefix),
+		queues:                 ppsdb.Queues(env.GetEtcdClient(), etcdPrefix),
+		alerts:                 ppsdb.Alerts(env.GetEtcdClient(), etcdPrefix),
+		alertEvents:            ppsdb.AlertEvents(env.GetEtcdClient(), etcdPrefix),
 		monitorCancels:         make(map[string]func()),
 		crashingMonitorCancels: make(map[string]func()),
 		workerGrpcPort:         workerGrpcPort,
@@ -64,6 +69,7 @@ func NewAPIServer(
 		httpPort:               httpPort,
 		peerPort:               peerPort,
 		gcPercent:              gcPercent,
+		maxWorkers:             maxWorkers,
 	}
 	apiServer.validateKube()
 	go apiServer.master()
@@ -95,6 +101,10 @@ func NewSidecarAPIServer(
 		workerUsesRoot: true,
 		pipelines:      ppsdb.Pipelines(env.GetEtcdClient(), etcdPrefix),
 		jobs:           ppsdb.Jobs(env.GetEtcdClient(), etcdPrefix),
+		templates:      ppsdb.Templates(env.GetEtcdClient(), etcdPrefix),
+		queues:         ppsdb.Queues(env.GetEtcdClient(), etcdPrefix),
+		alerts:         ppsdb.Alerts(env.GetEtcdClient(), etcdPrefix),
+		alertEvents:    ppsdb.AlertEvents(env.GetEtcdClient(), etcdPrefix),
 		workerGrpcPort: workerGrpcPort,
 		httpPort:       httpPort,
 		peerPort:       peerPort,
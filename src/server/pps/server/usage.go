@@ -0,0 +1,142 @@
+package server
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+)
+
+// usageGroup returns the group that a pipeline's usage is reported under: the
+// value of 'label' if it's set, and otherwise the pipeline's name.
+func usageGroup(pipelineInfo *pps.PipelineInfo, label string) string {
+	if label == "" {
+		return pipelineInfo.Pipeline.Name
+	}
+	if pipelineInfo.Metadata == nil {
+		return ""
+	}
+	return pipelineInfo.Metadata.Labels[label]
+}
+
+// jobFinishedIn returns true if jobInfo finished in [from, to). A zero from or
+// to leaves that side of the range open.
+func jobFinishedIn(jobInfo *pps.JobInfo, from, to time.Time) bool {
+	if jobInfo.Finished == nil {
+		return false
+	}
+	finished, err := types.TimestampFromProto(jobInfo.Finished)
+	if err != nil {
+		return false
+	}
+	return !finished.Before(from) && (to.IsZero() || finished.Before(to))
+}
+
+// durationSeconds converts d to seconds, treating nil as zero.
+func durationSeconds(d *types.Duration) float64 {
+	if d == nil {
+		return 0
+	}
+	duration, err := types.DurationFromProto(d)
+	if err != nil {
+		return 0
+	}
+	return duration.Seconds()
+}
+
+// addJobUsage adds a finished job's stats to usage.
+func addJobUsage(usage *pps.UsageInfo, jobInfo *pps.JobInfo) error {
+	usage.Jobs++
+	usage.DataProcessed += jobInfo.DataProcessed
+	usage.DataFailed += jobInfo.DataFailed
+	if usage.Total == nil {
+		usage.Total = &pps.ProcessStats{}
+	}
+	stats := jobInfo.Stats
+	if stats == nil {
+		return nil
+	}
+	total := usage.Total
+	var err error
+	if total.DownloadTime, err = ppsutil.PlusDuration(total.DownloadTime, stats.DownloadTime); err != nil {
+		return err
+	}
+	if total.ProcessTime, err = ppsutil.PlusDuration(total.ProcessTime, stats.ProcessTime); err != nil {
+		return err
+	}
+	if total.UploadTime, err = ppsutil.PlusDuration(total.UploadTime, stats.UploadTime); err != nil {
+		return err
+	}
+	if total.CpuTime, err = ppsutil.PlusDuration(total.CpuTime, stats.CpuTime); err != nil {
+		return err
+	}
+	total.DownloadBytes += stats.DownloadBytes
+	total.UploadBytes += stats.UploadBytes
+	if stats.PeakMemoryBytes > total.PeakMemoryBytes {
+		total.PeakMemoryBytes = stats.PeakMemoryBytes
+	}
+	return nil
+}
+
+// aggregate returns the distribution of values. Percentiles are computed
+// using the nearest rank.
+func aggregate(values []float64) *pps.Aggregate {
+	result := &pps.Aggregate{Count: int64(len(values))}
+	if len(values) == 0 {
+		return result
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	result.Mean = sum / float64(len(sorted))
+	var squares float64
+	for _, v := range sorted {
+		squares += (v - result.Mean) * (v - result.Mean)
+	}
+	result.Stddev = math.Sqrt(squares / float64(len(sorted)))
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		return sorted[rank]
+	}
+	result.FifthPercentile = percentile(0.05)
+	result.NinetyFifthPercentile = percentile(0.95)
+	return result
+}
+
+// aggregateStats returns the distribution of each of the jobs' stats. Times
+// are in seconds.
+func aggregateStats(stats []*pps.ProcessStats) *pps.AggregateProcessStats {
+	var downloadTime, processTime, uploadTime, cpuTime []float64
+	var downloadBytes, uploadBytes, peakMemory []float64
+	for _, s := range stats {
+		if s == nil {
+			s = &pps.ProcessStats{}
+		}
+		downloadTime = append(downloadTime, durationSeconds(s.DownloadTime))
+		processTime = append(processTime, durationSeconds(s.ProcessTime))
+		uploadTime = append(uploadTime, durationSeconds(s.UploadTime))
+		cpuTime = append(cpuTime, durationSeconds(s.CpuTime))
+		downloadBytes = append(downloadBytes, float64(s.DownloadBytes))
+		uploadBytes = append(uploadBytes, float64(s.UploadBytes))
+		peakMemory = append(peakMemory, float64(s.PeakMemoryBytes))
+	}
+	return &pps.AggregateProcessStats{
+		DownloadTime:    aggregate(downloadTime),
+		ProcessTime:     aggregate(processTime),
+		UploadTime:      aggregate(uploadTime),
+		DownloadBytes:   aggregate(downloadBytes),
+		UploadBytes:     aggregate(uploadBytes),
+		CpuTime:         aggregate(cpuTime),
+		PeakMemoryBytes: aggregate(peakMemory),
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestUsageGroup(t *testing.T) {
+	pipelineInfo := &pps.PipelineInfo{
+		Pipeline: client.NewPipeline("edges"),
+		Metadata: &pps.Metadata{Labels: map[string]string{"team": "vision"}},
+	}
+	require.Equal(t, "edges", usageGroup(pipelineInfo, ""))
+	require.Equal(t, "vision", usageGroup(pipelineInfo, "team"))
+	require.Equal(t, "", usageGroup(pipelineInfo, "cost-center"))
+	require.Equal(t, "", usageGroup(&pps.PipelineInfo{Pipeline: client.NewPipeline("montage")}, "team"))
+}
+
+func TestJobFinishedIn(t *testing.T) {
+	now := time.Now()
+	jobInfo := &pps.JobInfo{Finished: timestamp(now.Add(-time.Hour))}
+	require.True(t, jobFinishedIn(jobInfo, time.Time{}, time.Time{}))
+	require.True(t, jobFinishedIn(jobInfo, now.Add(-2*time.Hour), now))
+	require.False(t, jobFinishedIn(jobInfo, now.Add(-30*time.Minute), time.Time{}))
+	require.False(t, jobFinishedIn(jobInfo, time.Time{}, now.Add(-time.Hour)))
+	require.False(t, jobFinishedIn(&pps.JobInfo{}, time.Time{}, time.Time{}))
+}
+
+func TestAddJobUsage(t *testing.T) {
+	usage := &pps.UsageInfo{}
+	require.NoError(t, addJobUsage(usage, &pps.JobInfo{
+		DataProcessed: 10,
+		Stats: &pps.ProcessStats{
+			ProcessTime:     types.DurationProto(time.Minute),
+			CpuTime:         types.DurationProto(90 * time.Second),
+			DownloadBytes:   100,
+			PeakMemoryBytes: 1 << 30,
+		},
+	}))
+	require.NoError(t, addJobUsage(usage, &pps.JobInfo{
+		DataProcessed: 5,
+		DataFailed:    1,
+		Stats: &pps.ProcessStats{
+			CpuTime:         types.DurationProto(30 * time.Second),
+			DownloadBytes:   50,
+			PeakMemoryBytes: 1 << 20,
+		},
+	}))
+	require.NoError(t, addJobUsage(usage, &pps.JobInfo{}))
+	require.Equal(t, int64(3), usage.Jobs)
+	require.Equal(t, int64(15), usage.DataProcessed)
+	require.Equal(t, int64(1), usage.DataFailed)
+	require.Equal(t, int64(120), usage.Total.CpuTime.Seconds)
+	require.Equal(t, int64(60), usage.Total.ProcessTime.Seconds)
+	require.Equal(t, uint64(150), usage.Total.DownloadBytes)
+	// peak memory is the highest of any job, not the sum
+	require.Equal(t, uint64(1<<30), usage.Total.PeakMemoryBytes)
+}
+
+func TestAggregateStats(t *testing.T) {
+	var stats []*pps.ProcessStats
+	for i := 1; i <= 20; i++ {
+		stats = append(stats, &pps.ProcessStats{
+			CpuTime:       types.DurationProto(time.Duration(i) * time.Second),
+			DownloadBytes: 100,
+		})
+	}
+	aggregates := aggregateStats(stats)
+	require.Equal(t, int64(20), aggregates.CpuTime.Count)
+	require.Equal(t, 10.5, aggregates.CpuTime.Mean)
+	require.Equal(t, 1.0, aggregates.CpuTime.FifthPercentile)
+	require.Equal(t, 19.0, aggregates.CpuTime.NinetyFifthPercentile)
+	require.Equal(t, 100.0, aggregates.DownloadBytes.Mean)
+	require.Equal(t, 0.0, aggregates.DownloadBytes.Stddev)
+	require.Equal(t, int64(0), aggregateStats(nil).CpuTime.Count)
+}
@@ -0,0 +1,266 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	glob "github.com/pachyderm/ohmyglob"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/pipeline/transform"
+)
+
+// workerPoolNameRe matches valid worker pool names. Pool names are part of
+// the name of the pool's RC, so they must be valid in k8s names.
+var workerPoolNameRe = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+func validateWorkerPools(pipelineInfo *pps.PipelineInfo) error {
+	if pipelineInfo.Service != nil || pipelineInfo.Spout != nil {
+		return errors.Errorf("worker pools aren't supported in services or spouts")
+	}
+	names := make(map[string]bool)
+	for _, pool := range pipelineInfo.WorkerPools {
+		if !workerPoolNameRe.MatchString(pool.Name) {
+			return errors.Errorf("invalid worker pool name %q, it must consist of lower case letters, numbers and '-'", pool.Name)
+		}
+		if names[pool.Name] {
+			return errors.Errorf("worker pool %q is defined more than once", pool.Name)
+		}
+		names[pool.Name] = true
+		if pool.Glob == "" && pool.MinSizeBytes == 0 && pool.MaxSizeBytes == 0 {
+			return errors.Errorf("worker pool %q must specify a glob or size", pool.Name)
+		}
+		if pool.MaxSizeBytes != 0 && pool.MinSizeBytes > pool.MaxSizeBytes {
+			return errors.Errorf("worker pool %q min_size_bytes can't be greater than max_size_bytes", pool.Name)
+		}
+		if pool.Glob != "" {
+			if _, err := glob.Compile(pool.Glob, '/'); err != nil {
+				return errors.Wrapf(err, "error parsing worker pool %q glob", pool.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// workerPoolSize returns the number of workers in pool.
+func workerPoolSize(pool *pps.WorkerPool) int32 {
+	if pool.Parallelism == 0 {
+		return 1
+	}
+	return int32(pool.Parallelism)
+}
+
+// workerPoolWorkers returns the number of workers that pool runs while its
+// pipeline is scaled up: the number that its pending datums need, as recorded
+// by autoscaleWorkerPools, up to the pool's size.
+func workerPoolWorkers(pool *pps.WorkerPool, ptr *pps.EtcdPipelineInfo) int32 {
+	size := workerPoolSize(pool)
+	if workers := ptr.WorkerPoolWorkers[pool.Name]; workers < uint64(size) {
+		return int32(workers)
+	}
+	return size
+}
+
+// workerPoolAutoscaling returns the bounds that autoscaleWorkerPools scales
+// pool within: from no workers, when no datums are routed to the pool, up to
+// the pool's size. Pools follow the target and scale down delay of the
+// pipeline's autoscaling, if it has any.
+func workerPoolAutoscaling(pipelineInfo *pps.PipelineInfo, pool *pps.WorkerPool) *pps.Autoscaling {
+	result := &pps.Autoscaling{MaxWorkers: uint64(workerPoolSize(pool))}
+	if autoscaling := pipelineInfo.Autoscaling; autoscaling != nil {
+		result.TargetDatumsPerWorker = autoscaling.TargetDatumsPerWorker
+		result.ScaleDownDelay = autoscaling.ScaleDownDelay
+	}
+	return result
+}
+
+// autoscaleWorkerPools periodically counts the datums pending in the task
+// queue of each of a pipeline's worker pools, and records the number of
+// workers that each pool needs in the pipeline's EtcdPipelineInfo. The
+// pipeline controller then resizes the pools' RCs (see scaleWorkerPools). It's
+// a helper function called by monitorPipeline, and returns when pachClient's
+// context is done.
+func (a *apiServer) autoscaleWorkerPools(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
+	pipelineName := pipelineInfo.Pipeline.Name
+	scalers := make(map[string]*autoscaler)
+	for _, pool := range pipelineInfo.WorkerPools {
+		scalers[pool.Name] = &autoscaler{autoscaling: workerPoolAutoscaling(pipelineInfo, pool), busySince: time.Now()}
+	}
+	ticker := time.NewTicker(autoscalingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-pachClient.Ctx().Done():
+			return pachClient.Ctx().Err()
+		}
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		if err := a.pipelines.ReadOnly(pachClient.Ctx()).Get(pipelineName, pipelinePtr); err != nil {
+			return err
+		}
+		poolWorkers := make(map[string]uint64)
+		changed := false
+		for _, pool := range pipelineInfo.WorkerPools {
+			current := uint64(workerPoolWorkers(pool, pipelinePtr))
+			workers := uint64(0)
+			if pipelinePtr.State == pps.PipelineState_PIPELINE_RUNNING {
+				pending, err := transform.PendingDatums(pachClient.Ctx(), a.env.GetEtcdClient(), a.etcdPrefix, pipelineInfo, pool.Name)
+				if err != nil {
+					return err
+				}
+				var reason string
+				if workers, reason, err = scalers[pool.Name].scale(current, pending, time.Now()); err != nil {
+					return err
+				}
+				if workers != current {
+					log.Infof("PPS master: autoscaling worker pool %q of %q: %s", pool.Name, pipelineName, reason)
+				}
+			} else {
+				// standby and paused pipelines' pools have no workers, and start
+				// from scratch when the pipeline runs again
+				scalers[pool.Name].reset(time.Now())
+			}
+			if workers > 0 {
+				poolWorkers[pool.Name] = workers
+			}
+			if workers != current {
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		// writing the pipeline pointer triggers the pipeline controller, which
+		// resizes the pools' RCs
+		if _, err := col.NewSTM(pachClient.Ctx(), a.env.GetEtcdClient(), func(stm col.STM) error {
+			return a.pipelines.ReadWrite(stm).Update(pipelineName, pipelinePtr, func() error {
+				pipelinePtr.WorkerPoolWorkers = poolWorkers
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// workerPoolOptions returns the options for the RC of one of a pipeline's
+// worker pools, which are the same as the options for the pipeline's RC except
+// for their name, labels and resources.
+func workerPoolOptions(options *workerOptions, pipelineInfo *pps.PipelineInfo, pool *pps.WorkerPool) (*workerOptions, error) {
+	result := *options
+	result.rcName = ppsutil.WorkerPoolRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version, pool.Name)
+	result.labels = make(map[string]string)
+	for k, v := range options.labels {
+		result.labels[k] = v
+	}
+	result.labels["app"] = result.rcName
+	result.labels[workerPoolLabel] = pool.Name
+	result.parallelism = 0
+	result.service = nil
+	result.workerEnv = append(append([]v1.EnvVar{}, options.workerEnv...), v1.EnvVar{
+		Name:  client.PPSWorkerPoolEnv,
+		Value: pool.Name,
+	})
+	if pool.ResourceRequests != nil {
+		resourceRequests, err := ppsutil.GetRequestsResourceList(pool.ResourceRequests)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not determine resource request for worker pool %q", pool.Name)
+		}
+		result.resourceRequests = resourceRequests
+	}
+	if pool.ResourceLimits != nil {
+		resourceLimits, err := ppsutil.GetLimitsResourceList(pool.ResourceLimits)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not determine resource limit for worker pool %q", pool.Name)
+		}
+		result.resourceLimits = resourceLimits
+	}
+	return &result, nil
+}
+
+// createWorkerPoolRcs creates an RC for each of a pipeline's worker pools.
+// Like the pipeline's RC, they start with no workers.
+func (a *apiServer) createWorkerPoolRcs(options *workerOptions, pipelineInfo *pps.PipelineInfo) error {
+	for _, pool := range pipelineInfo.WorkerPools {
+		poolOptions, err := workerPoolOptions(options, pipelineInfo, pool)
+		if err != nil {
+			return noValidOptionsErr{err}
+		}
+		podSpec, err := a.workerPodSpec(poolOptions)
+		if err != nil {
+			return err
+		}
+		if _, err := a.env.GetKubeClient().CoreV1().ReplicationControllers(a.namespace).Create(workerRc(poolOptions, podSpec)); err != nil {
+			if !isAlreadyExistsErr(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scaleWorkerPools scales the worker pools of op's pipeline up to the number of
+// workers that their pending datums need (see autoscaleWorkerPools), or down
+// to zero workers.
+func (op *pipelineOp) scaleWorkerPools(up bool) error {
+	if len(op.pipelineInfo.WorkerPools) == 0 {
+		return nil
+	}
+	rcs := op.apiServer.env.GetKubeClient().CoreV1().ReplicationControllers(op.apiServer.namespace)
+	for _, pool := range op.pipelineInfo.WorkerPools {
+		var replicas int32
+		if up {
+			replicas = workerPoolWorkers(pool, op.ptr)
+		}
+		rc, err := rcs.Get(ppsutil.WorkerPoolRcName(op.name, op.pipelineInfo.Version, pool.Name), metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "could not get RC for worker pool %q", pool.Name)
+		}
+		if rc.Spec.Replicas != nil && *rc.Spec.Replicas == replicas {
+			continue
+		}
+		log.Infof("PPS master: scaling worker pool %q of %q to %d workers", pool.Name, op.name, replicas)
+		rc.Spec.Replicas = &replicas
+		if _, err := rcs.Update(rc); err != nil {
+			return errors.Wrapf(err, "could not scale worker pool %q", pool.Name)
+		}
+	}
+	return nil
+}
+
+// numWorkerPoolWorkers returns the number of workers in all of a pipeline's
+// worker pools when it's scaled up.
+func numWorkerPoolWorkers(pipelineInfo *pps.PipelineInfo, ptr *pps.EtcdPipelineInfo) int {
+	var result int
+	for _, pool := range pipelineInfo.WorkerPools {
+		result += int(workerPoolWorkers(pool, ptr))
+	}
+	return result
+}
+
+// workerPoolPods returns the pods of all of a pipeline's worker pools.
+func (a *apiServer) workerPoolPods(pipelineInfo *pps.PipelineInfo) ([]v1.Pod, error) {
+	var result []v1.Pod
+	for _, pool := range pipelineInfo.WorkerPools {
+		rcName := ppsutil.WorkerPoolRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version, pool.Name)
+		pods, err := a.rcPods(rcName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get pods in rc %q", rcName)
+		}
+		result = append(result, pods...)
+	}
+	return result, nil
+}
+
+// mainRcSelector selects a pipeline's RC, but not the RCs of its worker pools.
+func mainRcSelector(pipeline string) string {
+	return fmt.Sprintf("%s=%s,!%s", pipelineNameLabel, pipeline, workerPoolLabel)
+}
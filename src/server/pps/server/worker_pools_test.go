@@ -0,0 +1,116 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestValidateWorkerPools(t *testing.T) {
+	pipelineInfo := func(pools ...*pps.WorkerPool) *pps.PipelineInfo {
+		return &pps.PipelineInfo{Pipeline: client.NewPipeline("edges"), WorkerPools: pools}
+	}
+	require.NoError(t, validateWorkerPools(pipelineInfo(
+		&pps.WorkerPool{Name: "large", MinSizeBytes: 1 << 30},
+		&pps.WorkerPool{Name: "videos-2", Glob: "/videos/*"},
+	)))
+	require.YesError(t, validateWorkerPools(pipelineInfo(&pps.WorkerPool{Name: "Large", MinSizeBytes: 1})))
+	require.YesError(t, validateWorkerPools(pipelineInfo(
+		&pps.WorkerPool{Name: "large", MinSizeBytes: 1},
+		&pps.WorkerPool{Name: "large", MinSizeBytes: 2},
+	)))
+	// pools must have a rule
+	require.YesError(t, validateWorkerPools(pipelineInfo(&pps.WorkerPool{Name: "large"})))
+	require.YesError(t, validateWorkerPools(pipelineInfo(&pps.WorkerPool{Name: "large", MinSizeBytes: 2, MaxSizeBytes: 1})))
+	require.YesError(t, validateWorkerPools(pipelineInfo(&pps.WorkerPool{Name: "large", Glob: "/[a"})))
+
+	service := pipelineInfo(&pps.WorkerPool{Name: "large", MinSizeBytes: 1})
+	service.Service = &pps.Service{}
+	require.YesError(t, validateWorkerPools(service))
+}
+
+func TestWorkerPoolOptions(t *testing.T) {
+	pipelineInfo := &pps.PipelineInfo{Pipeline: client.NewPipeline("edges"), Version: 3}
+	options := &workerOptions{
+		rcName:      "pipeline-edges-v3",
+		labels:      map[string]string{"app": "pipeline-edges-v3", pipelineNameLabel: "edges"},
+		parallelism: 4,
+		workerEnv:   []v1.EnvVar{{Name: "FOO", Value: "bar"}},
+		service:     &pps.Service{},
+	}
+	pool := &pps.WorkerPool{Name: "large", ResourceRequests: &pps.ResourceSpec{Memory: "16G"}}
+	result, err := workerPoolOptions(options, pipelineInfo, pool)
+	require.NoError(t, err)
+	require.Equal(t, "pipeline-edges-v3-large", result.rcName)
+	require.Equal(t, "pipeline-edges-v3-large", result.labels["app"])
+	require.Equal(t, "large", result.labels[workerPoolLabel])
+	require.Equal(t, "edges", result.labels[pipelineNameLabel])
+	require.Equal(t, int32(0), result.parallelism)
+	require.Nil(t, result.service)
+	require.Equal(t, []v1.EnvVar{{Name: "FOO", Value: "bar"}, {Name: client.PPSWorkerPoolEnv, Value: "large"}}, result.workerEnv)
+	require.Equal(t, "16G", result.resourceRequests.Memory().String())
+	// the pipeline's options are untouched
+	require.Equal(t, "pipeline-edges-v3", options.labels["app"])
+	require.Equal(t, 1, len(options.workerEnv))
+
+	require.Equal(t, "pipelineName=edges,!workerPool", mainRcSelector("edges"))
+}
+
+func TestWorkerPoolWorkers(t *testing.T) {
+	large := &pps.WorkerPool{Name: "large", Parallelism: 3}
+	small := &pps.WorkerPool{Name: "small"}
+	pipelineInfo := &pps.PipelineInfo{WorkerPools: []*pps.WorkerPool{large, small}}
+	// pools don't run until datums are routed to them
+	ptr := &pps.EtcdPipelineInfo{}
+	require.Equal(t, int32(0), workerPoolWorkers(large, ptr))
+	require.Equal(t, 0, numWorkerPoolWorkers(pipelineInfo, ptr))
+	// and never run more than their size, which may have shrunk since the
+	// number was recorded
+	ptr.WorkerPoolWorkers = map[string]uint64{"large": 2, "small": 5}
+	require.Equal(t, int32(2), workerPoolWorkers(large, ptr))
+	require.Equal(t, int32(1), workerPoolWorkers(small, ptr))
+	require.Equal(t, 3, numWorkerPoolWorkers(pipelineInfo, ptr))
+}
+
+func TestWorkerPoolAutoscaling(t *testing.T) {
+	pool := &pps.WorkerPool{Name: "gpu", Parallelism: 4}
+	pipelineInfo := &pps.PipelineInfo{WorkerPools: []*pps.WorkerPool{pool}}
+	autoscaling := workerPoolAutoscaling(pipelineInfo, pool)
+	require.Equal(t, uint64(0), autoscaling.MinWorkers)
+	require.Equal(t, uint64(4), autoscaling.MaxWorkers)
+
+	start := time.Now()
+	scaler := &autoscaler{autoscaling: autoscaling, busySince: start}
+	current := uint64(0)
+	tick := func(tick int, pending int64) uint64 {
+		workers, _, err := scaler.scale(current, pending, start.Add(time.Duration(tick)*autoscalingInterval))
+		require.NoError(t, err)
+		current = workers
+		return workers
+	}
+	require.Equal(t, uint64(0), tick(1, 0))
+	require.Equal(t, uint64(2), tick(2, 2))
+	require.Equal(t, uint64(4), tick(3, 10))
+	// an idle pool shrinks to no workers after the default scale down delay
+	for i := 4; i < 9; i++ {
+		require.Equal(t, uint64(4), tick(i, 0))
+	}
+	require.Equal(t, uint64(0), tick(9, 0))
+
+	// pools follow the pipeline's autoscaling target and delay
+	pipelineInfo.Autoscaling = &pps.Autoscaling{
+		MaxWorkers:            1,
+		TargetDatumsPerWorker: 5,
+		ScaleDownDelay:        types.DurationProto(time.Second),
+	}
+	scaler = &autoscaler{autoscaling: workerPoolAutoscaling(pipelineInfo, pool), busySince: start}
+	current = 0
+	require.Equal(t, uint64(2), tick(1, 10))
+	require.Equal(t, uint64(0), tick(2, 0))
+}
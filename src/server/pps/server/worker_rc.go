@@ -32,6 +32,7 @@ const (
 	pachVersionAnnotation     = "version"
 	specCommitAnnotation      = "specCommit"
 	hashedAuthTokenAnnotation = "authTokenHash"
+	workerPoolLabel           = "workerPool"
 )
 
 // Parameters used when creating the kubernetes replication controller in charge
@@ -53,6 +54,7 @@ type workerOptions struct {
 	volumes               []v1.Volume         // Volumes that we expose to the user container
 	volumeMounts          []v1.VolumeMount    // Paths where we mount each volume in 'volumes'
 	schedulingSpec        *pps.SchedulingSpec // the SchedulingSpec for the pipeline
+	gracePeriodSeconds    int64               // How long preempted workers have to stop, 0 unless the pipeline is preemptible
 	podSpec               string
 	podPatch              string
 
@@ -191,6 +193,17 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 		sidecarEnv = append(sidecarEnv, v1.EnvVar{Name: "LOKI_LOGGING", Value: "true"})
 		workerEnv = append(workerEnv, v1.EnvVar{Name: "LOKI_LOGGING", Value: "true"})
 	}
+	// The sidecars of preemptible workers wait for the worker (on its gRPC
+	// port) to stop before exiting, so that it can save its checkpoints
+	if options.gracePeriodSeconds > 0 {
+		sidecarEnv = append(sidecarEnv, v1.EnvVar{
+			Name:  "PPS_PREEMPTION",
+			Value: "true",
+		}, v1.EnvVar{
+			Name:  client.PPSWorkerPortEnv,
+			Value: strconv.FormatUint(uint64(a.workerGrpcPort), 10),
+		})
+	}
 
 	// This only happens in local deployment.  We want the workers to be
 	// able to read from/write to the hostpath volume as well.
@@ -332,7 +345,7 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 		RestartPolicy:                 "Always",
 		Volumes:                       options.volumes,
 		ImagePullSecrets:              options.imagePullSecrets,
-		TerminationGracePeriodSeconds: &zeroVal,
+		TerminationGracePeriodSeconds: &options.gracePeriodSeconds,
 		SecurityContext:               securityContext,
 	}
 	if options.schedulingSpec != nil {
@@ -577,6 +590,16 @@ func (a *apiServer) getWorkerOptions(ptr *pps.EtcdPipelineInfo, pipelineInfo *pp
 	if ppsutil.ContainsS3Inputs(pipelineInfo.Input) || pipelineInfo.S3Out {
 		s3GatewayPort = int32(a.env.S3GatewayPort)
 	}
+	// Preemptible workers get to stop their user code and save its checkpoints
+	// when their node shuts down, other workers are killed right away
+	var gracePeriodSeconds int64
+	if pipelineInfo.Preemption != nil {
+		gracePeriod, err := ppsutil.GetPreemptionGracePeriod(pipelineInfo.Preemption)
+		if err != nil {
+			return nil, err
+		}
+		gracePeriodSeconds = int64((gracePeriod + ppsutil.PreemptionShutdownPeriod).Seconds())
+	}
 
 	// Generate options for new RC
 	return &workerOptions{
@@ -597,6 +620,7 @@ func (a *apiServer) getWorkerOptions(ptr *pps.EtcdPipelineInfo, pipelineInfo *pp
 		cacheSize:             pipelineInfo.CacheSize,
 		service:               service,
 		schedulingSpec:        pipelineInfo.SchedulingSpec,
+		gracePeriodSeconds:    gracePeriodSeconds,
 		podSpec:               pipelineInfo.PodSpec,
 		podPatch:              pipelineInfo.PodPatch,
 	}, nil
@@ -610,24 +634,10 @@ type noValidOptionsErr struct {
 	error
 }
 
-func (a *apiServer) createWorkerSvcAndRc(ctx context.Context, ptr *pps.EtcdPipelineInfo, pipelineInfo *pps.PipelineInfo) (retErr error) {
-	log.Infof("PPS master: upserting workers for %q", pipelineInfo.Pipeline.Name)
-	span, ctx := tracing.AddSpanToAnyExisting(ctx, "/pps.Master/CreateWorkerRC", //lint:ignore SA4006 ctx never used, but we want the right one in scope for future uses
-		"pipeline", pipelineInfo.Pipeline.Name)
-	defer func() {
-		tracing.TagAnySpan(span, "err", retErr)
-		tracing.FinishAnySpan(span)
-	}()
-
-	options, err := a.getWorkerOptions(ptr, pipelineInfo)
-	if err != nil {
-		return noValidOptionsErr{err}
-	}
-	podSpec, err := a.workerPodSpec(options)
-	if err != nil {
-		return err
-	}
-	rc := &v1.ReplicationController{
+// workerRc returns the replication controller that manages the workers
+// described by options.
+func workerRc(options *workerOptions, podSpec v1.PodSpec) *v1.ReplicationController {
+	return &v1.ReplicationController{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ReplicationController",
 			APIVersion: "v1",
@@ -650,11 +660,33 @@ func (a *apiServer) createWorkerSvcAndRc(ctx context.Context, ptr *pps.EtcdPipel
 			},
 		},
 	}
-	if _, err := a.env.GetKubeClient().CoreV1().ReplicationControllers(a.namespace).Create(rc); err != nil {
+}
+
+func (a *apiServer) createWorkerSvcAndRc(ctx context.Context, ptr *pps.EtcdPipelineInfo, pipelineInfo *pps.PipelineInfo) (retErr error) {
+	log.Infof("PPS master: upserting workers for %q", pipelineInfo.Pipeline.Name)
+	span, ctx := tracing.AddSpanToAnyExisting(ctx, "/pps.Master/CreateWorkerRC", //lint:ignore SA4006 ctx never used, but we want the right one in scope for future uses
+		"pipeline", pipelineInfo.Pipeline.Name)
+	defer func() {
+		tracing.TagAnySpan(span, "err", retErr)
+		tracing.FinishAnySpan(span)
+	}()
+
+	options, err := a.getWorkerOptions(ptr, pipelineInfo)
+	if err != nil {
+		return noValidOptionsErr{err}
+	}
+	podSpec, err := a.workerPodSpec(options)
+	if err != nil {
+		return err
+	}
+	if _, err := a.env.GetKubeClient().CoreV1().ReplicationControllers(a.namespace).Create(workerRc(options, podSpec)); err != nil {
 		if !isAlreadyExistsErr(err) {
 			return err
 		}
 	}
+	if err := a.createWorkerPoolRcs(options, pipelineInfo); err != nil {
+		return err
+	}
 	serviceAnnotations := map[string]string{
 		"prometheus.io/scrape": "true",
 		"prometheus.io/port":   strconv.Itoa(workerstats.PrometheusPort),
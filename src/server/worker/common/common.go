@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pps"
@@ -70,3 +71,9 @@ dataFilters:
 	}
 	return matchesData
 }
+
+// JobTagPrefix is the prefix of the tags of the objects that a job's workers
+// write while it runs, they're deleted when the job finishes.
+func JobTagPrefix(jobID string) string {
+	return fmt.Sprintf("job-%s", jobID)
+}
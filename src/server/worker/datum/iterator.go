@@ -452,6 +452,16 @@ func newCronIterator(pachClient *client.APIClient, input *pps.CronInput) (Iterat
 	})
 }
 
+func newObjectStorageIterator(pachClient *client.APIClient, input *pps.ObjectStorageInput) (Iterator, error) {
+	return newPFSIterator(pachClient, &pps.PFSInput{
+		Name:   input.Name,
+		Repo:   input.Repo,
+		Branch: "master",
+		Commit: input.Commit,
+		Glob:   input.Glob,
+	})
+}
+
 // NewIterator creates an Iterator for an input.
 func NewIterator(pachClient *client.APIClient, input *pps.Input) (Iterator, error) {
 	switch {
@@ -465,6 +475,8 @@ func NewIterator(pachClient *client.APIClient, input *pps.Input) (Iterator, erro
 		return newJoinIterator(pachClient, input.Join)
 	case input.Cron != nil:
 		return newCronIterator(pachClient, input.Cron)
+	case input.ObjectStorage != nil:
+		return newObjectStorageIterator(pachClient, input.ObjectStorage)
 	case input.Git != nil:
 		return newGitIterator(pachClient, input.Git)
 	}
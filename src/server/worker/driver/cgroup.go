@@ -0,0 +1,227 @@
+package driver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+const (
+	// cgroupSampleInterval is how often the user container's cgroup is sampled
+	// while user code runs.
+	cgroupSampleInterval = time.Second
+	// cgroupRoot is where the user container's cgroup is mounted.
+	cgroupRoot = "/sys/fs/cgroup"
+)
+
+// cgroupUsage is a reading of the user container's cgroup.
+type cgroupUsage struct {
+	cpu    time.Duration
+	memory uint64
+}
+
+// readUint reads a file that contains a single integer.
+func readUint(path string) (uint64, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	result, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return result, errors.EnsureStack(err)
+}
+
+// readStat reads the value of key from a cgroup stat file, such as
+// memory.stat. It returns 0 if the file or key doesn't exist.
+func readStat(path string, key string) (uint64, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, errors.EnsureStack(err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == key {
+			result, err := strconv.ParseUint(fields[1], 10, 64)
+			return result, errors.EnsureStack(err)
+		}
+	}
+	return 0, nil
+}
+
+// workingSet returns the memory usage of a cgroup without its inactive file
+// cache, as kubelet computes it. The cache includes the datum's inputs, which
+// the worker downloads into the same container, and can be reclaimed, so it
+// isn't counted as memory used by the user code.
+func workingSet(usage uint64, statPath string, inactiveKey string) (uint64, error) {
+	inactive, err := readStat(statPath, inactiveKey)
+	if err != nil {
+		return 0, err
+	}
+	if inactive > usage {
+		return 0, nil
+	}
+	return usage - inactive, nil
+}
+
+// readCgroupUsage reads the cpu time and working set memory of the cgroup
+// mounted at root. It supports both the unified (v2) hierarchy and the v1
+// cpuacct and memory controllers.
+func readCgroupUsage(root string) (*cgroupUsage, error) {
+	// cgroup v2
+	if data, err := ioutil.ReadFile(filepath.Join(root, "cpu.stat")); err == nil {
+		usage := &cgroupUsage{}
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 2 && fields[0] == "usage_usec" {
+				usec, err := strconv.ParseUint(fields[1], 10, 64)
+				if err != nil {
+					return nil, errors.EnsureStack(err)
+				}
+				usage.cpu = time.Duration(usec) * time.Microsecond
+			}
+		}
+		memory, err := readUint(filepath.Join(root, "memory.current"))
+		if err != nil {
+			return nil, err
+		}
+		if usage.memory, err = workingSet(memory, filepath.Join(root, "memory.stat"), "inactive_file"); err != nil {
+			return nil, err
+		}
+		return usage, nil
+	}
+	// cgroup v1, where cpuacct may be mounted on its own or with cpu
+	usage := &cgroupUsage{}
+	var nanos uint64
+	var err error
+	for _, dir := range []string{"cpuacct", "cpu,cpuacct"} {
+		if nanos, err = readUint(filepath.Join(root, dir, "cpuacct.usage")); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	usage.cpu = time.Duration(nanos)
+	memory, err := readUint(filepath.Join(root, "memory", "memory.usage_in_bytes"))
+	if err != nil {
+		return nil, err
+	}
+	if usage.memory, err = workingSet(memory, filepath.Join(root, "memory", "memory.stat"), "total_inactive_file"); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// datumUsage is the cpu time and peak memory attributed to one datum while
+// it runs user code.
+type datumUsage struct {
+	cpu  time.Duration
+	peak uint64
+}
+
+// cgroupMonitor samples the user container's cgroup while datums run user
+// code. The cgroup also contains the worker binary, which is mostly idle
+// while user code runs. When several datums run at once (datum_concurrency),
+// the cpu time used between samples is split evenly between them, and each
+// datum's peak memory is the container's peak while it ran. It's shared by
+// all copies of the driver.
+type cgroupMonitor struct {
+	root string
+
+	mu     sync.Mutex
+	last   *cgroupUsage
+	active map[*datumUsage]bool
+	done   chan struct{}
+}
+
+func newCgroupMonitor(root string) *cgroupMonitor {
+	return &cgroupMonitor{
+		root:   root,
+		active: make(map[*datumUsage]bool),
+	}
+}
+
+// sample reads the cgroup and attributes the usage since the last sample to
+// the active datums. m.mu must be held.
+func (m *cgroupMonitor) sample() error {
+	usage, err := readCgroupUsage(m.root)
+	if err != nil {
+		return err
+	}
+	if m.last != nil && len(m.active) > 0 && usage.cpu > m.last.cpu {
+		share := (usage.cpu - m.last.cpu) / time.Duration(len(m.active))
+		for datum := range m.active {
+			datum.cpu += share
+		}
+	}
+	for datum := range m.active {
+		if usage.memory > datum.peak {
+			datum.peak = usage.memory
+		}
+	}
+	m.last = usage
+	return nil
+}
+
+// start starts attributing the cgroup's usage to a datum, and starts sampling
+// if no other datum is running. It returns nil if the cgroup can't be read,
+// e.g. when the worker isn't running in a container.
+func (m *cgroupMonitor) start() *datumUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.sample(); err != nil {
+		return nil
+	}
+	datum := &datumUsage{peak: m.last.memory}
+	m.active[datum] = true
+	if len(m.active) == 1 {
+		m.done = make(chan struct{})
+		go m.run(m.done)
+	}
+	return datum
+}
+
+func (m *cgroupMonitor) run(done chan struct{}) {
+	ticker := time.NewTicker(cgroupSampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			m.sample()
+			m.mu.Unlock()
+		}
+	}
+}
+
+// stop stops attributing the cgroup's usage to datum, and records the datum's
+// cpu time and peak memory in procStats. It's a no-op if datum is nil.
+func (m *cgroupMonitor) stop(datum *datumUsage, procStats *pps.ProcessStats) {
+	if datum == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sample()
+	delete(m.active, datum)
+	if len(m.active) == 0 {
+		close(m.done)
+	}
+	if procStats != nil {
+		procStats.CpuTime = types.DurationProto(datum.cpu)
+		procStats.PeakMemoryBytes = datum.peak
+	}
+}
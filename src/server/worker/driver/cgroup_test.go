@@ -0,0 +1,113 @@
+package driver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func writeCgroupFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+// writeCgroupV2 writes a fake unified cgroup with the given usage to root.
+func writeCgroupV2(t *testing.T, root string, cpu time.Duration, memory uint64) {
+	writeCgroupFile(t, filepath.Join(root, "cpu.stat"),
+		fmt.Sprintf("usage_usec %d\nuser_usec 0\nsystem_usec 0\n", cpu/time.Microsecond))
+	writeCgroupFile(t, filepath.Join(root, "memory.current"), fmt.Sprintf("%d\n", memory))
+}
+
+func TestReadCgroupUsage(t *testing.T) {
+	v2, err := ioutil.TempDir("", "cgroup-v2")
+	require.NoError(t, err)
+	defer os.RemoveAll(v2)
+	writeCgroupV2(t, v2, 1500*time.Millisecond, 1024)
+	usage, err := readCgroupUsage(v2)
+	require.NoError(t, err)
+	require.Equal(t, 1500*time.Millisecond, usage.cpu)
+	require.Equal(t, uint64(1024), usage.memory)
+
+	v1, err := ioutil.TempDir("", "cgroup-v1")
+	require.NoError(t, err)
+	defer os.RemoveAll(v1)
+	writeCgroupFile(t, filepath.Join(v1, "cpu,cpuacct", "cpuacct.usage"), "2000000000\n")
+	writeCgroupFile(t, filepath.Join(v1, "memory", "memory.usage_in_bytes"), "2048\n")
+	usage, err = readCgroupUsage(v1)
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, usage.cpu)
+	require.Equal(t, uint64(2048), usage.memory)
+
+	// the inactive file cache, e.g. of the datum's downloaded inputs, isn't
+	// counted
+	writeCgroupFile(t, filepath.Join(v2, "memory.current"), "1073741824\n")
+	writeCgroupFile(t, filepath.Join(v2, "memory.stat"),
+		"anon 1048576\nfile 1072693248\nactive_file 4096\ninactive_file 1072689152\n")
+	usage, err = readCgroupUsage(v2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1052672), usage.memory)
+	writeCgroupFile(t, filepath.Join(v1, "memory", "memory.usage_in_bytes"), "1073741824\n")
+	writeCgroupFile(t, filepath.Join(v1, "memory", "memory.stat"),
+		"cache 1072693248\nrss 1048576\ninactive_file 0\ntotal_inactive_file 1072689152\n")
+	usage, err = readCgroupUsage(v1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1052672), usage.memory)
+
+	empty, err := ioutil.TempDir("", "cgroup-empty")
+	require.NoError(t, err)
+	defer os.RemoveAll(empty)
+	_, err = readCgroupUsage(empty)
+	require.YesError(t, err)
+}
+
+func TestCgroupMonitor(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	m := newCgroupMonitor(root)
+
+	// two datums overlap, and split the cpu time used while both were running
+	writeCgroupV2(t, root, time.Second, 100)
+	first := m.start()
+	writeCgroupV2(t, root, 3*time.Second, 300)
+	second := m.start()
+	writeCgroupV2(t, root, 5*time.Second, 200)
+	firstStats := &pps.ProcessStats{}
+	m.stop(first, firstStats)
+	writeCgroupV2(t, root, 6*time.Second, 200)
+	secondStats := &pps.ProcessStats{}
+	m.stop(second, secondStats)
+
+	firstCPU, err := types.DurationFromProto(firstStats.CpuTime)
+	require.NoError(t, err)
+	secondCPU, err := types.DurationFromProto(secondStats.CpuTime)
+	require.NoError(t, err)
+	require.Equal(t, 3*time.Second, firstCPU)
+	require.Equal(t, 2*time.Second, secondCPU)
+	require.Equal(t, uint64(300), firstStats.PeakMemoryBytes)
+	require.Equal(t, uint64(300), secondStats.PeakMemoryBytes)
+
+	// a datum's peak doesn't include the file cache, which fills up as its
+	// inputs are downloaded
+	writeCgroupV2(t, root, 6*time.Second, 1<<30)
+	writeCgroupFile(t, filepath.Join(root, "memory.stat"), fmt.Sprintf("inactive_file %d\n", 1<<30-100))
+	third := m.start()
+	writeCgroupV2(t, root, 7*time.Second, 1<<30+200)
+	thirdStats := &pps.ProcessStats{}
+	m.stop(third, thirdStats)
+	require.Equal(t, uint64(300), thirdStats.PeakMemoryBytes)
+
+	// without a cgroup, nothing is recorded
+	m = newCgroupMonitor(filepath.Join(root, "missing"))
+	stats := &pps.ProcessStats{}
+	m.stop(m.start(), stats)
+	require.Nil(t, stats.CpuTime)
+}
@@ -14,7 +14,6 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -23,6 +22,7 @@ import (
 	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/types"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
 	"gopkg.in/go-playground/webhooks.v5/github"
 	"gopkg.in/src-d/go-git.v4"
 	gitPlumbing "gopkg.in/src-d/go-git.v4/plumbing"
@@ -59,10 +59,22 @@ var (
 	errSpecialFile = errors.New("cannot upload special file")
 )
 
-func workNamespace(pipelineInfo *pps.PipelineInfo) string {
+// WorkNamespace returns the namespace of the pipeline's task queue.
+func WorkNamespace(pipelineInfo *pps.PipelineInfo) string {
 	return fmt.Sprintf("/pipeline-%s/v%d", pipelineInfo.Pipeline.Name, pipelineInfo.Version)
 }
 
+// WorkerPoolNamespace returns the namespace of the task queue of one of the
+// pipeline's worker pools, or of the pipeline's regular workers if pool is "".
+// It's not nested under WorkNamespace, so the regular workers don't see the
+// pools' tasks.
+func WorkerPoolNamespace(pipelineInfo *pps.PipelineInfo, pool string) string {
+	if pool == "" {
+		return WorkNamespace(pipelineInfo)
+	}
+	return fmt.Sprintf("%s-pool-%s", WorkNamespace(pipelineInfo), pool)
+}
+
 // Driver provides an interface for common functions needed by worker code, and
 // captures the relevant objects necessary to provide these functions so that
 // users do not need to keep track of as many variables.  In addition, this
@@ -72,8 +84,10 @@ type Driver interface {
 	Jobs() col.Collection
 	Pipelines() col.Collection
 
-	NewTaskWorker() *work.Worker
-	NewTaskQueue() (*work.TaskQueue, error)
+	// NewTaskWorker and NewTaskQueue return a worker and queue for the tasks
+	// of a worker pool, or of the pipeline's regular workers if pool is "".
+	NewTaskWorker(pool string) *work.Worker
+	NewTaskQueue(pool string) (*work.TaskQueue, error)
 
 	// Returns the PipelineInfo for the pipeline that this worker belongs to
 	PipelineInfo() *pps.PipelineInfo
@@ -104,10 +118,11 @@ type Driver interface {
 	// to the given callback.
 	WithData([]*common.Input, *hashtree.Ordered, logs.TaggedLogger, func(string, *pps.ProcessStats) error) (*pps.ProcessStats, error)
 
-	// WithActiveData swaps the given scratch directory into the 'active' input
-	// directory used when running user code. This also locks a mutex so that no
-	// two datums can be active concurrently.
-	WithActiveData([]*common.Input, string, func() error) error
+	// WithActiveData swaps the given scratch directory into an 'active' input
+	// directory used when running user code. At most DatumConcurrency datums
+	// can be active at once, each in its own input directory, so the callback
+	// is passed a copy of the driver whose InputDir is the datum's directory.
+	WithActiveData([]*common.Input, string, func(Driver) error) error
 
 	// UserCodeEnv returns the set of environment variables to construct when
 	// launching the configured user process.
@@ -121,6 +136,13 @@ type Driver interface {
 	// RunUserErrorHandlingCode runs the pipeline's configured error handling code
 	RunUserErrorHandlingCode(logs.TaggedLogger, []string, *pps.ProcessStats, *types.Duration) error
 
+	// Preempt sends SIGTERM to the running user code, which has the pipeline's
+	// preemption grace period to exit, and Preempted returns whether the
+	// worker has been preempted. Datums that are interrupted by preemption
+	// aren't failed, they're processed again by another worker.
+	Preempt()
+	Preempted() bool
+
 	// TODO: provide a more generic interface for modifying jobs, and
 	// some quality-of-life functions for common operations.
 	DeleteJob(col.STM, *pps.EtcdJobInfo) error
@@ -147,15 +169,23 @@ type Driver interface {
 	// bound to the callback, and any resources will be cleaned up upon return.
 	WithDatumCache(func(*hashtree.MergeCache, *hashtree.MergeCache) error) error
 
-	Egress(commit *pfs.Commit, egressURL string) error
+	// Egress copies the contents of commit to a single egress destination,
+	// either object storage or a SQL database. If status has a BaseCommit, only
+	// the changes since it are copied. The amount of data copied is recorded in
+	// status.
+	Egress(commit *pfs.Commit, egress *pps.Egress, status *pps.EgressStatus) error
 }
 
 type driver struct {
-	pipelineInfo    *pps.PipelineInfo
-	pachClient      *client.APIClient
-	etcdClient      *etcd.Client
-	etcdPrefix      string
-	activeDataMutex *sync.Mutex
+	pipelineInfo *pps.PipelineInfo
+	pachClient   *client.APIClient
+	etcdClient   *etcd.Client
+	etcdPrefix   string
+
+	// activeDataSlots holds the input directories that aren't being used by an
+	// active datum, there is one for each datum that can be processed
+	// concurrently.
+	activeDataSlots chan string
 
 	jobs col.Collection
 
@@ -187,6 +217,18 @@ type driver struct {
 	// These caches are used for storing and merging hashtrees from jobs until the
 	// job is complete
 	chunkCaches, chunkStatsCaches cache.WorkerCache
+
+	// userCodeServer runs the user code when the pipeline's transform is in
+	// server mode, it's shared by all copies of the driver.
+	userCodeServer *userCodeServer
+
+	// cgroups measures the cpu and memory used by user code, it's shared by all
+	// copies of the driver.
+	cgroups *cgroupMonitor
+
+	// preemption stops the user code when the worker is preempted, it's shared
+	// by all copies of the driver.
+	preemption *preemption
 }
 
 // NewDriver constructs a Driver object using the given clients and pipeline
@@ -225,6 +267,27 @@ func NewDriver(
 		return nil, errors.EnsureStack(err)
 	}
 
+	// The first datum uses the standard input directory, if datums are
+	// processed concurrently the others use sibling directories (/pfs-1, etc.).
+	// Services with rolling updates stage the new datum in a second directory
+	// while the old one is still being served.
+	concurrency := int(pipelineInfo.DatumConcurrency)
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if pipelineInfo.Service != nil && pipelineInfo.Service.RollingUpdate {
+		concurrency = 2
+	}
+	activeDataSlots := make(chan string, concurrency)
+	activeDataSlots <- pfsPath
+	for i := 1; i < concurrency; i++ {
+		slotPath := fmt.Sprintf("%s-%d", pfsPath, i)
+		if err := os.MkdirAll(slotPath, 0777); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		activeDataSlots <- slotPath
+	}
+
 	numShards, err := ppsutil.GetExpectedNumHashtrees(pipelineInfo.HashtreeSpec)
 	if err != nil {
 		logs.NewStatlessLogger(pipelineInfo).Logf("error getting number of shards, default to 1 shard: %v", err)
@@ -236,7 +299,7 @@ func NewDriver(
 		pachClient:       pachClient,
 		etcdClient:       etcdClient,
 		etcdPrefix:       etcdPrefix,
-		activeDataMutex:  &sync.Mutex{},
+		activeDataSlots:  activeDataSlots,
 		jobs:             ppsdb.Jobs(etcdClient, etcdPrefix),
 		pipelines:        ppsdb.Pipelines(etcdClient, etcdPrefix),
 		numShards:        numShards,
@@ -246,6 +309,9 @@ func NewDriver(
 		chunkCaches:      cache.NewWorkerCache(chunkCachePath),
 		chunkStatsCaches: cache.NewWorkerCache(chunkStatsCachePath),
 		namespace:        namespace,
+		userCodeServer:   newUserCodeServer(),
+		cgroups:          newCgroupMonitor(cgroupRoot),
+		preemption:       newPreemption(),
 	}
 
 	if pipelineInfo.Transform.User != "" {
@@ -363,6 +429,15 @@ func (d *driver) WithContext(ctx context.Context) Driver {
 	return result
 }
 
+// withInputDir clones the current driver and sets its input directory, this is
+// used to run user code for concurrent datums in separate directories.
+func (d *driver) withInputDir(inputDir string) *driver {
+	result := &driver{}
+	*result = *d
+	result.inputDir = inputDir
+	return result
+}
+
 func (d *driver) Jobs() col.Collection {
 	return d.jobs
 }
@@ -371,12 +446,12 @@ func (d *driver) Pipelines() col.Collection {
 	return d.pipelines
 }
 
-func (d *driver) NewTaskWorker() *work.Worker {
-	return work.NewWorker(d.etcdClient, d.etcdPrefix, workNamespace(d.pipelineInfo))
+func (d *driver) NewTaskWorker(pool string) *work.Worker {
+	return work.NewWorker(d.etcdClient, d.etcdPrefix, WorkerPoolNamespace(d.pipelineInfo, pool))
 }
 
-func (d *driver) NewTaskQueue() (*work.TaskQueue, error) {
-	return work.NewTaskQueue(d.PachClient().Ctx(), d.etcdClient, d.etcdPrefix, workNamespace(d.pipelineInfo))
+func (d *driver) NewTaskQueue(pool string) (*work.TaskQueue, error) {
+	return work.NewTaskQueue(d.PachClient().Ctx(), d.etcdClient, d.etcdPrefix, WorkerPoolNamespace(d.pipelineInfo, pool))
 }
 
 func (d *driver) ExpectedNumWorkers() (int64, error) {
@@ -488,6 +563,11 @@ func (d *driver) WithData(
 	if err := os.MkdirAll(d.InputDir(), 0777); err != nil {
 		return stats, errors.EnsureStack(err)
 	}
+	if d.checkpointing() {
+		if err := d.restoreCheckpoint(logger, inputs, dir); err != nil {
+			return stats, err
+		}
+	}
 	// If the pipeline spec set a custom user to execute the process, make sure
 	// the input directory and its content are owned by it
 	if d.uid != nil && d.gid != nil {
@@ -500,6 +580,11 @@ func (d *driver) WithData(
 	}
 
 	if err := cb(dir, stats); err != nil {
+		if d.checkpointing() && d.Preempted() {
+			if err := d.saveCheckpoint(logger, inputs, dir); err != nil {
+				logger.Logf("error saving checkpoint: %v", err)
+			}
+		}
 		return stats, err
 	}
 
@@ -707,6 +792,8 @@ func (d *driver) RunUserCode(
 	ctx := d.pachClient.Ctx()
 	d.reportUserCodeStats(logger)
 	defer func(start time.Time) { d.reportDeferredUserCodeStats(retErr, start, procStats, logger) }(time.Now())
+	usage := d.cgroups.start()
+	defer d.cgroups.stop(usage, procStats)
 	logger.Logf("beginning to run user code")
 	defer func(start time.Time) {
 		if retErr != nil {
@@ -729,6 +816,10 @@ func (d *driver) RunUserCode(
 		return errors.New("invalid pipeline transform, no command specified")
 	}
 
+	if d.pipelineInfo.Transform.ServerMode {
+		return d.userCodeServer.process(ctx, d, logger, environ)
+	}
+
 	// Run user code
 	cmd := exec.CommandContext(ctx, d.pipelineInfo.Transform.Cmd[0], d.pipelineInfo.Transform.Cmd[1:]...)
 	if d.pipelineInfo.Transform.Stdin != nil {
@@ -740,11 +831,18 @@ func (d *driver) RunUserCode(
 	if d.uid != nil && d.gid != nil {
 		cmd.SysProcAttr = makeCmdCredentials(*d.uid, *d.gid)
 	}
+	if d.pipelineInfo.Preemption != nil {
+		// Preemption stops the user code's whole process group, so that
+		// processes it started don't keep running, and writing to the
+		// checkpoint directory, after it's stopped.
+		cmd.SysProcAttr = makeProcessGroup(cmd.SysProcAttr)
+	}
 	cmd.Dir = filepath.Join(d.rootDir, d.pipelineInfo.Transform.WorkingDir)
 	err := cmd.Start()
 	if err != nil {
 		return errors.EnsureStack(err)
 	}
+	defer d.preemption.start(cmd.Process)()
 	// A context with a deadline will successfully cancel/kill
 	// the running process (minus zombies)
 	state, err := cmd.Process.Wait()
@@ -945,6 +1043,12 @@ func (d *driver) reportDeferredUserCodeStats(
 		d.updateCounter(stats.DatumProcSecondsCount, logger, "", func(counter prometheus.Counter) {
 			counter.Add(duration.Seconds())
 		})
+		if procStats.CpuTime != nil {
+			cpuTime, _ := types.DurationFromProto(procStats.CpuTime)
+			d.updateCounter(stats.DatumCPUSecondsCount, logger, "", func(counter prometheus.Counter) {
+				counter.Add(cpuTime.Seconds())
+			})
+		}
 	}
 }
 
@@ -1279,18 +1383,28 @@ func (d *driver) UserCodeEnv(
 
 	if outputCommit != nil {
 		result = append(result, fmt.Sprintf("%s=%s", client.OutputCommitIDEnv, outputCommit.ID))
+		if !d.PipelineInfo().S3Out {
+			result = append(result, fmt.Sprintf("%s=%s", client.OutputDirEnv, filepath.Join(d.InputDir(), "out")))
+		}
+	}
+
+	if d.checkpointing() {
+		result = append(result, fmt.Sprintf("%s=%s", client.CheckpointDirEnv, filepath.Join(d.InputDir(), checkpointDir)))
 	}
 
 	return result
 }
 
-func (d *driver) Egress(commit *pfs.Commit, egressURL string) error {
+func (d *driver) Egress(commit *pfs.Commit, egress *pps.Egress, status *pps.EgressStatus) error {
+	if egress.SQL != nil {
+		return d.egressSQL(commit, egress.SQL, status)
+	}
 	// copy the pach client (preserving auth info) so we can set a different
 	// number of concurrent streams
 	pachClient := d.PachClient().WithCtx(d.PachClient().Ctx())
 	pachClient.SetMaxConcurrentStreams(100)
 
-	url, err := obj.ParseURL(egressURL)
+	url, err := obj.ParseURL(egress.URL)
 	if err != nil {
 		return err
 	}
@@ -1298,5 +1412,87 @@ func (d *driver) Egress(commit *pfs.Commit, egressURL string) error {
 	if err != nil {
 		return err
 	}
-	return filesync.PushObj(pachClient, commit, objClient, url.Object)
+	upload, remove, err := egressChanges(pachClient, commit, status.BaseCommit, egress.DeleteRemoved)
+	if err != nil {
+		return err
+	}
+
+	var filesUploaded, bytesUploaded int64
+	var eg errgroup.Group
+	sem := make(chan struct{}, 200)
+	for _, fileInfo := range upload {
+		fileInfo := fileInfo
+		eg.Go(func() (retErr error) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			w, err := objClient.Writer(pachClient.Ctx(), filepath.Join(url.Object, fileInfo.File.Path))
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := w.Close(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+			if err := pachClient.GetFile(commit.Repo.Name, commit.ID, fileInfo.File.Path, 0, 0, w); err != nil {
+				return err
+			}
+			atomic.AddInt64(&filesUploaded, 1)
+			atomic.AddInt64(&bytesUploaded, int64(fileInfo.SizeBytes))
+			return nil
+		})
+	}
+	err = eg.Wait()
+	status.FilesUploaded = filesUploaded
+	status.BytesUploaded = uint64(bytesUploaded)
+	if err != nil {
+		return err
+	}
+	for _, p := range remove {
+		if err := objClient.Delete(pachClient.Ctx(), filepath.Join(url.Object, p)); err != nil && !objClient.IsNotExist(err) {
+			return err
+		}
+		status.FilesDeleted++
+	}
+	return nil
+}
+
+// egressChanges returns the files in commit that need to be uploaded, and the
+// paths of the files that need to be deleted, to update a destination that has
+// the contents of base. If base is nil, every file in commit is uploaded.
+func egressChanges(pachClient *client.APIClient, commit, base *pfs.Commit, deleteRemoved bool) ([]*pfs.FileInfo, []string, error) {
+	var upload []*pfs.FileInfo
+	if base == nil {
+		if err := pachClient.Walk(commit.Repo.Name, commit.ID, "", func(fileInfo *pfs.FileInfo) error {
+			if fileInfo.FileType == pfs.FileType_FILE {
+				upload = append(upload, fileInfo)
+			}
+			return nil
+		}); err != nil {
+			return nil, nil, err
+		}
+		return upload, nil, nil
+	}
+	newFiles, oldFiles, err := pachClient.DiffFile(commit.Repo.Name, commit.ID, "", base.Repo.Name, base.ID, "", false)
+	if err != nil {
+		return nil, nil, err
+	}
+	changed := make(map[string]bool)
+	for _, fileInfo := range newFiles {
+		if fileInfo.FileType == pfs.FileType_FILE {
+			upload = append(upload, fileInfo)
+			changed[fileInfo.File.Path] = true
+		}
+	}
+	var remove []string
+	if deleteRemoved {
+		// Modified files are in both newFiles and oldFiles, only the files
+		// that aren't in the new commit were removed.
+		for _, fileInfo := range oldFiles {
+			if fileInfo.FileType == pfs.FileType_FILE && !changed[fileInfo.File.Path] {
+				remove = append(remove, fileInfo.File.Path)
+			}
+		}
+	}
+	return upload, remove, nil
 }
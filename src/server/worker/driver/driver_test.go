@@ -24,6 +24,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
 	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
 	"github.com/pachyderm/pachyderm/src/server/worker/common"
@@ -516,7 +517,7 @@ func TestWithActiveDataCleanup(t *testing.T) {
 						newInputData("floop/blarp/blazj/etc", ""),
 					}
 
-					err := env.driver.WithActiveData([]*common.Input{}, dir, func() error {
+					err := env.driver.WithActiveData([]*common.Input{}, dir, func(Driver) error {
 						for _, x := range expectedContents {
 							create(x.path)
 						}
@@ -538,6 +539,61 @@ func TestWithActiveDataCleanup(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Run two datums with a datum_concurrency of 2 and verify that they are active
+// at the same time, each in its own input directory.
+func TestWithActiveDataConcurrency(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		// Set up the input directories as NewDriver would with a
+		// datum_concurrency of 2.
+		slotDir := env.driver.InputDir() + "-1"
+		require.NoError(t, os.MkdirAll(slotDir, 0777))
+		env.driver.activeDataSlots = make(chan string, 2)
+		env.driver.activeDataSlots <- env.driver.InputDir()
+		env.driver.activeDataSlots <- slotDir
+
+		inputs := []*common.Input{newInput("repoA", "input.txt")}
+		active := make(chan Driver)
+		release := make(chan struct{})
+		errs := make(chan error)
+		for i := 0; i < 2; i++ {
+			dir := filepath.Join(env.Directory, fmt.Sprintf("scratch-%d", i))
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "out"), 0777))
+			go func() {
+				errs <- env.driver.WithActiveData(inputs, dir, func(d Driver) error {
+					active <- d
+					<-release
+					return nil
+				})
+			}()
+		}
+
+		inputDirs := make(map[string]bool)
+		for i := 0; i < 2; i++ {
+			select {
+			case d := <-active:
+				inputDirs[d.InputDir()] = true
+				_, err := os.Stat(filepath.Join(d.InputDir(), "out"))
+				require.NoError(t, err)
+				userEnv := d.UserCodeEnv("job-id", client.NewCommit("testPipeline", "commit-id"), inputs)
+				require.OneOfEquals(t, fmt.Sprintf("repoA=%s", filepath.Join(d.InputDir(), "repoA", "input.txt")), userEnv)
+				require.OneOfEquals(t, fmt.Sprintf("%s=%s", client.OutputDirEnv, filepath.Join(d.InputDir(), "out")), userEnv)
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for concurrent datums")
+			}
+		}
+		require.Equal(t, map[string]bool{env.driver.InputDir(): true, slotDir: true}, inputDirs)
+
+		close(release)
+		for i := 0; i < 2; i++ {
+			require.NoError(t, <-errs)
+		}
+		requireContents(t, env.driver.InputDir(), []*inputData{})
+		requireContents(t, slotDir, []*inputData{})
+	})
+	require.NoError(t, err)
+}
+
 func newGitInput(repo string, url string) *common.Input {
 	return &common.Input{
 		FileInfo: &pfs.FileInfo{
@@ -741,6 +797,152 @@ func TestRunUserCodeEnv(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRunUserCodeServerMode(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		// The user code records the datums it's processed, fails datums with
+		// FAIL set and hangs on datums with HANG set.
+		requests := filepath.Join(env.Directory, "requests")
+		hangPid := filepath.Join(env.Directory, "hang-pid")
+		env.driver.pipelineInfo.Transform.ServerMode = true
+		env.driver.pipelineInfo.Transform.WorkingDir = ""
+		env.driver.pipelineInfo.Transform.Cmd = []string{"bash", "-c", `
+n=0
+while read -r req; do
+  n=$((n+1))
+  id=$(echo "$req" | sed 's/^{"id":\([0-9]*\).*/\1/')
+  echo "$n: $req" >> ` + requests + `
+  case "$req" in
+    *'"FAIL":'*) echo "{\"id\":$id,\"error\":\"failed\"}" >&3 ;;
+    *'"HANG":'*) sleep 30 & echo $! > ` + hangPid + `; wait ;;
+    *) echo "{\"id\":$id}" >&3 ;;
+  esac
+done`}
+		requireRequests := func(expected ...string) {
+			data, err := ioutil.ReadFile(requests)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			require.Equal(t, len(expected), len(lines))
+			for i, pattern := range expected {
+				require.Matches(t, pattern, lines[i])
+			}
+		}
+		run := func(environ []string, timeout *types.Duration) error {
+			var err error
+			collectLogs(func(logger logs.TaggedLogger) {
+				err = env.driver.RunUserCode(logger, environ, &pps.ProcessStats{}, timeout)
+			})
+			return err
+		}
+
+		require.NoError(t, run([]string{"FOO=bar"}, nil))
+		require.NoError(t, run(nil, nil))
+		requireRequests(`^1: \{"id":1,.*"env":\{"FOO":"bar"\}`, `^2: \{"id":2,`)
+
+		err := run([]string{"FAIL=1"}, nil)
+		require.YesError(t, err)
+		require.Matches(t, "failed to process datum: failed", err.Error())
+
+		// A datum which times out restarts the user code, and kills the
+		// processes it started.
+		err = run([]string{"HANG=1"}, types.DurationProto(100*time.Millisecond))
+		require.YesError(t, err)
+		require.Matches(t, "context deadline exceeded", err.Error())
+		pid, err := ioutil.ReadFile(hangPid)
+		require.NoError(t, err)
+		b := backoff.NewTestingBackOff()
+		b.MaxElapsedTime = 5 * time.Second
+		require.NoError(t, backoff.Retry(func() error {
+			// the killed 'sleep' is gone, or a zombie if nothing has reaped it
+			stat, err := ioutil.ReadFile(filepath.Join("/proc", strings.TrimSpace(string(pid)), "stat"))
+			if err != nil || strings.Contains(string(stat), ") Z ") {
+				return nil
+			}
+			return errors.Errorf("user code's subprocess %s is still running", strings.TrimSpace(string(pid)))
+		}, b))
+		require.NoError(t, run(nil, nil))
+		requireRequests(`^1: `, `^2: `, `^3: .*"FAIL"`, `^4: .*"HANG"`, `^1: \{"id":5,`)
+	})
+	require.NoError(t, err)
+}
+
+func TestRunUserCodeServerModeExit(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		// The user code exits after responding to datums with EXIT set.
+		requests := filepath.Join(env.Directory, "requests")
+		env.driver.pipelineInfo.Transform.ServerMode = true
+		env.driver.pipelineInfo.Transform.WorkingDir = ""
+		env.driver.pipelineInfo.Transform.Cmd = []string{"bash", "-c", `
+n=0
+while read -r req; do
+  n=$((n+1))
+  id=$(echo "$req" | sed 's/^{"id":\([0-9]*\).*/\1/')
+  echo "$n: $req" >> ` + requests + `
+  echo "{\"id\":$id}" >&3
+  case "$req" in
+    *'"EXIT":'*) exit 0 ;;
+  esac
+done`}
+		run := func(environ []string) error {
+			var err error
+			collectLogs(func(logger logs.TaggedLogger) {
+				err = env.driver.RunUserCode(logger, environ, &pps.ProcessStats{}, nil)
+			})
+			return err
+		}
+
+		require.NoError(t, run([]string{"EXIT=1"}))
+		server := env.driver.userCodeServer
+		select {
+		case <-server.exited:
+		case <-time.After(5 * time.Second):
+			t.Fatal("user code didn't exit")
+		}
+		// The user code is restarted for the next datum, rather than the datum
+		// failing.
+		require.NoError(t, run(nil))
+		data, err := ioutil.ReadFile(requests)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Equal(t, 2, len(lines))
+		require.Matches(t, `^1: \{"id":1,.*"EXIT"`, lines[0])
+		require.Matches(t, `^1: \{"id":2,`, lines[1])
+	})
+	require.NoError(t, err)
+}
+
+func TestRunUserCodeServerModePreemption(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		// The user code hangs on every datum, until it's told to stop
+		env.driver.pipelineInfo.Transform.ServerMode = true
+		env.driver.pipelineInfo.Transform.WorkingDir = ""
+		env.driver.pipelineInfo.Transform.Cmd = []string{"bash", "-c", `
+trap 'kill $!; exit 0' TERM
+while read -r req; do
+  sleep 30 &
+  wait
+done`}
+		go func() {
+			// Give the user code time to start and install its trap
+			time.Sleep(500 * time.Millisecond)
+			env.driver.preemption.preempt(time.Minute)
+		}()
+		start := time.Now()
+		collectLogs(func(logger logs.TaggedLogger) {
+			err := env.driver.RunUserCode(logger, nil, &pps.ProcessStats{}, types.DurationProto(20*time.Second))
+			require.YesError(t, err)
+			require.Matches(t, "user code exited", err.Error())
+		})
+		// The user code exits on SIGTERM, rather than being killed after the
+		// grace period or the datum timing out
+		require.True(t, time.Since(start) < 10*time.Second)
+		require.True(t, env.driver.Preempted())
+	})
+	require.NoError(t, err)
+}
+
 func TestRunUserCodeWithData(t *testing.T) {
 	t.Parallel()
 	err := withTestEnv(func(env *testEnv) {
@@ -769,7 +971,7 @@ func TestRunUserCodeWithData(t *testing.T) {
 						newInputData("repoB/input.md", "repoB-data"),
 					})
 
-					err := env.driver.WithActiveData(inputs, dir, func() error {
+					err := env.driver.WithActiveData(inputs, dir, func(Driver) error {
 						requireContents(t, env.driver.InputDir(), []*inputData{
 							newInputData("repoA/input.txt", "repoA-data"),
 							newInputData("repoB/input.md", "repoB-data"),
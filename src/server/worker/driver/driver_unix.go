@@ -27,28 +27,49 @@ func makeCmdCredentials(uid uint32, gid uint32) *syscall.SysProcAttr {
 	}
 }
 
+// makeProcessGroup puts the process started with attr in a new process group,
+// so that killProcessGroup also kills any processes that it starts.
+func makeProcessGroup(attr *syscall.SysProcAttr) *syscall.SysProcAttr {
+	if attr == nil {
+		attr = &syscall.SysProcAttr{}
+	}
+	attr.Setpgid = true
+	return attr
+}
+
+// killProcessGroup kills proc and the rest of its process group.
+func killProcessGroup(proc *os.Process) error {
+	return signalProcessGroup(proc, syscall.SIGKILL)
+}
+
+// signalProcessGroup sends sig to proc and the rest of its process group.
+func signalProcessGroup(proc *os.Process, sig syscall.Signal) error {
+	return syscall.Kill(-proc.Pid, sig)
+}
+
 // WithActiveData is implemented differently in unix vs windows because of how
 // symlinks work on windows. Here, we create symlinks to the scratch space
-// directory, then clean up before returning.
-func (d *driver) WithActiveData(inputs []*common.Input, dir string, cb func() error) (retErr error) {
-	d.activeDataMutex.Lock()
-	defer d.activeDataMutex.Unlock()
+// directory in a free input directory, then clean up before returning.
+func (d *driver) WithActiveData(inputs []*common.Input, dir string, cb func(Driver) error) (retErr error) {
+	inputDir := <-d.activeDataSlots
+	defer func() { d.activeDataSlots <- inputDir }()
+	active := d.withInputDir(inputDir)
 
-	if err := d.linkData(inputs, dir); err != nil {
+	if err := active.linkData(inputs, dir); err != nil {
 		return errors.Wrap(err, "error when linking active data directory")
 	}
 	defer func() {
-		if !d.PipelineInfo().S3Out {
-			if err := d.rewriteSymlinks(dir); err != nil && retErr == nil {
+		if !active.PipelineInfo().S3Out {
+			if err := active.rewriteSymlinks(dir); err != nil && retErr == nil {
 				retErr = errors.Wrap(err, "error when redirecting symlinks in the active data directory")
 			}
 		}
-		if err := d.unlinkData(inputs); err != nil && retErr == nil {
+		if err := active.unlinkData(inputs); err != nil && retErr == nil {
 			retErr = errors.Wrap(err, "error when unlinking active data directory")
 		}
 	}()
 
-	return cb()
+	return cb(active)
 }
 
 // When deactivating a data directory, there may be active symlinks from the
@@ -130,6 +151,12 @@ func (d *driver) linkData(inputs []*common.Input, dir string) error {
 		}
 	}
 
+	if d.checkpointing() {
+		if err := os.Symlink(filepath.Join(dir, checkpointDir), filepath.Join(d.InputDir(), checkpointDir)); err != nil {
+			return err
+		}
+	}
+
 	if !d.PipelineInfo().S3Out {
 		if err := os.Symlink(filepath.Join(dir, "out"), filepath.Join(d.InputDir(), "out")); err != nil {
 			return err
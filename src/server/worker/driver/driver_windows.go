@@ -19,6 +19,18 @@ func makeCmdCredentials(uid uint32, gid uint32) *syscall.SysProcAttr {
 	return nil
 }
 
+func makeProcessGroup(attr *syscall.SysProcAttr) *syscall.SysProcAttr {
+	return attr
+}
+
+func killProcessGroup(proc *os.Process) error {
+	return proc.Kill()
+}
+
+func signalProcessGroup(proc *os.Process, sig syscall.Signal) error {
+	return proc.Signal(sig)
+}
+
 // Note: this function only exists for tests, the real system uses a fifo for
 // this (which does not exist in the normal filesystem on Windows)
 func createSpoutFifo(path string) error {
@@ -32,20 +44,21 @@ func createSpoutFifo(path string) error {
 // WithActiveData is implemented differently in unix vs windows because of how
 // symlinks work on windows. Here, we move inputs into place before the
 // callback, then move them back to the scratch space before returning.
-func (d *driver) WithActiveData(inputs []*common.Input, dir string, cb func() error) (retErr error) {
-	d.activeDataMutex.Lock()
-	defer d.activeDataMutex.Unlock()
+func (d *driver) WithActiveData(inputs []*common.Input, dir string, cb func(Driver) error) (retErr error) {
+	inputDir := <-d.activeDataSlots
+	defer func() { d.activeDataSlots <- inputDir }()
+	active := d.withInputDir(inputDir)
 
-	if err := d.moveData(inputs, dir); err != nil {
+	if err := active.moveData(inputs, dir); err != nil {
 		return errors.Wrap(err, "error when linking active data directory")
 	}
 	defer func() {
-		if err := d.unmoveData(inputs, dir); err != nil && retErr == nil {
+		if err := active.unmoveData(inputs, dir); err != nil && retErr == nil {
 			retErr = errors.Wrap(err, "error when unlinking active data directory")
 		}
 	}()
 
-	return cb()
+	return cb(active)
 }
 
 // os.Symlink requires additional privileges on windows, so just move the files instead
@@ -74,6 +87,12 @@ func (d *driver) moveData(inputs []*common.Input, dir string) error {
 		}
 	}
 
+	if d.checkpointing() {
+		if err := os.Rename(filepath.Join(dir, checkpointDir), filepath.Join(d.InputDir(), checkpointDir)); err != nil {
+			return err
+		}
+	}
+
 	return os.Rename(filepath.Join(dir, "out"), filepath.Join(d.InputDir(), "out"))
 }
 
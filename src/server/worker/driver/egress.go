@@ -0,0 +1,112 @@
+package driver
+
+import (
+	"database/sql"
+	"path"
+
+	// Register the postgres driver with database/sql
+	_ "github.com/lib/pq"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	pgdump "github.com/pachyderm/pachyderm/src/server/pkg/sql"
+)
+
+// egressSQL loads the pgdump files in commit into a Postgres database. The
+// whole commit is loaded in a single transaction, so either all of it or none
+// of it is loaded. Only the files that changed since status.BaseCommit, the
+// last commit that was loaded into the database, are loaded. See loadSQL.
+func (d *driver) egressSQL(commit *pfs.Commit, egress *pps.SQLEgress, status *pps.EgressStatus) (retErr error) {
+	pachClient := d.PachClient()
+	files, _, err := egressChanges(pachClient, commit, status.BaseCommit, false)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	db, err := sql.Open("postgres", egress.URL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid SQL egress URL")
+	}
+	defer func() {
+		if err := db.Close(); err != nil && retErr == nil {
+			retErr = errors.WithStack(err)
+		}
+	}()
+	tx, err := db.BeginTx(pachClient.Ctx(), nil)
+	if err != nil {
+		return errors.Wrapf(err, "could not connect to SQL egress database")
+	}
+	defer func() {
+		if retErr != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := loadSQL(pachClient, tx, commit, status.BaseCommit, files, int(egress.BatchSize)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, fileInfo := range files {
+		status.FilesUploaded++
+		status.BytesUploaded += fileInfo.SizeBytes
+	}
+	return nil
+}
+
+// loadSQL loads files, the pgdump files in commit that changed since base, in
+// tx. Files are read a directory at a time, which means that the files split
+// from a single pgdump, which share a header and footer, are loaded with the
+// header and footer applied once. A directory's header, which creates its
+// tables, is only loaded when the directory is new since base, so only the
+// rows of the files added to an existing directory are loaded. SQL egress
+// only adds rows: the rows of modified files are loaded again, and the rows
+// of removed files aren't deleted.
+func loadSQL(pachClient *client.APIClient, tx *sql.Tx, commit, base *pfs.Commit, files []*pfs.FileInfo, batchSize int) error {
+	var dirs []string
+	dirFiles := make(map[string][]string)
+	for _, fileInfo := range files {
+		dir := path.Dir(fileInfo.File.Path)
+		if _, ok := dirFiles[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		dirFiles[dir] = append(dirFiles[dir], fileInfo.File.Path)
+	}
+	for _, dir := range dirs {
+		newDir := base == nil
+		if !newDir {
+			if _, err := pachClient.InspectFile(base.Repo.Name, base.ID, dir); err != nil {
+				if !errutil.IsNotFoundError(err) {
+					return err
+				}
+				newDir = true
+			}
+		}
+		if newDir {
+			r, err := pachClient.GetFileReader(commit.Repo.Name, commit.ID, path.Join(dir, "*"), 0, 0)
+			if err != nil {
+				return err
+			}
+			if err := pgdump.Load(tx, r, batchSize); err != nil {
+				return errors.Wrapf(err, "error loading %s", dir)
+			}
+			continue
+		}
+		for _, file := range dirFiles[dir] {
+			r, err := pachClient.GetFileReader(commit.Repo.Name, commit.ID, file, 0, 0)
+			if err != nil {
+				return err
+			}
+			if err := pgdump.LoadRows(tx, r, batchSize); err != nil {
+				return errors.Wrapf(err, "error loading %s", file)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package driver
+
+import (
+	sqldriver "database/sql/driver"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+)
+
+// paths returns the sorted paths of fileInfos, Walk and DiffFile don't agree
+// on whether paths have a leading slash.
+func paths(fileInfos []*pfs.FileInfo) []string {
+	var result []string
+	for _, fileInfo := range fileInfos {
+		result = append(result, strings.TrimPrefix(fileInfo.File.Path, "/"))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestEgressChanges(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		require.NoError(t, c.CreateRepo("out"))
+		_, err := c.PutFile("out", "master", "a", strings.NewReader("a"))
+		require.NoError(t, err)
+		_, err = c.PutFile("out", "master", "dir/b", strings.NewReader("b"))
+		require.NoError(t, err)
+		first, err := c.InspectCommit("out", "master")
+		require.NoError(t, err)
+
+		_, err = c.PutFileOverwrite("out", "master", "a", strings.NewReader("aa"), 0)
+		require.NoError(t, err)
+		require.NoError(t, c.DeleteFile("out", "master", "dir/b"))
+		_, err = c.PutFile("out", "master", "c", strings.NewReader("c"))
+		require.NoError(t, err)
+		second, err := c.InspectCommit("out", "master")
+		require.NoError(t, err)
+
+		// A full egress uploads every file.
+		upload, remove, err := egressChanges(c, second.Commit, nil, true)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "c"}, paths(upload))
+		require.Equal(t, 0, len(remove))
+
+		// An incremental egress only uploads the files that changed since the
+		// base commit, and deletes removed files if asked to.
+		upload, remove, err = egressChanges(c, second.Commit, first.Commit, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "c"}, paths(upload))
+		require.Equal(t, 0, len(remove))
+		upload, remove, err = egressChanges(c, second.Commit, first.Commit, true)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "c"}, paths(upload))
+		require.Equal(t, 1, len(remove))
+		require.Equal(t, "dir/b", strings.TrimPrefix(remove[0], "/"))
+
+		// Nothing changes between a commit and itself.
+		upload, remove, err = egressChanges(c, second.Commit, second.Commit, true)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(upload))
+		require.Equal(t, 0, len(remove))
+		return nil
+	}))
+}
+
+func TestLoadSQL(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+		expectRows := func(rows ...[]sqldriver.Value) {
+			copy := mock.ExpectPrepare("COPY public.cars").WillBeClosed()
+			for _, row := range rows {
+				copy.ExpectExec().WithArgs(row...).WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			copy.ExpectExec().WillReturnResult(sqlmock.NewResult(0, int64(len(rows))))
+		}
+		// load runs the SQL egress of a job that outputs commit, after a job
+		// that output base, with the statements that expect sets.
+		load := func(commit, base *pfs.Commit, expect func()) {
+			files, _, err := egressChanges(c, commit, base, false)
+			require.NoError(t, err)
+			mock.ExpectBegin()
+			expect()
+			mock.ExpectCommit()
+			tx, err := db.Begin()
+			require.NoError(t, err)
+			require.NoError(t, loadSQL(c, tx, commit, base, files, 0))
+			require.NoError(t, tx.Commit())
+			require.NoError(t, mock.ExpectationsWereMet())
+		}
+
+		require.NoError(t, c.CreateRepo("out"))
+		_, err = c.PutFileSplit("out", "master", "cars", pfs.Delimiter_SQL, 0, 0, 0, false, strings.NewReader(tu.TestPGDump))
+		require.NoError(t, err)
+		first, err := c.InspectCommit("out", "master")
+		require.NoError(t, err)
+		// The first job creates the table and loads all of its rows.
+		load(first.Commit, nil, func() {
+			mock.ExpectExec("CREATE TABLE public.cars").WillReturnResult(sqlmock.NewResult(0, 0))
+			expectRows(
+				[]sqldriver.Value{"Tesla", "Roadster", "2008", "literally a rocket"},
+				[]sqldriver.Value{"Bugatti", "Chiron", "2016", "literally a rocket"},
+				[]sqldriver.Value{"Dodge", "Viper", "2015", "literally a rocket"},
+				[]sqldriver.Value{"Honda", "Civic", "1998", "only a rocket if it has a spoiler"},
+				[]sqldriver.Value{"Toyota", "Corolla", "2005", "greatest car ever made"},
+			)
+			mock.ExpectExec("dump complete").WillReturnResult(sqlmock.NewResult(0, 0))
+		})
+
+		_, err = c.PutFileSplit("out", "master", "cars", pfs.Delimiter_SQL, 0, 0, 0, false, strings.NewReader(tu.TestPGDumpNewRows))
+		require.NoError(t, err)
+		second, err := c.InspectCommit("out", "master")
+		require.NoError(t, err)
+		// The next job only loads the new rows, the table isn't created again
+		// and the rows of the first job aren't loaded twice.
+		load(second.Commit, first.Commit, func() {
+			expectRows([]sqldriver.Value{"Fiat", "Panda", "1981", "If you extend the middle, it makes a great limo"})
+			expectRows([]sqldriver.Value{"Little Tikes", "Cozy Coupe", "2018", "Roomier than the panda, but the headlights are actually eyes"})
+			expectRows([]sqldriver.Value{"Sun Fresh", "Russet", "2019", "literally a potato"})
+		})
+		return nil
+	}))
+}
@@ -0,0 +1,231 @@
+package driver
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// checkpointDir is the name of the directory, next to the datum's inputs and
+// output, that user code can save its progress on the datum to.
+const checkpointDir = ".checkpoint"
+
+// preemption stops the user code when the worker is preempted. It's shared by
+// all copies of the driver.
+type preemption struct {
+	mu        sync.Mutex
+	preempted bool
+	// procs are the user code processes that are running
+	procs map[*os.Process]bool
+}
+
+func newPreemption() *preemption {
+	return &preemption{procs: make(map[*os.Process]bool)}
+}
+
+// start tracks a user code process until the returned func is called. If the
+// worker has already been preempted, the process is stopped right away. The
+// process must have been started in its own process group (see
+// makeProcessGroup), so that the processes it starts, which may be writing to
+// the checkpoint directory, are stopped with it.
+func (p *preemption) start(proc *os.Process) func() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.procs[proc] = true
+	if p.preempted {
+		signalProcessGroup(proc, syscall.SIGTERM)
+	}
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.procs, proc)
+	}
+}
+
+// preempt sends SIGTERM to the user code process groups, and kills the ones
+// that are still running after gracePeriod.
+func (p *preemption) preempt(gracePeriod time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.preempted {
+		return
+	}
+	p.preempted = true
+	for proc := range p.procs {
+		signalProcessGroup(proc, syscall.SIGTERM)
+	}
+	time.AfterFunc(gracePeriod, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for proc := range p.procs {
+			killProcessGroup(proc)
+		}
+	})
+}
+
+func (p *preemption) isPreempted() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.preempted
+}
+
+func (d *driver) Preempt() {
+	gracePeriod, err := ppsutil.GetPreemptionGracePeriod(d.pipelineInfo.Preemption)
+	if err != nil {
+		logs.NewStatlessLogger(d.pipelineInfo).Logf("error getting preemption grace period, stopping user code right away: %v", err)
+	}
+	d.preemption.preempt(gracePeriod)
+}
+
+func (d *driver) Preempted() bool {
+	return d.preemption.isPreempted()
+}
+
+// checkpointing returns whether the pipeline saves the checkpoints of
+// preempted datums.
+func (d *driver) checkpointing() bool {
+	return d.pipelineInfo.Preemption != nil && d.pipelineInfo.Preemption.Checkpoint
+}
+
+// checkpointTag is the tag that the checkpoint of a preempted datum is saved
+// to. It has the job's tag prefix, so it's deleted along with the job's other
+// artifacts when the job finishes.
+func (d *driver) checkpointTag(jobID string, inputs []*common.Input) string {
+	return fmt.Sprintf("%s-checkpoint-%s", common.JobTagPrefix(jobID), common.HashDatum(d.pipelineInfo.Pipeline.Name, d.pipelineInfo.Salt, inputs))
+}
+
+// restoreCheckpoint creates the checkpoint directory in the datum's scratch
+// directory, and fills it with the checkpoint saved when the datum was last
+// preempted, if any.
+func (d *driver) restoreCheckpoint(logger logs.TaggedLogger, inputs []*common.Input, dir string) error {
+	checkpointPath := filepath.Join(dir, checkpointDir)
+	if err := os.MkdirAll(checkpointPath, 0777); err != nil {
+		return errors.EnsureStack(err)
+	}
+	tag := d.checkpointTag(logger.JobID(), inputs)
+	if _, err := d.pachClient.InspectTag(d.pachClient.Ctx(), client.NewTag(tag)); err != nil {
+		if errutil.IsNotFoundError(err) {
+			return nil // the datum has no checkpoint
+		}
+		// Other errors are returned, so that the datum doesn't lose its
+		// checkpoint and start over because of a transient error
+		return errors.EnsureStack(err)
+	}
+	logger.Logf("restoring checkpoint %s", tag)
+	r, err := d.pachClient.GetTagReader(tag)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer r.Close()
+	return readCheckpoint(r, checkpointPath)
+}
+
+// saveCheckpoint saves the checkpoint directory of a preempted datum, so that
+// it's restored when the datum is processed again.
+func (d *driver) saveCheckpoint(logger logs.TaggedLogger, inputs []*common.Input, dir string) error {
+	tag := d.checkpointTag(logger.JobID(), inputs)
+	logger.Logf("saving checkpoint %s", tag)
+	// The datum may have been preempted before, replace its old checkpoint
+	if _, err := d.pachClient.DeleteTags(d.pachClient.Ctx(), &pfs.DeleteTagsRequest{Tags: []*pfs.Tag{client.NewTag(tag)}}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(writeCheckpoint(filepath.Join(dir, checkpointDir), w))
+	}()
+	_, _, err := d.pachClient.PutObject(r, tag)
+	r.CloseWithError(err)
+	return err
+}
+
+// writeCheckpoint writes the directories and regular files in dir to w as a
+// tar stream.
+func writeCheckpoint(dir string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." || !(info.IsDir() || info.Mode().IsRegular()) {
+			return nil
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return errors.EnsureStack(tw.Close())
+}
+
+// readCheckpoint extracts a tar stream written by writeCheckpoint into dir.
+func readCheckpoint(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.EnsureStack(err)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+			return errors.Errorf("invalid path in checkpoint: %q", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, os.FileMode(hdr.Mode)|0700); err != nil {
+				return errors.EnsureStack(err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+				return errors.EnsureStack(err)
+			}
+			if err := func() error {
+				f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(hdr.Mode))
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				_, err = io.Copy(f, tr)
+				return err
+			}(); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+	}
+}
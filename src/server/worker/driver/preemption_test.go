@@ -0,0 +1,154 @@
+package driver
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	src, err := ioutil.TempDir("", "checkpoint-src")
+	require.NoError(t, err)
+	defer os.RemoveAll(src)
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "a", "b"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "empty"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "top"), []byte("top"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "a", "b", "nested"), []byte("nested"), 0600))
+
+	var buf bytes.Buffer
+	require.NoError(t, writeCheckpoint(src, &buf))
+
+	dst, err := ioutil.TempDir("", "checkpoint-dst")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+	require.NoError(t, readCheckpoint(&buf, dst))
+
+	top, err := ioutil.ReadFile(filepath.Join(dst, "top"))
+	require.NoError(t, err)
+	require.Equal(t, "top", string(top))
+	nested, err := ioutil.ReadFile(filepath.Join(dst, "a", "b", "nested"))
+	require.NoError(t, err)
+	require.Equal(t, "nested", string(nested))
+	info, err := os.Stat(filepath.Join(dst, "empty"))
+	require.NoError(t, err)
+	require.True(t, info.IsDir())
+}
+
+func TestReadCheckpointInvalidPath(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../escape", Mode: 0644, Size: 1, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	dir, err := ioutil.TempDir("", "checkpoint")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.YesError(t, readCheckpoint(&buf, dir))
+	_, err = os.Stat(filepath.Join(filepath.Dir(dir), "escape"))
+	require.True(t, os.IsNotExist(err))
+}
+
+// startInGroup starts a user code process in its own process group, as
+// RunUserCode does when the pipeline is preemptible.
+func startInGroup(t *testing.T, name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	cmd.SysProcAttr = makeProcessGroup(cmd.SysProcAttr)
+	require.NoError(t, cmd.Start())
+	return cmd
+}
+
+// exited returns whether the process pid has exited, including if it's a
+// zombie that hasn't been reaped.
+func exited(pid int) bool {
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return true
+	}
+	fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+	return len(fields) > 0 && fields[0] == "Z"
+}
+
+func TestPreemption(t *testing.T) {
+	p := newPreemption()
+	// A process that ignores SIGTERM is killed after the grace period
+	stubborn := startInGroup(t, "sh", "-c", "trap '' TERM; sleep 60")
+	defer p.start(stubborn.Process)()
+	polite := startInGroup(t, "sleep", "60")
+	defer p.start(polite.Process)()
+	// The processes that the user code starts are stopped with it
+	dir, err := ioutil.TempDir("", "preemption")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	pidFile := filepath.Join(dir, "pid")
+	parent := startInGroup(t, "sh", "-c", "sleep 60 & echo $! > "+pidFile+"; wait")
+	defer p.start(parent.Process)()
+	// Give sh time to install its trap and start its child
+	time.Sleep(100 * time.Millisecond)
+	pid, err := ioutil.ReadFile(pidFile)
+	require.NoError(t, err)
+	child, err := strconv.Atoi(strings.TrimSpace(string(pid)))
+	require.NoError(t, err)
+
+	require.False(t, p.isPreempted())
+	start := time.Now()
+	p.preempt(time.Second)
+	require.True(t, p.isPreempted())
+	require.YesError(t, polite.Wait())
+	require.YesError(t, parent.Wait())
+	require.True(t, time.Since(start) < time.Second)
+	require.NoErrorWithinTRetry(t, time.Second, func() error {
+		if !exited(child) {
+			return errors.Errorf("child process %d is still running", child)
+		}
+		return nil
+	})
+	require.YesError(t, stubborn.Wait())
+	require.True(t, time.Since(start) >= time.Second)
+
+	// Processes started after the worker is preempted are stopped right away
+	late := startInGroup(t, "sleep", "60")
+	defer p.start(late.Process)()
+	require.YesError(t, late.Wait())
+}
+
+func TestRestoreCheckpointErrors(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		env.driver.pipelineInfo.Preemption = &pps.PreemptionSpec{Checkpoint: true}
+		restore := func() error {
+			var err error
+			collectLogs(func(logger logs.TaggedLogger) {
+				err = env.driver.restoreCheckpoint(logger, nil, env.Directory)
+			})
+			return err
+		}
+		// A datum without a checkpoint starts from scratch
+		env.MockPachd.Object.InspectTag.Use(func(_ context.Context, tag *pfs.Tag) (*pfs.ObjectInfo, error) {
+			return nil, errors.Errorf("tagGetter: tag %s not found", tag.Name)
+		})
+		require.NoError(t, restore())
+		// Other errors aren't mistaken for a missing checkpoint
+		env.MockPachd.Object.InspectTag.Use(func(context.Context, *pfs.Tag) (*pfs.ObjectInfo, error) {
+			return nil, errors.New("object storage is unavailable")
+		})
+		require.YesError(t, restore())
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,289 @@
+package driver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/exec"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// datumRequest is sent to user code running in server mode for each datum.
+type datumRequest struct {
+	ID int64 `json:"id"`
+	// Inputs are the paths of the datum's files, by input name.
+	Inputs []*datumInput `json:"inputs"`
+	// OutputDir is the directory that the datum's output is written to.
+	OutputDir string `json:"output_dir"`
+	// Env holds the environment variables which are specific to the datum,
+	// these are the variables that would be set if the user code were run
+	// for just this datum.
+	Env map[string]string `json:"env"`
+}
+
+type datumInput struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// datumResponse is sent by user code running in server mode once it's done
+// processing a datum, Error is set if it failed.
+type datumResponse struct {
+	ID    int64  `json:"id"`
+	Error string `json:"error,omitempty"`
+}
+
+// userCodeServer runs the user code of pipelines with Transform.ServerMode
+// set. The user code is started when the first datum is processed and keeps
+// running, datums are sent to it over stdin. If a datum times out, or the user
+// code exits, it's restarted for the next datum.
+type userCodeServer struct {
+	mu    sync.Mutex
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	// responses is closed once the user code's response pipe is closed.
+	responses chan *datumResponse
+	exited    chan struct{}
+	exitErr   error
+	// stopped is closed by stop, so that responses stops being read.
+	stopped chan struct{}
+	output  *switchWriter
+	nextID  int64
+}
+
+func newUserCodeServer() *userCodeServer {
+	return &userCodeServer{output: &switchWriter{}}
+}
+
+// process sends a datum to the user code and waits for it to be processed.
+// environ is the environment the user code would be run with if it weren't
+// in server mode.
+func (s *userCodeServer) process(ctx context.Context, d *driver, logger logs.TaggedLogger, environ []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The user code's logs go to the logger of the latest datum. Output is
+	// copied asynchronously, so logs written just before a response may be
+	// attributed to the next datum.
+	s.output.set(logger.WithUserCode())
+	if s.cmd != nil {
+		select {
+		case <-s.exited:
+			// The user code exited after the last datum, so it's restarted
+			// rather than sent a datum that it can't process.
+			logger.Logf("user code exited between datums (%v), restarting it", s.exitErr)
+			s.stop()
+		default:
+		}
+	}
+	if s.cmd == nil {
+		if err := s.start(d); err != nil {
+			return err
+		}
+	}
+	s.nextID++
+	req := &datumRequest{
+		ID:        s.nextID,
+		Inputs:    datumInputs(d.pipelineInfo.Input, environ),
+		OutputDir: filepath.Join(d.InputDir(), "out"),
+		Env:       datumEnv(environ),
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if _, err := s.stdin.Write(append(data, '\n')); err != nil {
+		s.stop()
+		return errors.Wrapf(err, "error sending datum to user code")
+	}
+	exited := s.exited
+	for {
+		select {
+		case resp, ok := <-s.responses:
+			if !ok {
+				// The response pipe is closed once the user code exits, the
+				// exit error is returned below.
+				s.stop()
+				return errors.Errorf("user code exited while processing datum: %v", s.exitErr)
+			}
+			if resp.ID != req.ID {
+				logger.Logf("ignoring user code response for datum %d, expected %d", resp.ID, req.ID)
+				continue
+			}
+			if resp.Error != "" {
+				return errors.Errorf("user code failed to process datum: %s", resp.Error)
+			}
+			return nil
+		case <-exited:
+			// The user code may have responded just before it exited, so the
+			// rest of its responses are read. Any processes it started are
+			// killed, so that the response pipe is closed.
+			killProcessGroup(s.cmd.Process)
+			exited = nil
+		case <-ctx.Done():
+			// The state of the user code is unknown, so it's restarted.
+			s.stop()
+			return errors.EnsureStack(ctx.Err())
+		}
+	}
+}
+
+func (s *userCodeServer) start(d *driver) error {
+	transform := d.pipelineInfo.Transform
+	r, w, err := os.Pipe()
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer w.Close()
+	cmd := exec.Command(transform.Cmd[0], transform.Cmd[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		r.Close()
+		return errors.EnsureStack(err)
+	}
+	cmd.Stdout = s.output
+	cmd.Stderr = s.output
+	// Responses are written to file descriptor 3, the first extra file, so
+	// that stdout and stderr can still be used for logs.
+	cmd.ExtraFiles = []*os.File{w}
+	if d.uid != nil && d.gid != nil {
+		cmd.SysProcAttr = makeCmdCredentials(*d.uid, *d.gid)
+	}
+	// The user code runs in its own process group, so that stop also kills
+	// any processes it started, which would otherwise keep running (and
+	// holding its stdout and stderr open) after it's restarted.
+	cmd.SysProcAttr = makeProcessGroup(cmd.SysProcAttr)
+	cmd.Dir = filepath.Join(d.rootDir, transform.WorkingDir)
+	if err := cmd.Start(); err != nil {
+		r.Close()
+		return errors.EnsureStack(err)
+	}
+	// The user code keeps running between datums, so it's tracked for
+	// preemption for as long as it runs, rather than per datum as in
+	// RunUserCode.
+	untrack := d.preemption.start(cmd.Process)
+	s.cmd = cmd
+	s.stdin = stdin
+	s.responses = make(chan *datumResponse)
+	s.exited = make(chan struct{})
+	s.exitErr = nil
+	s.stopped = make(chan struct{})
+	responses, exited, stopped := s.responses, s.exited, s.stopped
+	go func() {
+		defer r.Close()
+		defer close(responses)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			resp := &datumResponse{}
+			if err := json.Unmarshal(scanner.Bytes(), resp); err != nil {
+				continue
+			}
+			select {
+			case responses <- resp:
+			case <-stopped:
+				return
+			}
+		}
+	}()
+	go func() {
+		// See RunUserCode for why Process.Wait and WaitIO are used rather
+		// than Wait.
+		state, err := cmd.Process.Wait()
+		untrack()
+		if err == nil {
+			err = cmd.WaitIO(state, err)
+		}
+		s.exitErr = err
+		close(exited)
+	}()
+	return nil
+}
+
+// stop kills the user code, and any processes it started, if it's running. It
+// will be restarted for the next datum.
+func (s *userCodeServer) stop() {
+	if s.cmd == nil {
+		return
+	}
+	s.stdin.Close()
+	killProcessGroup(s.cmd.Process)
+	<-s.exited
+	close(s.stopped)
+	s.cmd = nil
+}
+
+// datumInputs returns the datum's files from the environment variables set
+// for each input.
+func datumInputs(input *pps.Input, environ []string) []*datumInput {
+	paths := make(map[string]string)
+	for _, kv := range environ {
+		if i := strings.Index(kv, "="); i > 0 {
+			paths[kv[:i]] = kv[i+1:]
+		}
+	}
+	var result []*datumInput
+	pps.VisitInput(input, func(input *pps.Input) {
+		var name string
+		switch {
+		case input.Pfs != nil:
+			name = input.Pfs.Name
+		case input.Cron != nil:
+			name = input.Cron.Name
+		case input.ObjectStorage != nil:
+			name = input.ObjectStorage.Name
+		case input.Git != nil:
+			name = input.Git.Name
+		}
+		if path, ok := paths[name]; ok && name != "" {
+			result = append(result, &datumInput{Name: name, Path: path})
+		}
+	})
+	return result
+}
+
+// datumEnv returns the variables in environ that aren't part of the worker's
+// own environment, which the user code inherited when it started.
+func datumEnv(environ []string) map[string]string {
+	base := make(map[string]bool)
+	for _, kv := range os.Environ() {
+		base[kv] = true
+	}
+	result := make(map[string]string)
+	for _, kv := range environ {
+		if base[kv] {
+			continue
+		}
+		if i := strings.Index(kv, "="); i > 0 {
+			result[kv[:i]] = kv[i+1:]
+		}
+	}
+	return result
+}
+
+// switchWriter forwards writes to a writer which can be changed.
+type switchWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *switchWriter) set(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.w = w
+}
+
+func (s *switchWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.w == nil {
+		return len(p), nil
+	}
+	return s.w.Write(p)
+}
@@ -0,0 +1,366 @@
+// Package local runs a pipeline's transform on a developer's machine, without
+// Kubernetes. It computes the pipeline's datums against a real pachd and
+// processes them with the same driver the workers use, so inputs are laid out
+// and outputs are uploaded exactly as they are in production. The output is
+// written to a scratch branch of the pipeline's output repo.
+package local
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/datum"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// DefaultBranch is the branch of the output repo that local runs write to if
+// no other branch is given.
+const DefaultBranch = "local"
+
+// defaultImage is the image pachd gives pipelines that don't set one.
+const defaultImage = "ubuntu:16.04"
+
+// Options configures a local run.
+type Options struct {
+	// Branch is the branch of the pipeline's output repo that the output
+	// commit is written to, it defaults to DefaultBranch. It must not be the
+	// pipeline's output branch.
+	Branch string
+	// Commits pins input repos to specific commits, instead of the head of the
+	// branch named in the pipeline's input.
+	Commits map[string]string
+	// Docker runs the transform in the pipeline's image with `docker run`,
+	// rather than as a local process.
+	Docker bool
+	// Dir is the directory that datums are downloaded into, a temporary
+	// directory is used (and removed) if it's unset.
+	Dir string
+	// Out receives the user code's output, and the driver's messages if
+	// Verbose is set.
+	Out     io.Writer
+	Verbose bool
+}
+
+// Result describes a finished local run.
+type Result struct {
+	// Commit is the output commit, it is nil if the pipeline had no datums.
+	Commit *pfs.Commit
+	// Datums is the number of datums that were processed.
+	Datums int
+}
+
+// Run processes every datum of the pipeline described by request and writes
+// the merged output to a commit on opts.Branch of the pipeline's output repo.
+// Datums are processed one at a time, and the run stops at the first datum
+// that fails.
+//
+// Output is uploaded through the object API, so pachd must serve it (as
+// `pachctl deploy local` does, or with --expose-object-api).
+func Run(pachClient *client.APIClient, request *pps.CreatePipelineRequest, opts *Options) (_ *Result, retErr error) {
+	if opts.Branch == "" {
+		opts.Branch = DefaultBranch
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	pipelineInfo, err := pipelineInfoFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Branch == pipelineInfo.OutputBranch {
+		return nil, errors.Errorf("cannot write local output to the pipeline's output branch %q", opts.Branch)
+	}
+	if err := resolveInputs(pachClient, pipelineInfo, opts.Commits); err != nil {
+		return nil, err
+	}
+
+	rootDir := opts.Dir
+	if rootDir == "" {
+		if rootDir, err = ioutil.TempDir("", "pachctl-run-local-"); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		defer func() {
+			if err := os.RemoveAll(rootDir); err != nil && retErr == nil {
+				retErr = errors.EnsureStack(err)
+			}
+		}()
+	}
+	if rootDir, err = filepath.Abs(rootDir); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := localTransform(pipelineInfo, rootDir, opts.Docker); err != nil {
+		return nil, err
+	}
+
+	di, err := datum.NewIterator(pachClient, pipelineInfo.Input)
+	if err != nil {
+		return nil, err
+	}
+	if di.Len() == 0 {
+		return &Result{}, nil
+	}
+	d, err := driver.NewDriver(pipelineInfo, pachClient, nil, "", filepath.Join(rootDir, "hashtrees"), rootDir, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// The output commit is started before any datums are processed so that
+	// the user code sees its ID, as it does in a job
+	repo := pipelineInfo.Pipeline.Name
+	if err := pachClient.CreateRepo(repo); err != nil && !errutil.IsAlreadyExistError(err) {
+		return nil, err
+	}
+	outputCommit, err := pachClient.StartCommit(repo, opts.Branch)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := pachClient.DeleteCommit(repo, outputCommit.ID); err != nil {
+				fmt.Fprintf(opts.Out, "could not delete output commit %s@%s: %v\n", repo, outputCommit.ID, err)
+			}
+		}
+	}()
+
+	runLogger := newLogger(opts.Out, opts.Verbose)
+	var trees []*hashtree.Reader
+	for n := 1; di.Next(); n++ {
+		inputs := di.Datum()
+		logger := runLogger.WithData(inputs)
+		logger.Errf("processing datum %d of %d", n, di.Len())
+		tree, err := processDatum(d, logger, inputs, outputCommit)
+		if err != nil {
+			return nil, errors.Wrapf(err, "datum %s failed", common.DatumID(inputs))
+		}
+		trees = append(trees, hashtree.NewReader(bytes.NewReader(tree), nil))
+	}
+
+	tree, err := mergeTrees(filepath.Join(rootDir, "hashtrees"), trees)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := tree.Destroy(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	treeRef, err := hashtree.PutHashTree(pachClient, tree)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
+		Commit: outputCommit,
+		Tree:   treeRef,
+	}); err != nil {
+		return nil, err
+	}
+	return &Result{Commit: outputCommit, Datums: di.Len()}, nil
+}
+
+// pipelineInfoFromRequest builds the parts of a PipelineInfo that the driver
+// reads from a pipeline spec, and rejects pipelines that can't run locally.
+func pipelineInfoFromRequest(request *pps.CreatePipelineRequest) (*pps.PipelineInfo, error) {
+	if request.Pipeline == nil || request.Pipeline.Name == "" {
+		return nil, errors.New("the pipeline spec must have a name")
+	}
+	switch {
+	case request.Transform == nil || len(request.Transform.Cmd) == 0:
+		return nil, errors.New("only pipelines with a transform cmd can be run locally")
+	case request.Service != nil:
+		return nil, errors.New("service pipelines cannot be run locally")
+	case request.Spout != nil:
+		return nil, errors.New("spout pipelines cannot be run locally")
+	case request.S3Out || ppsutil.ContainsS3Inputs(request.Input):
+		return nil, errors.New("pipelines that use the s3 gateway cannot be run locally")
+	case request.Transform.ServerMode:
+		return nil, errors.New("server mode pipelines cannot be run locally")
+	case request.Input == nil:
+		return nil, errors.New("the pipeline spec must have an input")
+	}
+	pipelineInfo := &pps.PipelineInfo{
+		Pipeline:         request.Pipeline,
+		Transform:        proto.Clone(request.Transform).(*pps.Transform),
+		Input:            proto.Clone(request.Input).(*pps.Input),
+		OutputBranch:     request.OutputBranch,
+		DatumTimeout:     request.DatumTimeout,
+		DatumTries:       request.DatumTries,
+		DatumConcurrency: 1,
+		HashtreeSpec:     request.HashtreeSpec,
+	}
+	if pipelineInfo.OutputBranch == "" {
+		pipelineInfo.OutputBranch = "master"
+	}
+	return pipelineInfo, nil
+}
+
+// resolveInputs fills in the input defaults that pachd would set when the
+// pipeline is created, and points every input at a commit: the one pinned in
+// commits, or else the head of the input's branch.
+func resolveInputs(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, commits map[string]string) error {
+	pps.VisitInput(pipelineInfo.Input, func(input *pps.Input) {
+		switch {
+		case input.Pfs != nil:
+			if input.Pfs.Name == "" {
+				input.Pfs.Name = input.Pfs.Repo
+			}
+			if input.Pfs.Branch == "" {
+				input.Pfs.Branch = "master"
+			}
+		case input.Cron != nil:
+			if input.Cron.Repo == "" {
+				input.Cron.Repo = fmt.Sprintf("%s_%s", pipelineInfo.Pipeline.Name, input.Cron.Name)
+			}
+		case input.ObjectStorage != nil:
+			if input.ObjectStorage.Repo == "" {
+				input.ObjectStorage.Repo = fmt.Sprintf("%s_%s", pipelineInfo.Pipeline.Name, input.ObjectStorage.Name)
+			}
+			if input.ObjectStorage.Glob == "" {
+				input.ObjectStorage.Glob = "/*"
+			}
+		case input.Git != nil:
+			if input.Git.Branch == "" {
+				input.Git.Branch = "master"
+			}
+		}
+	})
+	return ppsutil.ResolveInputCommits(pachClient, pipelineInfo.Input, commits)
+}
+
+// localTransform adapts the pipeline's transform to run on this machine. The
+// driver runs the user code in rootDir+working_dir, which in a worker is the
+// container's working directory; locally the code runs in the current
+// directory instead, and as the current user.
+func localTransform(pipelineInfo *pps.PipelineInfo, rootDir string, docker bool) error {
+	transform := pipelineInfo.Transform
+	wd, err := os.Getwd()
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if docker {
+		transform.Cmd = dockerCmd(pipelineInfo, rootDir)
+	}
+	if transform.WorkingDir, err = filepath.Rel(rootDir, wd); err != nil {
+		return errors.EnsureStack(err)
+	}
+	transform.User = ""
+	return nil
+}
+
+// dockerCmd returns a command that runs the transform in the pipeline's
+// image. rootDir is mounted at the same path in the container, so the paths
+// in the datum's environment and the driver's symlinks resolve, and the input
+// directory is also mounted at /pfs, where the user code expects it.
+func dockerCmd(pipelineInfo *pps.PipelineInfo, rootDir string) []string {
+	transform := pipelineInfo.Transform
+	image := transform.Image
+	if image == "" {
+		image = defaultImage
+	}
+	cmd := []string{"docker", "run", "--rm"}
+	if transform.Stdin != nil {
+		cmd = append(cmd, "-i")
+	}
+	cmd = append(cmd,
+		"-v", rootDir+":"+rootDir,
+		"-v", filepath.Join(rootDir, client.PPSInputPrefix)+":"+client.PPSInputPrefix,
+	)
+	// `-e NAME` passes NAME through from the environment the driver gives
+	// `docker run`, which is the datum's environment
+	for _, name := range dockerEnv(pipelineInfo) {
+		cmd = append(cmd, "-e", name)
+	}
+	if transform.WorkingDir != "" {
+		cmd = append(cmd, "-w", transform.WorkingDir)
+	}
+	if transform.User != "" {
+		cmd = append(cmd, "-u", transform.User)
+	}
+	cmd = append(cmd, "--entrypoint", transform.Cmd[0], image)
+	return append(cmd, transform.Cmd[1:]...)
+}
+
+// dockerEnv returns the names of the environment variables that the driver
+// and the pipeline spec set for the user code.
+func dockerEnv(pipelineInfo *pps.PipelineInfo) []string {
+	names := []string{client.OutputCommitIDEnv, client.OutputDirEnv}
+	pps.VisitInput(pipelineInfo.Input, func(input *pps.Input) {
+		if name := pps.InputName(input); name != "" && input.Cross == nil && input.Union == nil && input.Join == nil {
+			names = append(names, name, name+"_COMMIT")
+		}
+	})
+	for name := range pipelineInfo.Transform.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// processDatum downloads a datum, runs the user code on it and uploads its
+// output, returning the datum's serialized hashtree.
+func processDatum(d driver.Driver, logger logs.TaggedLogger, inputs []*common.Input, outputCommit *pfs.Commit) ([]byte, error) {
+	var tree []byte
+	// The datum hashtree is tagged with a random tag, so that the pipeline
+	// doesn't mistake it for a datum that it has already processed
+	tag := uuid.NewWithoutDashes()
+	_, err := d.WithData(inputs, nil, logger, func(dir string, stats *pps.ProcessStats) error {
+		if err := d.WithActiveData(inputs, dir, func(active driver.Driver) error {
+			env := active.UserCodeEnv("", outputCommit, inputs)
+			for name, value := range active.PipelineInfo().Transform.Env {
+				env = append(env, fmt.Sprintf("%s=%s", name, value))
+			}
+			return active.RunUserCode(logger, env, stats, active.PipelineInfo().DatumTimeout)
+		}); err != nil {
+			return err
+		}
+		var err error
+		tree, err = d.UploadOutput(dir, tag, logger, inputs, stats, nil)
+		return err
+	})
+	return tree, err
+}
+
+// mergeTrees merges the datums' hashtrees into the output commit's tree.
+func mergeTrees(storageRoot string, trees []*hashtree.Reader) (hashtree.HashTree, error) {
+	buf := &bytes.Buffer{}
+	if err := hashtree.Merge(hashtree.NewWriter(buf), trees); err != nil {
+		return nil, err
+	}
+	tree, err := hashtree.NewDBHashTree(storageRoot)
+	if err != nil {
+		return nil, err
+	}
+	if err := hashtree.Walk([]io.ReadCloser{ioutil.NopCloser(buf)}, "/", func(path string, node *hashtree.NodeProto) error {
+		switch {
+		case node.FileNode != nil:
+			return tree.PutFileBlockRefs(path, node.FileNode.BlockRefs, node.SubtreeSize)
+		case path != "/":
+			return tree.PutDir(path)
+		}
+		return nil
+	}); err != nil {
+		tree.Destroy()
+		return nil, err
+	}
+	if err := tree.Hash(); err != nil {
+		tree.Destroy()
+		return nil, err
+	}
+	return tree, nil
+}
@@ -0,0 +1,100 @@
+package local
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+)
+
+func TestPipelineInfoFromRequest(t *testing.T) {
+	request := func() *pps.CreatePipelineRequest {
+		return &pps.CreatePipelineRequest{
+			Pipeline:  &pps.Pipeline{Name: "edges"},
+			Transform: &pps.Transform{Cmd: []string{"python3", "/edges.py"}},
+			Input:     &pps.Input{Pfs: &pps.PFSInput{Repo: "images", Glob: "/*"}},
+		}
+	}
+	pipelineInfo, err := pipelineInfoFromRequest(request())
+	require.NoError(t, err)
+	require.Equal(t, "master", pipelineInfo.OutputBranch)
+	require.Equal(t, int64(1), pipelineInfo.DatumConcurrency)
+
+	r := request()
+	r.Service = &pps.Service{}
+	_, err = pipelineInfoFromRequest(r)
+	require.YesError(t, err)
+	r = request()
+	r.Transform.ServerMode = true
+	_, err = pipelineInfoFromRequest(r)
+	require.YesError(t, err)
+	r = request()
+	r.Input.Pfs.S3 = true
+	_, err = pipelineInfoFromRequest(r)
+	require.YesError(t, err)
+}
+
+func TestDockerCmd(t *testing.T) {
+	pipelineInfo := &pps.PipelineInfo{
+		Transform: &pps.Transform{
+			Image:      "edges:dev",
+			Cmd:        []string{"python3", "/edges.py", "--fast"},
+			Stdin:      []string{"echo hi"},
+			Env:        map[string]string{"MODE": "test"},
+			WorkingDir: "/app",
+		},
+		Input: &pps.Input{Cross: []*pps.Input{
+			{Pfs: &pps.PFSInput{Name: "images"}},
+			{Pfs: &pps.PFSInput{Name: "labels"}},
+		}},
+	}
+	require.Equal(t,
+		"docker run --rm -i -v /tmp/run:/tmp/run -v /tmp/run/pfs:/pfs "+
+			"-e MODE -e PACH_OUTPUT_COMMIT_ID -e PACH_OUTPUT_DIR -e images -e images_COMMIT -e labels -e labels_COMMIT "+
+			"-w /app --entrypoint python3 edges:dev /edges.py --fast",
+		strings.Join(dockerCmd(pipelineInfo, "/tmp/run"), " "))
+}
+
+func TestMergeTrees(t *testing.T) {
+	datumTree := func(files ...string) *hashtree.Reader {
+		// files are added the way UploadOutput walks the output directory
+		tree := hashtree.NewOrdered("/")
+		for _, file := range files {
+			if strings.HasSuffix(file, "/") {
+				tree.PutDir(file)
+				continue
+			}
+			tree.PutFile(file, []byte(file), 1, &hashtree.FileNodeProto{
+				BlockRefs: []*pfs.BlockRef{{Block: &pfs.Block{Hash: file}, Range: &pfs.ByteRange{Upper: 1}}},
+			})
+		}
+		buf := &bytes.Buffer{}
+		require.NoError(t, tree.Serialize(buf))
+		return hashtree.NewReader(buf, nil)
+	}
+	dir, err := ioutil.TempDir("", "merge-trees")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tree, err := mergeTrees(dir, []*hashtree.Reader{
+		datumTree("a", "dir/", "dir/b"),
+		datumTree("dir/", "dir/c"),
+	})
+	require.NoError(t, err)
+	defer tree.Destroy()
+	require.Equal(t, int64(3), tree.FSSize())
+	children, err := tree.ListAll("/dir")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(children))
+	require.Equal(t, "b", children[0].Name)
+	require.Equal(t, "c", children[1].Name)
+	node, err := tree.Get("/a")
+	require.NoError(t, err)
+	require.Equal(t, "a", node.FileNode.BlockRefs[0].Block.Hash)
+}
@@ -0,0 +1,86 @@
+package local
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// logger is a logs.TaggedLogger that writes plain text instead of the json
+// messages workers write, since its output is read by a person at a terminal.
+// The user code's output is passed through unchanged, the driver's own
+// messages are only written in verbose mode.
+type logger struct {
+	mu      *sync.Mutex
+	w       io.Writer
+	verbose bool
+	prefix  string
+	jobID   string
+}
+
+func newLogger(w io.Writer, verbose bool) *logger {
+	return &logger{mu: &sync.Mutex{}, w: w, verbose: verbose}
+}
+
+func (l *logger) clone() *logger {
+	result := *l
+	return &result
+}
+
+func (l *logger) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+func (l *logger) Logf(formatString string, args ...interface{}) {
+	if l.verbose {
+		l.Errf(formatString, args...)
+	}
+}
+
+func (l *logger) Errf(formatString string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.w, "%s%s\n", l.prefix, fmt.Sprintf(formatString, args...))
+}
+
+func (l *logger) LogStep(name string, cb func() error) (retErr error) {
+	l.Logf("started %v", name)
+	defer func() {
+		if retErr != nil {
+			l.Logf("errored %v: %v", name, retErr)
+		} else {
+			l.Logf("finished %v", name)
+		}
+	}()
+	return cb()
+}
+
+func (l *logger) WithJob(jobID string) logs.TaggedLogger {
+	result := l.clone()
+	result.jobID = jobID
+	return result
+}
+
+func (l *logger) WithData(data []*common.Input) logs.TaggedLogger {
+	result := l.clone()
+	result.prefix = fmt.Sprintf("[datum %s] ", common.DatumID(data)[:12])
+	return result
+}
+
+func (l *logger) WithUserCode() logs.TaggedLogger {
+	return l.clone()
+}
+
+func (l *logger) JobID() string {
+	return l.jobID
+}
+
+func (l *logger) Close() (*pfs.Object, int64, error) {
+	return nil, 0, nil
+}
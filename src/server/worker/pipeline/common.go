@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -39,16 +40,21 @@ func openAndWait() error {
 
 // RunUserCode will run the pipeline's user code until canceled by the context
 // - used for services and spouts. Unlike how the transform worker runs user
-// code, this does not set environment variables or collect stats.
+// code, this does not set environment variables or collect stats. extraEnv is
+// added to the user code's environment.
 func RunUserCode(
 	driver driver.Driver,
 	logger logs.TaggedLogger,
 	outputCommit *pfs.Commit,
 	inputs []*common.Input,
+	extraEnv ...string,
 ) error {
 	return backoff.RetryUntilCancel(driver.PachClient().Ctx(), func() error {
 		// TODO: what about the user error handling code?
-		env := driver.UserCodeEnv(logger.JobID(), outputCommit, inputs)
+		env := append(driver.UserCodeEnv(logger.JobID(), outputCommit, inputs), extraEnv...)
+		if spout := driver.PipelineInfo().Spout; spout != nil && spout.Transactional {
+			env = append(env, fmt.Sprintf("%s=%s", client.SpoutSocketEnv, spoutSocketPath))
+		}
 		return driver.RunUserCode(logger, env, &pps.ProcessStats{}, nil)
 	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
 		logger.Logf("error in RunUserCode: %+v, retrying in: %+v", err, d)
@@ -59,13 +65,17 @@ func RunUserCode(
 // ReceiveSpout is used by both services and spouts if a spout is defined on the
 // pipeline. ctx is separate from pachClient because services may call this, and
 // they use a cancel function that affects the context but not the pachClient
-// (so metadata updates can still be made while unwinding).
+// (so metadata updates can still be made while unwinding). Transactional spouts
+// are served over a socket rather than the /pfs/out named pipe.
 func ReceiveSpout(
 	ctx context.Context,
 	pachClient *client.APIClient,
 	pipelineInfo *pps.PipelineInfo,
 	logger logs.TaggedLogger,
 ) (retErr error) {
+	if pipelineInfo.Spout.Transactional {
+		return serveSpoutTransactions(ctx, pachClient, pipelineInfo, logger, spoutSocketPath)
+	}
 	// Open a read connection to the /pfs/out named pipe.
 	out, err := os.Open("/pfs/out")
 	if err != nil {
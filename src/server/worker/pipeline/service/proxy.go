@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// proxy forwards connections on the service's internal port to the user code
+// that is currently being served. Services with rolling updates run two copies
+// of the user code during an update, each on its own port, so traffic is
+// switched between them by changing the proxy's backend.
+type proxy struct {
+	listener net.Listener
+	logger   logs.TaggedLogger
+
+	mu      sync.Mutex
+	backend string
+	// conns tracks the open connections to each backend, so that an old
+	// backend can be drained before it's stopped.
+	conns map[string]*sync.WaitGroup
+}
+
+func newProxy(addr string, logger logs.TaggedLogger) (*proxy, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	p := &proxy{
+		listener: listener,
+		logger:   logger,
+		conns:    make(map[string]*sync.WaitGroup),
+	}
+	go p.serve()
+	return p, nil
+}
+
+func (p *proxy) serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		go p.forward(conn)
+	}
+}
+
+// setBackend sends new connections to backend, existing connections to the
+// previous backend are left open.
+func (p *proxy) setBackend(backend string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.backend = backend
+	if _, ok := p.conns[backend]; !ok {
+		p.conns[backend] = &sync.WaitGroup{}
+	}
+}
+
+// drain waits until there are no open connections to backend, or until timeout
+// has passed.
+func (p *proxy) drain(backend string, timeout time.Duration) {
+	p.mu.Lock()
+	wg, ok := p.conns[backend]
+	delete(p.conns, backend)
+	p.mu.Unlock()
+	if !ok {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		p.logger.Logf("connections to %s were still open after %v", backend, timeout)
+	}
+}
+
+func (p *proxy) forward(conn net.Conn) {
+	defer conn.Close()
+	p.mu.Lock()
+	backend := p.backend
+	wg := p.conns[backend]
+	if wg != nil {
+		wg.Add(1)
+	}
+	p.mu.Unlock()
+	if wg == nil {
+		// The user code hasn't started yet.
+		return
+	}
+	defer wg.Done()
+	backendConn, err := net.Dial("tcp", backend)
+	if err != nil {
+		p.logger.Logf("error connecting to service backend %s: %v", backend, err)
+		return
+	}
+	defer backendConn.Close()
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(backendConn, conn)
+		closeWrite(backendConn)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(conn, backendConn)
+		closeWrite(conn)
+		done <- struct{}{}
+	}()
+	<-done
+	<-done
+}
+
+// closeWrite signals EOF to the other end of conn, while still allowing it to
+// send a response.
+func closeWrite(conn net.Conn) {
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		tcpConn.CloseWrite()
+	}
+}
+
+func (p *proxy) Close() error {
+	return errors.EnsureStack(p.listener.Close())
+}
+
+// freePort returns a local address that nothing is listening on, for the user
+// code of a rolling update to serve on.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func localAddr(port int) string {
+	return fmt.Sprintf("127.0.0.1:%d", port)
+}
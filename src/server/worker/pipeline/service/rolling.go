@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/datum"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+	"github.com/pachyderm/pachyderm/src/server/worker/pipeline"
+)
+
+const (
+	defaultReadinessPath    = "/"
+	defaultReadinessPeriod  = time.Second
+	defaultReadinessTimeout = 5 * time.Minute
+	// drainTimeout is how long connections to the old user code are given to
+	// finish after traffic has been switched to the new user code.
+	drainTimeout = 30 * time.Second
+)
+
+// instance is a copy of the user code serving a single output commit.
+type instance struct {
+	commitInfo *pfs.CommitInfo
+	jobID      string
+	addr       string
+	cancel     func()
+	// done is closed once the user code has exited.
+	done chan struct{}
+}
+
+// startInstance starts the user code for commitInfo in the background, the
+// user code is told to serve on port.
+func startInstance(d driver.Driver, logger logs.TaggedLogger, commitInfo *pfs.CommitInfo, port int) (*instance, error) {
+	pachClient := d.PachClient()
+	pipelineInfo := d.PipelineInfo()
+	jobInput := ppsutil.JobInput(pipelineInfo, commitInfo)
+	job, err := pachClient.CreateJob(pipelineInfo.Pipeline.Name, commitInfo.Commit, nil)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithJob(job.ID)
+	dit, err := datum.NewIterator(pachClient, jobInput)
+	if err != nil {
+		return nil, err
+	}
+	if dit.Len() != 1 {
+		return nil, errors.New("services must have a single datum")
+	}
+	inputs := dit.DatumN(0)
+	logger = logger.WithData(inputs)
+
+	ctx, cancel := context.WithCancel(pachClient.Ctx())
+	inst := &instance{
+		commitInfo: commitInfo,
+		jobID:      job.ID,
+		addr:       localAddr(port),
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	go func() {
+		defer close(inst.done)
+		if _, err := d.WithData(inputs, nil, logger, func(dir string, stats *pps.ProcessStats) error {
+			if err := d.UpdateJobState(job.ID, pps.JobState_JOB_RUNNING, ""); err != nil {
+				logger.Logf("error updating job state: %+v", err)
+			}
+			// The new user code's inputs are staged in a separate input
+			// directory while the old user code is still running.
+			return d.WithActiveData(inputs, dir, func(activeDriver driver.Driver) error {
+				return pipeline.RunUserCode(activeDriver.WithContext(ctx), logger, nil, inputs,
+					fmt.Sprintf("%s=%d", client.ServicePortEnv, port))
+			})
+		}); err != nil && ctx.Err() == nil {
+			logger.Logf("error running user code: %+v", err)
+		}
+	}()
+	return inst, nil
+}
+
+// stop stops the user code and finishes its job and output commit.
+func (inst *instance) stop(d driver.Driver, logger logs.TaggedLogger, state pps.JobState, reason string) {
+	inst.cancel()
+	<-inst.done
+	// TODO: do this in a transaction
+	if err := d.UpdateJobState(inst.jobID, state, reason); err != nil {
+		logger.Logf("error updating job state: %+v", err)
+	}
+	commit := inst.commitInfo.Commit
+	if err := d.PachClient().FinishCommit(commit.Repo.Name, commit.ID); err != nil {
+		logger.Logf("could not finish output commit: %v", err)
+	}
+}
+
+// runRolling runs a service with rolling updates. The user code for each new
+// output commit is started alongside the user code that's being served, and
+// traffic is switched to it once it passes its readiness probe, the old user
+// code is then stopped. If the new user code never becomes ready, it's stopped
+// and the old user code continues to be served.
+func runRolling(d driver.Driver, logger logs.TaggedLogger) (retErr error) {
+	pachClient := d.PachClient()
+	pipelineInfo := d.PipelineInfo()
+	p, err := newProxy(fmt.Sprintf(":%d", pipelineInfo.Service.InternalPort), logger)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	probe, err := newReadinessProbe(pipelineInfo.Service.ReadinessProbe)
+	if err != nil {
+		return err
+	}
+
+	var current *instance
+	defer func() {
+		if current != nil {
+			current.cancel()
+			<-current.done
+		}
+	}()
+	return pachClient.SubscribeCommitF(
+		pipelineInfo.Pipeline.Name,
+		"",
+		client.NewCommitProvenance(ppsconsts.SpecRepo, pipelineInfo.Pipeline.Name, pipelineInfo.SpecCommit.ID),
+		"",
+		pfs.CommitState_READY,
+		func(ci *pfs.CommitInfo) error {
+			port, err := freePort()
+			if err != nil {
+				return err
+			}
+			logger.Logf("starting new service on port %d, commit: %s", port, ci.Commit.ID)
+			next, err := startInstance(d, logger, ci, port)
+			if err != nil {
+				return err
+			}
+			if err := probe.wait(pachClient.Ctx(), next); err != nil {
+				if current != nil {
+					logger.Logf("abandoning update to commit %s: %v", ci.Commit.ID, err)
+					next.stop(d, logger, pps.JobState_JOB_FAILURE, fmt.Sprintf("service did not become ready: %v", err))
+					return nil
+				}
+				// There's nothing else to serve, so serve the new user code
+				// even though it isn't ready.
+				logger.Logf("serving commit %s, which is not ready: %v", ci.Commit.ID, err)
+			}
+			logger.Logf("switching traffic to commit %s", ci.Commit.ID)
+			p.setBackend(next.addr)
+			if err := setServiceCommit(d, ci.Commit); err != nil {
+				logger.Logf("error updating service commit: %v", err)
+			}
+			if current != nil {
+				p.drain(current.addr, drainTimeout)
+				current.stop(d, logger, pps.JobState_JOB_SUCCESS, "")
+			}
+			current = next
+			return nil
+		},
+	)
+}
+
+type readinessProbe struct {
+	path    string
+	period  time.Duration
+	timeout time.Duration
+	client  *http.Client
+}
+
+func newReadinessProbe(spec *pps.ReadinessProbe) (*readinessProbe, error) {
+	probe := &readinessProbe{
+		path:    defaultReadinessPath,
+		period:  defaultReadinessPeriod,
+		timeout: defaultReadinessTimeout,
+	}
+	if spec != nil {
+		if spec.Path != "" {
+			probe.path = spec.Path
+		}
+		if spec.Period != nil {
+			period, err := types.DurationFromProto(spec.Period)
+			if err != nil {
+				return nil, err
+			}
+			probe.period = period
+		}
+		if spec.Timeout != nil {
+			timeout, err := types.DurationFromProto(spec.Timeout)
+			if err != nil {
+				return nil, err
+			}
+			probe.timeout = timeout
+		}
+	}
+	probe.client = &http.Client{Timeout: probe.period}
+	return probe, nil
+}
+
+// wait polls the instance's readiness path until it succeeds, the timeout
+// passes or the instance exits.
+func (p *readinessProbe) wait(ctx context.Context, inst *instance) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	ticker := time.NewTicker(p.period)
+	defer ticker.Stop()
+	url := "http://" + inst.addr + p.path
+	var lastErr error
+	for {
+		resp, err := p.client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode >= 200 && resp.StatusCode < 400 {
+				return nil
+			}
+			err = errors.Errorf("GET %s returned %s", p.path, resp.Status)
+		}
+		lastErr = err
+		select {
+		case <-ticker.C:
+		case <-inst.done:
+			return errors.Errorf("user code exited before it was ready")
+		case <-ctx.Done():
+			return errors.Wrapf(lastErr, "not ready after %v", p.timeout)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+func backend(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+}
+
+func get(t *testing.T, url string) string {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestProxySwitch(t *testing.T) {
+	p, err := newProxy("127.0.0.1:0", logs.NewMockLogger())
+	require.NoError(t, err)
+	defer p.Close()
+	url := "http://" + p.listener.Addr().String()
+
+	// Connections are refused until there's a backend.
+	_, err = http.Get(url)
+	require.YesError(t, err)
+
+	old, next := backend("old"), backend("new")
+	defer old.Close()
+	defer next.Close()
+	oldAddr := strings.TrimPrefix(old.URL, "http://")
+	p.setBackend(oldAddr)
+	require.Equal(t, "old", get(t, url))
+
+	// New connections go to the new backend, and the old backend can be drained
+	// once its connections are closed.
+	http.DefaultClient.CloseIdleConnections()
+	p.setBackend(strings.TrimPrefix(next.URL, "http://"))
+	require.Equal(t, "new", get(t, url))
+	drained := make(chan struct{})
+	go func() {
+		p.drain(oldAddr, time.Minute)
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(10 * time.Second):
+		t.Fatal("old backend was not drained")
+	}
+}
+
+func TestReadinessProbe(t *testing.T) {
+	var ready int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" || atomic.LoadInt32(&ready) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	inst := &instance{
+		addr: strings.TrimPrefix(server.URL, "http://"),
+		done: make(chan struct{}),
+	}
+
+	probe, err := newReadinessProbe(&pps.ReadinessProbe{
+		Path:    "/healthz",
+		Period:  types.DurationProto(10 * time.Millisecond),
+		Timeout: types.DurationProto(100 * time.Millisecond),
+	})
+	require.NoError(t, err)
+	err = probe.wait(context.Background(), inst)
+	require.YesError(t, err)
+	require.Matches(t, "503", err.Error())
+
+	atomic.StoreInt32(&ready, 1)
+	require.NoError(t, probe.wait(context.Background(), inst))
+
+	// The probe gives up if the user code exits.
+	atomic.StoreInt32(&ready, 0)
+	probe.timeout = time.Minute
+	close(inst.done)
+	err = probe.wait(context.Background(), inst)
+	require.YesError(t, err)
+	require.Matches(t, "exited", err.Error())
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"golang.org/x/sync/errgroup"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/worker/common"
@@ -62,13 +64,16 @@ func forLatestCommit(
 }
 
 // Run will run a service pipeline until the driver is canceled.
-func Run(driver driver.Driver, logger logs.TaggedLogger) error {
-	pachClient := driver.PachClient()
-	pipelineInfo := driver.PipelineInfo()
+func Run(d driver.Driver, logger logs.TaggedLogger) error {
+	pachClient := d.PachClient()
+	pipelineInfo := d.PipelineInfo()
+	if pipelineInfo.Service != nil && pipelineInfo.Service.RollingUpdate {
+		return runRolling(d, logger)
+	}
 
 	// The serviceCtx is only used for canceling user code (due to a new output
 	// commit being ready)
-	return forLatestCommit(pachClient, driver.PipelineInfo(), logger, func(serviceCtx context.Context, commitInfo *pfs.CommitInfo) error {
+	return forLatestCommit(pachClient, d.PipelineInfo(), logger, func(serviceCtx context.Context, commitInfo *pfs.CommitInfo) error {
 		// Create a job document matching the service's output commit
 		jobInput := ppsutil.JobInput(pipelineInfo, commitInfo)
 		job, err := pachClient.CreateJob(pipelineInfo.Pipeline.Name, commitInfo.Commit, nil)
@@ -88,15 +93,23 @@ func Run(driver driver.Driver, logger logs.TaggedLogger) error {
 		logger = logger.WithData(inputs)
 
 		// TODO: do something with stats? - this isn't an output repo so there's nowhere to put them
-		_, err = driver.WithData(inputs, nil, logger, func(dir string, stats *pps.ProcessStats) error {
-			if err := driver.UpdateJobState(job.ID, pps.JobState_JOB_RUNNING, ""); err != nil {
+		_, err = d.WithData(inputs, nil, logger, func(dir string, stats *pps.ProcessStats) error {
+			if err := d.UpdateJobState(job.ID, pps.JobState_JOB_RUNNING, ""); err != nil {
 				logger.Logf("error updating job state: %+v", err)
 			}
 
+			if err := setServiceCommit(d, commitInfo.Commit); err != nil {
+				logger.Logf("error updating service commit: %+v", err)
+			}
+
+			var env []string
+			if pipelineInfo.Service != nil {
+				env = append(env, fmt.Sprintf("%s=%d", client.ServicePortEnv, pipelineInfo.Service.InternalPort))
+			}
 			eg, serviceCtx := errgroup.WithContext(serviceCtx)
 			eg.Go(func() error {
-				return driver.WithActiveData(inputs, dir, func() error {
-					return pipeline.RunUserCode(driver.WithContext(serviceCtx), logger, nil, inputs)
+				return d.WithActiveData(inputs, dir, func(activeDriver driver.Driver) error {
+					return pipeline.RunUserCode(activeDriver.WithContext(serviceCtx), logger, nil, inputs, env...)
 				})
 			})
 			if pipelineInfo.Spout != nil {
@@ -110,7 +123,7 @@ func Run(driver driver.Driver, logger logs.TaggedLogger) error {
 			// Only want to update this stuff if we were canceled due to a new commit
 			if common.IsDone(serviceCtx) {
 				// TODO: do this in a transaction
-				if err := driver.UpdateJobState(job.ID, pps.JobState_JOB_SUCCESS, ""); err != nil {
+				if err := d.UpdateJobState(job.ID, pps.JobState_JOB_SUCCESS, ""); err != nil {
 					logger.Logf("error updating job progress: %+v", err)
 				}
 				if err := pachClient.FinishCommit(commitInfo.Commit.Repo.Name, commitInfo.Commit.ID); err != nil {
@@ -122,3 +135,16 @@ func Run(driver driver.Driver, logger logs.TaggedLogger) error {
 		return err
 	})
 }
+
+// setServiceCommit records the output commit that the service is serving in
+// the pipeline's etcd info, so that it's reported by InspectPipeline.
+func setServiceCommit(d driver.Driver, commit *pfs.Commit) error {
+	_, err := d.NewSTM(func(stm col.STM) error {
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		return d.Pipelines().ReadWrite(stm).Update(d.PipelineInfo().Pipeline.Name, pipelinePtr, func() error {
+			pipelinePtr.ServiceCommit = commit
+			return nil
+		})
+	})
+	return errors.EnsureStack(err)
+}
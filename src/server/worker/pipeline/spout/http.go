@@ -0,0 +1,116 @@
+package spout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+// httpSource accepts messages that are POSTed or PUT to the worker. The
+// request's path is the path of the file that the body is written to, a
+// unique name is generated if the path is empty. Requests don't return until
+// the message has been committed, so a successful response means the message
+// is durably stored in the output repo. Bodies larger than maxBytes, the
+// spout's batch size, are rejected.
+type httpSource struct {
+	listener net.Listener
+	maxBytes int64
+	server   *http.Server
+	msgs     chan *message
+	errs     chan error
+}
+
+type httpResult struct {
+	commit *pfs.Commit
+	err    error
+}
+
+func newHTTPSource(addr string, maxBytes int64) (source, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	s := &httpSource{
+		listener: listener,
+		maxBytes: maxBytes,
+		msgs:     make(chan *message),
+		errs:     make(chan error, 1),
+	}
+	s.server = &http.Server{Handler: http.HandlerFunc(s.handle)}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.errs <- errors.EnsureStack(err)
+		}
+	}()
+	return s, nil
+}
+
+func (s *httpSource) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		http.Error(w, "only POST and PUT are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, s.maxBytes))
+	if err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			http.Error(w, fmt.Sprintf("message is larger than the spout's max_bytes (%d)", s.maxBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := strings.Trim(r.URL.Path, "/")
+	if name == "" {
+		name = uuid.NewWithoutDashes()
+	}
+	result := make(chan httpResult, 1)
+	msg := &message{
+		name: name,
+		data: data,
+		done: func(commit *pfs.Commit, err error) {
+			result <- httpResult{commit: commit, err: err}
+		},
+	}
+	select {
+	case s.msgs <- msg:
+	case <-r.Context().Done():
+		return
+	}
+	select {
+	case res := <-result:
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"commit": res.commit.ID})
+	case <-r.Context().Done():
+	}
+}
+
+func (s *httpSource) Next(ctx context.Context) (*message, error) {
+	select {
+	case msg := <-s.msgs:
+		return msg, nil
+	case err := <-s.errs:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *httpSource) Marker(batch []*message) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *httpSource) Close() error {
+	return errors.EnsureStack(s.server.Close())
+}
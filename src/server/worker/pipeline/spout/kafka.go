@@ -0,0 +1,140 @@
+package spout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// kafkaOffsets maps each partition of a topic to the offset of the next
+// message to read from it, it's stored as JSON in the spout's marker.
+type kafkaOffsets map[int]int64
+
+type kafkaPosition struct {
+	partition int
+	offset    int64
+}
+
+// kafkaSource reads every partition of a Kafka topic, starting from the
+// offsets stored in the marker. Because the offsets are committed along with
+// the messages, each message is written to the output repo exactly once.
+type kafkaSource struct {
+	topic   string
+	readers []*kafka.Reader
+	cancel  func()
+	wg      sync.WaitGroup
+	msgs    chan kafka.Message
+	errs    chan error
+	offsets kafkaOffsets
+}
+
+func newKafkaSource(ctx context.Context, config *pps.KafkaSource, marker []byte, logger logs.TaggedLogger) (source, error) {
+	offsets := make(kafkaOffsets)
+	if len(marker) > 0 {
+		if err := json.Unmarshal(marker, &offsets); err != nil {
+			return nil, errors.Wrapf(err, "could not parse kafka offsets from the spout marker")
+		}
+	}
+	var partitions []kafka.Partition
+	var err error
+	for _, broker := range config.Brokers {
+		if partitions, err = kafka.LookupPartitions(ctx, "tcp", broker, config.Topic); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not look up the partitions of kafka topic %q", config.Topic)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s := &kafkaSource{
+		topic:   config.Topic,
+		cancel:  cancel,
+		msgs:    make(chan kafka.Message),
+		errs:    make(chan error, len(partitions)),
+		offsets: offsets,
+	}
+	for _, partition := range partitions {
+		reader := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:   config.Brokers,
+			Topic:     config.Topic,
+			Partition: partition.ID,
+			MinBytes:  1,
+			MaxBytes:  10e6,
+		})
+		offset, ok := offsets[partition.ID]
+		if !ok {
+			offset = kafka.FirstOffset
+		}
+		if err := reader.SetOffset(offset); err != nil {
+			s.Close()
+			return nil, errors.EnsureStack(err)
+		}
+		logger.Logf("reading kafka topic %q partition %d from offset %d", config.Topic, partition.ID, offset)
+		s.readers = append(s.readers, reader)
+		s.wg.Add(1)
+		go s.read(ctx, reader)
+	}
+	return s, nil
+}
+
+func (s *kafkaSource) read(ctx context.Context, reader *kafka.Reader) {
+	defer s.wg.Done()
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.errs <- errors.EnsureStack(err)
+			}
+			return
+		}
+		select {
+		case s.msgs <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *kafkaSource) Next(ctx context.Context) (*message, error) {
+	select {
+	case msg := <-s.msgs:
+		return &message{
+			name:     fmt.Sprintf("%s-%d-%d", s.topic, msg.Partition, msg.Offset),
+			data:     msg.Value,
+			position: kafkaPosition{partition: msg.Partition, offset: msg.Offset},
+		}, nil
+	case err := <-s.errs:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *kafkaSource) Marker(batch []*message) ([]byte, error) {
+	for _, msg := range batch {
+		pos := msg.position.(kafkaPosition)
+		if next, ok := s.offsets[pos.partition]; !ok || pos.offset+1 > next {
+			s.offsets[pos.partition] = pos.offset + 1
+		}
+	}
+	return json.Marshal(s.offsets)
+}
+
+func (s *kafkaSource) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	var retErr error
+	for _, reader := range s.readers {
+		if err := reader.Close(); err != nil && retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}
+	return retErr
+}
@@ -0,0 +1,225 @@
+package spout
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+	"github.com/pachyderm/pachyderm/src/server/worker/pipeline"
+)
+
+const (
+	defaultMaxMessages = 1000
+	defaultMaxBytes    = 64 * 1024 * 1024
+	defaultMaxDelay    = 10 * time.Second
+)
+
+// message is a single message read from a source.
+type message struct {
+	// name is the path of the file that the message is written to.
+	name string
+	data []byte
+	// position is the source specific position of the message, it's passed
+	// back to the source when computing the marker.
+	position interface{}
+	// done, if set, is called once the batch containing the message has been
+	// committed, or has failed to be committed.
+	done func(*pfs.Commit, error)
+}
+
+// source is a built-in spout source.
+type source interface {
+	// Next blocks until the next message is available.
+	Next(ctx context.Context) (*message, error)
+	// Marker returns the contents of the spout's marker once batch has been
+	// committed, or nil if the source doesn't keep track of its position.
+	Marker(batch []*message) ([]byte, error)
+	Close() error
+}
+
+// newSource creates the source configured in the pipeline's spout.
+func newSource(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, logger logs.TaggedLogger) (source, error) {
+	spout := pipelineInfo.Spout
+	switch {
+	case spout.Source.Kafka != nil:
+		marker, err := readMarker(pachClient, pipelineInfo)
+		if err != nil {
+			return nil, err
+		}
+		return newKafkaSource(pachClient.Ctx(), spout.Source.Kafka, marker, logger)
+	case spout.Source.NATS != nil:
+		return newNATSSource(spout.Source.NATS)
+	case spout.Source.HTTP != nil:
+		port := spout.Source.HTTP.Port
+		if port == 0 && spout.Service != nil {
+			port = spout.Service.InternalPort
+		}
+		_, maxBytes, _, err := batchLimits(spout.Source)
+		if err != nil {
+			return nil, err
+		}
+		return newHTTPSource(fmt.Sprintf(":%d", port), maxBytes)
+	default:
+		return nil, errors.Errorf("spout source must set kafka, nats or http")
+	}
+}
+
+// readMarker returns the contents of the spout's marker, or nil if it hasn't
+// been written yet.
+func readMarker(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) ([]byte, error) {
+	if pipelineInfo.Spout.Marker == "" {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	if err := pachClient.GetFile(pipelineInfo.Pipeline.Name, ppsconsts.SpoutMarkerBranch, pipelineInfo.Spout.Marker, 0, 0, &buf); err != nil {
+		if errutil.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// runSource reads messages from the spout's source and commits them in
+// batches until ctx is canceled or the spout is outdated.
+func runSource(ctx context.Context, pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, src source, logger logs.TaggedLogger) (retErr error) {
+	defer func() {
+		if err := src.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	maxMessages, maxBytes, maxDelay, err := batchLimits(pipelineInfo.Spout.Source)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	messages := make(chan *message)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := src.Next(ctx)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case messages <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var batch []*message
+	var batchBytes int64
+	var timer <-chan time.Time
+	for {
+		full := false
+		select {
+		case msg := <-messages:
+			if len(batch) == 0 {
+				timer = time.After(maxDelay)
+			}
+			batch = append(batch, msg)
+			batchBytes += int64(len(msg.data))
+			full = int64(len(batch)) >= maxMessages || batchBytes >= maxBytes
+		case <-timer:
+			full = true
+		case err := <-errCh:
+			finishBatch(batch, nil, err)
+			return err
+		case <-ctx.Done():
+			finishBatch(batch, nil, ctx.Err())
+			return ctx.Err()
+		}
+		if !full {
+			continue
+		}
+		commit, err := commitBatch(ctx, pachClient, pipelineInfo, src, batch, logger)
+		finishBatch(batch, commit, err)
+		if err != nil {
+			return err
+		}
+		batch, batchBytes, timer = nil, 0, nil
+	}
+}
+
+func batchLimits(source *pps.SpoutSource) (int64, int64, time.Duration, error) {
+	maxMessages, maxBytes, maxDelay := int64(defaultMaxMessages), int64(defaultMaxBytes), defaultMaxDelay
+	if source.MaxMessages > 0 {
+		maxMessages = source.MaxMessages
+	}
+	if source.MaxBytes > 0 {
+		maxBytes = source.MaxBytes
+	}
+	if source.MaxDelay != nil {
+		d, err := types.DurationFromProto(source.MaxDelay)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if d > 0 {
+			maxDelay = d
+		}
+	}
+	return maxMessages, maxBytes, maxDelay, nil
+}
+
+// commitBatch writes batch, along with the source's marker, to a new output
+// commit, retrying until it succeeds or the spout is outdated.
+func commitBatch(ctx context.Context, pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, src source, batch []*message, logger logs.TaggedLogger) (*pfs.Commit, error) {
+	var files []*pipeline.SpoutFile
+	for _, msg := range batch {
+		files = append(files, &pipeline.SpoutFile{
+			Path: msg.name,
+			Data: bytes.NewReader(msg.data),
+		})
+	}
+	marker, err := src.Marker(batch)
+	if err != nil {
+		return nil, err
+	}
+	if marker != nil && pipelineInfo.Spout.Marker != "" {
+		files = append(files, &pipeline.SpoutFile{
+			Path:      pipelineInfo.Spout.Marker,
+			Marker:    true,
+			Overwrite: true,
+			Data:      bytes.NewReader(marker),
+		})
+	}
+	var commit *pfs.Commit
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		var err error
+		commit, err = pipeline.CommitSpout(pachClient.WithCtx(ctx), pipelineInfo, files)
+		return err
+	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
+		if errors.Is(err, pipeline.ErrOutdatedSpout) {
+			return err
+		}
+		logger.Logf("error committing %d spout messages: %v; retrying in %v", len(batch), err, d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	logger.Logf("committed %d spout messages to %s", len(batch), commit.ID)
+	return commit, nil
+}
+
+func finishBatch(batch []*message, commit *pfs.Commit, err error) {
+	for _, msg := range batch {
+		if msg.done != nil {
+			msg.done(commit, err)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package spout
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// testSource is a source that returns the messages sent on its channel, its
+// marker is the number of messages that have been committed.
+type testSource struct {
+	msgs      chan *message
+	committed int
+}
+
+func (s *testSource) Next(ctx context.Context) (*message, error) {
+	select {
+	case msg := <-s.msgs:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *testSource) Marker(batch []*message) ([]byte, error) {
+	s.committed += len(batch)
+	return []byte(fmt.Sprint(s.committed)), nil
+}
+
+func (s *testSource) Close() error { return nil }
+
+func newTestSpout(t *testing.T, env *testpachd.RealEnv, source *pps.SpoutSource) *pps.PipelineInfo {
+	pipeline := "spout"
+	require.NoError(t, env.PachClient.CreateRepo(pipeline))
+	specCommit, err := env.PachClient.StartCommit(ppsconsts.SpecRepo, pipeline)
+	require.NoError(t, err)
+	require.NoError(t, env.PachClient.FinishCommit(ppsconsts.SpecRepo, specCommit.ID))
+	return &pps.PipelineInfo{
+		Pipeline:     client.NewPipeline(pipeline),
+		OutputBranch: "master",
+		SpecCommit:   specCommit,
+		Spout: &pps.Spout{
+			Marker: "marker",
+			Source: source,
+		},
+	}
+}
+
+func TestSourceBatching(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		pachClient := env.PachClient
+		pipelineInfo := newTestSpout(t, env, &pps.SpoutSource{
+			MaxMessages: 2,
+			MaxDelay:    types.DurationProto(time.Second),
+		})
+		src := &testSource{msgs: make(chan *message)}
+		ctx, cancel := context.WithCancel(env.Context)
+		defer cancel()
+		go runSource(ctx, pachClient, pipelineInfo, src, logs.NewMockLogger())
+
+		// The first two messages fill a batch, the third is committed once
+		// max_delay has passed.
+		for i := 0; i < 3; i++ {
+			src.msgs <- &message{name: fmt.Sprintf("msg-%d", i), data: []byte(fmt.Sprint(i))}
+		}
+		require.NoErrorWithinT(t, 30*time.Second, func() error {
+			for {
+				// The branch doesn't exist until the first batch is committed.
+				commitInfos, err := pachClient.ListCommit(pipelineInfo.Pipeline.Name, "master", "", 0)
+				if err != nil && !errutil.IsNotFoundError(err) {
+					return err
+				}
+				if len(commitInfos) == 2 {
+					return nil
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+		})
+		commitInfos, err := pachClient.ListCommit(pipelineInfo.Pipeline.Name, "master", "", 0)
+		require.NoError(t, err)
+		// ListCommit returns the newest commit first.
+		files, err := pachClient.ListFile(pipelineInfo.Pipeline.Name, commitInfos[1].Commit.ID, "")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(files))
+		files, err = pachClient.ListFile(pipelineInfo.Pipeline.Name, commitInfos[0].Commit.ID, "")
+		require.NoError(t, err)
+		require.Equal(t, 3, len(files))
+		var buf bytes.Buffer
+		require.NoError(t, pachClient.GetFile(pipelineInfo.Pipeline.Name, "master", "msg-2", 0, 0, &buf))
+		require.Equal(t, "2", buf.String())
+		marker, err := readMarker(pachClient, pipelineInfo)
+		require.NoError(t, err)
+		require.Equal(t, "3", string(marker))
+		return nil
+	}))
+}
+
+func TestHTTPSource(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		pachClient := env.PachClient
+		pipelineInfo := newTestSpout(t, env, &pps.SpoutSource{
+			HTTP:        &pps.HTTPSource{},
+			MaxMessages: 1,
+		})
+		src, err := newHTTPSource("127.0.0.1:0", 8)
+		require.NoError(t, err)
+		addr := src.(*httpSource).listener.Addr().String()
+		ctx, cancel := context.WithCancel(env.Context)
+		defer cancel()
+		go runSource(ctx, pachClient, pipelineInfo, src, logs.NewMockLogger())
+
+		resp, err := http.Post("http://"+addr+"/dir/foo", "text/plain", strings.NewReader("bar"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var result map[string]string
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		commitInfo, err := pachClient.InspectCommit(pipelineInfo.Pipeline.Name, "master")
+		require.NoError(t, err)
+		require.Equal(t, commitInfo.Commit.ID, result["commit"])
+		var buf bytes.Buffer
+		require.NoError(t, pachClient.GetFile(pipelineInfo.Pipeline.Name, "master", "dir/foo", 0, 0, &buf))
+		require.Equal(t, "bar", buf.String())
+
+		resp, err = http.Get("http://" + addr + "/dir/foo")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+		// Messages larger than the batch size are rejected.
+		resp, err = http.Post("http://"+addr+"/dir/big", "text/plain", strings.NewReader("0123456789"))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		return nil
+	}))
+}
+
+// serveTestNATS runs a stand-in NATS server that speaks just enough of the
+// protocol to deliver "hello" and "world" to a single client. If user is set,
+// the server requires clients to connect with it and password.
+func serveTestNATS(t *testing.T, user, password string) (string, <-chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	serverErr := make(chan error, 1)
+	go func() {
+		defer listener.Close()
+		serverErr <- func() error {
+			conn, err := listener.Accept()
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			r := bufio.NewReader(conn)
+			if _, err := fmt.Fprintf(conn, "INFO {\"server_id\":\"test\",\"auth_required\":%t}\r\n", user != ""); err != nil {
+				return err
+			}
+			expect := func(prefix string) (string, error) {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return "", err
+				}
+				if !strings.HasPrefix(line, prefix) {
+					return "", fmt.Errorf("expected %q, got %q", prefix, line)
+				}
+				return strings.TrimSpace(strings.TrimPrefix(line, prefix)), nil
+			}
+			connect, err := expect("CONNECT ")
+			if err != nil {
+				return err
+			}
+			var opts struct {
+				User string `json:"user"`
+				Pass string `json:"pass"`
+			}
+			if err := json.Unmarshal([]byte(connect), &opts); err != nil {
+				return err
+			}
+			if opts.User != user || opts.Pass != password {
+				_, err := fmt.Fprint(conn, "-ERR 'Authorization Violation'\r\n")
+				return err
+			}
+			if _, err := expect("PING"); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprint(conn, "PONG\r\n"); err != nil {
+				return err
+			}
+			if _, err := expect("SUB events workers 1"); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprint(conn, "PING\r\n"); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprint(conn, "MSG events 1 5\r\nhello\r\n"); err != nil {
+				return err
+			}
+			if _, err := expect("PONG"); err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(conn, "MSG events 1 reply 5\r\nworld\r\n")
+			if err != nil {
+				return err
+			}
+			_, err = ioutil.ReadAll(conn)
+			return err
+		}()
+	}()
+	return listener.Addr().String(), serverErr
+}
+
+func testNATSSource(t *testing.T, natsURL string, serverErr <-chan error) {
+	src, err := newNATSSource(&pps.NATSSource{
+		URL:        natsURL,
+		Subject:    "events",
+		QueueGroup: "workers",
+	})
+	require.NoError(t, err)
+	for _, expected := range []string{"hello", "world"} {
+		msg, err := src.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expected, string(msg.data))
+		require.True(t, strings.HasPrefix(msg.name, "events-"))
+	}
+	require.NoError(t, src.Close())
+	require.NoError(t, <-serverErr)
+}
+
+func TestNATSSource(t *testing.T) {
+	addr, serverErr := serveTestNATS(t, "", "")
+	testNATSSource(t, "nats://"+addr, serverErr)
+}
+
+// TestNATSSourceAuth checks that the credentials in the URL are sent to a
+// server that requires them, and that the spout fails clearly without them.
+func TestNATSSourceAuth(t *testing.T) {
+	addr, serverErr := serveTestNATS(t, "spout", "secret")
+	testNATSSource(t, "nats://spout:secret@"+addr, serverErr)
+
+	addr, serverErr = serveTestNATS(t, "spout", "secret")
+	_, err := newNATSSource(&pps.NATSSource{
+		URL:     "nats://spout:wrong@" + addr,
+		Subject: "events",
+	})
+	require.YesError(t, err)
+	require.Matches(t, "rejected the spout's credentials", err.Error())
+	require.False(t, strings.Contains(err.Error(), "wrong"))
+	require.NoError(t, <-serverErr)
+}
+
+func TestKafkaMarker(t *testing.T) {
+	s := &kafkaSource{offsets: kafkaOffsets{0: 5}}
+	marker, err := s.Marker([]*message{
+		{position: kafkaPosition{partition: 0, offset: 7}},
+		{position: kafkaPosition{partition: 0, offset: 6}},
+		{position: kafkaPosition{partition: 1, offset: 0}},
+	})
+	require.NoError(t, err)
+	var offsets kafkaOffsets
+	require.NoError(t, json.Unmarshal(marker, &offsets))
+	require.Equal(t, kafkaOffsets{0: 8, 1: 1}, offsets)
+}
@@ -12,9 +12,9 @@ import (
 )
 
 // Run will run a spout pipeline until the driver is canceled.
-func Run(driver driver.Driver, logger logs.TaggedLogger) error {
-	pachClient := driver.PachClient()
-	pipelineInfo := driver.PipelineInfo()
+func Run(d driver.Driver, logger logs.TaggedLogger) error {
+	pachClient := d.PachClient()
+	pipelineInfo := d.PipelineInfo()
 	logger = logger.WithJob("spout")
 
 	// Spouts typically have an open commit waiting for new data. So if the spout needs to be updated, and
@@ -28,16 +28,29 @@ func Run(driver driver.Driver, logger logs.TaggedLogger) error {
 		return pachClient.DeleteCommit(pipelineInfo.Pipeline.Name, c.Commit.ID)
 	})
 
+	// Spouts with a built-in source don't run user code, the worker reads the
+	// source itself.
+	if pipelineInfo.Spout.Source != nil {
+		if err := pipeline.DeleteUnfinishedSpoutCommits(pachClient, pipelineInfo); err != nil {
+			return err
+		}
+		src, err := newSource(pachClient, pipelineInfo, logger)
+		if err != nil {
+			return err
+		}
+		return runSource(pachClient.Ctx(), pachClient, pipelineInfo, src, logger)
+	}
+
 	// TODO: do something with stats?
-	_, err := driver.WithData(nil, nil, logger, func(dir string, stats *pps.ProcessStats) error {
+	_, err := d.WithData(nil, nil, logger, func(dir string, stats *pps.ProcessStats) error {
 		inputs := []*common.Input{} // Spouts take no inputs
-		return driver.WithActiveData(inputs, dir, func() error {
+		return d.WithActiveData(inputs, dir, func(activeDriver driver.Driver) error {
 			eg, serviceCtx := errgroup.WithContext(pachClient.Ctx())
 
 			// While spouts do write to output commits, the output commit changes
 			// frequently and we do not restart the user code for each one. Therefore,
 			// we leave the output commit out of the user code env.
-			eg.Go(func() error { return pipeline.RunUserCode(driver.WithContext(serviceCtx), logger, nil, inputs) })
+			eg.Go(func() error { return pipeline.RunUserCode(activeDriver.WithContext(serviceCtx), logger, nil, inputs) })
 			eg.Go(func() error { return pipeline.ReceiveSpout(serviceCtx, pachClient, pipelineInfo, logger) })
 			return eg.Wait()
 		})
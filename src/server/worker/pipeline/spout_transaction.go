@@ -0,0 +1,403 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// spoutSocketPath is the unix socket that transactional spouts use to talk to
+// the worker.
+var spoutSocketPath = filepath.Join(os.TempDir(), "pachyderm_spout.sock")
+
+// ErrOutdatedSpout is returned when a spout tries to commit data after its
+// pipeline has been updated.
+var ErrOutdatedSpout = errors.New("outdated spout, now shutting down")
+
+// spoutTransactions implements the API used by transactional spouts. User code
+// starts a transaction, writes files and the marker to it, and then commits or
+// aborts it. Writes are buffered locally until the transaction is committed,
+// the data is then written to a new output commit, and the marker to a new
+// marker commit, and both commits are finished in a single PFS transaction, so
+// either both or neither of them are visible.
+type spoutTransactions struct {
+	pachClient   *client.APIClient
+	pipelineInfo *pps.PipelineInfo
+	logger       logs.TaggedLogger
+	// cancel stops serving transactions, it's called if the spout is outdated.
+	cancel func()
+
+	mu       sync.Mutex
+	txns     map[string]*spoutTransaction
+	outdated bool
+	// commitMu serializes commits, so that each transaction's output commit is
+	// the child of the previous one.
+	commitMu sync.Mutex
+}
+
+type spoutTransaction struct {
+	mu     sync.Mutex
+	writes []*spoutWrite
+	done   bool
+}
+
+// spoutWrite is a write to a file in a transaction, the data is buffered in a
+// temporary file.
+type spoutWrite struct {
+	SpoutFile
+	f *os.File
+}
+
+// serveSpoutTransactions serves the transactional spout API on a unix socket at
+// socketPath until ctx is canceled.
+func serveSpoutTransactions(
+	ctx context.Context,
+	pachClient *client.APIClient,
+	pipelineInfo *pps.PipelineInfo,
+	logger logs.TaggedLogger,
+	socketPath string,
+) (retErr error) {
+	if err := DeleteUnfinishedSpoutCommits(pachClient, pipelineInfo); err != nil {
+		return err
+	}
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return errors.EnsureStack(err)
+	}
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	// The user code may run as a different user than the worker.
+	if err := os.Chmod(socketPath, 0777); err != nil {
+		l.Close()
+		return errors.EnsureStack(err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s := &spoutTransactions{
+		pachClient:   pachClient,
+		pipelineInfo: pipelineInfo,
+		logger:       logger,
+		cancel:       cancel,
+		txns:         make(map[string]*spoutTransaction),
+	}
+	defer s.abortAll()
+	server := &http.Server{Handler: s.router()}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	if err := server.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.EnsureStack(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.outdated {
+		return ErrOutdatedSpout
+	}
+	return errors.EnsureStack(ctx.Err())
+}
+
+func (s *spoutTransactions) router() http.Handler {
+	router := mux.NewRouter()
+	router.Methods("POST").Path("/v1/transactions").HandlerFunc(s.start)
+	router.Methods("PUT").Path("/v1/transactions/{id}/files/{path:.+}").HandlerFunc(s.write(false))
+	router.Methods("PUT").Path("/v1/transactions/{id}/marker").HandlerFunc(s.write(true))
+	router.Methods("PUT").Path("/v1/transactions/{id}/marker/{path:.+}").HandlerFunc(s.write(true))
+	router.Methods("POST").Path("/v1/transactions/{id}/commit").HandlerFunc(s.commit)
+	router.Methods("POST").Path("/v1/transactions/{id}/abort").HandlerFunc(s.abort)
+	return router
+}
+
+func (s *spoutTransactions) start(w http.ResponseWriter, r *http.Request) {
+	id := uuid.NewWithoutDashes()
+	s.mu.Lock()
+	s.txns[id] = &spoutTransaction{}
+	s.mu.Unlock()
+	writeSpoutResponse(w, map[string]string{"id": id})
+}
+
+func (s *spoutTransactions) write(marker bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		var filePath string
+		if marker {
+			if s.pipelineInfo.Spout.Marker == "" {
+				http.Error(w, "the spout doesn't have a marker", http.StatusBadRequest)
+				return
+			}
+			filePath = path.Join(s.pipelineInfo.Spout.Marker, vars["path"])
+			if filePath != s.pipelineInfo.Spout.Marker && !strings.HasPrefix(filePath, s.pipelineInfo.Spout.Marker+"/") {
+				http.Error(w, fmt.Sprintf("%q is outside of the marker", vars["path"]), http.StatusBadRequest)
+				return
+			}
+		} else {
+			filePath = path.Clean("/" + vars["path"])
+		}
+		txn, ok := s.get(vars["id"])
+		if !ok {
+			http.Error(w, fmt.Sprintf("transaction %q not found", vars["id"]), http.StatusNotFound)
+			return
+		}
+		txn.mu.Lock()
+		defer txn.mu.Unlock()
+		if txn.done {
+			http.Error(w, fmt.Sprintf("transaction %q is finished", vars["id"]), http.StatusConflict)
+			return
+		}
+		f, err := ioutil.TempFile(os.TempDir(), "pachyderm_spout_transaction")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if _, err := io.Copy(f, r.Body); err != nil {
+			f.Close()
+			os.Remove(f.Name())
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		txn.writes = append(txn.writes, &spoutWrite{
+			SpoutFile: SpoutFile{
+				Path:      filePath,
+				Marker:    marker,
+				Overwrite: marker || s.pipelineInfo.Spout.Overwrite || r.URL.Query().Get("overwrite") == "true",
+				Data:      f,
+			},
+			f: f,
+		})
+		writeSpoutResponse(w, map[string]string{})
+	}
+}
+
+func (s *spoutTransactions) commit(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	txn, ok := s.get(id)
+	if !ok {
+		http.Error(w, fmt.Sprintf("transaction %q not found", id), http.StatusNotFound)
+		return
+	}
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	if txn.done {
+		http.Error(w, fmt.Sprintf("transaction %q is finished", id), http.StatusConflict)
+		return
+	}
+	commit, err := s.commitWrites(txn.writes)
+	if err != nil {
+		// The transaction is left open, so that the commit can be retried.
+		s.logger.Logf("error committing spout transaction %s: %v", id, err)
+		if errors.Is(err, ErrOutdatedSpout) {
+			s.mu.Lock()
+			s.outdated = true
+			s.mu.Unlock()
+			s.cancel()
+			http.Error(w, err.Error(), http.StatusGone)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.finish(id, txn)
+	writeSpoutResponse(w, map[string]string{"commit": commit.ID})
+}
+
+func (s *spoutTransactions) abort(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	txn, ok := s.get(id)
+	if !ok {
+		http.Error(w, fmt.Sprintf("transaction %q not found", id), http.StatusNotFound)
+		return
+	}
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	s.finish(id, txn)
+	writeSpoutResponse(w, map[string]string{})
+}
+
+// commitWrites commits the writes of a transaction, commits are serialized so
+// that each transaction's output commit is the child of the previous one.
+func (s *spoutTransactions) commitWrites(writes []*spoutWrite) (*pfs.Commit, error) {
+	s.commitMu.Lock()
+	defer s.commitMu.Unlock()
+	var files []*SpoutFile
+	for _, write := range writes {
+		files = append(files, &write.SpoutFile)
+	}
+	return CommitSpout(s.pachClient, s.pipelineInfo, files)
+}
+
+// SpoutFile is a file written by a spout to its output commit, or to its
+// marker if Marker is set.
+type SpoutFile struct {
+	Path      string
+	Marker    bool
+	Overwrite bool
+	Data      io.ReadSeeker
+}
+
+// CommitSpout writes files to a new output commit of a spout and its marker
+// files to a new marker commit, then finishes both commits in a single PFS
+// transaction, so either both or neither of them are visible. If anything
+// fails, the commits are deleted, even if pachClient's context was canceled.
+// A spout that dies before then leaves the commits open, and they're deleted
+// by DeleteUnfinishedSpoutCommits when it restarts. ErrOutdatedSpout is
+// returned if the pipeline has been updated, in which case the spout must
+// stop.
+func CommitSpout(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, files []*SpoutFile) (_ *pfs.Commit, retErr error) {
+	repo := pipelineInfo.Pipeline.Name
+	// Check that this spout is the latest version of the pipeline by seeing if
+	// its spec commit has any children, outdated spouts must not write data.
+	spec, err := pachClient.InspectCommit(ppsconsts.SpecRepo, pipelineInfo.SpecCommit.ID)
+	if err != nil && !errutil.IsNotFoundError(err) {
+		return nil, err
+	}
+	if spec != nil && len(spec.ChildCommits) != 0 {
+		return nil, ErrOutdatedSpout
+	}
+	var commits []*pfs.Commit
+	defer func() {
+		if retErr != nil {
+			cleanupClient := withoutCancel(pachClient)
+			for _, commit := range commits {
+				cleanupClient.DeleteCommit(repo, commit.ID)
+			}
+		}
+	}()
+	commit, err := pachClient.PfsAPIClient.StartCommit(pachClient.Ctx(), &pfs.StartCommitRequest{
+		Parent:     client.NewCommit(repo, ""),
+		Branch:     pipelineInfo.OutputBranch,
+		Provenance: []*pfs.CommitProvenance{client.NewCommitProvenance(ppsconsts.SpecRepo, repo, pipelineInfo.SpecCommit.ID)},
+	})
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	commits = append(commits, commit)
+	var markerCommit *pfs.Commit
+	for _, file := range files {
+		target := commit
+		if file.Marker {
+			if markerCommit == nil {
+				markerCommit, err = pachClient.StartCommit(repo, ppsconsts.SpoutMarkerBranch)
+				if err != nil {
+					return nil, err
+				}
+				commits = append(commits, markerCommit)
+			}
+			target = markerCommit
+		}
+		if _, err := file.Data.Seek(0, 0); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if file.Overwrite {
+			_, err = pachClient.PutFileOverwrite(repo, target.ID, file.Path, file.Data, 0)
+		} else {
+			_, err = pachClient.PutFile(repo, target.ID, file.Path, file.Data)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, err := pachClient.ExecuteInTransaction(func(c *client.APIClient) error {
+		for _, commit := range commits {
+			if err := c.FinishCommit(repo, commit.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
+// DeleteUnfinishedSpoutCommits deletes the open commits at the heads of a
+// spout's output and marker branches, which are left by a spout that died
+// while committing, so that the partial data and marker aren't finished by a
+// later commit.
+func DeleteUnfinishedSpoutCommits(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
+	repo := pipelineInfo.Pipeline.Name
+	for _, branch := range []string{pipelineInfo.OutputBranch, ppsconsts.SpoutMarkerBranch} {
+		commitInfo, err := pachClient.InspectCommit(repo, branch)
+		if err != nil {
+			if errutil.IsNotFoundError(err) || pfsserver.IsNoHeadErr(err) {
+				continue
+			}
+			return err
+		}
+		if commitInfo.Finished != nil {
+			continue
+		}
+		if err := pachClient.DeleteCommit(repo, commitInfo.Commit.ID); err != nil {
+			return errors.Wrapf(err, "could not delete unfinished spout commit %s@%s", repo, commitInfo.Commit.ID)
+		}
+	}
+	return nil
+}
+
+// withoutCancel returns a client that sends the same metadata as pachClient,
+// but isn't canceled with its context, to clean up after a canceled request.
+func withoutCancel(pachClient *client.APIClient) *client.APIClient {
+	md, _ := metadata.FromOutgoingContext(pachClient.Ctx())
+	return pachClient.WithCtx(metadata.NewOutgoingContext(context.Background(), md))
+}
+
+func (s *spoutTransactions) get(id string) (*spoutTransaction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txn, ok := s.txns[id]
+	return txn, ok
+}
+
+// finish removes a transaction and its buffered data, txn.mu must be held.
+func (s *spoutTransactions) finish(id string, txn *spoutTransaction) {
+	s.mu.Lock()
+	delete(s.txns, id)
+	s.mu.Unlock()
+	txn.done = true
+	for _, write := range txn.writes {
+		write.f.Close()
+		os.Remove(write.f.Name())
+	}
+	txn.writes = nil
+}
+
+// abortAll aborts the open transactions when the server stops.
+func (s *spoutTransactions) abortAll() {
+	s.mu.Lock()
+	txns := s.txns
+	s.txns = make(map[string]*spoutTransaction)
+	s.mu.Unlock()
+	for id, txn := range txns {
+		txn.mu.Lock()
+		s.finish(id, txn)
+		txn.mu.Unlock()
+	}
+}
+
+func writeSpoutResponse(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	// The response has already started, so there's no way to report an error.
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,231 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// spoutTestClient makes requests to the transactional spout API over the
+// socket, as user code would.
+type spoutTestClient struct {
+	t      *testing.T
+	client *http.Client
+}
+
+func newSpoutTestClient(t *testing.T, socketPath string) *spoutTestClient {
+	return &spoutTestClient{
+		t: t,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+func (c *spoutTestClient) do(method string, path string, body string) (int, map[string]string) {
+	req, err := http.NewRequest(method, "http://spout"+path, strings.NewReader(body))
+	require.NoError(c.t, err)
+	resp, err := c.client.Do(req)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	require.NoError(c.t, err)
+	result := make(map[string]string)
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(c.t, json.Unmarshal(data, &result))
+	}
+	return resp.StatusCode, result
+}
+
+func (c *spoutTestClient) start() string {
+	status, resp := c.do("POST", "/v1/transactions", "")
+	require.Equal(c.t, http.StatusOK, status)
+	return resp["id"]
+}
+
+func TestSpoutTransactions(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		pachClient := env.PachClient
+		pipeline := "spout"
+		pipelineInfo := newTestSpoutPipeline(t, env, pipeline)
+
+		socketPath := filepath.Join(env.Directory, "spout.sock")
+		served := make(chan error, 1)
+		go func() {
+			served <- serveSpoutTransactions(env.Context, pachClient, pipelineInfo, logs.NewMockLogger(), socketPath)
+		}()
+		require.NoError(t, backoff.Retry(func() error {
+			_, err := os.Stat(socketPath)
+			return err
+		}, backoff.NewTestingBackOff()))
+		c := newSpoutTestClient(t, socketPath)
+
+		// A committed transaction writes its files and marker together.
+		id := c.start()
+		status, _ := c.do("PUT", "/v1/transactions/"+id+"/files/dir/a", "foo")
+		require.Equal(t, http.StatusOK, status)
+		status, _ = c.do("PUT", "/v1/transactions/"+id+"/files/dir/a", "bar")
+		require.Equal(t, http.StatusOK, status)
+		status, _ = c.do("PUT", "/v1/transactions/"+id+"/marker", "1")
+		require.Equal(t, http.StatusOK, status)
+		status, resp := c.do("POST", "/v1/transactions/"+id+"/commit", "")
+		require.Equal(t, http.StatusOK, status)
+		commitInfo, err := pachClient.InspectCommit(pipeline, "master")
+		require.NoError(t, err)
+		require.Equal(t, resp["commit"], commitInfo.Commit.ID)
+		require.NotNil(t, commitInfo.Finished)
+		var buf bytes.Buffer
+		require.NoError(t, pachClient.GetFile(pipeline, "master", "dir/a", 0, 0, &buf))
+		require.Equal(t, "foobar", buf.String())
+		buf.Reset()
+		require.NoError(t, pachClient.GetFile(pipeline, ppsconsts.SpoutMarkerBranch, "marker", 0, 0, &buf))
+		require.Equal(t, "1", buf.String())
+
+		// The transaction is finished, so it can't be written to again.
+		status, _ = c.do("PUT", "/v1/transactions/"+id+"/files/b", "baz")
+		require.Equal(t, http.StatusNotFound, status)
+
+		// An aborted transaction doesn't write anything.
+		id = c.start()
+		status, _ = c.do("PUT", "/v1/transactions/"+id+"/files/b", "baz")
+		require.Equal(t, http.StatusOK, status)
+		status, _ = c.do("PUT", "/v1/transactions/"+id+"/marker", "2")
+		require.Equal(t, http.StatusOK, status)
+		status, _ = c.do("POST", "/v1/transactions/"+id+"/abort", "")
+		require.Equal(t, http.StatusOK, status)
+		commitInfos, err := pachClient.ListCommit(pipeline, "master", "", 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(commitInfos))
+		buf.Reset()
+		require.NoError(t, pachClient.GetFile(pipeline, ppsconsts.SpoutMarkerBranch, "marker", 0, 0, &buf))
+		require.Equal(t, "1", buf.String())
+
+		// Once the pipeline is updated, the spout stops committing data.
+		id = c.start()
+		newSpecCommit, err := pachClient.StartCommit(ppsconsts.SpecRepo, pipeline)
+		require.NoError(t, err)
+		require.NoError(t, pachClient.FinishCommit(ppsconsts.SpecRepo, newSpecCommit.ID))
+		status, _ = c.do("PUT", "/v1/transactions/"+id+"/files/c", "qux")
+		require.Equal(t, http.StatusOK, status)
+		status, _ = c.do("POST", "/v1/transactions/"+id+"/commit", "")
+		require.Equal(t, http.StatusGone, status)
+		select {
+		case err := <-served:
+			require.YesError(t, err)
+			require.Matches(t, "outdated spout", err.Error())
+		case <-time.After(30 * time.Second):
+			t.Fatal("spout transactions were still served after the spout was outdated")
+		}
+		commitInfos, err = pachClient.ListCommit(pipeline, "master", "", 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(commitInfos))
+		return nil
+	}))
+}
+
+// newTestSpoutPipeline creates the output repo and spec commit of a
+// transactional spout.
+func newTestSpoutPipeline(t *testing.T, env *testpachd.RealEnv, pipeline string) *pps.PipelineInfo {
+	pachClient := env.PachClient
+	require.NoError(t, pachClient.CreateRepo(pipeline))
+	specCommit, err := pachClient.StartCommit(ppsconsts.SpecRepo, pipeline)
+	require.NoError(t, err)
+	require.NoError(t, pachClient.FinishCommit(ppsconsts.SpecRepo, specCommit.ID))
+	return &pps.PipelineInfo{
+		Pipeline:     client.NewPipeline(pipeline),
+		OutputBranch: "master",
+		SpecCommit:   specCommit,
+		Spout: &pps.Spout{
+			Marker:        "marker",
+			Transactional: true,
+		},
+	}
+}
+
+// cancelingReader cancels a request's context when it's read, as if the
+// spout were shut down while writing the file.
+type cancelingReader struct {
+	cancel func()
+}
+
+func (r *cancelingReader) Read([]byte) (int, error) {
+	r.cancel()
+	return 0, context.Canceled
+}
+
+func (r *cancelingReader) Seek(int64, int) (int64, error) {
+	return 0, nil
+}
+
+func TestCommitSpoutInterrupted(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		pachClient := env.PachClient
+		pipeline := "spout"
+		pipelineInfo := newTestSpoutPipeline(t, env, pipeline)
+
+		// The spout is shut down after both commits are started, they're
+		// deleted even though the context is canceled.
+		ctx, cancel := context.WithCancel(env.Context)
+		_, err := CommitSpout(pachClient.WithCtx(ctx), pipelineInfo, []*SpoutFile{
+			{Path: "a", Data: strings.NewReader("foo")},
+			{Path: "marker", Marker: true, Overwrite: true, Data: &cancelingReader{cancel: cancel}},
+		})
+		require.YesError(t, err)
+		for _, branch := range []string{"master", ppsconsts.SpoutMarkerBranch} {
+			commitInfos, err := pachClient.ListCommit(pipeline, branch, "", 0)
+			require.NoError(t, err)
+			require.Equal(t, 0, len(commitInfos))
+		}
+
+		// A spout that dies between starting and finishing its commits leaves
+		// them open, they're deleted when it restarts rather than finished with
+		// the next transaction.
+		commit, err := pachClient.StartCommit(pipeline, "master")
+		require.NoError(t, err)
+		_, err = pachClient.PutFile(pipeline, commit.ID, "partial", strings.NewReader("foo"))
+		require.NoError(t, err)
+		markerCommit, err := pachClient.StartCommit(pipeline, ppsconsts.SpoutMarkerBranch)
+		require.NoError(t, err)
+		_, err = pachClient.PutFile(pipeline, markerCommit.ID, "marker", strings.NewReader("1"))
+		require.NoError(t, err)
+
+		require.NoError(t, DeleteUnfinishedSpoutCommits(pachClient, pipelineInfo))
+		commit, err = CommitSpout(pachClient, pipelineInfo, []*SpoutFile{
+			{Path: "b", Data: strings.NewReader("bar")},
+			{Path: "marker", Marker: true, Overwrite: true, Data: strings.NewReader("2")},
+		})
+		require.NoError(t, err)
+		commitInfos, err := pachClient.ListCommit(pipeline, "master", "", 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(commitInfos))
+		require.Equal(t, commit.ID, commitInfos[0].Commit.ID)
+		fileInfos, err := pachClient.ListFile(pipeline, "master", "")
+		require.NoError(t, err)
+		require.Equal(t, 1, len(fileInfos))
+		require.Equal(t, "/b", fileInfos[0].File.Path)
+		var buf bytes.Buffer
+		require.NoError(t, pachClient.GetFile(pipeline, ppsconsts.SpoutMarkerBranch, "marker", 0, 0, &buf))
+		require.Equal(t, "2", buf.String())
+		return nil
+	}))
+}
@@ -69,11 +69,11 @@ func (td *testDriver) Jobs() col.Collection {
 func (td *testDriver) Pipelines() col.Collection {
 	return td.inner.Pipelines()
 }
-func (td *testDriver) NewTaskWorker() *work.Worker {
-	return td.inner.NewTaskWorker()
+func (td *testDriver) NewTaskWorker(pool string) *work.Worker {
+	return td.inner.NewTaskWorker(pool)
 }
-func (td *testDriver) NewTaskQueue() (*work.TaskQueue, error) {
-	return td.inner.NewTaskQueue()
+func (td *testDriver) NewTaskQueue(pool string) (*work.TaskQueue, error) {
+	return td.inner.NewTaskQueue(pool)
 }
 func (td *testDriver) PipelineInfo() *pps.PipelineInfo {
 	return td.inner.PipelineInfo()
@@ -99,8 +99,10 @@ func (td *testDriver) WithContext(ctx context.Context) driver.Driver {
 func (td *testDriver) WithData(inputs []*common.Input, tree *hashtree.Ordered, logger logs.TaggedLogger, cb func(string, *pps.ProcessStats) error) (*pps.ProcessStats, error) {
 	return td.inner.WithData(inputs, tree, logger, cb)
 }
-func (td *testDriver) WithActiveData(inputs []*common.Input, dir string, cb func() error) error {
-	return td.inner.WithActiveData(inputs, dir, cb)
+func (td *testDriver) WithActiveData(inputs []*common.Input, dir string, cb func(driver.Driver) error) error {
+	return td.inner.WithActiveData(inputs, dir, func(d driver.Driver) error {
+		return cb(&testDriver{d})
+	})
 }
 func (td *testDriver) UserCodeEnv(job string, commit *pfs.Commit, inputs []*common.Input) []string {
 	return td.inner.UserCodeEnv(job, commit, inputs)
@@ -111,6 +113,12 @@ func (td *testDriver) RunUserCode(logger logs.TaggedLogger, env []string, stats
 func (td *testDriver) RunUserErrorHandlingCode(logger logs.TaggedLogger, env []string, stats *pps.ProcessStats, d *types.Duration) error {
 	return td.inner.RunUserErrorHandlingCode(logger, env, stats, d)
 }
+func (td *testDriver) Preempt() {
+	td.inner.Preempt()
+}
+func (td *testDriver) Preempted() bool {
+	return td.inner.Preempted()
+}
 func (td *testDriver) DeleteJob(stm col.STM, ji *pps.EtcdJobInfo) error {
 	return td.inner.DeleteJob(stm, ji)
 }
@@ -136,7 +144,7 @@ func (td *testDriver) WithDatumCache(cb func(*hashtree.MergeCache, *hashtree.Mer
 	return td.inner.WithDatumCache(cb)
 }
 
-func (td *testDriver) Egress(commit *pfs.Commit, egressURL string) error {
+func (td *testDriver) Egress(commit *pfs.Commit, egress *pps.Egress, status *pps.EgressStatus) error {
 	return nil
 }
 
@@ -0,0 +1,138 @@
+package transform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+)
+
+// skippedPrefix starts the description of output commits that were skipped
+// because the pipeline's condition didn't hold.
+const skippedPrefix = "skipped: "
+
+// skipCommit finishes commitInfo without creating a job if the pipeline's
+// condition doesn't hold for its input commits, and returns true if it did.
+// Commits that already have a job are never skipped, so that a restarted
+// master continues the jobs it had started.
+func (reg *registry) skipCommit(commitInfo *pfs.CommitInfo, statsCommit *pfs.Commit) (bool, error) {
+	pipelineInfo := reg.driver.PipelineInfo()
+	if pipelineInfo.Condition == nil {
+		return false, nil
+	}
+	pachClient := reg.driver.PachClient()
+	jobInfos, err := pachClient.ListJob("", nil, commitInfo.Commit, -1, false)
+	if err != nil {
+		return false, err
+	}
+	if len(jobInfos) > 0 {
+		return false, nil
+	}
+	reason, err := checkCondition(pachClient, pipelineInfo, commitInfo)
+	if err != nil {
+		return false, err
+	}
+	if reason == "" {
+		return false, nil
+	}
+	reg.logger.Logf("skipping output commit %q: %s", commitInfo.Commit.ID, reason)
+	if statsCommit != nil {
+		if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
+			Commit: statsCommit,
+			Empty:  true,
+		}); err != nil && !pfsserver.IsCommitFinishedErr(err) {
+			return false, err
+		}
+	}
+	// The output commit is finished without any writes, so it has the same
+	// contents as its parent and downstream pipelines don't treat it as the
+	// output of a failed job.
+	if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
+		Commit:      commitInfo.Commit,
+		Description: skippedPrefix + reason,
+	}); err != nil && !pfsserver.IsCommitFinishedErr(err) {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkCondition returns the reason the output commit commitInfo should be
+// skipped, or "" if the pipeline's condition holds for its input commits.
+func checkCondition(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, commitInfo *pfs.CommitInfo) (string, error) {
+	condition := pipelineInfo.Condition
+	var reasons []string
+	var checked, passed bool
+	var visitErr error
+	pps.VisitInput(ppsutil.JobInput(pipelineInfo, commitInfo), func(input *pps.Input) {
+		name, commit := ppsutil.InputCommit(input)
+		if visitErr != nil || commit == nil || (condition.Input != "" && name != condition.Input) {
+			return
+		}
+		checked = true
+		reason, err := checkInputCommit(pachClient, condition, commit)
+		if err != nil {
+			visitErr = err
+			return
+		}
+		if reason == "" {
+			passed = true
+		} else {
+			reasons = append(reasons, fmt.Sprintf("%s %s", name, reason))
+		}
+	})
+	if visitErr != nil {
+		return "", visitErr
+	}
+	if !checked {
+		if condition.Input != "" {
+			return fmt.Sprintf("input %s has no commit", condition.Input), nil
+		}
+		return "no input commits", nil
+	}
+	// If the condition doesn't name an input, it holds if any input commit
+	// passes.
+	if passed {
+		return "", nil
+	}
+	return strings.Join(reasons, ", "), nil
+}
+
+// checkInputCommit returns the reason commit fails the condition, or "" if it
+// passes.
+func checkInputCommit(pachClient *client.APIClient, condition *pps.PipelineCondition, commit *pfs.Commit) (string, error) {
+	commitInfo, err := pachClient.InspectCommit(commit.Repo.Name, commit.ID)
+	if err != nil {
+		return "", err
+	}
+	if condition.MinSizeBytes != 0 && commitInfo.SizeBytes < condition.MinSizeBytes {
+		return fmt.Sprintf("is smaller than %d bytes", condition.MinSizeBytes), nil
+	}
+	if condition.MaxSizeBytes != 0 && commitInfo.SizeBytes > condition.MaxSizeBytes {
+		return fmt.Sprintf("is larger than %d bytes", condition.MaxSizeBytes), nil
+	}
+	if condition.Description != "" {
+		matched, err := regexp.MatchString(condition.Description, commitInfo.Description)
+		if err != nil {
+			return "", errors.EnsureStack(err)
+		}
+		if !matched {
+			return fmt.Sprintf("description doesn't match %q", condition.Description), nil
+		}
+	}
+	if condition.Glob != "" {
+		fileInfos, err := pachClient.GlobFile(commit.Repo.Name, commit.ID, condition.Glob)
+		if err != nil {
+			return "", err
+		}
+		if len(fileInfos) == 0 {
+			return fmt.Sprintf("has no files matching %q", condition.Glob), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,124 @@
+package transform
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestSkipCommit(t *testing.T) {
+	pi := defaultPipelineInfo()
+	pi.Condition = &pps.PipelineCondition{Glob: "/*.csv"}
+	require.NoError(t, withTestEnv(pi, func(env *testEnv) error {
+		c := env.PachClient
+		env.MockPachd.PPS.ListJobStream.Use(func(*pps.ListJobRequest, pps.API_ListJobStreamServer) error {
+			return nil
+		})
+		require.NoError(t, c.CreateRepo("inputRepo"))
+		require.NoError(t, c.CreateRepo(pi.Pipeline.Name))
+		require.NoError(t, c.CreateBranch(pi.Pipeline.Name, "master", "", []*pfs.Branch{
+			client.NewBranch("inputRepo", "master"),
+		}))
+		reg := &registry{driver: env.driver, logger: env.logger}
+		outputCommit := func(file string) *pfs.CommitInfo {
+			_, err := c.PutFile("inputRepo", "master", file, strings.NewReader(file))
+			require.NoError(t, err)
+			commitInfo, err := c.InspectCommit(pi.Pipeline.Name, "master")
+			require.NoError(t, err)
+			return commitInfo
+		}
+
+		commitInfo := outputCommit("a.csv")
+		skipped, err := reg.skipCommit(commitInfo, nil)
+		require.NoError(t, err)
+		require.False(t, skipped)
+		require.NoError(t, c.FinishCommit(pi.Pipeline.Name, commitInfo.Commit.ID))
+
+		// The input commit still matches the glob.
+		commitInfo = outputCommit("b.txt")
+		skipped, err = reg.skipCommit(commitInfo, nil)
+		require.NoError(t, err)
+		require.False(t, skipped)
+		_, err = c.PutFile(pi.Pipeline.Name, commitInfo.Commit.ID, "out", strings.NewReader("out"))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(pi.Pipeline.Name, commitInfo.Commit.ID))
+		parentInfo, err := c.InspectCommit(pi.Pipeline.Name, commitInfo.Commit.ID)
+		require.NoError(t, err)
+
+		// Skipped commits are finished with the parent's contents.
+		require.NoError(t, c.DeleteFile("inputRepo", "master", "a.csv"))
+		commitInfo, err = c.InspectCommit(pi.Pipeline.Name, "master")
+		require.NoError(t, err)
+		skipped, err = reg.skipCommit(commitInfo, nil)
+		require.NoError(t, err)
+		require.True(t, skipped)
+		commitInfo, err = c.InspectCommit(pi.Pipeline.Name, commitInfo.Commit.ID)
+		require.NoError(t, err)
+		require.NotNil(t, commitInfo.Finished)
+		require.Equal(t, parentInfo.Commit.ID, commitInfo.ParentCommit.ID)
+		require.Equal(t, parentInfo.Tree, commitInfo.Tree)
+		require.Equal(t, parentInfo.SizeBytes, commitInfo.SizeBytes)
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(pi.Pipeline.Name, commitInfo.Commit.ID, "out", 0, 0, &buf))
+		require.Equal(t, "out", buf.String())
+		require.Equal(t, `skipped: inputRepo has no files matching "/*.csv"`, commitInfo.Description)
+		return nil
+	}))
+}
+
+func TestCheckCondition(t *testing.T) {
+	pi := defaultPipelineInfo()
+	pi.Input = client.NewCrossInput(
+		client.NewPFSInput("left", "/*"),
+		client.NewPFSInput("right", "/*"),
+	)
+	pps.VisitInput(pi.Input, func(input *pps.Input) {
+		if input.Pfs != nil {
+			input.Pfs.Name = input.Pfs.Repo
+			input.Pfs.Branch = "master"
+		}
+	})
+	require.NoError(t, withTestEnv(pi, func(env *testEnv) error {
+		c := env.PachClient
+		var provenance []*pfs.CommitProvenance
+		for _, repo := range []string{"left", "right"} {
+			require.NoError(t, c.CreateRepo(repo))
+			commit, err := c.StartCommit(repo, "master")
+			require.NoError(t, err)
+			_, err = c.PutFile(repo, commit.ID, "file", strings.NewReader(repo))
+			require.NoError(t, err)
+			_, err = c.PfsAPIClient.FinishCommit(c.Ctx(), &pfs.FinishCommitRequest{
+				Commit:      commit,
+				Description: "from " + repo,
+			})
+			require.NoError(t, err)
+			provenance = append(provenance, client.NewCommitProvenance(repo, "master", commit.ID))
+		}
+		commitInfo := &pfs.CommitInfo{Provenance: provenance}
+		check := func(condition *pps.PipelineCondition) string {
+			pi.Condition = condition
+			reason, err := checkCondition(c, pi, commitInfo)
+			require.NoError(t, err)
+			return reason
+		}
+
+		// Without an input, the condition holds if any input passes.
+		require.Equal(t, "", check(&pps.PipelineCondition{Description: "left"}))
+		require.Equal(t, `left description doesn't match "middle", right description doesn't match "middle"`,
+			check(&pps.PipelineCondition{Description: "middle"}))
+		require.Equal(t, "right description doesn't match \"^from left$\"",
+			check(&pps.PipelineCondition{Input: "right", Description: "^from left$"}))
+		require.Equal(t, "", check(&pps.PipelineCondition{Input: "right", Glob: "/file"}))
+		require.Equal(t, "right is smaller than 1000 bytes",
+			check(&pps.PipelineCondition{Input: "right", MinSizeBytes: 1000}))
+		require.Equal(t, "left is larger than 1 bytes",
+			check(&pps.PipelineCondition{Input: "left", MaxSizeBytes: 1}))
+		require.Equal(t, "input missing has no commit", check(&pps.PipelineCondition{Input: "missing", Glob: "*"}))
+		return nil
+	}))
+}
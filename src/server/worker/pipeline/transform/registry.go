@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/types"
 	"golang.org/x/sync/errgroup"
 
@@ -34,16 +35,12 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/worker/pipeline/transform/chain"
 )
 
-func jobTagPrefix(jobID string) string {
-	return fmt.Sprintf("job-%s", jobID)
-}
-
 func jobHashtreesTag(jobID string) string {
-	return fmt.Sprintf("%s-hashtrees", jobTagPrefix(jobID))
+	return fmt.Sprintf("%s-hashtrees", common.JobTagPrefix(jobID))
 }
 
 func jobRecoveredDatumTagsTag(jobID string) string {
-	return fmt.Sprintf("%s-recovered", jobTagPrefix(jobID))
+	return fmt.Sprintf("%s-recovered", common.JobTagPrefix(jobID))
 }
 
 type pendingJob struct {
@@ -69,6 +66,11 @@ type registry struct {
 	concurrency int64
 	limiter     limit.ConcurrencyLimiter
 	jobChain    chain.JobChain
+
+	// poolQueues are the task queues of the pipeline's worker pools, by name,
+	// and router picks the pool that processes each datum.
+	poolQueues map[string]*work.TaskQueue
+	router     *workerPoolRouter
 }
 
 type hasher struct {
@@ -91,11 +93,23 @@ func newRegistry(
 		return nil, err
 	}
 
-	taskQueue, err := driver.NewTaskQueue()
+	taskQueue, err := driver.NewTaskQueue("")
 	if err != nil {
 		return nil, err
 	}
 
+	pools := driver.PipelineInfo().WorkerPools
+	router, err := newWorkerPoolRouter(pools)
+	if err != nil {
+		return nil, err
+	}
+	poolQueues := make(map[string]*work.TaskQueue)
+	for _, pool := range pools {
+		if poolQueues[pool.Name], err = driver.NewTaskQueue(pool.Name); err != nil {
+			return nil, err
+		}
+	}
+
 	return &registry{
 		driver:      driver,
 		logger:      logger,
@@ -103,9 +117,22 @@ func newRegistry(
 		taskQueue:   taskQueue,
 		limiter:     limit.New(int(concurrency)),
 		jobChain:    nil,
+		poolQueues:  poolQueues,
+		router:      router,
 	}, nil
 }
 
+// workerPool returns the spec of the worker pool named pool, or nil for the
+// pipeline's regular workers.
+func (reg *registry) workerPool(pool string) *pps.WorkerPool {
+	for _, p := range reg.driver.PipelineInfo().WorkerPools {
+		if p.Name == pool {
+			return p
+		}
+	}
+	return nil
+}
+
 // Helper function for succeedJob and failJob, do not use directly
 func finishJob(
 	pipelineInfo *pps.PipelineInfo,
@@ -325,7 +352,7 @@ func forEachTag(pachClient *client.APIClient, prefix string, cb func(tag *pfs.Ta
 
 func (reg *registry) cleanJobArtifacts(job *pps.Job) error {
 	reg.logger.WithJob(job.ID).Logf("Cleaning job artifacts")
-	prefix := jobTagPrefix(job.ID)
+	prefix := common.JobTagPrefix(job.ID)
 	tags := []*pfs.Tag{}
 	objects := []*pfs.Object{}
 
@@ -357,6 +384,7 @@ func writeJobInfo(pachClient *client.APIClient, jobInfo *pps.JobInfo) error {
 		DataFailed:    jobInfo.DataFailed,
 		DataRecovered: jobInfo.DataRecovered,
 		Stats:         jobInfo.Stats,
+		EgressStatus:  jobInfo.EgressStatus,
 	})
 	return err
 }
@@ -408,103 +436,168 @@ func (reg *registry) initializeJobChain(commitInfo *pfs.CommitInfo) error {
 }
 
 // Generate a datum task (and split it up into subtasks) for the added datums
-// in the pending job.
-func (reg *registry) sendDatumTasks(ctx context.Context, pj *pendingJob, numDatums int64, subtasks chan<- *work.Task) error {
-	chunkSpec := pj.ji.ChunkSpec
-	if chunkSpec == nil {
-		chunkSpec = &pps.ChunkSpec{}
+// in the pending job. Each datum is sent to the subtasks of the worker pool
+// that processes it, by pool name ("" for the pipeline's regular workers).
+// Datums are streamed into one in-progress subtask per pool, which is sent as
+// soon as it's full.
+func (reg *registry) sendDatumTasks(ctx context.Context, pj *pendingJob, numDatums int64, subtasks map[string]chan *work.Task) error {
+	chunkers := make(map[string]*datumChunker)
+	for i := int64(0); i < numDatums; i++ {
+		inputs, index := pj.jdit.NextDatum()
+		if inputs == nil {
+			return errors.New("job datum iterator returned nil inputs")
+		}
+		pool := reg.router.route(inputs)
+		chunker, ok := chunkers[pool]
+		if !ok {
+			datumsPerTask := batchDatumsPerTask(pj.ji.ChunkSpec, numDatums, reg.concurrency)
+			if spec := reg.workerPool(pool); spec != nil {
+				datumsPerTask = poolDatumsPerTask(pj.ji.ChunkSpec, spec)
+			}
+			chunker = newDatumChunker(ctx, pj, datumsPerTask, subtasks[pool])
+			chunkers[pool] = chunker
+		}
+		if err := chunker.add(&DatumInputs{Inputs: inputs, Index: index}); err != nil {
+			return err
+		}
+	}
+	for _, chunker := range chunkers {
+		if err := chunker.finish(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// datumChunker splits the datums of a worker pool into subtasks for the
+// pool's 'concurrency' workers.
+type datumChunker struct {
+	ctx           context.Context
+	pj            *pendingJob
+	driver        driver.Driver
+	subtasks      chan<- *work.Task
+	datumsPerTask int64
+	bytesPerTask  int64
+
+	datums     []*DatumInputs
+	datumsSize int64
+}
+
+func newDatumChunker(ctx context.Context, pj *pendingJob, datumsPerTask int64, subtasks chan<- *work.Task) *datumChunker {
+	return &datumChunker{
+		ctx:           ctx,
+		pj:            pj,
+		driver:        pj.driver.WithContext(ctx),
+		subtasks:      subtasks,
+		datumsPerTask: datumsPerTask,
+		bytesPerTask:  pj.ji.ChunkSpec.GetSizeBytes(),
 	}
+}
 
-	maxDatumsPerTask := int64(chunkSpec.Number)
-	maxBytesPerTask := int64(chunkSpec.SizeBytes)
-	driver := pj.driver.WithContext(ctx)
+// batchDatumsPerTask returns the number of datums in each subtask of the
+// pipeline's regular workers, which split a batch of numDatums datums between
+// their 'concurrency' workers.
+func batchDatumsPerTask(chunkSpec *pps.ChunkSpec, numDatums int64, concurrency int64) int64 {
+	maxDatumsPerTask := chunkSpec.GetNumber()
 	var numTasks int64
-	if numDatums < reg.concurrency {
+	if numDatums < concurrency {
 		numTasks = numDatums
-	} else if maxDatumsPerTask > 0 && numDatums/maxDatumsPerTask > reg.concurrency {
+	} else if maxDatumsPerTask > 0 && numDatums/maxDatumsPerTask > concurrency {
 		numTasks = numDatums / maxDatumsPerTask
 	} else {
-		numTasks = reg.concurrency
+		numTasks = concurrency
 	}
-	datumsPerTask := int64(math.Ceil(float64(numDatums) / float64(numTasks)))
-
-	datumsSize := int64(0)
-	datums := []*DatumInputs{}
+	return int64(math.Ceil(float64(numDatums) / float64(numTasks)))
+}
 
-	// finishTask will finish the currently-writing object and append it to the
-	// subtasks, then reset all the relevant variables
-	finishTask := func() error {
-		putObjectWriter, err := driver.PachClient().PutObjectAsync([]*pfs.Tag{})
-		if err != nil {
-			return err
-		}
+// poolDatumsPerTask returns the number of datums in each subtask of a worker
+// pool. Pools usually get a small share of a batch's datums, and how many
+// isn't known until the whole batch has been read, so their subtasks aren't
+// sized from the batch. Instead they have chunk_spec.number datums, or just
+// one if it's unset or the pool is for datums above a minimum size, so that
+// the datums are spread over the pool's workers.
+func poolDatumsPerTask(chunkSpec *pps.ChunkSpec, pool *pps.WorkerPool) int64 {
+	if chunkSpec.GetNumber() == 0 || pool.MinSizeBytes != 0 {
+		return 1
+	}
+	return chunkSpec.GetNumber()
+}
 
-		protoWriter := pbutil.NewWriter(putObjectWriter)
-		if _, err := protoWriter.Write(&DatumInputsList{Datums: datums}); err != nil {
-			putObjectWriter.Close()
-			return err
-		}
+// add adds datum to the current subtask, and sends the subtask if it's full.
+func (c *datumChunker) add(datum *DatumInputs) error {
+	c.datums = append(c.datums, datum)
 
-		if err := putObjectWriter.Close(); err != nil {
-			return err
+	// If we have enough input bytes, finish the task
+	if c.bytesPerTask != 0 {
+		for _, input := range datum.Inputs {
+			c.datumsSize += int64(input.FileInfo.SizeBytes)
 		}
-
-		datumsObject, err := putObjectWriter.Object()
-		if err != nil {
-			return err
+		if c.datumsSize >= c.bytesPerTask {
+			if err := c.finishTask(); err != nil {
+				return err
+			}
 		}
+	}
 
-		taskData, err := serializeDatumData(&DatumData{Datums: datumsObject, OutputCommit: pj.ji.OutputCommit, JobID: pj.ji.Job.ID})
-		if err != nil {
-			return err
-		}
+	// If we hit the upper threshold for task size, finish the task
+	if int64(len(c.datums)) >= c.datumsPerTask {
+		return c.finishTask()
+	}
+	return nil
+}
 
-		select {
-		case subtasks <- &work.Task{ID: uuid.NewWithoutDashes(), Data: taskData}:
-		case <-ctx.Done():
-			return ctx.Err()
-		}
+// finish sends the current subtask, if it has any datums.
+func (c *datumChunker) finish() error {
+	if len(c.datums) > 0 {
+		return c.finishTask()
+	}
+	return nil
+}
 
-		datumsSize = 0
-		datums = []*DatumInputs{}
+// finishTask will finish the currently-writing object and append it to the
+// subtasks, then reset all the relevant variables
+func (c *datumChunker) finishTask() error {
+	if len(c.datums) == 0 {
 		return nil
 	}
+	putObjectWriter, err := c.driver.PachClient().PutObjectAsync([]*pfs.Tag{})
+	if err != nil {
+		return err
+	}
 
-	// Build up chunks to be put into work tasks from the datum iterator
-	for i := int64(0); i < numDatums; i++ {
-		inputs, index := pj.jdit.NextDatum()
-		if inputs == nil {
-			return errors.New("job datum iterator returned nil inputs")
-		}
+	protoWriter := pbutil.NewWriter(putObjectWriter)
+	if _, err := protoWriter.Write(&DatumInputsList{Datums: c.datums}); err != nil {
+		putObjectWriter.Close()
+		return err
+	}
 
-		datums = append(datums, &DatumInputs{Inputs: inputs, Index: index})
+	if err := putObjectWriter.Close(); err != nil {
+		return err
+	}
 
-		// If we have enough input bytes, finish the task
-		if maxBytesPerTask != 0 {
-			for _, input := range inputs {
-				datumsSize += int64(input.FileInfo.SizeBytes)
-			}
-			if datumsSize >= maxBytesPerTask {
-				if err := finishTask(); err != nil {
-					return err
-				}
-			}
-		}
+	datumsObject, err := putObjectWriter.Object()
+	if err != nil {
+		return err
+	}
 
-		// If we hit the upper threshold for task size, finish the task
-		if int64(len(datums)) >= datumsPerTask {
-			if err := finishTask(); err != nil {
-				return err
-			}
-		}
+	taskData, err := serializeDatumData(&DatumData{
+		Datums:       datumsObject,
+		OutputCommit: c.pj.ji.OutputCommit,
+		JobID:        c.pj.ji.Job.ID,
+		NumDatums:    int64(len(c.datums)),
+	})
+	if err != nil {
+		return err
 	}
 
-	if len(datums) > 0 {
-		if err := finishTask(); err != nil {
-			return err
-		}
+	select {
+	case c.subtasks <- &work.Task{ID: uuid.NewWithoutDashes(), Data: taskData}:
+	case <-c.ctx.Done():
+		return c.ctx.Err()
 	}
 
+	c.datumsSize = 0
+	c.datums = []*DatumInputs{}
 	return nil
 }
 
@@ -524,6 +617,28 @@ func deserializeDatumData(any *types.Any) (*DatumData, error) {
 	return data, nil
 }
 
+// PendingDatums returns the number of datums that are waiting to be
+// processed, or are being processed, by the workers in the pipeline's worker
+// pool 'pool' ("" for its regular workers). Merge subtasks aren't counted, as
+// they're quick compared to processing datums.
+func PendingDatums(ctx context.Context, etcdClient *etcd.Client, etcdPrefix string, pipelineInfo *pps.PipelineInfo, pool string) (int64, error) {
+	var pending int64
+	if err := work.PendingSubtasks(ctx, etcdClient, etcdPrefix, driver.WorkerPoolNamespace(pipelineInfo, pool), func(subtask *work.Task) error {
+		if !types.Is(subtask.Data, &DatumData{}) {
+			return nil
+		}
+		data, err := deserializeDatumData(subtask.Data)
+		if err != nil {
+			return err
+		}
+		pending += data.NumDatums
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return pending, nil
+}
+
 func serializeMergeData(data *MergeData) (*types.Any, error) {
 	serialized, err := types.MarshalAny(data)
 	if err != nil {
@@ -633,6 +748,9 @@ func (reg *registry) ensureJob(
 }
 
 func (reg *registry) startJob(commitInfo *pfs.CommitInfo, statsCommit *pfs.Commit) error {
+	if skipped, err := reg.skipCommit(commitInfo, statsCommit); err != nil || skipped {
+		return err
+	}
 	if err := reg.initializeJobChain(commitInfo); err != nil {
 		return err
 	}
@@ -970,14 +1088,23 @@ func (pj *pendingJob) Iterator() (datum.Iterator, error) {
 }
 
 func (reg *registry) processJobRunning(pj *pendingJob) error {
-	pj.logger.Logf("processJobRunning creating task channel")
-	subtasks := make(chan *work.Task, 10)
+	pj.logger.Logf("processJobRunning creating task channels")
+	// Each worker pool gets its own task channel, and "" is the channel of the
+	// pipeline's regular workers
+	subtasks := map[string]chan *work.Task{"": make(chan *work.Task, 10)}
+	for pool := range reg.poolQueues {
+		subtasks[pool] = make(chan *work.Task, 10)
+	}
 
 	eg, ctx := errgroup.WithContext(reg.driver.PachClient().Ctx())
 
-	// Spawn a goroutine to emit tasks on the datum task channel
+	// Spawn a goroutine to emit tasks on the datum task channels
 	eg.Go(func() error {
-		defer close(subtasks)
+		defer func() {
+			for _, ch := range subtasks {
+				close(ch)
+			}
+		}()
 		return pj.logger.LogStep("collecting datums for tasks", func() error {
 			for {
 				numDatums, err := pj.jdit.NextBatch(ctx)
@@ -1001,42 +1128,52 @@ func (reg *registry) processJobRunning(pj *pendingJob) error {
 	statsHashtrees := []*HashtreeInfo{}
 	recoveredTags := []string{}
 
-	// Run subtasks until we are done
-	eg.Go(func() error {
-		return pj.logger.LogStep("running datum tasks", func() error {
-			return pj.taskMaster.RunSubtasksChan(
-				subtasks,
-				func(ctx context.Context, taskInfo *work.TaskInfo) error {
-					if taskInfo.State == work.State_FAILURE {
-						return errors.Errorf("datum task failed: %s", taskInfo.Reason)
-					}
+	collectFunc := func(ctx context.Context, taskInfo *work.TaskInfo) error {
+		if taskInfo.State == work.State_FAILURE {
+			return errors.Errorf("datum task failed: %s", taskInfo.Reason)
+		}
 
-					data, err := deserializeDatumData(taskInfo.Task.Data)
-					if err != nil {
-						return err
-					}
+		data, err := deserializeDatumData(taskInfo.Task.Data)
+		if err != nil {
+			return err
+		}
 
-					mutex.Lock()
-					defer mutex.Unlock()
+		mutex.Lock()
+		defer mutex.Unlock()
 
-					mergeStats(stats, data.Stats)
+		mergeStats(stats, data.Stats)
 
-					if data.ChunkHashtree != nil {
-						chunkHashtrees = append(chunkHashtrees, data.ChunkHashtree)
-					}
-					if data.StatsHashtree != nil {
-						statsHashtrees = append(statsHashtrees, data.StatsHashtree)
-					}
-					if data.RecoveredDatumsTag != "" {
-						recoveredTags = append(recoveredTags, data.RecoveredDatumsTag)
-					}
-					// propagate the stats to etcd
-					pj.saveJobStats(stats)
-					return pj.writeJobInfo()
-				},
-			)
+		if data.ChunkHashtree != nil {
+			chunkHashtrees = append(chunkHashtrees, data.ChunkHashtree)
+		}
+		if data.StatsHashtree != nil {
+			statsHashtrees = append(statsHashtrees, data.StatsHashtree)
+		}
+		if data.RecoveredDatumsTag != "" {
+			recoveredTags = append(recoveredTags, data.RecoveredDatumsTag)
+		}
+		// propagate the stats to etcd
+		pj.saveJobStats(stats)
+		return pj.writeJobInfo()
+	}
+
+	// Run subtasks until we are done
+	eg.Go(func() error {
+		return pj.logger.LogStep("running datum tasks", func() error {
+			return pj.taskMaster.RunSubtasksChan(subtasks[""], collectFunc)
 		})
 	})
+	// Worker pools take their subtasks from their own task queues
+	for pool, queue := range reg.poolQueues {
+		pool, queue := pool, queue
+		eg.Go(func() error {
+			return pj.logger.LogStep(fmt.Sprintf("running datum tasks in worker pool %q", pool), func() error {
+				return queue.RunTaskBlock(ctx, func(master *work.Master) error {
+					return master.RunSubtasksChan(subtasks[pool], collectFunc)
+				})
+			})
+		})
+	}
 
 	err := eg.Wait()
 	if err != nil {
@@ -1396,6 +1533,9 @@ func failedInputs(pachClient *client.APIClient, jobInfo *pps.JobInfo) ([]string,
 		if input.Cron != nil && input.Cron.Commit != "" {
 			blockCommit(input.Cron.Name, client.NewCommit(input.Cron.Repo, input.Cron.Commit))
 		}
+		if input.ObjectStorage != nil && input.ObjectStorage.Commit != "" {
+			blockCommit(input.ObjectStorage.Name, client.NewCommit(input.ObjectStorage.Repo, input.ObjectStorage.Commit))
+		}
 		if input.Git != nil && input.Git.Commit != "" {
 			blockCommit(input.Git.Name, client.NewCommit(input.Git.Name, input.Git.Commit))
 		}
@@ -1403,21 +1543,93 @@ func failedInputs(pachClient *client.APIClient, jobInfo *pps.JobInfo) ([]string,
 	return failed, vistErr
 }
 
+// egress copies the job's output commit to each of its egress targets in turn,
+// recording the progress of each in the job's EgressStatus. Targets that were
+// already egressed, before the worker restarted, are skipped.
 func (reg *registry) egress(pj *pendingJob) error {
-	var egressFailureCount int
-	return backoff.RetryNotify(func() (retErr error) {
-		if pj.ji.Egress != nil {
-			return pj.logger.LogStep("egress upload", func() error {
-				return pj.driver.Egress(pj.ji.OutputCommit, pj.ji.Egress.URL)
+	targets := pps.EgressTargets(pj.ji.Egress)
+	if len(targets) == 0 {
+		return nil
+	}
+	if len(pj.ji.EgressStatus) != len(targets) {
+		pj.ji.EgressStatus = nil
+		for _, target := range targets {
+			pj.ji.EgressStatus = append(pj.ji.EgressStatus, &pps.EgressStatus{Target: pps.EgressTarget(target)})
+		}
+		if err := pj.writeJobInfo(); err != nil {
+			return err
+		}
+	}
+	for i, target := range targets {
+		status := pj.ji.EgressStatus[i]
+		if status.Finished != nil && status.Error == "" {
+			continue
+		}
+		status.Error = ""
+		// SQL egress is always incremental, loading the whole output commit
+		// again would load the rows of the earlier commits twice.
+		if target.Incremental || target.SQL != nil {
+			base, err := lastEgressedCommit(pj.driver.PachClient(), pj.ji.OutputCommit, status.Target)
+			if err != nil {
+				return err
+			}
+			status.BaseCommit = base
+		}
+		var egressFailureCount int
+		err := backoff.RetryNotify(func() (retErr error) {
+			return pj.logger.LogStep("egress upload to "+status.Target, func() error {
+				return pj.driver.Egress(pj.ji.OutputCommit, target, status)
 			})
+		}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
+			egressFailureCount++
+			if egressFailureCount > 3 {
+				return err
+			}
+			pj.logger.Logf("egress to %s failed: %v; retrying in %v", status.Target, err, d)
+			return nil
+		})
+		if err != nil {
+			status.Error = err.Error()
+			return err
 		}
-		return nil
-	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
-		egressFailureCount++
-		if egressFailureCount > 3 {
+		status.Finished = types.TimestampNow()
+		if err := pj.writeJobInfo(); err != nil {
 			return err
 		}
-		pj.logger.Logf("egress failed: %v; retrying in %v", err, d)
-		return nil
-	})
+	}
+	return nil
+}
+
+// lastEgressedCommit returns the output commit of the most recent successful
+// job before commit, if that job egressed it to target. Incremental egress
+// only uploads the changes since that commit, if there's no such commit the
+// whole output commit is uploaded.
+func lastEgressedCommit(pachClient *client.APIClient, commit *pfs.Commit, target string) (*pfs.Commit, error) {
+	commitInfo, err := pachClient.InspectCommit(commit.Repo.Name, commit.ID)
+	if err != nil {
+		return nil, err
+	}
+	for commitInfo.ParentCommit != nil {
+		commitInfo, err = pachClient.InspectCommit(commitInfo.ParentCommit.Repo.Name, commitInfo.ParentCommit.ID)
+		if err != nil {
+			return nil, err
+		}
+		jobInfo, err := pachClient.InspectJobOutputCommit(commitInfo.Commit.Repo.Name, commitInfo.Commit.ID, false)
+		if err != nil {
+			if errutil.IsNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+		if jobInfo.State != pps.JobState_JOB_SUCCESS {
+			continue
+		}
+		for _, status := range jobInfo.EgressStatus {
+			if status.Target == target && status.Finished != nil && status.Error == "" {
+				return commitInfo.Commit, nil
+			}
+		}
+		return nil, nil
+	}
+	return nil, nil
 }
@@ -13,7 +13,7 @@ import (
 
 // Status is a struct representing the current status of the transform worker,
 // its public interface only allows getting the status of a task and canceling
-// the currently-processing datum.
+// the currently-processing datums.
 type Status struct {
 	mutex         sync.Mutex
 	jobID         string
@@ -21,9 +21,15 @@ type Status struct {
 	queueSize     *int64
 	dataProcessed *int64
 	dataRecovered *int64
-	datum         []*pps.InputFile
-	cancel        func()
-	started       time.Time
+	// datums holds the datums being processed, there may be several if the
+	// pipeline has a datum_concurrency greater than 1.
+	datums map[*activeDatum]bool
+}
+
+type activeDatum struct {
+	datum   []*pps.InputFile
+	cancel  func()
+	started time.Time
 }
 
 func convertInputs(inputs []*common.Input) []*pps.InputFile {
@@ -74,33 +80,47 @@ func (s *Status) withStats(stats *pps.ProcessStats, queueSize *int64, dataProces
 }
 
 func (s *Status) withDatum(inputs []*common.Input, cancel func(), cb func() error) error {
+	active := &activeDatum{
+		datum:   convertInputs(inputs),
+		cancel:  cancel,
+		started: time.Now(),
+	}
 	s.withLock(func() {
-		s.datum = convertInputs(inputs)
-		s.cancel = cancel
-		s.started = time.Now()
+		if s.datums == nil {
+			s.datums = make(map[*activeDatum]bool)
+		}
+		s.datums[active] = true
 	})
 
 	defer s.withLock(func() {
-		s.datum = nil
-		s.cancel = nil
-		s.started = time.Time{}
+		delete(s.datums, active)
 	})
 
 	return cb()
 }
 
-// GetStatus returns the current WorkerStatus for the transform worker
+// GetStatus returns the current WorkerStatus for the transform worker. If
+// several datums are being processed, the one that started first is reported.
 func (s *Status) GetStatus() (*pps.WorkerStatus, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	started, err := types.TimestampProto(s.started)
+	var oldest *activeDatum
+	for active := range s.datums {
+		if oldest == nil || active.started.Before(oldest.started) {
+			oldest = active
+		}
+	}
+	if oldest == nil {
+		oldest = &activeDatum{}
+	}
+	started, err := types.TimestampProto(oldest.started)
 	if err != nil {
 		return nil, err
 	}
 	result := &pps.WorkerStatus{
 		JobID:   s.jobID,
-		Data:    s.datum,
+		Data:    oldest.datum,
 		Started: started,
 	}
 	if s.queueSize != nil {
@@ -115,15 +135,21 @@ func (s *Status) GetStatus() (*pps.WorkerStatus, error) {
 	return result, nil
 }
 
-// Cancel cancels the currently running datum if it matches the specified job and inputs
+// Cancel cancels the currently running datums that match the specified job and inputs
 func (s *Status) Cancel(jobID string, datumFilter []string) bool {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	if jobID == s.jobID && common.MatchDatum(datumFilter, s.datum) {
-		// Fields will be cleared as the worker stack unwinds
-		s.cancel()
-		return true
+	if jobID != s.jobID {
+		return false
+	}
+	canceled := false
+	for active := range s.datums {
+		if common.MatchDatum(datumFilter, active.datum) {
+			// Datums will be removed as the worker stack unwinds
+			active.cancel()
+			canceled = true
+		}
 	}
-	return false
+	return canceled
 }
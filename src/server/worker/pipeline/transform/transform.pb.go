@@ -442,14 +442,23 @@ type DatumData struct {
 	JobID        string      `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	Datums       *pfs.Object `protobuf:"bytes,2,opt,name=datums,proto3" json:"datums,omitempty"`
 	OutputCommit *pfs.Commit `protobuf:"bytes,3,opt,name=output_commit,json=outputCommit,proto3" json:"output_commit,omitempty"`
+	// num_datums is the number of datums in datums, the PPS master uses it to
+	// autoscale the pipeline.
+	NumDatums int64 `protobuf:"varint,8,opt,name=num_datums,json=numDatums,proto3" json:"num_datums,omitempty"`
 	// Outputs
-	Stats                *DatumStats   `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
-	ChunkHashtree        *HashtreeInfo `protobuf:"bytes,5,opt,name=chunk_hashtree,json=chunkHashtree,proto3" json:"chunk_hashtree,omitempty"`
-	StatsHashtree        *HashtreeInfo `protobuf:"bytes,6,opt,name=stats_hashtree,json=statsHashtree,proto3" json:"stats_hashtree,omitempty"`
-	RecoveredDatumsTag   string        `protobuf:"bytes,7,opt,name=recovered_datums_tag,json=recoveredDatumsTag,proto3" json:"recovered_datums_tag,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Stats              *DatumStats   `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	ChunkHashtree      *HashtreeInfo `protobuf:"bytes,5,opt,name=chunk_hashtree,json=chunkHashtree,proto3" json:"chunk_hashtree,omitempty"`
+	StatsHashtree      *HashtreeInfo `protobuf:"bytes,6,opt,name=stats_hashtree,json=statsHashtree,proto3" json:"stats_hashtree,omitempty"`
+	RecoveredDatumsTag string        `protobuf:"bytes,7,opt,name=recovered_datums_tag,json=recoveredDatumsTag,proto3" json:"recovered_datums_tag,omitempty"`
+	// preempted_datums are the hashes of the datums that were finished by
+	// earlier, preempted attempts at the subtask, and preempted_stats are their
+	// stats. The datums are skipped when the subtask is processed again, but
+	// they're counted with preempted_stats rather than as skipped.
+	PreemptedDatums      []string    `protobuf:"bytes,9,rep,name=preempted_datums,json=preemptedDatums,proto3" json:"preempted_datums,omitempty"`
+	PreemptedStats       *DatumStats `protobuf:"bytes,10,opt,name=preempted_stats,json=preemptedStats,proto3" json:"preempted_stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *DatumData) Reset()         { *m = DatumData{} }
@@ -506,6 +515,13 @@ func (m *DatumData) GetOutputCommit() *pfs.Commit {
 	return nil
 }
 
+func (m *DatumData) GetNumDatums() int64 {
+	if m != nil {
+		return m.NumDatums
+	}
+	return 0
+}
+
 func (m *DatumData) GetStats() *DatumStats {
 	if m != nil {
 		return m.Stats
@@ -534,6 +550,20 @@ func (m *DatumData) GetRecoveredDatumsTag() string {
 	return ""
 }
 
+func (m *DatumData) GetPreemptedDatums() []string {
+	if m != nil {
+		return m.PreemptedDatums
+	}
+	return nil
+}
+
+func (m *DatumData) GetPreemptedStats() *DatumStats {
+	if m != nil {
+		return m.PreemptedStats
+	}
+	return nil
+}
+
 type MergeData struct {
 	// Inputs
 	JobID     string          `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
@@ -648,55 +678,57 @@ func init() {
 }
 
 var fileDescriptor_21583a759eb7fa97 = []byte{
-	// 753 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x5d, 0x6e, 0xe3, 0x36,
-	0x10, 0x86, 0xff, 0xb4, 0xd1, 0xd8, 0x5e, 0xef, 0x12, 0x46, 0x61, 0x6c, 0xd1, 0xc4, 0x55, 0xb0,
-	0x80, 0xf7, 0x45, 0x72, 0x5d, 0xa0, 0x40, 0x5f, 0xb3, 0x6e, 0xb1, 0x5e, 0x6c, 0xb1, 0x29, 0x93,
-	0x87, 0xa2, 0x7d, 0x10, 0x68, 0x89, 0x96, 0x94, 0xc4, 0xa2, 0x40, 0xd2, 0x69, 0x9b, 0x3b, 0xf4,
-	0x10, 0x3d, 0x42, 0x6f, 0xd1, 0xc7, 0x9e, 0x20, 0x28, 0x7c, 0x92, 0x82, 0x43, 0xc9, 0x91, 0x8b,
-	0x00, 0x6b, 0xe4, 0xc1, 0xd0, 0xcc, 0x37, 0xc3, 0x8f, 0xc3, 0xf9, 0x86, 0x34, 0x4c, 0x15, 0x97,
-	0xb7, 0x5c, 0x06, 0xbf, 0x0a, 0x79, 0xcd, 0x65, 0x50, 0x64, 0x05, 0xbf, 0xc9, 0x72, 0x1e, 0x68,
-	0xc9, 0x72, 0xb5, 0x12, 0x72, 0xfd, 0x60, 0xf9, 0x85, 0x14, 0x5a, 0x90, 0xd3, 0x82, 0x45, 0xe9,
-	0xef, 0x31, 0x97, 0x6b, 0xdf, 0x2e, 0xf2, 0xab, 0x45, 0xfe, 0x2e, 0xf5, 0xd5, 0x30, 0x11, 0x89,
-	0xc0, 0xfc, 0xc0, 0x58, 0x76, 0xe9, 0xab, 0x61, 0x74, 0x93, 0xf1, 0x5c, 0x07, 0xc5, 0x4a, 0x99,
-	0xdf, 0xff, 0xd1, 0x42, 0x99, 0x5f, 0x89, 0x7e, 0xb9, 0x5f, 0x58, 0x24, 0xd6, 0x6b, 0x91, 0x97,
-	0x1f, 0x9b, 0xe2, 0xbd, 0x87, 0xee, 0x9c, 0xe9, 0xcd, 0x7a, 0x91, 0x17, 0x1b, 0xad, 0xc8, 0x6b,
-	0x70, 0x32, 0xb4, 0x46, 0x8d, 0x71, 0x6b, 0xd2, 0x9d, 0xf5, 0xfd, 0x32, 0x1b, 0xe3, 0xb4, 0x0c,
-	0x92, 0x21, 0x74, 0xb2, 0x3c, 0xe6, 0xbf, 0x8d, 0x9a, 0xe3, 0xc6, 0xa4, 0x45, 0xad, 0xe3, 0xfd,
-	0x02, 0x83, 0x1a, 0xd7, 0x87, 0x4c, 0x69, 0xf2, 0x0e, 0x9c, 0xd8, 0x40, 0x15, 0xdf, 0xd4, 0x3f,
-	0xe0, 0xe4, 0x7e, 0x8d, 0x85, 0x96, 0xeb, 0xbd, 0x0f, 0xd0, 0x7b, 0xc7, 0x54, 0xaa, 0x25, 0xe7,
-	0x97, 0x2c, 0x51, 0xe4, 0x0b, 0x80, 0x28, 0xdd, 0xe4, 0xd7, 0xa1, 0x66, 0x89, 0x65, 0x77, 0xa9,
-	0x8b, 0x48, 0x15, 0x56, 0x9a, 0x69, 0x65, 0xc3, 0x4d, 0x1b, 0x46, 0xc4, 0x84, 0xbd, 0x37, 0x30,
-	0xa0, 0x3c, 0x12, 0xb7, 0x5c, 0xf2, 0x18, 0x77, 0x53, 0xe4, 0x33, 0x70, 0x52, 0xa6, 0x52, 0x5e,
-	0x91, 0x95, 0x9e, 0x37, 0x01, 0xb2, 0x9f, 0x8a, 0xfc, 0x04, 0xda, 0xb5, 0x8d, 0xd1, 0xf6, 0xc2,
-	0x87, 0x12, 0x17, 0xf9, 0x4a, 0x90, 0x11, 0x3c, 0x63, 0x71, 0x2c, 0xb9, 0x32, 0x69, 0x8d, 0x89,
-	0x4b, 0x2b, 0x97, 0xbc, 0x80, 0x96, 0x66, 0x09, 0x76, 0xcf, 0xa5, 0xc6, 0x24, 0xa7, 0xe0, 0x88,
-	0xe5, 0x15, 0x8f, 0xf4, 0xa8, 0x35, 0x6e, 0x4c, 0xba, 0xb3, 0xae, 0x6f, 0xc4, 0xfd, 0x88, 0x10,
-	0x2d, 0x43, 0xde, 0x9f, 0x4d, 0x00, 0x2c, 0xe1, 0xc2, 0x1c, 0x84, 0x7c, 0x03, 0xfd, 0x42, 0x8a,
-	0x88, 0x2b, 0x15, 0xe2, 0xc9, 0x70, 0x97, 0xee, 0xec, 0xa5, 0x6f, 0x26, 0xe0, 0xdc, 0x46, 0x30,
-	0x93, 0xf6, 0x8a, 0x9a, 0x47, 0xde, 0xc0, 0x0b, 0xdb, 0xd4, 0xb0, 0x84, 0x79, 0x5c, 0x0a, 0x39,
-	0xb0, 0xf8, 0x79, 0x05, 0x93, 0xd7, 0xf0, 0xbc, 0x4c, 0x55, 0xd7, 0x59, 0x51, 0xf0, 0x18, 0xcb,
-	0x6b, 0xd1, 0xbe, 0x45, 0x2f, 0x2c, 0x48, 0x4e, 0xa1, 0x04, 0xc2, 0x15, 0xcb, 0x6e, 0x78, 0x3c,
-	0xea, 0x60, 0x56, 0xcf, 0x82, 0xdf, 0x23, 0x56, 0xdb, 0x56, 0x56, 0xfd, 0x1c, 0x39, 0xf5, 0x6d,
-	0x77, 0x6d, 0x26, 0xdf, 0xc2, 0xc0, 0x12, 0x85, 0x18, 0x09, 0xb3, 0x78, 0x74, 0x64, 0x7a, 0x75,
-	0xf6, 0x72, 0x7b, 0x7f, 0xd2, 0xb7, 0x7c, 0x76, 0x48, 0xe6, 0xb4, 0xbf, 0xaa, 0xb9, 0xb1, 0xf7,
-	0x57, 0x0b, 0x5c, 0xb4, 0xe7, 0x4c, 0x33, 0x32, 0x06, 0xe7, 0x4a, 0x2c, 0xcd, 0x7a, 0x54, 0xe0,
-	0xcc, 0xdd, 0xde, 0x9f, 0x74, 0xde, 0x8b, 0xe5, 0x62, 0x4e, 0x3b, 0x57, 0x62, 0xb9, 0x30, 0xa5,
-	0x57, 0x13, 0xda, 0x7c, 0xa4, 0xf1, 0x36, 0x44, 0xa6, 0xd0, 0x17, 0x1b, 0x5d, 0x6c, 0x74, 0x68,
-	0xae, 0x43, 0xb6, 0x2f, 0xd2, 0x5b, 0x84, 0x68, 0xcf, 0x66, 0x58, 0x8f, 0x7c, 0x07, 0x1d, 0xab,
-	0x49, 0x1b, 0x33, 0x83, 0xc3, 0xe7, 0xde, 0x2a, 0x66, 0x57, 0x93, 0x9f, 0xe0, 0xb9, 0x9d, 0xf2,
-	0xb4, 0x1c, 0x2c, 0xec, 0x6c, 0x77, 0xf6, 0xd5, 0x41, 0x7c, 0xf5, 0x69, 0xa4, 0x7d, 0x24, 0xaa,
-	0x20, 0xc3, 0x6c, 0x2f, 0xc8, 0x8e, 0xd9, 0x79, 0x32, 0x33, 0x12, 0xed, 0x98, 0xa7, 0x30, 0xdc,
-	0x09, 0x1c, 0x96, 0x8a, 0x9b, 0x69, 0x7f, 0x86, 0xd3, 0x4e, 0xe4, 0xfe, 0xbd, 0xbb, 0x64, 0x89,
-	0xf7, 0x47, 0x13, 0xdc, 0x1f, 0xb8, 0x4c, 0xf8, 0x81, 0x9a, 0x7d, 0x04, 0xb7, 0xaa, 0xda, 0xde,
-	0xed, 0x27, 0x95, 0xfd, 0xc0, 0x61, 0x86, 0xa0, 0x60, 0x92, 0xe7, 0x8f, 0xdf, 0x3e, 0x1b, 0x32,
-	0x8f, 0x9e, 0x4a, 0x99, 0x8c, 0x51, 0xd2, 0x16, 0xb5, 0x0e, 0xa2, 0x28, 0xb4, 0x11, 0xe6, 0xa8,
-	0xd2, 0xed, 0x04, 0xda, 0xb5, 0x9e, 0xee, 0xd1, 0x61, 0x80, 0x7c, 0x0e, 0xae, 0xf9, 0x86, 0x2a,
-	0xbb, 0xe3, 0xd8, 0x99, 0x36, 0x3d, 0x32, 0xc0, 0x45, 0x76, 0xc7, 0xcf, 0x7e, 0xfc, 0x7b, 0x7b,
-	0xdc, 0xf8, 0x67, 0x7b, 0xdc, 0xf8, 0x77, 0x7b, 0xdc, 0xf8, 0xf9, 0x6d, 0x92, 0xe9, 0x74, 0xb3,
-	0x34, 0x2f, 0x71, 0xb0, 0x3b, 0x64, 0xcd, 0x52, 0x32, 0x0a, 0x3e, 0xf5, 0x0f, 0xb4, 0x74, 0xf0,
-	0xb9, 0xff, 0xfa, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x06, 0x53, 0x61, 0xb2, 0xac, 0x06, 0x00,
-	0x00,
+	// 794 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xcd, 0x6e, 0xdc, 0x36,
+	0x10, 0xc6, 0xfe, 0x29, 0xd6, 0xac, 0xd7, 0x9b, 0x10, 0x46, 0x21, 0xa4, 0xa8, 0xed, 0xca, 0x08,
+	0xb0, 0xb9, 0x48, 0xae, 0x0b, 0x14, 0xe8, 0xd5, 0x71, 0x8b, 0x38, 0x48, 0x91, 0x94, 0xce, 0x21,
+	0x68, 0x0f, 0x02, 0x57, 0xe2, 0x4a, 0xb2, 0xbd, 0xa2, 0x40, 0x72, 0xd3, 0x36, 0xef, 0xd0, 0x87,
+	0xe8, 0xdb, 0xf4, 0xd8, 0x4b, 0xaf, 0x41, 0xb1, 0x4f, 0x52, 0x70, 0x48, 0x69, 0xb5, 0x85, 0x81,
+	0x2e, 0x7c, 0x10, 0xc4, 0xf9, 0x66, 0xe6, 0x23, 0xf9, 0xcd, 0x8c, 0x04, 0x67, 0x8a, 0xcb, 0x0f,
+	0x5c, 0xc6, 0xbf, 0x08, 0x79, 0xcb, 0x65, 0x5c, 0x97, 0x35, 0xbf, 0x2b, 0x2b, 0x1e, 0x6b, 0xc9,
+	0x2a, 0xb5, 0x10, 0x72, 0xb9, 0x59, 0x45, 0xb5, 0x14, 0x5a, 0x90, 0xd3, 0x9a, 0xa5, 0xc5, 0x6f,
+	0x19, 0x97, 0xcb, 0xc8, 0x26, 0x45, 0x4d, 0x52, 0xd4, 0x86, 0x3e, 0x3d, 0xcc, 0x45, 0x2e, 0x30,
+	0x3e, 0x36, 0x2b, 0x9b, 0xfa, 0xf4, 0x30, 0xbd, 0x2b, 0x79, 0xa5, 0xe3, 0x7a, 0xa1, 0xcc, 0xf3,
+	0x5f, 0xb4, 0x56, 0xe6, 0x71, 0xe8, 0x97, 0xdb, 0x07, 0x4b, 0xc5, 0x72, 0x29, 0x2a, 0xf7, 0xb2,
+	0x21, 0xe1, 0x2b, 0x18, 0x5f, 0x32, 0xbd, 0x5a, 0x5e, 0x55, 0xf5, 0x4a, 0x2b, 0xf2, 0x0c, 0xbc,
+	0x12, 0x57, 0x41, 0xef, 0x64, 0x30, 0x1b, 0x9f, 0x4f, 0x22, 0x17, 0x8d, 0x7e, 0xea, 0x9c, 0xe4,
+	0x10, 0x46, 0x65, 0x95, 0xf1, 0x5f, 0x83, 0xfe, 0x49, 0x6f, 0x36, 0xa0, 0xd6, 0x08, 0x7f, 0x86,
+	0x69, 0x87, 0xeb, 0x75, 0xa9, 0x34, 0x79, 0x09, 0x5e, 0x66, 0xa0, 0x86, 0xef, 0x2c, 0xda, 0xe1,
+	0xe6, 0x51, 0x87, 0x85, 0xba, 0xfc, 0xf0, 0x35, 0xec, 0xbf, 0x64, 0xaa, 0xd0, 0x92, 0xf3, 0x77,
+	0x2c, 0x57, 0xe4, 0x0b, 0x80, 0xb4, 0x58, 0x55, 0xb7, 0x89, 0x66, 0xb9, 0x65, 0xf7, 0xa9, 0x8f,
+	0x48, 0xe3, 0x56, 0x9a, 0x69, 0x65, 0xdd, 0x7d, 0xeb, 0x46, 0xc4, 0xb8, 0xc3, 0xe7, 0x30, 0xa5,
+	0x3c, 0x15, 0x1f, 0xb8, 0xe4, 0x19, 0xee, 0xa6, 0xc8, 0x67, 0xe0, 0x15, 0x4c, 0x15, 0xbc, 0x21,
+	0x73, 0x56, 0x38, 0x03, 0xb2, 0x1d, 0x8a, 0xfc, 0x04, 0x86, 0x9d, 0x8d, 0x71, 0x1d, 0x26, 0x9b,
+	0x23, 0x5e, 0x55, 0x0b, 0x41, 0x02, 0x78, 0xc4, 0xb2, 0x4c, 0x72, 0x65, 0xc2, 0x7a, 0x33, 0x9f,
+	0x36, 0x26, 0x79, 0x0c, 0x03, 0xcd, 0x72, 0x54, 0xcf, 0xa7, 0x66, 0x49, 0x4e, 0xc1, 0x13, 0xf3,
+	0x1b, 0x9e, 0xea, 0x60, 0x70, 0xd2, 0x9b, 0x8d, 0xcf, 0xc7, 0x91, 0x29, 0xee, 0x1b, 0x84, 0xa8,
+	0x73, 0x85, 0x7f, 0xf4, 0x01, 0xf0, 0x08, 0xd7, 0xe6, 0x22, 0xe4, 0x1b, 0x98, 0xd4, 0x52, 0xa4,
+	0x5c, 0xa9, 0x04, 0x6f, 0x86, 0xbb, 0x8c, 0xcf, 0x9f, 0x44, 0xa6, 0x03, 0xde, 0x5a, 0x0f, 0x46,
+	0xd2, 0xfd, 0xba, 0x63, 0x91, 0xe7, 0xf0, 0xd8, 0x8a, 0x9a, 0x38, 0x98, 0x67, 0xae, 0x90, 0x53,
+	0x8b, 0xbf, 0x6d, 0x60, 0xf2, 0x0c, 0x0e, 0x5c, 0xa8, 0xba, 0x2d, 0xeb, 0x9a, 0x67, 0x78, 0xbc,
+	0x01, 0x9d, 0x58, 0xf4, 0xda, 0x82, 0xe4, 0x14, 0x1c, 0x90, 0x2c, 0x58, 0x79, 0xc7, 0xb3, 0x60,
+	0x84, 0x51, 0xfb, 0x16, 0xfc, 0x1e, 0xb1, 0xce, 0xb6, 0xb2, 0xd1, 0x33, 0xf0, 0xba, 0xdb, 0xb6,
+	0x32, 0x93, 0x6f, 0x61, 0x6a, 0x89, 0x12, 0xf4, 0x24, 0x65, 0x16, 0xec, 0x19, 0xad, 0x2e, 0x9e,
+	0xac, 0x3f, 0x1d, 0x4f, 0x2c, 0x9f, 0x6d, 0x92, 0x4b, 0x3a, 0x59, 0x74, 0xcc, 0x2c, 0xfc, 0x7b,
+	0x08, 0x3e, 0xae, 0x2f, 0x99, 0x66, 0xe4, 0x04, 0xbc, 0x1b, 0x31, 0x37, 0xf9, 0x58, 0x81, 0x0b,
+	0x7f, 0xfd, 0xe9, 0x78, 0xf4, 0x4a, 0xcc, 0xaf, 0x2e, 0xe9, 0xe8, 0x46, 0xcc, 0xaf, 0xcc, 0xd1,
+	0x9b, 0x0e, 0xed, 0xdf, 0x23, 0xbc, 0x75, 0x91, 0x33, 0x98, 0x88, 0x95, 0xae, 0x57, 0x3a, 0x31,
+	0xe3, 0x50, 0x6e, 0x17, 0xe9, 0x05, 0x42, 0x74, 0xdf, 0x46, 0x58, 0xcb, 0xf4, 0x5f, 0xb5, 0x5a,
+	0x26, 0x8e, 0x7a, 0x0f, 0xaf, 0xe9, 0x57, 0x78, 0x2a, 0x43, 0xf8, 0x1d, 0x8c, 0x6c, 0xc9, 0x86,
+	0x48, 0x14, 0xef, 0x3e, 0x16, 0xb6, 0xa0, 0x36, 0x9b, 0xbc, 0x87, 0x03, 0x3b, 0x04, 0x85, 0xeb,
+	0x3b, 0x14, 0x7e, 0x7c, 0xfe, 0xd5, 0x4e, 0x7c, 0xdd, 0x66, 0xa5, 0x13, 0x24, 0x6a, 0x20, 0xc3,
+	0x6c, 0xe7, 0xa7, 0x65, 0xf6, 0x1e, 0xcc, 0x8c, 0x44, 0x2d, 0xf3, 0x19, 0x1c, 0xb6, 0xf5, 0x77,
+	0xfa, 0x98, 0x21, 0x0d, 0x1e, 0xe1, 0x30, 0x10, 0xb9, 0x3d, 0x96, 0xef, 0x58, 0x6e, 0x1a, 0xa7,
+	0x96, 0x9c, 0x2f, 0x6b, 0xdd, 0x66, 0x04, 0x3e, 0xce, 0xdd, 0xb4, 0xc5, 0x9d, 0xae, 0xef, 0x61,
+	0x03, 0xb9, 0xa1, 0x80, 0x87, 0x29, 0x7c, 0xd0, 0xf2, 0xa0, 0x1d, 0xfe, 0xde, 0x07, 0xff, 0x07,
+	0x2e, 0x73, 0xbe, 0x63, 0x5f, 0xbd, 0x01, 0xbf, 0x91, 0xce, 0x7e, 0x7f, 0x1e, 0xa4, 0xdd, 0x86,
+	0xc3, 0x34, 0x6a, 0xcd, 0x24, 0xaf, 0xee, 0xff, 0x42, 0x58, 0x97, 0xf9, 0x30, 0xab, 0x82, 0xc9,
+	0x0c, 0xfb, 0x6a, 0x40, 0xad, 0x81, 0x28, 0x6a, 0x61, 0xba, 0x63, 0xaf, 0x69, 0x9e, 0x63, 0x18,
+	0x76, 0x0a, 0xbb, 0x45, 0x87, 0x0e, 0xf2, 0x39, 0xf8, 0xe6, 0x9d, 0xa8, 0xf2, 0x23, 0xc7, 0xf2,
+	0x0c, 0xe9, 0x9e, 0x01, 0xae, 0xcb, 0x8f, 0xfc, 0xe2, 0xc7, 0x3f, 0xd7, 0x47, 0xbd, 0xbf, 0xd6,
+	0x47, 0xbd, 0x7f, 0xd6, 0x47, 0xbd, 0x9f, 0x5e, 0xe4, 0xa5, 0x2e, 0x56, 0x73, 0xf3, 0xb7, 0x88,
+	0xdb, 0x4b, 0x76, 0x56, 0x4a, 0xa6, 0xf1, 0xff, 0xfd, 0x25, 0xe7, 0x1e, 0xfe, 0x92, 0xbe, 0xfe,
+	0x77, 0x00, 0xec, 0x24, 0x1b, 0x80, 0x50, 0x07, 0x00, 0x00,
 }
 
 func (m *DatumInputs) Marshal() (dAtA []byte, err error) {
@@ -1046,6 +1078,32 @@ func (m *DatumData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.PreemptedStats != nil {
+		{
+			size, err := m.PreemptedStats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransform(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.PreemptedDatums) > 0 {
+		for iNdEx := len(m.PreemptedDatums) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PreemptedDatums[iNdEx])
+			copy(dAtA[i:], m.PreemptedDatums[iNdEx])
+			i = encodeVarintTransform(dAtA, i, uint64(len(m.PreemptedDatums[iNdEx])))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if m.NumDatums != 0 {
+		i = encodeVarintTransform(dAtA, i, uint64(m.NumDatums))
+		i--
+		dAtA[i] = 0x40
+	}
 	if len(m.RecoveredDatumsTag) > 0 {
 		i -= len(m.RecoveredDatumsTag)
 		copy(dAtA[i:], m.RecoveredDatumsTag)
@@ -1415,6 +1473,19 @@ func (m *DatumData) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTransform(uint64(l))
 	}
+	if m.NumDatums != 0 {
+		n += 1 + sovTransform(uint64(m.NumDatums))
+	}
+	if len(m.PreemptedDatums) > 0 {
+		for _, s := range m.PreemptedDatums {
+			l = len(s)
+			n += 1 + l + sovTransform(uint64(l))
+		}
+	}
+	if m.PreemptedStats != nil {
+		l = m.PreemptedStats.Size()
+		n += 1 + l + sovTransform(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2576,6 +2647,93 @@ func (m *DatumData) Unmarshal(dAtA []byte) error {
 			}
 			m.RecoveredDatumsTag = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumDatums", wireType)
+			}
+			m.NumDatums = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumDatums |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreemptedDatums", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreemptedDatums = append(m.PreemptedDatums, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreemptedStats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PreemptedStats == nil {
+				m.PreemptedStats = &DatumStats{}
+			}
+			if err := m.PreemptedStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTransform(dAtA[iNdEx:])
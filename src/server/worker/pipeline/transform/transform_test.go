@@ -111,7 +111,7 @@ func withWorkerSpawnerPair(pipelineInfo *pps.PipelineInfo, cb func(env *testEnv)
 
 		eg.Go(func() error {
 			err := backoff.RetryUntilCancel(env.driver.PachClient().Ctx(), func() error {
-				return env.driver.NewTaskWorker().Run(
+				return env.driver.NewTaskWorker("").Run(
 					env.driver.PachClient().Ctx(),
 					func(ctx context.Context, subtask *work.Task) error {
 						status := &Status{}
@@ -14,7 +14,6 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
-	"github.com/gogo/protobuf/types"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pachyderm/pachyderm/src/client"
@@ -38,6 +37,7 @@ import (
 
 var (
 	errDatumRecovered = errors.New("the datum errored, and the error was handled successfully")
+	errDatumPreempted = errors.New("the datum was interrupted because the worker was preempted")
 	statsTagSuffix    = "_stats"
 )
 
@@ -45,34 +45,15 @@ var (
 // off the subtask ID so we can shortcut processing if we get interrupted and
 // restarted
 func jobRecoveredDatumsTag(jobID string, subtaskID string) string {
-	return fmt.Sprintf("%s-recovered-%s", jobTagPrefix(jobID), subtaskID)
+	return fmt.Sprintf("%s-recovered-%s", common.JobTagPrefix(jobID), subtaskID)
 }
 
 func jobChunkStatsTag(jobID string, subtaskID string) string {
-	return fmt.Sprintf("%s-chunk-stats-%s", jobTagPrefix(jobID), subtaskID)
+	return fmt.Sprintf("%s-chunk-stats-%s", common.JobTagPrefix(jobID), subtaskID)
 }
 
 func jobChunkTag(jobID string, subtaskID string) string {
-	return fmt.Sprintf("%s-chunk-%s", jobTagPrefix(jobID), subtaskID)
-}
-
-func plusDuration(x *types.Duration, y *types.Duration) (*types.Duration, error) {
-	var xd time.Duration
-	var yd time.Duration
-	var err error
-	if x != nil {
-		xd, err = types.DurationFromProto(x)
-		if err != nil {
-			return nil, err
-		}
-	}
-	if y != nil {
-		yd, err = types.DurationFromProto(y)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return types.DurationProto(xd + yd), nil
+	return fmt.Sprintf("%s-chunk-%s", common.JobTagPrefix(jobID), subtaskID)
 }
 
 // mergeStats merges y into x
@@ -80,17 +61,23 @@ func mergeStats(x, y *DatumStats) error {
 	if yps := y.ProcessStats; yps != nil {
 		var err error
 		xps := x.ProcessStats
-		if xps.DownloadTime, err = plusDuration(xps.DownloadTime, yps.DownloadTime); err != nil {
+		if xps.DownloadTime, err = ppsutil.PlusDuration(xps.DownloadTime, yps.DownloadTime); err != nil {
 			return err
 		}
-		if xps.ProcessTime, err = plusDuration(xps.ProcessTime, yps.ProcessTime); err != nil {
+		if xps.ProcessTime, err = ppsutil.PlusDuration(xps.ProcessTime, yps.ProcessTime); err != nil {
 			return err
 		}
-		if xps.UploadTime, err = plusDuration(xps.UploadTime, yps.UploadTime); err != nil {
+		if xps.UploadTime, err = ppsutil.PlusDuration(xps.UploadTime, yps.UploadTime); err != nil {
+			return err
+		}
+		if xps.CpuTime, err = ppsutil.PlusDuration(xps.CpuTime, yps.CpuTime); err != nil {
 			return err
 		}
 		xps.DownloadBytes += yps.DownloadBytes
 		xps.UploadBytes += yps.UploadBytes
+		if yps.PeakMemoryBytes > xps.PeakMemoryBytes {
+			xps.PeakMemoryBytes = yps.PeakMemoryBytes
+		}
 	}
 
 	x.DatumsProcessed += y.DatumsProcessed
@@ -103,6 +90,51 @@ func mergeStats(x, y *DatumStats) error {
 	return nil
 }
 
+// finishedDatums tracks the datums that a subtask finishes, so that if the
+// worker is preempted their stats are stored with the subtask. When the
+// subtask is processed again those datums are skipped, and they're counted
+// with their stats from the preempted attempt rather than as skipped.
+type finishedDatums struct {
+	// preempted is the set of datums finished by earlier, preempted attempts
+	// at the subtask.
+	preempted map[string]bool
+	datums    []string
+	stats     *DatumStats
+}
+
+func newFinishedDatums(data *DatumData) *finishedDatums {
+	preempted := make(map[string]bool)
+	for _, tag := range data.PreemptedDatums {
+		preempted[tag] = true
+	}
+	return &finishedDatums{
+		preempted: preempted,
+		stats:     &DatumStats{ProcessStats: &pps.ProcessStats{}},
+	}
+}
+
+// add records that the datum with hash tag was processed or skipped with
+// stats. If it was finished by an earlier attempt it's already counted in
+// DatumData.PreemptedStats, so its skip is removed from stats.
+func (f *finishedDatums) add(tag string, stats *DatumStats) error {
+	if f.preempted[tag] {
+		stats.DatumsSkipped = 0
+		return nil
+	}
+	f.datums = append(f.datums, tag)
+	return mergeStats(f.stats, stats)
+}
+
+// preempt adds the finished datums and their stats to data, which is stored
+// with the subtask when the worker is preempted.
+func (f *finishedDatums) preempt(data *DatumData) error {
+	data.PreemptedDatums = append(data.PreemptedDatums, f.datums...)
+	if data.PreemptedStats == nil {
+		data.PreemptedStats = &DatumStats{ProcessStats: &pps.ProcessStats{}}
+	}
+	return mergeStats(data.PreemptedStats, f.stats)
+}
+
 // Worker handles a transform pipeline work subtask, then returns.
 func Worker(driver driver.Driver, logger logs.TaggedLogger, subtask *work.Task, status *Status) (retErr error) {
 	defer func() {
@@ -123,6 +155,14 @@ func Worker(driver driver.Driver, logger logs.TaggedLogger, subtask *work.Task,
 			if err := logger.LogStep("datum task", func() error {
 				return handleDatumTask(driver, logger, datumData, subtask.ID, status)
 			}); err != nil {
+				if errors.Is(err, work.ErrPreempted) {
+					// The subtask is stored along with the stats of the datums
+					// that were finished before the worker was preempted
+					if subtask.Data, err = serializeDatumData(datumData); err != nil {
+						return err
+					}
+					return work.ErrPreempted
+				}
 				return err
 			}
 
@@ -259,11 +299,18 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 	// TODO: check for existing tagged output files - continue with processing if any are missing
 	return driver.WithDatumCache(func(datumCache *hashtree.MergeCache, statsCache *hashtree.MergeCache) error {
 		logger.Logf("transform worker datum task: %v", data)
-		limiter := limit.New(int(driver.PipelineInfo().MaxQueueSize))
+		// The queue must be at least as long as the number of datums that can be
+		// processed concurrently.
+		maxQueueSize := driver.PipelineInfo().MaxQueueSize
+		if driver.PipelineInfo().DatumConcurrency > maxQueueSize {
+			maxQueueSize = driver.PipelineInfo().DatumConcurrency
+		}
+		limiter := limit.New(int(maxQueueSize))
 
 		// statsMutex controls access to stats so that they can be safely merged
 		statsMutex := &sync.Mutex{}
 		recoveredDatums := []string{}
+		finished := newFinishedDatums(data)
 		data.Stats = &DatumStats{
 			ProcessStats: &pps.ProcessStats{},
 		}
@@ -278,6 +325,9 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 				eg, ctx := errgroup.WithContext(ctx)
 				driver := driver.WithContext(ctx)
 				if err := forEachDatum(driver, data.Datums, func(index int64, inputs []*common.Input) error {
+					if driver.Preempted() {
+						return nil // the remaining datums are processed by another worker
+					}
 					limiter.Acquire()
 					atomic.AddInt64(&queueSize, 1)
 					eg.Go(func() error {
@@ -298,7 +348,18 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 
 						statsMutex.Lock()
 						defer statsMutex.Unlock()
+						if err == nil && len(subRecovered) == 0 && subStats.DatumsFailed == 0 {
+							tag := common.HashDatum(driver.PipelineInfo().Pipeline.Name, driver.PipelineInfo().Salt, inputs)
+							if err := finished.add(tag, subStats); err != nil {
+								return err
+							}
+						}
 						statsErr := mergeStats(data.Stats, subStats)
+						if errors.Is(err, errDatumPreempted) {
+							// Don't cancel the other datums, they may be saving
+							// their checkpoints
+							return statsErr
+						}
 						if err != nil {
 							return err
 						}
@@ -321,6 +382,19 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 		}); err != nil {
 			return err
 		}
+		if driver.Preempted() {
+			// The subtask is processed again by another worker, which skips the
+			// datums that were finished here
+			if err := finished.preempt(data); err != nil {
+				return err
+			}
+			return work.ErrPreempted
+		}
+		if data.PreemptedStats != nil {
+			if err := mergeStats(data.Stats, data.PreemptedStats); err != nil {
+				return err
+			}
+		}
 
 		if data.Stats.DatumsFailed == 0 && !driver.PipelineInfo().S3Out {
 			if len(recoveredDatums) > 0 {
@@ -423,6 +497,9 @@ func processDatum(
 		}
 		statsTree.PutFile("index", h, size, objectInfo.BlockRef)
 		defer func() {
+			if errors.Is(retErr, errDatumPreempted) {
+				return // the datum's stats are written when it's processed again
+			}
 			logger.Logf("writing stats for chunk, current err: %v", retErr)
 			if err := writeStats(driver, logger, stats.ProcessStats, inputTree, outputTree, statsTree, tag, datumStatsCache); err != nil && retErr == nil {
 				retErr = err
@@ -437,27 +514,9 @@ func processDatum(
 		// WithData will download the inputs for this datum
 		stats.ProcessStats, err = driver.WithData(inputs, inputTree, logger, func(dir string, processStats *pps.ProcessStats) error {
 
-			// WithActiveData acquires a mutex so that we don't run this section concurrently
-			if err := driver.WithActiveData(inputs, dir, func() error {
-				ctx, cancel := context.WithCancel(driver.PachClient().Ctx())
-				defer cancel()
-
-				driver := driver.WithContext(ctx)
-
-				return status.withDatum(inputs, cancel, func() error {
-					env := driver.UserCodeEnv(logger.JobID(), outputCommit, inputs)
-					if err := driver.RunUserCode(logger, env, processStats, driver.PipelineInfo().DatumTimeout); err != nil {
-						if driver.PipelineInfo().Transform.ErrCmd != nil && failures == driver.PipelineInfo().DatumTries-1 {
-							if err = driver.RunUserErrorHandlingCode(logger, env, processStats, driver.PipelineInfo().DatumTimeout); err != nil {
-								return errors.Wrap(err, "RunUserErrorHandlingCode")
-							}
-							return errDatumRecovered
-						}
-						return err
-					}
-					return nil
-				})
-			}); err != nil {
+			// WithActiveData waits for a free input directory, so that at most
+			// DatumConcurrency datums run the user code concurrently
+			if err := driver.WithActiveData(inputs, dir, runUserCode(logger, inputs, outputCommit, processStats, status, failures)); err != nil {
 				return err
 			}
 
@@ -475,6 +534,9 @@ func processDatum(
 		})
 		return err
 	}, &backoff.ZeroBackOff{}, func(err error, d time.Duration) error {
+		if errors.Is(err, errDatumPreempted) {
+			return err // preempted datums aren't retried, nor do they count as failures
+		}
 		failures++
 		if failures >= driver.PipelineInfo().DatumTries {
 			logger.Logf("failed to process datum with error: %+v", err)
@@ -503,7 +565,9 @@ func processDatum(
 		}
 		logger.Logf("failed processing datum: %v, retrying in %v", err, d)
 		return nil
-	}); errors.Is(err, errDatumRecovered) {
+	}); errors.Is(err, errDatumPreempted) {
+		return stats, recoveredDatumTags, err
+	} else if errors.Is(err, errDatumRecovered) {
 		// keep track of the recovered datums
 		recoveredDatumTags = []string{tag}
 		stats.DatumsRecovered++
@@ -516,6 +580,47 @@ func processDatum(
 	return stats, recoveredDatumTags, nil
 }
 
+// runUserCode returns the WithActiveData callback which runs the user code for
+// a datum, failures is the number of times that the datum has already failed.
+func runUserCode(
+	logger logs.TaggedLogger,
+	inputs []*common.Input,
+	outputCommit *pfs.Commit,
+	processStats *pps.ProcessStats,
+	status *Status,
+	failures int64,
+) func(driver.Driver) error {
+	return func(activeDriver driver.Driver) error {
+		ctx, cancel := context.WithCancel(activeDriver.PachClient().Ctx())
+		defer cancel()
+
+		driver := activeDriver.WithContext(ctx)
+
+		return status.withDatum(inputs, cancel, func() error {
+			if driver.Preempted() {
+				return errDatumPreempted
+			}
+			env := driver.UserCodeEnv(logger.JobID(), outputCommit, inputs)
+			err := driver.RunUserCode(logger, env, processStats, driver.PipelineInfo().DatumTimeout)
+			if driver.Preempted() {
+				// The user code may exit successfully when it's sent SIGTERM,
+				// e.g. after saving a checkpoint, but its output is incomplete
+				return errDatumPreempted
+			}
+			if err != nil {
+				if driver.PipelineInfo().Transform.ErrCmd != nil && failures == driver.PipelineInfo().DatumTries-1 {
+					if err = driver.RunUserErrorHandlingCode(logger, env, processStats, driver.PipelineInfo().DatumTimeout); err != nil {
+						return errors.Wrap(err, "RunUserErrorHandlingCode")
+					}
+					return errDatumRecovered
+				}
+				return err
+			}
+			return nil
+		})
+	}
+}
+
 func writeStats(
 	driver driver.Driver,
 	logger logs.TaggedLogger,
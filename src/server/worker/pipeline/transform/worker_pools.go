@@ -0,0 +1,66 @@
+package transform
+
+import (
+	glob "github.com/pachyderm/ohmyglob"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+)
+
+// workerPoolRouter picks the worker pool that processes each datum of a
+// pipeline.
+type workerPoolRouter struct {
+	pools []*pps.WorkerPool
+	globs []*glob.Glob
+}
+
+func newWorkerPoolRouter(pools []*pps.WorkerPool) (*workerPoolRouter, error) {
+	r := &workerPoolRouter{pools: pools}
+	for _, pool := range pools {
+		var g *glob.Glob
+		if pool.Glob != "" {
+			var err error
+			if g, err = glob.Compile(pool.Glob, '/'); err != nil {
+				return nil, errors.Wrapf(err, "error parsing worker pool %q glob", pool.Name)
+			}
+		}
+		r.globs = append(r.globs, g)
+	}
+	return r, nil
+}
+
+// route returns the name of the first worker pool that a datum with inputs
+// matches, or "" if the datum should be processed by the pipeline's regular
+// workers.
+func (r *workerPoolRouter) route(inputs []*common.Input) string {
+	if len(r.pools) == 0 {
+		return ""
+	}
+	var size uint64
+	for _, input := range inputs {
+		size += input.FileInfo.SizeBytes
+	}
+	for i, pool := range r.pools {
+		if pool.MinSizeBytes != 0 && size < pool.MinSizeBytes {
+			continue
+		}
+		if pool.MaxSizeBytes != 0 && size > pool.MaxSizeBytes {
+			continue
+		}
+		if r.globs[i] != nil && !matchesAny(r.globs[i], inputs) {
+			continue
+		}
+		return pool.Name
+	}
+	return ""
+}
+
+func matchesAny(g *glob.Glob, inputs []*common.Input) bool {
+	for _, input := range inputs {
+		if g.Match(input.FileInfo.File.Path) {
+			return true
+		}
+	}
+	return false
+}
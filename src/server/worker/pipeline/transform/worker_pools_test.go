@@ -0,0 +1,46 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+)
+
+func TestWorkerPoolRouter(t *testing.T) {
+	router, err := newWorkerPoolRouter([]*pps.WorkerPool{
+		{Name: "videos", Glob: "/videos/*"},
+		{Name: "large", MinSizeBytes: 100},
+		{Name: "medium", MinSizeBytes: 10, MaxSizeBytes: 99},
+	})
+	require.NoError(t, err)
+	input := func(path string, size uint64) *common.Input {
+		return &common.Input{FileInfo: &pfs.FileInfo{File: client.NewFile("images", "1", path), SizeBytes: size}}
+	}
+	require.Equal(t, "", router.route([]*common.Input{input("/a", 5)}))
+	require.Equal(t, "medium", router.route([]*common.Input{input("/a", 10)}))
+	require.Equal(t, "large", router.route([]*common.Input{input("/a", 100)}))
+	// a datum's size is the sum of its inputs' sizes
+	require.Equal(t, "large", router.route([]*common.Input{input("/a", 50), input("/b", 50)}))
+	// the first matching pool wins
+	require.Equal(t, "videos", router.route([]*common.Input{input("/videos/a", 100)}))
+	require.Equal(t, "videos", router.route([]*common.Input{input("/a", 5), input("/videos/b", 5)}))
+
+	router, err = newWorkerPoolRouter(nil)
+	require.NoError(t, err)
+	require.Equal(t, "", router.route([]*common.Input{input("/a", 100)}))
+}
+
+func TestWorkerPoolDatumsPerTask(t *testing.T) {
+	// regular workers split the whole batch between them
+	require.Equal(t, int64(10), batchDatumsPerTask(nil, 1000, 100))
+	require.Equal(t, int64(5), batchDatumsPerTask(&pps.ChunkSpec{Number: 5}, 1000, 100))
+
+	// a pool's subtasks aren't sized from the batch, however large it is
+	require.Equal(t, int64(1), poolDatumsPerTask(nil, &pps.WorkerPool{Name: "videos", Glob: "/videos/*"}))
+	require.Equal(t, int64(5), poolDatumsPerTask(&pps.ChunkSpec{Number: 5}, &pps.WorkerPool{Name: "videos", Glob: "/videos/*"}))
+	require.Equal(t, int64(1), poolDatumsPerTask(&pps.ChunkSpec{Number: 5}, &pps.WorkerPool{Name: "large", MinSizeBytes: 100}))
+}
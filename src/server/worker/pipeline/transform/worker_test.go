@@ -0,0 +1,82 @@
+package transform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// TestFinishedDatumsPreempted processes a subtask of three datums twice: the
+// first attempt processes datum "a" and skips datum "b" before it's
+// preempted, and the second attempt skips both of them and processes "c".
+// Each datum should be counted once, with the stats of the attempt that
+// finished it.
+func TestFinishedDatumsPreempted(t *testing.T) {
+	processed := func(seconds int64) *DatumStats {
+		return &DatumStats{
+			DatumsProcessed: 1,
+			ProcessStats:    &pps.ProcessStats{ProcessTime: &types.Duration{Seconds: seconds}},
+		}
+	}
+	skipped := func() *DatumStats {
+		return &DatumStats{DatumsSkipped: 1}
+	}
+
+	// The first attempt is preempted, and the subtask is stored
+	data := &DatumData{JobID: "job"}
+	finished := newFinishedDatums(data)
+	require.NoError(t, finished.add("a", processed(1)))
+	require.NoError(t, finished.add("b", skipped()))
+	require.NoError(t, finished.preempt(data))
+	serialized, err := serializeDatumData(data)
+	require.NoError(t, err)
+
+	// The second attempt processes the rest of the datums
+	data, err = deserializeDatumData(serialized)
+	require.NoError(t, err)
+	require.ElementsEqual(t, []string{"a", "b"}, data.PreemptedDatums)
+	finished = newFinishedDatums(data)
+	stats := &DatumStats{ProcessStats: &pps.ProcessStats{}}
+	for _, datum := range []struct {
+		tag   string
+		stats *DatumStats
+	}{
+		{"a", skipped()},
+		{"b", skipped()},
+		{"c", processed(2)},
+	} {
+		require.NoError(t, finished.add(datum.tag, datum.stats))
+		require.NoError(t, mergeStats(stats, datum.stats))
+	}
+	require.NoError(t, mergeStats(stats, data.PreemptedStats))
+
+	require.Equal(t, int64(2), stats.DatumsProcessed)
+	require.Equal(t, int64(1), stats.DatumsSkipped)
+	require.Equal(t, int64(3), stats.ProcessStats.ProcessTime.Seconds)
+}
+
+// TestRunUserCodePreempted checks that a datum is preempted, rather than
+// processed, when the user code exits successfully on SIGTERM.
+func TestRunUserCodePreempted(t *testing.T) {
+	pi := defaultPipelineInfo()
+	pi.Preemption = &pps.PreemptionSpec{GracePeriod: types.DurationProto(time.Minute)}
+	pi.Transform.Cmd = []string{"bash", "-c", "trap 'exit 0' TERM; sleep 30 & wait"}
+	pi.Transform.WorkingDir = ""
+	require.NoError(t, withTestEnv(pi, func(env *testEnv) error {
+		go func() {
+			// Give the user code time to start and install its trap
+			time.Sleep(500 * time.Millisecond)
+			env.driver.Preempt()
+		}()
+		start := time.Now()
+		err := runUserCode(env.logger, nil, nil, &pps.ProcessStats{}, &Status{}, 0)(env.driver)
+		require.True(t, errors.Is(err, errDatumPreempted), "expected errDatumPreempted, got %v", err)
+		require.True(t, time.Since(start) < 10*time.Second)
+		return nil
+	}))
+}
@@ -65,6 +65,20 @@ var (
 		},
 	)
 
+	// DatumCPUSecondsCount is a counter tracking the total cpu time used by a pipeline's user code
+	DatumCPUSecondsCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pachyderm",
+			Subsystem: "worker",
+			Name:      "datum_cpu_seconds_count",
+			Help:      "Cumulative number of cpu seconds used by user code",
+		},
+		[]string{
+			"pipeline",
+			"job",
+		},
+	)
+
 	// DatumDownloadTime is a histogram tracking the time spent downloading input data by a pipeline
 	DatumDownloadTime = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -189,6 +203,7 @@ func InitPrometheus() {
 		DatumCount,
 		DatumProcTime,
 		DatumProcSecondsCount,
+		DatumCPUSecondsCount,
 		DatumDownloadTime,
 		DatumDownloadSecondsCount,
 		DatumUploadTime,
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path"
+	"syscall"
 	"time"
 
 	etcd "github.com/coreos/etcd/clientv3"
@@ -35,9 +37,11 @@ const (
 
 // The Worker object represents
 type Worker struct {
-	APIServer *server.APIServer // Provides rpcs for other nodes in the cluster
-	driver    driver.Driver     // Provides common functions used by worker code
-	status    *transform.Status // An interface for inspecting and canceling the actively running task
+	APIServer  *server.APIServer // Provides rpcs for other nodes in the cluster
+	driver     driver.Driver     // Provides common functions used by worker code
+	status     *transform.Status // An interface for inspecting and canceling the actively running task
+	pool       string            // The worker pool that this worker belongs to, or "" for regular workers
+	taskWorker *work.Worker      // Processes the subtasks that the master creates
 }
 
 // NewWorker constructs a Worker object that provides all worker functionality:
@@ -45,6 +49,9 @@ type Worker struct {
 //  2. a worker goroutine that gets tasks from the master and processes them
 //  3. an api server that serves requests for status or cross-worker communication
 //  4. a driver that provides common functionality between the above components
+//
+// Workers in one of the pipeline's worker pools (pool != "") never become the
+// master, and only process the tasks routed to their pool.
 func NewWorker(
 	pachClient *client.APIClient,
 	etcdClient *etcd.Client,
@@ -54,6 +61,7 @@ func NewWorker(
 	namespace string,
 	hashtreePath string,
 	rootPath string,
+	pool string,
 ) (*Worker, error) {
 	stats.InitPrometheus()
 
@@ -101,17 +109,47 @@ func NewWorker(
 	}
 
 	worker := &Worker{
-		driver: driver,
-		status: &transform.Status{},
+		driver:     driver,
+		status:     &transform.Status{},
+		pool:       pool,
+		taskWorker: driver.NewTaskWorker(pool),
 	}
 
 	worker.APIServer = server.NewAPIServer(driver, worker.status, workerName)
 
-	go worker.master(etcdClient, etcdPrefix)
+	if pool == "" {
+		go worker.master(etcdClient, etcdPrefix)
+	}
 	go worker.worker()
+	if pipelineInfo.Preemption != nil {
+		go worker.handlePreemption()
+	}
 	return worker, nil
 }
 
+// handlePreemption waits for the SIGTERM that kubernetes sends when the
+// worker's node is shutting down, and then stops the user code and marks the
+// subtasks in progress as preempted, so that they're processed by another
+// worker without counting as failures.
+func (w *Worker) handlePreemption() {
+	logger := logs.NewStatlessLogger(w.driver.PipelineInfo())
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM)
+	<-sigs
+	logger.Logf("worker preempted, stopping user code")
+	gracePeriod, err := ppsutil.GetPreemptionGracePeriod(w.driver.PipelineInfo().Preemption)
+	if err != nil {
+		logger.Logf("error getting preemption grace period: %v", err)
+	}
+	w.driver.Preempt()
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod+ppsutil.PreemptionShutdownPeriod)
+	defer cancel()
+	if err := w.taskWorker.Preempt(ctx); err != nil {
+		logger.Logf("error waiting for preempted subtasks: %v", err)
+	}
+	os.Exit(0)
+}
+
 func (w *Worker) worker() {
 	ctx := w.driver.PachClient().Ctx()
 	logger := logs.NewStatlessLogger(w.driver.PipelineInfo())
@@ -137,7 +175,7 @@ func (w *Worker) worker() {
 
 		// Run any worker tasks that the master creates
 		eg.Go(func() error {
-			return driver.NewTaskWorker().Run(
+			return w.taskWorker.Run(
 				ctx,
 				func(ctx context.Context, subtask *work.Task) error {
 					driver := w.driver.WithContext(ctx)